@@ -23,11 +23,15 @@ import (
 )
 
 var (
-	baseBranchFlag = "base-branch"
-	failOnFlag     = "fail-on"
-	teamCityFlag   = "teamcity"
-	checkStyleFlag = "checkstyle"
-	jsonFlag       = "json"
+	baseBranchFlag        = "base-branch"
+	failOnFlag            = "fail-on"
+	teamCityFlag          = "teamcity"
+	checkStyleFlag        = "checkstyle"
+	jsonFlag              = "json"
+	sarifFlag             = "sarif"
+	jsonLinesFlag         = "jsonl"
+	changedOnlyFlag       = "changed-only"
+	modifiedLinesOnlyFlag = "modified-lines-only"
 )
 
 var ciCmd = &cli.Command{
@@ -71,6 +75,22 @@ var ciCmd = &cli.Command{
 			Value:   "",
 			Usage:   "Write a JSON formatted report of all problems to this path.",
 		},
+		&cli.StringFlag{
+			Name:    sarifFlag,
+			Aliases: []string{"s"},
+			Value:   "",
+			Usage:   "Write a SARIF formatted report of all problems to this path.",
+		},
+		&cli.BoolFlag{
+			Name:  changedOnlyFlag,
+			Value: false,
+			Usage: "Only run checks on rules modified on the current branch, unmodified and removed rules will be skipped.",
+		},
+		&cli.BoolFlag{
+			Name:  modifiedLinesOnlyFlag,
+			Value: false,
+			Usage: "Only report problems on lines modified on the current branch.",
+		},
 	},
 }
 
@@ -128,7 +148,11 @@ func actionCI(c *cli.Context) error {
 
 	slog.Debug("Generated all Prometheus servers", slog.Int("count", gen.Count()))
 
-	summary, err := checkRules(ctx, meta.workers, meta.isOffline, gen, meta.cfg, entries)
+	summary, err := checkRules(ctx, meta.workers, checkOptions{
+		isOffline:         meta.isOffline,
+		changedOnly:       c.Bool(changedOnlyFlag),
+		modifiedLinesOnly: c.Bool(modifiedLinesOnlyFlag),
+	}, gen, meta.cfg, entries)
 	if err != nil {
 		return err
 	}
@@ -161,6 +185,15 @@ func actionCI(c *cli.Context) error {
 		defer j.Close()
 		reps = append(reps, reporter.NewJSONReporter(j))
 	}
+	if c.String(sarifFlag) != "" {
+		var sf *os.File
+		sf, err = os.Create(c.String(sarifFlag))
+		if err != nil {
+			return err
+		}
+		defer sf.Close()
+		reps = append(reps, reporter.NewSARIFReporter(sf, version))
+	}
 
 	if meta.cfg.Repository != nil && meta.cfg.Repository.BitBucket != nil {
 		token, ok := os.LookupEnv("BITBUCKET_AUTH_TOKEN")
@@ -17,7 +17,10 @@ import (
 	"github.com/urfave/cli/v2"
 )
 
-var requireOwnerFlag = "require-owner"
+var (
+	requireOwnerFlag = "require-owner"
+	ownerSummaryFlag = "owner-summary"
+)
 
 var lintCmd = &cli.Command{
 	Name:   "lint",
@@ -30,6 +33,11 @@ var lintCmd = &cli.Command{
 			Value:   false,
 			Usage:   "Require all rules to have an owner set via comment.",
 		},
+		&cli.BoolFlag{
+			Name:  ownerSummaryFlag,
+			Value: false,
+			Usage: "Print the number of problems found for each rule owner.",
+		},
 		&cli.StringFlag{
 			Name:    minSeverityFlag,
 			Aliases: []string{"n"},
@@ -60,6 +68,17 @@ var lintCmd = &cli.Command{
 			Value:   "",
 			Usage:   "Write a JSON formatted report of all problems to this path.",
 		},
+		&cli.StringFlag{
+			Name:    sarifFlag,
+			Aliases: []string{"s"},
+			Value:   "",
+			Usage:   "Write a SARIF formatted report of all problems to this path.",
+		},
+		&cli.StringFlag{
+			Name:  jsonLinesFlag,
+			Value: "",
+			Usage: "Write each problem as a JSON object on a new line to this path, as soon as it's found.",
+		},
 	},
 }
 
@@ -101,13 +120,32 @@ func actionLint(c *cli.Context) error {
 		return err
 	}
 
-	summary, err := checkRules(ctx, meta.workers, meta.isOffline, gen, meta.cfg, entries)
+	var streams []reporter.StreamReporter
+	if c.String(jsonLinesFlag) != "" {
+		var jl *os.File
+		jl, err = os.Create(c.String(jsonLinesFlag))
+		if err != nil {
+			return err
+		}
+		defer jl.Close()
+		streams = append(streams, reporter.NewJSONLinesReporter(jl))
+	}
+
+	summary, err := checkRules(ctx, meta.workers, checkOptions{isOffline: meta.isOffline}, gen, meta.cfg, entries, streams...)
 	if err != nil {
 		return err
 	}
 
 	if c.Bool(requireOwnerFlag) {
-		summary.Report(verifyOwners(entries, allowedOwners)...)
+		ownerReports := verifyOwners(entries, allowedOwners)
+		summary.Report(ownerReports...)
+		for _, stream := range streams {
+			for _, report := range ownerReports {
+				if err = stream.Push(report); err != nil {
+					return fmt.Errorf("streaming reports: %w", err)
+				}
+			}
+		}
 	}
 
 	minSeverity, err := checks.ParseSeverity(c.String(minSeverityFlag))
@@ -126,6 +164,10 @@ func actionLint(c *cli.Context) error {
 		reps = append(reps, reporter.NewConsoleReporter(os.Stderr, minSeverity, c.Bool(noColorFlag)))
 	}
 
+	if c.Bool(ownerSummaryFlag) {
+		reps = append(reps, reporter.NewOwnerSummaryReporter(os.Stderr))
+	}
+
 	if c.String(checkStyleFlag) != "" {
 		var f *os.File
 		f, err = os.Create(c.String(checkStyleFlag))
@@ -146,6 +188,16 @@ func actionLint(c *cli.Context) error {
 		reps = append(reps, reporter.NewJSONReporter(j))
 	}
 
+	if c.String(sarifFlag) != "" {
+		var sf *os.File
+		sf, err = os.Create(c.String(sarifFlag))
+		if err != nil {
+			return err
+		}
+		defer sf.Close()
+		reps = append(reps, reporter.NewSARIFReporter(sf, version))
+	}
+
 	summary.SortReports()
 	for _, rep := range reps {
 		err = rep.Submit(summary)
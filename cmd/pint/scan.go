@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"sync"
 	"time"
@@ -9,15 +10,24 @@ import (
 	"go.uber.org/atomic"
 
 	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/comments"
 	"github.com/cloudflare/pint/internal/config"
 	"github.com/cloudflare/pint/internal/discovery"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/parser/utils"
 	"github.com/cloudflare/pint/internal/promapi"
 	"github.com/cloudflare/pint/internal/reporter"
 )
 
-func checkRules(ctx context.Context, workers int, isOffline bool, gen *config.PrometheusGenerator, cfg config.Config, entries []discovery.Entry) (summary reporter.Summary, err error) {
-	slog.Info("Checking Prometheus rules", slog.Int("entries", len(entries)), slog.Int("workers", workers), slog.Bool("online", !isOffline))
-	if isOffline {
+type checkOptions struct {
+	isOffline         bool // Skip Prometheus discovery.
+	changedOnly       bool // Only check rules that were modified.
+	modifiedLinesOnly bool // Only report problems on modified lines.
+}
+
+func checkRules(ctx context.Context, workers int, opts checkOptions, gen *config.PrometheusGenerator, cfg config.Config, entries []discovery.Entry, streams ...reporter.StreamReporter) (summary reporter.Summary, err error) {
+	slog.Info("Checking Prometheus rules", slog.Int("entries", len(entries)), slog.Int("workers", workers), slog.Bool("online", !opts.isOffline))
+	if opts.isOffline {
 		slog.Info("Offline mode, skipping Prometheus discovery")
 	} else {
 		if len(entries) > 0 {
@@ -43,6 +53,8 @@ func checkRules(ctx context.Context, workers int, isOffline bool, gen *config.Pr
 	wg := sync.WaitGroup{}
 
 	ctx = context.WithValue(ctx, promapi.AllPrometheusServers, gen.Servers())
+	ctx = context.WithValue(ctx, utils.SourceCacheKey, utils.NewSourceCache())
+	ctx = context.WithValue(ctx, checks.SuggestRecordCacheKey, checks.NewSuggestRecordCache())
 	for _, s := range cfg.Check {
 		settings, _ := s.Decode()
 		key := checks.SettingsKey(s.Name)
@@ -53,7 +65,7 @@ func checkRules(ctx context.Context, workers int, isOffline bool, gen *config.Pr
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			scanWorker(ctx, jobs, results)
+			scanWorker(ctx, jobs, results, opts.modifiedLinesOnly)
 		}()
 	}
 
@@ -70,6 +82,13 @@ func checkRules(ctx context.Context, workers int, isOffline bool, gen *config.Pr
 				continue
 			case entry.Rule.Error.Err != nil && entry.State == discovery.Removed:
 				continue
+			case opts.changedOnly && !entry.IsChanged():
+				slog.Debug("Skipping unchanged rule",
+					slog.String("path", entry.Path.Name),
+					slog.String("lines", entry.Rule.Lines.String()),
+					slog.String("state", entry.State.String()),
+				)
+				continue
 			default:
 				if entry.Rule.RecordingRule != nil {
 					rulesParsedTotal.WithLabelValues(config.RecordingRuleType).Inc()
@@ -114,8 +133,21 @@ func checkRules(ctx context.Context, workers int, isOffline bool, gen *config.Pr
 		defer close(jobs)
 	}()
 
+	var streamErr error
 	for result := range results {
 		summary.Report(result)
+		for _, stream := range streams {
+			// Keep reading results after an error so workers don't get stuck.
+			if streamErr != nil {
+				break
+			}
+			if err = stream.Push(result); err != nil {
+				streamErr = fmt.Errorf("streaming reports: %w", err)
+			}
+		}
+	}
+	if streamErr != nil {
+		return summary, streamErr
 	}
 	summary.Duration = time.Since(start)
 	summary.TotalEntries = len(entries)
@@ -150,7 +182,7 @@ type scanJob struct {
 	entry      discovery.Entry
 }
 
-func scanWorker(ctx context.Context, jobs <-chan scanJob, results chan<- reporter.Report) {
+func scanWorker(ctx context.Context, jobs <-chan scanJob, results chan<- reporter.Report, modifiedLinesOnly bool) {
 	for job := range jobs {
 		select {
 		case <-ctx.Done():
@@ -168,7 +200,18 @@ func scanWorker(ctx context.Context, jobs <-chan scanJob, results chan<- reporte
 			start := time.Now()
 			problems := job.check.Check(ctx, job.entry.Path, job.entry.Rule, job.allEntries)
 			checkDuration.WithLabelValues(job.check.Reporter()).Observe(time.Since(start).Seconds())
+			mapSeverity(checks.SeverityMapperFromContext(ctx), job.check, problems)
+			setSeverityFromComments(job.entry.Rule, job.check, problems)
 			for _, problem := range problems {
+				if modifiedLinesOnly && !isModified(problem.Lines, job.entry.ModifiedLines) {
+					slog.Debug(
+						"Skipping problem reported on unmodified lines",
+						slog.String("path", job.entry.Path.String()),
+						slog.String("lines", problem.Lines.String()),
+						slog.String("reporter", problem.Reporter),
+					)
+					continue
+				}
 				results <- reporter.Report{
 					Path:          job.entry.Path,
 					ModifiedLines: job.entry.ModifiedLines,
@@ -182,3 +225,56 @@ func scanWorker(ctx context.Context, jobs <-chan scanJob, results chan<- reporte
 		checkIterationChecksDone.Inc()
 	}
 }
+
+// isModified returns true if any of given lines was modified.
+func isModified(lines parser.LineRange, modifiedLines []int) bool {
+	for _, line := range modifiedLines {
+		if lines.Contains(line) {
+			return true
+		}
+	}
+	return false
+}
+
+// mapSeverity passes severity of all problems reported by given check
+// through the SeverityMapper.
+func mapSeverity(mapper checks.SeverityMapper, check checks.RuleChecker, problems []checks.Problem) {
+	for i := range problems {
+		severity := mapper.MapSeverity(problems[i].Reporter, problems[i].Severity)
+		if severity == problems[i].Severity {
+			continue
+		}
+		slog.Debug(
+			"Problem severity changed by mapper",
+			slog.String("check", check.String()),
+			slog.String("reporter", problems[i].Reporter),
+			slog.String("from", problems[i].Severity.String()),
+			slog.String("to", severity.String()),
+		)
+		problems[i].Severity = severity
+	}
+}
+
+// setSeverityFromComments applies all "# pint severity/set" comments
+// on given rule to problems reported by given check.
+func setSeverityFromComments(rule parser.Rule, check checks.RuleChecker, problems []checks.Problem) {
+	for _, ss := range comments.Only[comments.SeveritySet](rule.Comments, comments.SeveritySetType) {
+		severity, err := checks.ParseSeverity(ss.Severity)
+		if err != nil {
+			continue
+		}
+		for i := range problems {
+			if ss.Match != problems[i].Reporter && ss.Match != check.String() {
+				continue
+			}
+			slog.Debug(
+				"Problem severity changed by comment",
+				slog.String("check", check.String()),
+				slog.String("match", ss.Match),
+				slog.String("from", problems[i].Severity.String()),
+				slog.String("to", severity.String()),
+			)
+			problems[i].Severity = severity
+		}
+	}
+}
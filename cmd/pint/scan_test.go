@@ -0,0 +1,109 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/parser"
+)
+
+func TestIsModified(t *testing.T) {
+	type testCaseT struct {
+		description   string
+		modifiedLines []int
+		lines         parser.LineRange
+		isModified    bool
+	}
+
+	testCases := []testCaseT{
+		{
+			description: "no modified lines",
+			lines:       parser.LineRange{First: 1, Last: 2},
+		},
+		{
+			description:   "fully inside",
+			lines:         parser.LineRange{First: 3, Last: 4},
+			modifiedLines: []int{2, 3, 4, 5},
+			isModified:    true,
+		},
+		{
+			description:   "fully outside",
+			lines:         parser.LineRange{First: 3, Last: 4},
+			modifiedLines: []int{1, 2, 5, 6},
+		},
+		{
+			description:   "straddling",
+			lines:         parser.LineRange{First: 3, Last: 6},
+			modifiedLines: []int{5, 6, 7},
+			isModified:    true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			require.Equal(t, tc.isModified, isModified(tc.lines, tc.modifiedLines))
+		})
+	}
+}
+
+type promoteSeverityMapper struct {
+	reporter string
+}
+
+func (m promoteSeverityMapper) MapSeverity(reporter string, severity checks.Severity) checks.Severity {
+	if reporter == m.reporter && severity == checks.Warning {
+		return checks.Bug
+	}
+	return severity
+}
+
+func TestMapSeverity(t *testing.T) {
+	type testCaseT struct {
+		mapper      checks.SeverityMapper
+		description string
+		problems    []checks.Problem
+		expected    []checks.Problem
+	}
+
+	testCases := []testCaseT{
+		{
+			description: "no problems",
+			mapper:      checks.IdentitySeverityMapper{},
+		},
+		{
+			description: "identity mapper",
+			mapper:      checks.IdentitySeverityMapper{},
+			problems: []checks.Problem{
+				{Reporter: "promql/series", Severity: checks.Warning},
+				{Reporter: "promql/rate", Severity: checks.Bug},
+			},
+			expected: []checks.Problem{
+				{Reporter: "promql/series", Severity: checks.Warning},
+				{Reporter: "promql/rate", Severity: checks.Bug},
+			},
+		},
+		{
+			description: "promote warnings from one reporter",
+			mapper:      promoteSeverityMapper{reporter: "promql/series"},
+			problems: []checks.Problem{
+				{Reporter: "promql/series", Severity: checks.Warning},
+				{Reporter: "promql/series", Severity: checks.Information},
+				{Reporter: "promql/rate", Severity: checks.Warning},
+			},
+			expected: []checks.Problem{
+				{Reporter: "promql/series", Severity: checks.Bug},
+				{Reporter: "promql/series", Severity: checks.Information},
+				{Reporter: "promql/rate", Severity: checks.Warning},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			mapSeverity(tc.mapper, checks.NewSyntaxCheck(), tc.problems)
+			require.Equal(t, tc.expected, tc.problems)
+		})
+	}
+}
@@ -61,7 +61,7 @@ func (c AlertsAbsentCheck) Check(ctx context.Context, _ discovery.Path, rule par
 	}
 
 	var hasAbsent bool
-	for _, s := range utils.LabelsSource(rule.AlertingRule.Expr.Value.Value, rule.AlertingRule.Expr.Query.Expr) {
+	for _, s := range utils.CachedLabelsSource(ctx, rule.AlertingRule.Expr.Value.Value, rule.AlertingRule.Expr.Query.Expr) {
 		if s.Operation == "absent" {
 			hasAbsent = true
 		}
@@ -0,0 +1,84 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudflare/pint/internal/discovery"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/parser/utils"
+)
+
+const (
+	AnonymousCheckName    = "alerts/anonymous"
+	AnonymousCheckDetails = "Alerts generated by this rule will only have labels set on the alerting rule itself.\n" +
+		"Without any labels coming from the query it's impossible to tell which service or instance is affected " +
+		"and there's nothing to use for routing or grouping alerts."
+)
+
+func NewAnonymousCheck(comment string, severity Severity) AnonymousCheck {
+	return AnonymousCheck{
+		comment:  comment,
+		severity: severity,
+	}
+}
+
+type AnonymousCheck struct {
+	comment  string
+	severity Severity
+}
+
+func (c AnonymousCheck) Meta() CheckMeta {
+	return CheckMeta{
+		States: []discovery.ChangeType{
+			discovery.Noop,
+			discovery.Added,
+			discovery.Modified,
+			discovery.Moved,
+		},
+		Online:        false,
+		AlwaysEnabled: false,
+	}
+}
+
+func (c AnonymousCheck) String() string {
+	return AnonymousCheckName
+}
+
+func (c AnonymousCheck) Reporter() string {
+	return AnonymousCheckName
+}
+
+func (c AnonymousCheck) Check(ctx context.Context, _ discovery.Path, rule parser.Rule, _ []discovery.Entry) (problems []Problem) {
+	if rule.AlertingRule == nil {
+		return problems
+	}
+
+	expr := rule.Expr()
+	if expr.SyntaxError != nil {
+		return problems
+	}
+
+	for _, src := range utils.CachedLabelsSource(ctx, expr.Value.Value, expr.Query.Expr) {
+		if src.IsDead || !src.FixedLabels || len(src.IncludedLabels) > 0 || len(src.GuaranteedLabels) > 0 {
+			continue
+		}
+		details := AnonymousCheckDetails
+		if reasons := src.ExcludeReasons(""); len(reasons) > 0 {
+			details = fmt.Sprintf("%s\n%s", details, excludeReasonDetails(expr.Value.Value, reasons))
+		}
+		if c.comment != "" {
+			details += "\n" + maybeComment(c.comment)
+		}
+		problems = append(problems, Problem{
+			Lines:    expr.Value.Lines,
+			Reporter: c.Reporter(),
+			Text:     "This alert query removes all labels from the results, alerts generated by it won't have any labels identifying what is affected.",
+			Details:  details,
+			Severity: c.severity,
+		})
+		break
+	}
+
+	return problems
+}
@@ -0,0 +1,124 @@
+package checks_test
+
+import (
+	"testing"
+
+	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/promapi"
+)
+
+func newAnonymousCheck(_ *promapi.FailoverGroup) checks.RuleChecker {
+	return checks.NewAnonymousCheck("", checks.Warning)
+}
+
+func TestAnonymousCheck(t *testing.T) {
+	testCases := []checkTest{
+		{
+			description: "ignores recording rules",
+			content:     "- record: foo\n  expr: sum(up)\n",
+			checker:     newAnonymousCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores rules with syntax errors",
+			content:     "- alert: foo\n  expr: sum(up) == 0)\n",
+			checker:     newAnonymousCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores queries without aggregation",
+			content:     "- alert: foo\n  expr: up == 0\n",
+			checker:     newAnonymousCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores aggregation with by()",
+			content:     "- alert: foo\n  expr: sum by(job) (up) == 0\n",
+			checker:     newAnonymousCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores aggregation with without()",
+			content:     "- alert: foo\n  expr: sum without(instance) (up) == 0\n",
+			checker:     newAnonymousCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "reports aggregation removing all labels",
+			content:     "- alert: foo\n  expr: sum(up) == 0\n",
+			checker:     newAnonymousCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.AnonymousCheckName,
+						Text:     "This alert query removes all labels from the results, alerts generated by it won't have any labels identifying what is affected.",
+						Details:  checks.AnonymousCheckDetails + "\nQuery is using aggregation that removes all labels.\nQuery fragment causing this problem: `sum(up)`.",
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+		{
+			description: "reports count() removing all labels",
+			content:     "- alert: foo\n  expr: count(up{job=\"foo\"} == 0) > 5\n",
+			checker:     newAnonymousCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.AnonymousCheckName,
+						Text:     "This alert query removes all labels from the results, alerts generated by it won't have any labels identifying what is affected.",
+						Details:  checks.AnonymousCheckDetails + "\nQuery is using aggregation that removes all labels.\nQuery fragment causing this problem: `count(up{job=\"foo\"} == 0)`.",
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+		{
+			description: "ignores dead code",
+			content:     "- alert: foo\n  expr: count(up) < 0\n",
+			checker:     newAnonymousCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "uses configured comment and severity",
+			content:     "- alert: foo\n  expr: count(up{job=\"foo\"} == 0) > 5\n",
+			checker: func(_ *promapi.FailoverGroup) checks.RuleChecker {
+				return checks.NewAnonymousCheck("Add labels for routing.", checks.Bug)
+			},
+			prometheus: noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.AnonymousCheckName,
+						Text:     "This alert query removes all labels from the results, alerts generated by it won't have any labels identifying what is affected.",
+						Details:  checks.AnonymousCheckDetails + "\nQuery is using aggregation that removes all labels.\nQuery fragment causing this problem: `count(up{job=\"foo\"} == 0)`.\nRule comment: Add labels for routing.",
+						Severity: checks.Bug,
+					},
+				}
+			},
+		},
+	}
+
+	runTests(t, testCases)
+}
@@ -98,50 +98,25 @@ func (c ComparisonCheck) Check(_ context.Context, _ discovery.Path, rule parser.
 }
 
 func hasComparision(n promParser.Node) *promParser.BinaryExpr {
-	if node, ok := n.(*promParser.BinaryExpr); ok {
-		if node.Op.IsComparisonOperator() {
-			return node
-		}
-		if node.Op == promParser.LUNLESS {
-			return node
-		}
-	}
-
-	for _, child := range promParser.Children(n) {
-		if node := hasComparision(child); node != nil {
-			return node
-		}
+	node := utils.FindNode(n, func(node promParser.Node) bool {
+		be, ok := node.(*promParser.BinaryExpr)
+		return ok && (be.Op.IsComparisonOperator() || be.Op == promParser.LUNLESS)
+	})
+	if node == nil {
+		return nil
 	}
-
-	return nil
+	return node.(*promParser.BinaryExpr)
 }
 
 func isAbsent(node promParser.Node) bool {
-	if node, ok := node.(*promParser.Call); ok {
-		if node.Func.Name == "absent" || node.Func.Name == "absent_over_time" {
-			return true
-		}
-	}
-
-	for _, child := range promParser.Children(node) {
-		if isAbsent(child) {
-			return true
-		}
-	}
-
-	return false
+	return utils.FindNode(node, func(node promParser.Node) bool {
+		call, ok := node.(*promParser.Call)
+		return ok && (call.Func.Name == "absent" || call.Func.Name == "absent_over_time")
+	}) != nil
 }
 
 func hasAbsent(n *parser.PromQLNode) bool {
-	if isAbsent(n.Expr) {
-		return true
-	}
-	for _, child := range n.Children {
-		if hasAbsent(child) {
-			return true
-		}
-	}
-	return false
+	return isAbsent(n.Expr)
 }
 
 func rewriteSeverity(s Severity, nodes ...promParser.Node) Severity {
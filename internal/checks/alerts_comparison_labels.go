@@ -0,0 +1,159 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/prometheus/prometheus/model/labels"
+	promParser "github.com/prometheus/prometheus/promql/parser"
+
+	"github.com/cloudflare/pint/internal/discovery"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/parser/utils"
+)
+
+const (
+	ComparisonLabelsCheckName    = "alerts/comparison_labels"
+	ComparisonLabelsCheckDetails = `Comparing two queries with each other will only return results for time series that have the exact same set of labels on both sides.
+If one side of the comparison always has a label that cannot be present on the other side, or has a different value for it, then the comparison will never return anything and the alert will never fire.
+You can match time series with different labels by using ` + "`on(...)`" + ` or ` + "`ignoring(...)`" + `.
+[Click here](https://prometheus.io/docs/prometheus/latest/querying/operators/#vector-matching) to read PromQL documentation that explains it.`
+)
+
+func NewComparisonLabelsCheck() ComparisonLabelsCheck {
+	return ComparisonLabelsCheck{}
+}
+
+type ComparisonLabelsCheck struct{}
+
+func (c ComparisonLabelsCheck) Meta() CheckMeta {
+	return CheckMeta{
+		States: []discovery.ChangeType{
+			discovery.Noop,
+			discovery.Added,
+			discovery.Modified,
+			discovery.Moved,
+		},
+		Online:        false,
+		AlwaysEnabled: false,
+	}
+}
+
+func (c ComparisonLabelsCheck) String() string {
+	return ComparisonLabelsCheckName
+}
+
+func (c ComparisonLabelsCheck) Reporter() string {
+	return ComparisonLabelsCheckName
+}
+
+func (c ComparisonLabelsCheck) Check(ctx context.Context, _ discovery.Path, rule parser.Rule, _ []discovery.Entry) (problems []Problem) {
+	if rule.AlertingRule == nil {
+		return problems
+	}
+
+	expr := rule.Expr()
+	if expr.SyntaxError != nil {
+		return problems
+	}
+
+	for _, node := range parser.WalkDownExpr[*promParser.BinaryExpr](expr.Query) {
+		n := node.Expr.(*promParser.BinaryExpr)
+		if !n.Op.IsComparisonOperator() {
+			continue
+		}
+		if n.LHS.Type() != promParser.ValueTypeVector || n.RHS.Type() != promParser.ValueTypeVector {
+			continue
+		}
+		if n.VectorMatching == nil || n.VectorMatching.On || len(n.VectorMatching.MatchingLabels) > 0 {
+			continue
+		}
+
+		query := expr.Value.Value[n.PositionRange().Start:n.PositionRange().End]
+		if text, ok := compareSides(
+			utils.CachedLabelsSource(ctx, expr.Value.Value, n.LHS),
+			utils.CachedLabelsSource(ctx, expr.Value.Value, n.RHS),
+			query,
+		); ok {
+			problems = append(problems, Problem{
+				Lines:    expr.Value.Lines,
+				Reporter: c.Reporter(),
+				Text:     text,
+				Details:  ComparisonLabelsCheckDetails,
+				Severity: Warning,
+			})
+		}
+	}
+
+	return problems
+}
+
+// compareSides returns the text of the problem to report if time series
+// from both sides of a comparison can never have the same set of labels.
+func compareSides(lhs, rhs []utils.Source, query string) (string, bool) {
+	for _, ls := range lhs {
+		if ls.IsDead {
+			continue
+		}
+		for _, rs := range rhs {
+			if rs.IsDead {
+				continue
+			}
+			for _, name := range ls.GuaranteedLabels {
+				if cannotHaveLabel(rs, name) {
+					return fmt.Sprintf("The left hand side of `%s` will always have the `%s` label but the right hand side will never have it, this comparison will never match anything.",
+						query, name), true
+				}
+			}
+			for _, name := range rs.GuaranteedLabels {
+				if cannotHaveLabel(ls, name) {
+					return fmt.Sprintf("The right hand side of `%s` will always have the `%s` label but the left hand side will never have it, this comparison will never match anything.",
+						query, name), true
+				}
+			}
+			lv, rv := fixedLabelValues(ls), fixedLabelValues(rs)
+			for _, name := range ls.GuaranteedLabels {
+				if !slices.Contains(rs.GuaranteedLabels, name) {
+					continue
+				}
+				l, lok := lv[name]
+				r, rok := rv[name]
+				if lok && rok && l != r {
+					return fmt.Sprintf("The left hand side of `%s` uses `{%s=%q}` while the right hand side uses `{%s=%q}`, this comparison will never match anything.",
+						query, name, l, name, r), true
+				}
+			}
+		}
+	}
+	return "", false
+}
+
+func cannotHaveLabel(src utils.Source, name string) bool {
+	if src.FixedLabels && !slices.Contains(src.IncludedLabels, name) {
+		return true
+	}
+	return slices.Contains(src.ExcludedLabels, name)
+}
+
+// fixedLabelValues returns values of all labels set using equal matchers
+// on the selectors of given source. Labels with more than one value are skipped.
+func fixedLabelValues(src utils.Source) map[string]string {
+	values := map[string]string{}
+	conflicts := map[string]struct{}{}
+	for _, vs := range src.Selectors {
+		for _, lm := range vs.LabelMatchers {
+			if lm.Type != labels.MatchEqual || lm.Name == labels.MetricName {
+				continue
+			}
+			if v, ok := values[lm.Name]; ok && v != lm.Value {
+				conflicts[lm.Name] = struct{}{}
+			}
+			values[lm.Name] = lm.Value
+		}
+	}
+	for name := range conflicts {
+		delete(values, name)
+	}
+	return values
+}
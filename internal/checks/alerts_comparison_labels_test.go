@@ -0,0 +1,143 @@
+package checks_test
+
+import (
+	"testing"
+
+	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/promapi"
+)
+
+func newComparisonLabelsCheck(_ *promapi.FailoverGroup) checks.RuleChecker {
+	return checks.NewComparisonLabelsCheck()
+}
+
+func TestComparisonLabelsCheck(t *testing.T) {
+	testCases := []checkTest{
+		{
+			description: "ignores recording rules",
+			content:     "- record: foo\n  expr: cpu{job=\"a\"} > mem{job=\"b\"}\n",
+			checker:     newComparisonLabelsCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores rules with syntax errors",
+			content:     "- alert: foo\n  expr: cpu{job=\"a\"} > mem{job=\"b\"\n",
+			checker:     newComparisonLabelsCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores comparison with a number",
+			content:     "- alert: foo\n  expr: sum(cpu{job=\"a\"}) > 1\n",
+			checker:     newComparisonLabelsCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores same labels",
+			content:     "- alert: foo\n  expr: cpu{job=\"a\"} > mem{job=\"a\"}\n",
+			checker:     newComparisonLabelsCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores different labels that can be present on both sides",
+			content:     "- alert: foo\n  expr: cpu{job=\"a\"} > mem{instance=\"b\"}\n",
+			checker:     newComparisonLabelsCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores aggregations removing conflicting labels",
+			content:     "- alert: foo\n  expr: sum(cpu{job=\"a\"}) > sum(mem{job=\"b\"})\n",
+			checker:     newComparisonLabelsCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores on()",
+			content:     "- alert: foo\n  expr: cpu{job=\"a\"} > on(instance) mem{job=\"b\"}\n",
+			checker:     newComparisonLabelsCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores ignoring()",
+			content:     "- alert: foo\n  expr: cpu{job=\"a\"} > ignoring(job) mem{job=\"b\"}\n",
+			checker:     newComparisonLabelsCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores arithmetic operators",
+			content:     "- alert: foo\n  expr: cpu{job=\"a\"} / mem{job=\"b\"}\n",
+			checker:     newComparisonLabelsCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "reports different label values",
+			content:     "- alert: foo\n  expr: cpu{job=\"a\"} > mem{job=\"b\"}\n",
+			checker:     newComparisonLabelsCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.ComparisonLabelsCheckName,
+						Text:     "The left hand side of `cpu{job=\"a\"} > mem{job=\"b\"}` uses `{job=\"a\"}` while the right hand side uses `{job=\"b\"}`, this comparison will never match anything.",
+						Details:  checks.ComparisonLabelsCheckDetails,
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+		{
+			description: "reports label removed from the right hand side",
+			content:     "- alert: foo\n  expr: cpu{job=\"a\"} > sum by(instance) (mem{instance=\"b\"})\n",
+			checker:     newComparisonLabelsCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.ComparisonLabelsCheckName,
+						Text:     "The left hand side of `cpu{job=\"a\"} > sum by(instance) (mem{instance=\"b\"})` will always have the `job` label but the right hand side will never have it, this comparison will never match anything.",
+						Details:  checks.ComparisonLabelsCheckDetails,
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+		{
+			description: "reports label removed from the left hand side",
+			content:     "- alert: foo\n  expr: sum without(job) (cpu) > mem{job=\"b\"}\n",
+			checker:     newComparisonLabelsCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.ComparisonLabelsCheckName,
+						Text:     "The right hand side of `sum without(job) (cpu) > mem{job=\"b\"}` will always have the `job` label but the left hand side will never have it, this comparison will never match anything.",
+						Details:  checks.ComparisonLabelsCheckDetails,
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+	}
+
+	runTests(t, testCases)
+}
@@ -0,0 +1,71 @@
+package checks
+
+import (
+	"context"
+
+	"github.com/cloudflare/pint/internal/discovery"
+	"github.com/cloudflare/pint/internal/parser"
+)
+
+const (
+	AlertsConstantValueCheckName = "alerts/constant_value"
+
+	AlertsConstantValueCheckDetails = `This alert query only uses constant values, like ` + "`vector(1)`" + `, so the result of the comparison is known upfront and doesn't depend on any metric.`
+)
+
+func NewAlertsConstantValueCheck() AlertsConstantValueCheck {
+	return AlertsConstantValueCheck{}
+}
+
+type AlertsConstantValueCheck struct{}
+
+func (c AlertsConstantValueCheck) Meta() CheckMeta {
+	return CheckMeta{
+		States: []discovery.ChangeType{
+			discovery.Noop,
+			discovery.Added,
+			discovery.Modified,
+			discovery.Moved,
+		},
+		Online:        false,
+		AlwaysEnabled: false,
+	}
+}
+
+func (c AlertsConstantValueCheck) String() string {
+	return AlertsConstantValueCheckName
+}
+
+func (c AlertsConstantValueCheck) Reporter() string {
+	return AlertsConstantValueCheckName
+}
+
+func (c AlertsConstantValueCheck) Check(ctx context.Context, _ discovery.Path, rule parser.Rule, _ []discovery.Entry) (problems []Problem) {
+	if rule.AlertingRule == nil {
+		return problems
+	}
+
+	if rule.AlertingRule.Expr.SyntaxError != nil {
+		return problems
+	}
+
+	// Queries with no comparison or with a bool comparison are reported by alerts/comparison.
+	isConstant, isAlive := constantComparison(ctx, rule.AlertingRule.Expr.Value.Value, rule.AlertingRule.Expr.Query.Expr)
+	if !isConstant {
+		return problems
+	}
+
+	text := "Alert query is comparing constant values and the result is always false, this alert will never fire."
+	if isAlive {
+		text = "Alert query is comparing constant values and the result is always true, this alert will always fire."
+	}
+	problems = append(problems, Problem{
+		Lines:    rule.AlertingRule.Expr.Value.Lines,
+		Reporter: c.Reporter(),
+		Text:     text,
+		Details:  AlertsConstantValueCheckDetails,
+		Severity: Bug,
+	})
+
+	return problems
+}
@@ -0,0 +1,143 @@
+package checks_test
+
+import (
+	"testing"
+
+	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/promapi"
+)
+
+func newAlertsConstantValueCheck(_ *promapi.FailoverGroup) checks.RuleChecker {
+	return checks.NewAlertsConstantValueCheck()
+}
+
+func TestAlertsConstantValueCheck(t *testing.T) {
+	testCases := []checkTest{
+		{
+			description: "ignores recording rules",
+			content:     "- record: foo\n  expr: vector(1) > 0\n",
+			checker:     newAlertsConstantValueCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores rules with syntax errors",
+			content:     "- alert: foo\n  expr: vector(1) >\n",
+			checker:     newAlertsConstantValueCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores alerts without comparison",
+			content:     "- alert: foo\n  expr: vector(1)\n",
+			checker:     newAlertsConstantValueCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores alerts using bool",
+			content:     "- alert: foo\n  expr: vector(1) > bool 0\n",
+			checker:     newAlertsConstantValueCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores alerts using metrics",
+			content:     "- alert: foo\n  expr: up == 0\n",
+			checker:     newAlertsConstantValueCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores alerts with metrics and constants",
+			content:     "- alert: foo\n  expr: (foo or vector(0)) > 1\n",
+			checker:     newAlertsConstantValueCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores hour() comparison",
+			content:     "- alert: foo\n  expr: hour() > 22\n",
+			checker:     newAlertsConstantValueCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores day_of_week() comparison",
+			content:     "- alert: foo\n  expr: day_of_week() == 0\n",
+			checker:     newAlertsConstantValueCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores vector(time()) comparison",
+			content:     "- alert: foo\n  expr: vector(time()) > 1700000000\n",
+			checker:     newAlertsConstantValueCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "reports alerts that always fire",
+			content:     "- alert: foo\n  expr: vector(1) > 0\n",
+			checker:     newAlertsConstantValueCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.AlertsConstantValueCheckName,
+						Text:     "Alert query is comparing constant values and the result is always true, this alert will always fire.",
+						Details:  checks.AlertsConstantValueCheckDetails,
+						Severity: checks.Bug,
+					},
+				}
+			},
+		},
+		{
+			description: "reports alerts that never fire",
+			content:     "- alert: foo\n  expr: vector(0) > 1\n",
+			checker:     newAlertsConstantValueCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.AlertsConstantValueCheckName,
+						Text:     "Alert query is comparing constant values and the result is always false, this alert will never fire.",
+						Details:  checks.AlertsConstantValueCheckDetails,
+						Severity: checks.Bug,
+					},
+				}
+			},
+		},
+		{
+			description: "reports alerts that never fire with constant folding",
+			content:     "- alert: foo\n  expr: vector(2) * 2 == 5\n",
+			checker:     newAlertsConstantValueCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.AlertsConstantValueCheckName,
+						Text:     "Alert query is comparing constant values and the result is always false, this alert will never fire.",
+						Details:  checks.AlertsConstantValueCheckDetails,
+						Severity: checks.Bug,
+					},
+				}
+			},
+		},
+	}
+
+	runTests(t, testCases)
+}
@@ -0,0 +1,89 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/common/model"
+
+	"github.com/cloudflare/pint/internal/discovery"
+	"github.com/cloudflare/pint/internal/output"
+	"github.com/cloudflare/pint/internal/parser"
+)
+
+const (
+	AlertForIntervalCheckName    = "alerts/for_interval"
+	AlertForIntervalCheckDetails = "Alerting rules are only evaluated once every group `interval`, so the alert will stay in pending state for at least one full interval before it can fire.\n" +
+		"Setting `for` to a value lower than the evaluation interval has the same effect as setting it to the interval, which is likely not what you want."
+)
+
+func NewAlertsForIntervalCheck() AlertsForIntervalCheck {
+	return AlertsForIntervalCheck{}
+}
+
+type AlertsForIntervalCheck struct{}
+
+func (c AlertsForIntervalCheck) Meta() CheckMeta {
+	return CheckMeta{
+		States: []discovery.ChangeType{
+			discovery.Noop,
+			discovery.Added,
+			discovery.Modified,
+			discovery.Moved,
+		},
+		Online:        false,
+		AlwaysEnabled: false,
+	}
+}
+
+func (c AlertsForIntervalCheck) String() string {
+	return AlertForIntervalCheckName
+}
+
+func (c AlertsForIntervalCheck) Reporter() string {
+	return AlertForIntervalCheckName
+}
+
+func (c AlertsForIntervalCheck) Check(_ context.Context, path discovery.Path, rule parser.Rule, entries []discovery.Entry) (problems []Problem) {
+	if rule.AlertingRule == nil || rule.AlertingRule.For == nil {
+		// Alerts without for will fire on the first evaluation.
+		return problems
+	}
+
+	interval := groupInterval(path, rule, entries)
+	if interval == 0 {
+		return problems
+	}
+
+	forDur, err := model.ParseDuration(rule.AlertingRule.For.Value)
+	if err != nil || forDur == 0 || time.Duration(forDur) >= interval {
+		return problems
+	}
+
+	problems = append(problems, Problem{
+		Lines:    rule.AlertingRule.For.Lines,
+		Reporter: c.Reporter(),
+		Text: fmt.Sprintf("This alert is using `for: %s` but its rule group has `interval: %s`, alerts can't fire faster than the evaluation interval so `for` is effectively `%s`.",
+			rule.AlertingRule.For.Value, output.HumanizeDuration(interval), output.HumanizeDuration(interval)),
+		Details:  AlertForIntervalCheckDetails,
+		Severity: Warning,
+	})
+
+	return problems
+}
+
+// groupInterval returns the evaluation interval of the group given rule belongs to,
+// or zero if that group doesn't set it.
+func groupInterval(path discovery.Path, rule parser.Rule, entries []discovery.Entry) time.Duration {
+	for _, entry := range entries {
+		if entry.State == discovery.Removed || entry.PathError != nil {
+			continue
+		}
+		if entry.Path.SymlinkTarget != path.SymlinkTarget || !entry.Rule.IsSame(rule) {
+			continue
+		}
+		return entry.GroupInterval
+	}
+	return 0
+}
@@ -0,0 +1,106 @@
+package checks_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/discovery"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/promapi"
+)
+
+func newAlertsForIntervalCheck(_ *promapi.FailoverGroup) checks.RuleChecker {
+	return checks.NewAlertsForIntervalCheck()
+}
+
+func withGroupInterval(entries []discovery.Entry, interval time.Duration) []discovery.Entry {
+	for i := range entries {
+		entries[i].GroupInterval = interval
+	}
+	return entries
+}
+
+func TestAlertsForIntervalCheck(t *testing.T) {
+	testCases := []checkTest{
+		{
+			description: "ignores recording rules",
+			content:     "- record: foo\n  expr: up == 0\n",
+			checker:     newAlertsForIntervalCheck,
+			prometheus:  noProm,
+			entries:     withGroupInterval(mustParseContent("- record: foo\n  expr: up == 0\n"), time.Minute),
+			problems:    noProblems,
+		},
+		{
+			description: "ignores alerts without for",
+			content:     "- alert: foo\n  expr: up == 0\n",
+			checker:     newAlertsForIntervalCheck,
+			prometheus:  noProm,
+			entries:     withGroupInterval(mustParseContent("- alert: foo\n  expr: up == 0\n"), time.Minute),
+			problems:    noProblems,
+		},
+		{
+			description: "ignores for: 0",
+			content:     "- alert: foo\n  expr: up == 0\n  for: 0s\n",
+			checker:     newAlertsForIntervalCheck,
+			prometheus:  noProm,
+			entries:     withGroupInterval(mustParseContent("- alert: foo\n  expr: up == 0\n  for: 0s\n"), time.Minute),
+			problems:    noProblems,
+		},
+		{
+			description: "ignores groups without interval",
+			content:     "- alert: foo\n  expr: up == 0\n  for: 30s\n",
+			checker:     newAlertsForIntervalCheck,
+			prometheus:  noProm,
+			entries:     mustParseContent("- alert: foo\n  expr: up == 0\n  for: 30s\n"),
+			problems:    noProblems,
+		},
+		{
+			description: "ignores for equal to interval",
+			content:     "- alert: foo\n  expr: up == 0\n  for: 1m\n",
+			checker:     newAlertsForIntervalCheck,
+			prometheus:  noProm,
+			entries:     withGroupInterval(mustParseContent("- alert: foo\n  expr: up == 0\n  for: 1m\n"), time.Minute),
+			problems:    noProblems,
+		},
+		{
+			description: "ignores for longer than interval",
+			content:     "- alert: foo\n  expr: up == 0\n  for: 5m\n",
+			checker:     newAlertsForIntervalCheck,
+			prometheus:  noProm,
+			entries:     withGroupInterval(mustParseContent("- alert: foo\n  expr: up == 0\n  for: 5m\n"), time.Minute),
+			problems:    noProblems,
+		},
+		{
+			description: "ignores invalid for",
+			content:     "- alert: foo\n  expr: up == 0\n  for: abc\n",
+			checker:     newAlertsForIntervalCheck,
+			prometheus:  noProm,
+			entries:     withGroupInterval(mustParseContent("- alert: foo\n  expr: up == 0\n  for: abc\n"), time.Minute),
+			problems:    noProblems,
+		},
+		{
+			description: "reports for shorter than interval",
+			content:     "- alert: foo\n  expr: up == 0\n  for: 30s\n",
+			checker:     newAlertsForIntervalCheck,
+			prometheus:  noProm,
+			entries:     withGroupInterval(mustParseContent("- alert: foo\n  expr: up == 0\n  for: 30s\n"), time.Minute),
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 3,
+							Last:  3,
+						},
+						Reporter: checks.AlertForIntervalCheckName,
+						Text:     "This alert is using `for: 30s` but its rule group has `interval: 1m`, alerts can't fire faster than the evaluation interval so `for` is effectively `1m`.",
+						Details:  checks.AlertForIntervalCheckDetails,
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+	}
+
+	runTests(t, testCases)
+}
@@ -0,0 +1,97 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/prometheus/common/model"
+	promParser "github.com/prometheus/prometheus/promql/parser"
+
+	"github.com/cloudflare/pint/internal/discovery"
+	"github.com/cloudflare/pint/internal/parser"
+)
+
+const (
+	ForMissingCheckName    = "alerts/for_missing"
+	ForMissingCheckDetails = "Alerting rules without `for` will fire as soon as the query returns any results, even if that only happens during a single evaluation.\n" +
+		"Functions like `rate()` or `delta()` can return short spikes that will cause alerts to fire and resolve quickly, which is noisy.\n" +
+		"Setting `for` will make Prometheus wait until the query returns results for the given duration before firing."
+)
+
+var DefaultForMissingFunctions = []string{"rate", "irate", "delta"}
+
+func NewForMissingCheck(functions []string, comment string, severity Severity) ForMissingCheck {
+	if len(functions) == 0 {
+		functions = DefaultForMissingFunctions
+	}
+	return ForMissingCheck{
+		functions: functions,
+		comment:   comment,
+		severity:  severity,
+	}
+}
+
+type ForMissingCheck struct {
+	comment   string
+	functions []string
+	severity  Severity
+}
+
+func (c ForMissingCheck) Meta() CheckMeta {
+	return CheckMeta{
+		States: []discovery.ChangeType{
+			discovery.Noop,
+			discovery.Added,
+			discovery.Modified,
+			discovery.Moved,
+		},
+		Online:        false,
+		AlwaysEnabled: false,
+	}
+}
+
+func (c ForMissingCheck) String() string {
+	return ForMissingCheckName
+}
+
+func (c ForMissingCheck) Reporter() string {
+	return ForMissingCheckName
+}
+
+func (c ForMissingCheck) Check(_ context.Context, _ discovery.Path, rule parser.Rule, _ []discovery.Entry) (problems []Problem) {
+	if rule.AlertingRule == nil || rule.AlertingRule.Expr.SyntaxError != nil {
+		return problems
+	}
+
+	lines := rule.AlertingRule.Alert.Lines
+	if rule.AlertingRule.For != nil {
+		d, err := model.ParseDuration(rule.AlertingRule.For.Value)
+		if err != nil || d > 0 {
+			return problems
+		}
+		lines = rule.AlertingRule.For.Lines
+	}
+
+	for _, node := range parser.WalkDownExpr[*promParser.Call](rule.AlertingRule.Expr.Query) {
+		n := node.Expr.(*promParser.Call)
+		if !slices.Contains(c.functions, n.Func.Name) {
+			continue
+		}
+		details := ForMissingCheckDetails
+		if c.comment != "" {
+			details += "\n" + maybeComment(c.comment)
+		}
+		problems = append(problems, Problem{
+			Lines:    lines,
+			Reporter: c.Reporter(),
+			Text: fmt.Sprintf("This alert doesn't set `for` but the query is using `%s()`, it will fire on a single evaluation that returns any results.",
+				n.Func.Name),
+			Details:  details,
+			Severity: c.severity,
+		})
+		break
+	}
+
+	return problems
+}
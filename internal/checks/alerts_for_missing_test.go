@@ -0,0 +1,142 @@
+package checks_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/promapi"
+)
+
+func newForMissingCheck(_ *promapi.FailoverGroup) checks.RuleChecker {
+	return checks.NewForMissingCheck(nil, "", checks.Information)
+}
+
+func forMissingText(fn string) string {
+	return fmt.Sprintf("This alert doesn't set `for` but the query is using `%s()`, it will fire on a single evaluation that returns any results.", fn)
+}
+
+func TestForMissingCheck(t *testing.T) {
+	testCases := []checkTest{
+		{
+			description: "ignores recording rules",
+			content:     "- record: foo\n  expr: rate(errors[5m]) > 0\n",
+			checker:     newForMissingCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores rules with syntax errors",
+			content:     "- alert: foo\n  expr: rate(errors[5m] > 0\n",
+			checker:     newForMissingCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores alerts with for",
+			content:     "- alert: foo\n  expr: rate(errors[5m]) > 0\n  for: 5m\n",
+			checker:     newForMissingCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores alerts with invalid for",
+			content:     "- alert: foo\n  expr: rate(errors[5m]) > 0\n  for: foo\n",
+			checker:     newForMissingCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores other functions",
+			content:     "- alert: foo\n  expr: increase(errors[5m]) > 0\n",
+			checker:     newForMissingCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "reports rate() without for",
+			content:     "- alert: foo\n  expr: rate(errors[5m]) > 0\n",
+			checker:     newForMissingCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 1,
+							Last:  1,
+						},
+						Reporter: checks.ForMissingCheckName,
+						Text:     forMissingText("rate"),
+						Details:  checks.ForMissingCheckDetails,
+						Severity: checks.Information,
+					},
+				}
+			},
+		},
+		{
+			description: "reports for: 0s",
+			content:     "- alert: foo\n  expr: sum(irate(errors[5m])) by(job) > 0\n  for: 0s\n",
+			checker:     newForMissingCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 3,
+							Last:  3,
+						},
+						Reporter: checks.ForMissingCheckName,
+						Text:     forMissingText("irate"),
+						Details:  checks.ForMissingCheckDetails,
+						Severity: checks.Information,
+					},
+				}
+			},
+		},
+		{
+			description: "reports only once",
+			content:     "- alert: foo\n  expr: delta(temp[5m]) > 0 and rate(errors[5m]) > 0\n",
+			checker:     newForMissingCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 1,
+							Last:  1,
+						},
+						Reporter: checks.ForMissingCheckName,
+						Text:     forMissingText("delta"),
+						Details:  checks.ForMissingCheckDetails,
+						Severity: checks.Information,
+					},
+				}
+			},
+		},
+		{
+			description: "custom functions, comment and severity",
+			content:     "- alert: foo\n  expr: increase(errors[5m]) > 0\n",
+			checker: func(_ *promapi.FailoverGroup) checks.RuleChecker {
+				return checks.NewForMissingCheck([]string{"increase"}, "use for", checks.Warning)
+			},
+			prometheus: noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 1,
+							Last:  1,
+						},
+						Reporter: checks.ForMissingCheckName,
+						Text:     forMissingText("increase"),
+						Details:  checks.ForMissingCheckDetails + "\nRule comment: use for",
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+	}
+
+	runTests(t, testCases)
+}
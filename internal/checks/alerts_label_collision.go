@@ -0,0 +1,76 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/cloudflare/pint/internal/discovery"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/parser/utils"
+)
+
+const (
+	LabelCollisionCheckName = "alerts/label_collision"
+)
+
+func NewLabelCollisionCheck() LabelCollisionCheck {
+	return LabelCollisionCheck{}
+}
+
+type LabelCollisionCheck struct{}
+
+func (c LabelCollisionCheck) Meta() CheckMeta {
+	return CheckMeta{
+		States: []discovery.ChangeType{
+			discovery.Noop,
+			discovery.Added,
+			discovery.Modified,
+			discovery.Moved,
+		},
+		Online:        false,
+		AlwaysEnabled: false,
+	}
+}
+
+func (c LabelCollisionCheck) String() string {
+	return LabelCollisionCheckName
+}
+
+func (c LabelCollisionCheck) Reporter() string {
+	return LabelCollisionCheckName
+}
+
+func (c LabelCollisionCheck) Check(ctx context.Context, _ discovery.Path, rule parser.Rule, _ []discovery.Entry) (problems []Problem) {
+	if rule.AlertingRule == nil || rule.AlertingRule.Labels == nil {
+		return problems
+	}
+
+	expr := rule.Expr()
+	if expr.SyntaxError != nil {
+		return problems
+	}
+
+	src := utils.CachedLabelsSource(ctx, expr.Value.Value, expr.Query.Expr)
+	for _, label := range rule.AlertingRule.Labels.Items {
+		for _, s := range src {
+			if s.IsDead || !slices.Contains(s.ExcludedLabels, label.Key.Value) {
+				continue
+			}
+			problems = append(problems, Problem{
+				Lines: parser.LineRange{
+					First: label.Key.Lines.First,
+					Last:  label.Value.Lines.Last,
+				},
+				Reporter: c.Reporter(),
+				Text: fmt.Sprintf("This alert sets the `%s` label but the query explicitly removes it from the results, the static value `%s` will always be used.",
+					label.Key.Value, label.Value.Value),
+				Details:  excludeReasonDetails(expr.Value.Value, s.ExcludeReasons(label.Key.Value)),
+				Severity: Warning,
+			})
+			break
+		}
+	}
+
+	return problems
+}
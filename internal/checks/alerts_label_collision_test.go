@@ -0,0 +1,95 @@
+package checks_test
+
+import (
+	"testing"
+
+	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/promapi"
+)
+
+func newLabelCollisionCheck(_ *promapi.FailoverGroup) checks.RuleChecker {
+	return checks.NewLabelCollisionCheck()
+}
+
+func TestLabelCollisionCheck(t *testing.T) {
+	testCases := []checkTest{
+		{
+			description: "ignores recording rules",
+			content:     "- record: foo\n  expr: sum(foo) without(job)\n  labels:\n    job: x\n",
+			checker:     newLabelCollisionCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores alerts without labels",
+			content:     "- alert: foo\n  expr: sum(foo) without(job) > 0\n",
+			checker:     newLabelCollisionCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores rules with syntax errors",
+			content:     "- alert: foo\n  expr: sum(foo) without(job) > 0)\n  labels:\n    job: x\n",
+			checker:     newLabelCollisionCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores labels not removed by the query",
+			content:     "- alert: foo\n  expr: sum(foo) without(instance) > 0\n  labels:\n    job: x\n",
+			checker:     newLabelCollisionCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores labels removed by by()",
+			content:     "- alert: foo\n  expr: sum(foo) by(instance) > 0\n  labels:\n    job: x\n",
+			checker:     newLabelCollisionCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "reports labels removed by without()",
+			content:     "- alert: foo\n  expr: sum(foo) without(job) > 0\n  labels:\n    severity: page\n    job: x\n",
+			checker:     newLabelCollisionCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 5,
+							Last:  5,
+						},
+						Reporter: checks.LabelCollisionCheckName,
+						Text:     "This alert sets the `job` label but the query explicitly removes it from the results, the static value `x` will always be used.",
+						Details:  "Query is using aggregation with `without(job)`, all labels included inside `without(...)` will be removed from the results.\nQuery fragment causing this problem: `sum(foo) without(job)`.",
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+		{
+			description: "reports labels removed by ignoring()",
+			content:     "- alert: foo\n  expr: foo > ignoring(job) bar\n  labels:\n    job: x\n",
+			checker:     newLabelCollisionCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 4,
+							Last:  4,
+						},
+						Reporter: checks.LabelCollisionCheckName,
+						Text:     "This alert sets the `job` label but the query explicitly removes it from the results, the static value `x` will always be used.",
+						Details:  "Query is using one-to-one vector matching with `ignoring(job)`, all labels included inside `ignoring(...)` will be removed on the results.",
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+	}
+
+	runTests(t, testCases)
+}
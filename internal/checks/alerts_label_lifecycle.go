@@ -0,0 +1,272 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/prometheus/prometheus/model/labels"
+	promParser "github.com/prometheus/prometheus/promql/parser"
+
+	"github.com/cloudflare/pint/internal/discovery"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/parser/utils"
+)
+
+const (
+	LabelLifecycleCheckName = "alerts/label_lifecycle"
+)
+
+func NewLabelLifecycleCheck() LabelLifecycleCheck {
+	return LabelLifecycleCheck{}
+}
+
+type LabelLifecycleCheck struct{}
+
+func (c LabelLifecycleCheck) Meta() CheckMeta {
+	return CheckMeta{
+		States: []discovery.ChangeType{
+			discovery.Noop,
+			discovery.Added,
+			discovery.Modified,
+			discovery.Moved,
+		},
+		Online:        false,
+		AlwaysEnabled: false,
+	}
+}
+
+func (c LabelLifecycleCheck) String() string {
+	return LabelLifecycleCheckName
+}
+
+func (c LabelLifecycleCheck) Reporter() string {
+	return LabelLifecycleCheckName
+}
+
+func (c LabelLifecycleCheck) Check(ctx context.Context, _ discovery.Path, rule parser.Rule, entries []discovery.Entry) (problems []Problem) {
+	if rule.AlertingRule == nil || rule.AlertingRule.Annotations == nil {
+		return nil
+	}
+
+	expr := rule.AlertingRule.Expr
+	if expr.SyntaxError != nil {
+		return nil
+	}
+
+	src := utils.CachedLabelsSource(ctx, expr.Value.Value, expr.Query.Expr)
+	for _, annotation := range rule.AlertingRule.Annotations.Items {
+		for _, name := range templateLabelNames(annotation.Key.Value, annotation.Value.Value) {
+			if _, ok := findMissingLabel(name, src); ok {
+				// Already reported by alerts/template.
+				continue
+			}
+			problem, ok := c.findDroppedLabel(ctx, expr, name)
+			if !ok {
+				problem, ok = c.findDroppedByRecord(ctx, expr, name, entries)
+			}
+			if !ok {
+				continue
+			}
+			problems = append(problems, Problem{
+				Lines: parser.LineRange{
+					First: annotation.Key.Lines.First,
+					Last:  annotation.Value.Lines.Last,
+				},
+				Reporter: c.Reporter(),
+				Text:     problem.text,
+				Details:  problem.details,
+				Severity: problem.severity,
+			})
+		}
+	}
+
+	return problems
+}
+
+// findDroppedLabel looks for an aggregation that removes given label
+// while the query it aggregates is known to return it.
+func (c LabelLifecycleCheck) findDroppedLabel(ctx context.Context, expr parser.PromQLExpr, name string) (exprProblem, bool) {
+	for _, node := range parser.WalkDownExpr[*promParser.AggregateExpr](expr.Query) {
+		agg := node.Expr.(*promParser.AggregateExpr)
+		if !aggregationDropsLabel(agg, name) {
+			continue
+		}
+		if !isOnOutputPath(node, name) {
+			continue
+		}
+		for _, s := range utils.CachedLabelsSource(ctx, expr.Value.Value, agg.Expr) {
+			if s.IsDead {
+				continue
+			}
+			if !slices.Contains(s.IncludedLabels, name) && !slices.Contains(s.GuaranteedLabels, name) {
+				continue
+			}
+
+			inner := agg.Expr.PositionRange()
+			outer := agg.PositionRange()
+			return exprProblem{
+				text: fmt.Sprintf("Template is using `%s` label, this label is present on the results of `%s` but it's removed by `%s()` wrapping it.",
+					name, expr.Value.Value[inner.Start:inner.End], agg.Op),
+				details: fmt.Sprintf("%s\nQuery fragment causing this problem: `%s`.",
+					aggregationDropReason(agg), expr.Value.Value[outer.Start:outer.End]),
+				severity: Warning,
+			}, true
+		}
+	}
+	return exprProblem{}, false
+}
+
+// findDroppedByRecord looks for selectors using recording rules that will
+// never produce given label.
+func (c LabelLifecycleCheck) findDroppedByRecord(ctx context.Context, expr parser.PromQLExpr, name string, entries []discovery.Entry) (exprProblem, bool) {
+	for _, node := range parser.WalkDownExpr[*promParser.VectorSelector](expr.Query) {
+		vs := node.Expr.(*promParser.VectorSelector)
+		if vs.Name == "" || !isOnOutputPath(node, name) {
+			continue
+		}
+		if slices.ContainsFunc(vs.LabelMatchers, func(m *labels.Matcher) bool { return m.Name == name }) {
+			continue
+		}
+
+		var found, dropped []recordDrop
+		for _, entry := range entries {
+			if entry.State == discovery.Removed || entry.PathError != nil || entry.Rule.Error.Err != nil {
+				continue
+			}
+			rr := entry.Rule.RecordingRule
+			if rr == nil || rr.Record.Value != vs.Name || rr.Expr.SyntaxError != nil {
+				continue
+			}
+			found = append(found, recordDrop{entry: entry})
+			if buildRuleLabels(rr.Labels).Has(name) {
+				continue
+			}
+			if src, ok := recordDropsLabel(ctx, rr.Expr, name); ok {
+				dropped = append(dropped, recordDrop{entry: entry, src: src})
+			}
+		}
+		// Only report it if every definition of this recording rule drops the label.
+		if len(found) == 0 || len(found) != len(dropped) {
+			continue
+		}
+
+		drop := dropped[0]
+		rr := drop.entry.Rule.RecordingRule
+		reasonLabel := ""
+		if slices.Contains(drop.src.ExcludedLabels, name) {
+			reasonLabel = name
+		}
+		details := fmt.Sprintf("`%s` recording rule is defined at `%s:%s`.",
+			rr.Record.Value, drop.entry.Path.SymlinkTarget, drop.entry.Rule.Lines)
+		if reason, ok := drop.src.ExcludeReason[reasonLabel]; ok {
+			details = fmt.Sprintf("%s\n%s\nQuery fragment causing this problem: `%s`.", details, reason.Reason, reason.Fragment)
+		}
+		return exprProblem{
+			text: fmt.Sprintf("Template is using `%s` label but `%s` recording rule used in this query doesn't produce it.",
+				name, vs.Name),
+			details:  details,
+			severity: Warning,
+		}, true
+	}
+	return exprProblem{}, false
+}
+
+type recordDrop struct {
+	entry discovery.Entry
+	src   utils.Source
+}
+
+// recordDropsLabel returns true if none of the sources of given recording rule
+// query will have given label.
+func recordDropsLabel(ctx context.Context, expr parser.PromQLExpr, name string) (utils.Source, bool) {
+	var src utils.Source
+	var live int
+	for _, s := range utils.CachedLabelsSource(ctx, expr.Value.Value, expr.Query.Expr) {
+		if s.IsDead {
+			continue
+		}
+		live++
+		if len(utils.CompatibleLabels(s, []string{name})) == 0 {
+			return utils.Source{}, false
+		}
+		if live == 1 {
+			src = s
+		}
+	}
+	return src, live > 0
+}
+
+func aggregationDropReason(agg *promParser.AggregateExpr) string {
+	switch {
+	case agg.Without:
+		return fmt.Sprintf("Query is using aggregation with `without(%s)`, all labels included inside `without(...)` will be removed from the results.",
+			strings.Join(agg.Grouping, ", "))
+	case len(agg.Grouping) == 0:
+		return "Query is using aggregation that removes all labels."
+	default:
+		return fmt.Sprintf("Query is using aggregation with `by(%s)`, only labels included inside `by(...)` will be present on the results.",
+			strings.Join(agg.Grouping, ", "))
+	}
+}
+
+func aggregationDropsLabel(agg *promParser.AggregateExpr, name string) bool {
+	if agg.Op == promParser.COUNT_VALUES {
+		if s, ok := agg.Param.(*promParser.StringLiteral); ok && s.Val == name {
+			return false
+		}
+	}
+	if agg.Without {
+		return slices.Contains(agg.Grouping, name)
+	}
+	return !slices.Contains(agg.Grouping, name)
+}
+
+// isOnOutputPath returns true if labels of given node results are passed all the way
+// to the query results and nothing along the way is setting given label.
+func isOnOutputPath(node *parser.PromQLNode, name string) bool {
+	for child, parent := node, node.Parent; parent != nil; child, parent = parent, parent.Parent {
+		switch n := parent.Expr.(type) {
+		case *promParser.Call:
+			switch n.Func.Name {
+			case "absent", "absent_over_time", "scalar", "vector":
+				return false
+			case "label_replace", "label_join":
+				if len(n.Args) > 1 {
+					if dst, ok := n.Args[1].(*promParser.StringLiteral); ok && dst.Val == name {
+						return false
+					}
+				}
+			}
+		case *promParser.BinaryExpr:
+			isLHS := n.LHS == child.Expr
+			if n.VectorMatching == nil {
+				continue
+			}
+			if n.LHS.Type() != promParser.ValueTypeVector || n.RHS.Type() != promParser.ValueTypeVector {
+				continue
+			}
+			// nolint:exhaustive
+			switch n.VectorMatching.Card {
+			case promParser.CardOneToMany:
+				if isLHS || slices.Contains(n.VectorMatching.Include, name) {
+					return false
+				}
+			case promParser.CardManyToOne:
+				if !isLHS || slices.Contains(n.VectorMatching.Include, name) {
+					return false
+				}
+			case promParser.CardManyToMany:
+				if n.Op != promParser.LOR && !isLHS {
+					return false
+				}
+			default:
+				if !isLHS {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,214 @@
+package checks_test
+
+import (
+	"testing"
+
+	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/discovery"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/promapi"
+)
+
+func newLabelLifecycleCheck(_ *promapi.FailoverGroup) checks.RuleChecker {
+	return checks.NewLabelLifecycleCheck()
+}
+
+func TestLabelLifecycleCheck(t *testing.T) {
+	testCases := []checkTest{
+		{
+			description: "ignores recording rules",
+			content:     "- record: foo\n  expr: sum(foo * on(instance) group_left(team) bar) by(instance)\n",
+			checker:     newLabelLifecycleCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores rules with syntax errors",
+			content:     "- alert: Foo\n  expr: sum(foo) by(\n  annotations:\n    summary: '{{ $labels.team }}'\n",
+			checker:     newLabelLifecycleCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores alerts without annotations",
+			content:     "- alert: Foo\n  expr: sum(foo * on(instance) group_left(team) bar) by(instance) > 0\n",
+			checker:     newLabelLifecycleCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores labels kept by aggregation",
+			content:     "- alert: Foo\n  expr: sum(foo * on(instance) group_left(team) bar) by(instance, team) > 0\n  annotations:\n    summary: '{{ $labels.team }} {{ $labels.instance }}'\n",
+			checker:     newLabelLifecycleCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores labels never present on results",
+			content:     "- alert: Foo\n  expr: sum(foo) by(instance) > 0\n  annotations:\n    summary: '{{ $labels.team }}'\n",
+			checker:     newLabelLifecycleCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores labels reported by alerts/template",
+			content:     "- alert: Foo\n  expr: sum(foo * on(instance) group_left(team) bar) without(team) > 0\n  annotations:\n    summary: '{{ $labels.team }}'\n",
+			checker:     newLabelLifecycleCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores labels added back with label_replace",
+			content:     "- alert: Foo\n  expr: label_replace(sum(sum(foo) by(instance, team)) by(instance), \"team\", \"$1\", \"instance\", \"(.+)\")\n  annotations:\n    summary: '{{ $labels.team }}'\n",
+			checker:     newLabelLifecycleCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores labels added back with group_left",
+			content:     "- alert: Foo\n  expr: sum(sum(foo) by(instance, team)) by(instance) * on(instance) group_left(team) bar\n  annotations:\n    summary: '{{ $labels.team }}'\n",
+			checker:     newLabelLifecycleCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores aggregations on the right hand side",
+			content:     "- alert: Foo\n  expr: foo{team=\"a\"} > on(instance) sum(sum(bar) by(instance, team)) by(instance)\n  annotations:\n    summary: '{{ $labels.team }}'\n",
+			checker:     newLabelLifecycleCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores count_values label",
+			content:     "- alert: Foo\n  expr: count_values(\"team\", sum(foo) by(instance, team)) > 0\n  annotations:\n    summary: '{{ $labels.team }}'\n",
+			checker:     newLabelLifecycleCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "reports join label dropped by outer sum by()",
+			content:     "- alert: Foo\n  expr: sum(foo * on(instance) group_left(team) bar) by(instance) > 0\n  annotations:\n    summary: '{{ $labels.team }} {{ $labels.instance }}'\n",
+			checker:     newLabelLifecycleCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 4,
+							Last:  4,
+						},
+						Reporter: checks.LabelLifecycleCheckName,
+						Text:     "Template is using `team` label, this label is present on the results of `foo * on(instance) group_left(team) bar` but it's removed by `sum()` wrapping it.",
+						Details:  "Query is using aggregation with `by(instance)`, only labels included inside `by(...)` will be present on the results.\nQuery fragment causing this problem: `sum(foo * on(instance) group_left(team) bar) by(instance)`.",
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+		{
+			description: "ignores label dropped by nested aggregation, reported by alerts/template",
+			content:     "- alert: Foo\n  expr: max(sum(foo{env=\"prod\"}) by(instance, team)) by(instance) > 0 or bar\n  annotations:\n    summary: '{{ .Labels.team }}'\n",
+			checker:     newLabelLifecycleCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores recording rules that are not defined",
+			content:     "- alert: Foo\n  expr: job:up:sum > 0\n  annotations:\n    summary: '{{ $labels.instance }}'\n",
+			checker:     newLabelLifecycleCheck,
+			prometheus:  noProm,
+			entries:     mustParseContentAt("- record: job:down:sum\n  expr: sum(up) by(job)\n", "records.yml"),
+			problems:    noProblems,
+		},
+		{
+			description: "ignores labels kept by recording rule",
+			content:     "- alert: Foo\n  expr: job:up:sum > 0\n  annotations:\n    summary: '{{ $labels.instance }}'\n",
+			checker:     newLabelLifecycleCheck,
+			prometheus:  noProm,
+			entries:     mustParseContentAt("- record: job:up:sum\n  expr: sum(up) by(job, instance)\n", "records.yml"),
+			problems:    noProblems,
+		},
+		{
+			description: "ignores labels set by recording rule",
+			content:     "- alert: Foo\n  expr: job:up:sum > 0\n  annotations:\n    summary: '{{ $labels.instance }}'\n",
+			checker:     newLabelLifecycleCheck,
+			prometheus:  noProm,
+			entries:     mustParseContentAt("- record: job:up:sum\n  expr: sum(up) by(job)\n  labels:\n    instance: all\n", "records.yml"),
+			problems:    noProblems,
+		},
+		{
+			description: "ignores labels kept by one of recording rule definitions",
+			content:     "- alert: Foo\n  expr: job:up:sum > 0\n  annotations:\n    summary: '{{ $labels.instance }}'\n",
+			checker:     newLabelLifecycleCheck,
+			prometheus:  noProm,
+			entries: append(
+				mustParseContentAt("- record: job:up:sum\n  expr: sum(up) by(job)\n", "a.yml"),
+				mustParseContentAt("- record: job:up:sum\n  expr: up\n", "b.yml")...,
+			),
+			problems: noProblems,
+		},
+		{
+			description: "ignores labels set by the alert query",
+			content:     "- alert: Foo\n  expr: label_replace(job:up:sum, \"instance\", \"$1\", \"job\", \"(.+)\") > 0\n  annotations:\n    summary: '{{ $labels.instance }}'\n",
+			checker:     newLabelLifecycleCheck,
+			prometheus:  noProm,
+			entries:     mustParseContentAt("- record: job:up:sum\n  expr: sum(up) by(job)\n", "records.yml"),
+			problems:    noProblems,
+		},
+		{
+			description: "ignores removed recording rules",
+			content:     "- alert: Foo\n  expr: job:up:sum > 0\n  annotations:\n    summary: '{{ $labels.instance }}'\n",
+			checker:     newLabelLifecycleCheck,
+			prometheus:  noProm,
+			entries: func() []discovery.Entry {
+				entries := mustParseContentAt("- record: job:up:sum\n  expr: sum(up) by(job)\n", "records.yml")
+				entries[0].State = discovery.Removed
+				return entries
+			}(),
+			problems: noProblems,
+		},
+		{
+			description: "reports label dropped by recording rule by()",
+			content:     "- alert: Foo\n  expr: job:up:sum > 0\n  annotations:\n    summary: '{{ $labels.instance }}'\n",
+			checker:     newLabelLifecycleCheck,
+			prometheus:  noProm,
+			entries:     mustParseContentAt("- record: job:up:sum\n  expr: sum(up) by(job)\n", "records.yml"),
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 4,
+							Last:  4,
+						},
+						Reporter: checks.LabelLifecycleCheckName,
+						Text:     "Template is using `instance` label but `job:up:sum` recording rule used in this query doesn't produce it.",
+						Details:  "`job:up:sum` recording rule is defined at `records.yml:1-2`.\nQuery is using aggregation with `by(job)`, only labels included inside `by(...)` will be present on the results.\nQuery fragment causing this problem: `sum(up) by(job)`.",
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+		{
+			description: "reports label dropped by recording rule without()",
+			content:     "- alert: Foo\n  expr: rate(job:requests:rate5m[5m]) > 0\n  annotations:\n    summary: '{{ $labels.instance }}'\n",
+			checker:     newLabelLifecycleCheck,
+			prometheus:  noProm,
+			entries:     mustParseContentAt("- record: job:requests:rate5m\n  expr: sum(rate(requests_total[5m])) without(instance)\n", "records.yml"),
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 4,
+							Last:  4,
+						},
+						Reporter: checks.LabelLifecycleCheckName,
+						Text:     "Template is using `instance` label but `job:requests:rate5m` recording rule used in this query doesn't produce it.",
+						Details:  "`job:requests:rate5m` recording rule is defined at `records.yml:1-2`.\nQuery is using aggregation with `without(instance)`, all labels included inside `without(...)` will be removed from the results.\nQuery fragment causing this problem: `sum(rate(requests_total[5m])) without(instance)`.",
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+	}
+	runTests(t, testCases)
+}
@@ -0,0 +1,154 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	promParser "github.com/prometheus/prometheus/promql/parser"
+
+	"github.com/cloudflare/pint/internal/discovery"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/parser/utils"
+)
+
+const (
+	AlertsOrLabelsCheckName    = "alerts/or_labels"
+	AlertsOrLabelsCheckDetails = "Results of each side of the `or` operator are returned with their own labels.\n" +
+		"If some side of the `or` returns a different set of labels then alerts created from this query will have inconsistent labels, " +
+		"which can break Alertmanager routing, grouping and inhibition rules.\n" +
+		"Make sure that all sides of the `or` operator return the same labels, for example by using aggregations with the same `by(...)` clause."
+)
+
+func NewAlertsOrLabelsCheck() AlertsOrLabelsCheck {
+	return AlertsOrLabelsCheck{}
+}
+
+type AlertsOrLabelsCheck struct{}
+
+func (c AlertsOrLabelsCheck) Meta() CheckMeta {
+	return CheckMeta{
+		States: []discovery.ChangeType{
+			discovery.Noop,
+			discovery.Added,
+			discovery.Modified,
+			discovery.Moved,
+		},
+		Online:        false,
+		AlwaysEnabled: false,
+	}
+}
+
+func (c AlertsOrLabelsCheck) String() string {
+	return AlertsOrLabelsCheckName
+}
+
+func (c AlertsOrLabelsCheck) Reporter() string {
+	return AlertsOrLabelsCheckName
+}
+
+func (c AlertsOrLabelsCheck) Check(ctx context.Context, _ discovery.Path, rule parser.Rule, _ []discovery.Entry) (problems []Problem) {
+	if rule.AlertingRule == nil {
+		return problems
+	}
+
+	expr := rule.Expr()
+	if expr.SyntaxError != nil {
+		return problems
+	}
+
+	// Only look at the top level of the query, results of an or nested inside
+	// other operations might have their labels changed later.
+	branches := orBranches(expr.Query.Expr)
+	if len(branches) < 2 {
+		return problems
+	}
+
+	var first promParser.Node
+	var firstLabels orLabels
+	for _, branch := range branches {
+		ls, ok := branchLabels(ctx, expr.Value.Value, branch)
+		if !ok {
+			continue
+		}
+		if first == nil {
+			first, firstLabels = branch, ls
+			continue
+		}
+		if ls.equal(firstLabels) {
+			continue
+		}
+		problems = append(problems, Problem{
+			Lines:    expr.Value.Lines,
+			Reporter: c.Reporter(),
+			Text: fmt.Sprintf("`%s` returns %s but `%s` returns %s, alerts created from this query will have inconsistent labels.",
+				first.String(), firstLabels, branch.String(), ls),
+			Details:  AlertsOrLabelsCheckDetails,
+			Severity: Warning,
+		})
+	}
+
+	return problems
+}
+
+// orBranches returns all sides of a chain of or operators, like `a or b or c`.
+func orBranches(node promParser.Node) (branches []promParser.Node) {
+	switch n := node.(type) {
+	case *promParser.ParenExpr:
+		return orBranches(n.Expr)
+	case *promParser.BinaryExpr:
+		if n.Op == promParser.LOR {
+			branches = append(branches, orBranches(n.LHS)...)
+			branches = append(branches, orBranches(n.RHS)...)
+			return branches
+		}
+	}
+	return []promParser.Node{node}
+}
+
+type orLabels struct {
+	names []string
+	fixed bool
+}
+
+func (ol orLabels) equal(other orLabels) bool {
+	return ol.fixed == other.fixed && slices.Equal(ol.names, other.names)
+}
+
+func (ol orLabels) String() string {
+	if !ol.fixed {
+		return "all labels of the queried time series"
+	}
+	if len(ol.names) == 0 {
+		return "no labels"
+	}
+	names := make([]string, 0, len(ol.names))
+	for _, name := range ol.names {
+		names = append(names, "`"+name+"`")
+	}
+	return "only " + strings.Join(names, ", ") + " labels"
+}
+
+// branchLabels returns the set of labels on the results of given branch.
+// It returns false if that can't be determined because the branch has
+// multiple sources that would return different labels.
+func branchLabels(ctx context.Context, expr string, node promParser.Node) (ol orLabels, ok bool) {
+	var found bool
+	for _, src := range utils.CachedLabelsSource(ctx, expr, node) {
+		if src.IsDead {
+			continue
+		}
+		ls := orLabels{fixed: src.FixedLabels}
+		if src.FixedLabels {
+			ls.names = slices.Clone(src.IncludedLabels)
+			slices.Sort(ls.names)
+			ls.names = slices.Compact(ls.names)
+		}
+		if found && !ls.equal(ol) {
+			return ol, false
+		}
+		ol, found = ls, true
+	}
+	return ol, found
+}
@@ -0,0 +1,112 @@
+package checks_test
+
+import (
+	"testing"
+
+	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/promapi"
+)
+
+func newAlertsOrLabelsCheck(_ *promapi.FailoverGroup) checks.RuleChecker {
+	return checks.NewAlertsOrLabelsCheck()
+}
+
+func TestAlertsOrLabelsCheck(t *testing.T) {
+	testCases := []checkTest{
+		{
+			description: "ignores rules with syntax errors",
+			content:     "- alert: foo\n  expr: up == 0 or\n",
+			checker:     newAlertsOrLabelsCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores recording rules",
+			content:     "- record: foo\n  expr: up == 0 or absent(up)\n",
+			checker:     newAlertsOrLabelsCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores queries without or",
+			content:     "- alert: foo\n  expr: up == 0\n",
+			checker:     newAlertsOrLabelsCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores or with the same labels",
+			content:     "- alert: foo\n  expr: sum(foo) by(job) > 0 or sum(bar) by(job) > 0\n",
+			checker:     newAlertsOrLabelsCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores or between selectors",
+			content:     "- alert: foo\n  expr: foo > 0 or bar > 0\n",
+			checker:     newAlertsOrLabelsCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores nested or",
+			content:     "- alert: foo\n  expr: sum(foo or vector(0)) > 0\n",
+			checker:     newAlertsOrLabelsCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "reports absent() in or",
+			content:     "- alert: foo\n  expr: up == 0 or absent(up)\n",
+			checker:     newAlertsOrLabelsCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.AlertsOrLabelsCheckName,
+						Text:     "`up == 0` returns all labels of the queried time series but `absent(up)` returns no labels, alerts created from this query will have inconsistent labels.",
+						Details:  checks.AlertsOrLabelsCheckDetails,
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+		{
+			description: "reports different by() labels",
+			content:     "- alert: foo\n  expr: (sum(foo) by(job) > 0) or (sum(bar) by(job, instance) > 0) or (sum(bar) by(instance, job) > 0)\n",
+			checker:     newAlertsOrLabelsCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.AlertsOrLabelsCheckName,
+						Text:     "`sum by (job) (foo) > 0` returns only `job` labels but `sum by (job, instance) (bar) > 0` returns only `instance`, `job` labels, alerts created from this query will have inconsistent labels.",
+						Details:  checks.AlertsOrLabelsCheckDetails,
+						Severity: checks.Warning,
+					},
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.AlertsOrLabelsCheckName,
+						Text:     "`sum by (job) (foo) > 0` returns only `job` labels but `sum by (instance, job) (bar) > 0` returns only `instance`, `job` labels, alerts created from this query will have inconsistent labels.",
+						Details:  checks.AlertsOrLabelsCheckDetails,
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+	}
+
+	runTests(t, testCases)
+}
@@ -0,0 +1,89 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cloudflare/pint/internal/discovery"
+	"github.com/cloudflare/pint/internal/parser"
+)
+
+const (
+	RequiredAnnotationsCheckName    = "alerts/required_annotations"
+	RequiredAnnotationsCheckDetails = "Annotations are used to provide more information about firing alerts to anyone receiving them.\n" +
+		"Alerts without them are harder to understand and act on."
+)
+
+var DefaultRequiredAnnotations = []string{"summary", "description"}
+
+func NewRequiredAnnotationsCheck(names []string, comment string, severity Severity) RequiredAnnotationsCheck {
+	if len(names) == 0 {
+		names = DefaultRequiredAnnotations
+	}
+	return RequiredAnnotationsCheck{
+		names:    names,
+		comment:  comment,
+		severity: severity,
+	}
+}
+
+type RequiredAnnotationsCheck struct {
+	comment  string
+	names    []string
+	severity Severity
+}
+
+func (c RequiredAnnotationsCheck) Meta() CheckMeta {
+	return CheckMeta{
+		States: []discovery.ChangeType{
+			discovery.Noop,
+			discovery.Added,
+			discovery.Modified,
+			discovery.Moved,
+		},
+		Online:        false,
+		AlwaysEnabled: false,
+	}
+}
+
+func (c RequiredAnnotationsCheck) String() string {
+	return RequiredAnnotationsCheckName
+}
+
+func (c RequiredAnnotationsCheck) Reporter() string {
+	return RequiredAnnotationsCheckName
+}
+
+func (c RequiredAnnotationsCheck) Check(_ context.Context, _ discovery.Path, rule parser.Rule, _ []discovery.Entry) (problems []Problem) {
+	if rule.AlertingRule == nil {
+		return problems
+	}
+
+	lines := rule.Lines
+	if rule.AlertingRule.Annotations != nil {
+		lines = rule.AlertingRule.Annotations.Lines
+	}
+
+	details := RequiredAnnotationsCheckDetails
+	if c.comment != "" {
+		details += "\n" + maybeComment(c.comment)
+	}
+
+	for _, name := range c.names {
+		if rule.AlertingRule.Annotations != nil {
+			if val := rule.AlertingRule.Annotations.GetValue(name); val != nil && strings.TrimSpace(val.Value) != "" {
+				continue
+			}
+		}
+		problems = append(problems, Problem{
+			Lines:    lines,
+			Reporter: c.Reporter(),
+			Text:     fmt.Sprintf("`%s` annotation is required but it's missing or empty.", name),
+			Details:  details,
+			Severity: c.severity,
+		})
+	}
+
+	return problems
+}
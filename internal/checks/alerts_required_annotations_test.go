@@ -0,0 +1,131 @@
+package checks_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/promapi"
+)
+
+func newRequiredAnnotationsCheck(_ *promapi.FailoverGroup) checks.RuleChecker {
+	return checks.NewRequiredAnnotationsCheck(nil, "", checks.Information)
+}
+
+func requiredAnnotationsText(name string) string {
+	return fmt.Sprintf("`%s` annotation is required but it's missing or empty.", name)
+}
+
+func TestRequiredAnnotationsCheck(t *testing.T) {
+	testCases := []checkTest{
+		{
+			description: "ignores recording rules",
+			content:     "- record: foo\n  expr: sum(up)\n",
+			checker:     newRequiredAnnotationsCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores alerts with all annotations",
+			content:     "- alert: foo\n  expr: up == 0\n  annotations:\n    summary: foo\n    description: bar\n",
+			checker:     newRequiredAnnotationsCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "reports alerts without annotations",
+			content:     "- alert: foo\n  expr: up == 0\n",
+			checker:     newRequiredAnnotationsCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 1,
+							Last:  2,
+						},
+						Reporter: checks.RequiredAnnotationsCheckName,
+						Text:     requiredAnnotationsText("summary"),
+						Details:  checks.RequiredAnnotationsCheckDetails,
+						Severity: checks.Information,
+					},
+					{
+						Lines: parser.LineRange{
+							First: 1,
+							Last:  2,
+						},
+						Reporter: checks.RequiredAnnotationsCheckName,
+						Text:     requiredAnnotationsText("description"),
+						Details:  checks.RequiredAnnotationsCheckDetails,
+						Severity: checks.Information,
+					},
+				}
+			},
+		},
+		{
+			description: "reports alerts missing description",
+			content:     "- alert: foo\n  expr: up == 0\n  annotations:\n    summary: foo\n",
+			checker:     newRequiredAnnotationsCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 3,
+							Last:  4,
+						},
+						Reporter: checks.RequiredAnnotationsCheckName,
+						Text:     requiredAnnotationsText("description"),
+						Details:  checks.RequiredAnnotationsCheckDetails,
+						Severity: checks.Information,
+					},
+				}
+			},
+		},
+		{
+			description: "reports empty annotations",
+			content:     "- alert: foo\n  expr: up == 0\n  annotations:\n    summary: \"\"\n    description: bar\n",
+			checker:     newRequiredAnnotationsCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 3,
+							Last:  5,
+						},
+						Reporter: checks.RequiredAnnotationsCheckName,
+						Text:     requiredAnnotationsText("summary"),
+						Details:  checks.RequiredAnnotationsCheckDetails,
+						Severity: checks.Information,
+					},
+				}
+			},
+		},
+		{
+			description: "uses configured names, comment and severity",
+			content:     "- alert: foo\n  expr: up == 0\n  annotations:\n    summary: foo\n",
+			checker: func(_ *promapi.FailoverGroup) checks.RuleChecker {
+				return checks.NewRequiredAnnotationsCheck([]string{"summary", "runbook"}, "add a runbook", checks.Warning)
+			},
+			prometheus: noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 3,
+							Last:  4,
+						},
+						Reporter: checks.RequiredAnnotationsCheckName,
+						Text:     requiredAnnotationsText("runbook"),
+						Details:  checks.RequiredAnnotationsCheckDetails + "\nRule comment: add a runbook",
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+	}
+
+	runTests(t, testCases)
+}
@@ -104,7 +104,7 @@ func (c TemplateCheck) Check(ctx context.Context, _ discovery.Path, rule parser.
 		return nil
 	}
 
-	src := utils.LabelsSource(rule.AlertingRule.Expr.Value.Value, rule.AlertingRule.Expr.Query.Expr)
+	src := utils.CachedLabelsSource(ctx, rule.AlertingRule.Expr.Value.Value, rule.AlertingRule.Expr.Query.Expr)
 	data := promTemplate.AlertTemplateData(map[string]string{}, map[string]string{}, "", promql.Sample{})
 
 	if rule.AlertingRule.Labels != nil {
@@ -425,47 +425,65 @@ func findTemplateVariables(name, text string) (vars [][]string, aliases aliasMap
 	return vars, aliases, true
 }
 
-func checkQueryLabels(query, labelName, labelValue string, src []utils.Source) (problems []exprProblem) {
-	vars, aliases, ok := findTemplateVariables(labelName, labelValue)
+// templateLabelNames returns the names of all labels accessed via $labels in given template.
+func templateLabelNames(name, text string) (names []string) {
+	vars, aliases, ok := findTemplateVariables(name, text)
 	if !ok {
 		return nil
 	}
 
-	done := map[string]struct{}{}
 	labelsAliases := aliases.varAliases(".Labels")
 	for _, v := range vars {
 		for _, a := range labelsAliases {
-			if len(v) > 1 && v[0] == a {
-				if _, ok := done[v[1]]; ok {
-					continue
-				}
-				for _, s := range src {
-					if s.IsDead {
-						continue
-					}
-					if s.FixedLabels && !slices.Contains(s.IncludedLabels, v[1]) {
-						problems = append(problems, textForProblem(query, v[1], "", s, Bug))
-						goto NEXT
-					}
-					if slices.Contains(s.ExcludedLabels, v[1]) {
-						problems = append(problems, textForProblem(query, v[1], v[1], s, Bug))
-						goto NEXT
-					}
-				}
-			NEXT:
-				done[v[1]] = struct{}{}
+			if len(v) > 1 && v[0] == a && !slices.Contains(names, v[1]) {
+				names = append(names, v[1])
 			}
 		}
 	}
+	return names
+}
 
+func checkQueryLabels(query, labelName, labelValue string, src []utils.Source) (problems []exprProblem) {
+	for _, name := range templateLabelNames(labelName, labelValue) {
+		if s, ok := findMissingLabel(name, src); ok {
+			problems = append(problems, textForProblem(query, name, s, Bug))
+		}
+	}
 	return problems
 }
 
-func textForProblem(query, label, reasonLabel string, src utils.Source, severity Severity) exprProblem {
-	details := src.ExcludeReason[reasonLabel].Reason
-	if query != src.ExcludeReason[reasonLabel].Fragment {
-		details = fmt.Sprintf("%s\nQuery fragment causing this problem: `%s`.", details, src.ExcludeReason[reasonLabel].Fragment)
+// findMissingLabel returns the first source that is guaranteed not to have given label
+// on its results.
+func findMissingLabel(name string, src []utils.Source) (utils.Source, bool) {
+	for _, s := range src {
+		if s.IsDead {
+			continue
+		}
+		if s.FixedLabels && !slices.Contains(s.IncludedLabels, name) {
+			return s, true
+		}
+		if slices.Contains(s.ExcludedLabels, name) {
+			return s, true
+		}
+	}
+	return utils.Source{}, false
+}
+
+// excludeReasonDetails explains all the reasons why a label was removed from the query results.
+func excludeReasonDetails(query string, reasons []utils.ExcludedLabel) string {
+	lines := make([]string, 0, len(reasons))
+	for _, reason := range reasons {
+		line := reason.Reason
+		if query != reason.Fragment {
+			line = fmt.Sprintf("%s\nQuery fragment causing this problem: `%s`.", line, reason.Fragment)
+		}
+		lines = append(lines, line)
 	}
+	return strings.Join(lines, "\n")
+}
+
+func textForProblem(query, label string, src utils.Source, severity Severity) exprProblem {
+	details := excludeReasonDetails(query, src.ExcludeReasons(label))
 	return exprProblem{
 		text:     fmt.Sprintf("Template is using `%s` label but the query results won't have this label.", label),
 		details:  details,
@@ -1309,6 +1309,30 @@ func TestTemplateCheck(t *testing.T) {
 				}
 			},
 		},
+		{
+			description: "without(...) + on(...)",
+			content: `- alert: Foo
+  expr: sum(foo) without(job) + on(instance) bar
+  annotations:
+    summary: "{{ $labels.job }} is broken"
+`,
+			checker:    newTemplateCheck,
+			prometheus: noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 4,
+							Last:  4,
+						},
+						Reporter: checks.TemplateCheckName,
+						Text:     "Template is using `job` label but the query results won't have this label.",
+						Details:  "Query is using aggregation with `without(job)`, all labels included inside `without(...)` will be removed from the results.\nQuery fragment causing this problem: `sum(foo) without(job)`.\nQuery is using one-to-one vector matching with `on(instance)`, only labels included inside `on(...)` will be present on the results.",
+						Severity: checks.Bug,
+					},
+				}
+			},
+		},
 		{
 			description: "multiple or",
 			content: `
@@ -18,6 +18,7 @@ var (
 		AlertsExternalLabelsCheckName,
 		AlertForCheckName,
 		TemplateCheckName,
+		AlertsConstantValueCheckName,
 		LabelsConflictCheckName,
 		AggregationCheckName,
 		ComparisonCheckName,
@@ -30,6 +31,46 @@ var (
 		CostCheckName,
 		CounterCheckName,
 		SeriesCheckName,
+		UnusedRecordCheckName,
+		ByVsWithoutCheckName,
+		RateSuffixCheckName,
+		ScopeCheckName,
+		RangeIntervalCheckName,
+		GaugeOnlyCheckName,
+		ForMissingCheckName,
+		RequiredAnnotationsCheckName,
+		RecordingNameCheckName,
+		AnonymousCheckName,
+		CountAbsenceCheckName,
+		DeadCodeCheckName,
+		ConstantCheckName,
+		LabelReplaceOverwriteCheckName,
+		AbsentCheckName,
+		RedundantParensCheckName,
+		AtModifierCheckName,
+		CrossFileCollisionCheckName,
+		LabelLifecycleCheckName,
+		DeprecatedFunctionCheckName,
+		SuggestRecordCheckName,
+		NestedRateCheckName,
+		HighChurnLabelCheckName,
+		ThresholdVectorCheckName,
+		QuantileCheckName,
+		CountValuesCheckName,
+		AlertForIntervalCheckName,
+		LabelShadowCheckName,
+		RecordingBoolCheckName,
+		SubqueryCheckName,
+		AlertsOrLabelsCheckName,
+		AggregateEmptyCheckName,
+		DoubleAggregateCheckName,
+		HistogramCheckName,
+		ComparisonLabelsCheckName,
+		LabelCollisionCheckName,
+		SelfReferenceCheckName,
+		JoinLabelCheckName,
+		CountConfusionCheckName,
+		GroupLabelsCheckName,
 		RuleDependencyCheckName,
 		RuleDuplicateCheckName,
 		RuleForCheckName,
@@ -102,6 +143,34 @@ const (
 
 type SettingsKey string
 
+// SeverityMapper can change the severity of problems reported by checks,
+// for example to enforce an organisation wide policy.
+// It's called for every problem, after the check returns it.
+type SeverityMapper interface {
+	MapSeverity(reporter string, severity Severity) Severity
+}
+
+// IdentitySeverityMapper is the default SeverityMapper that keeps
+// the severity set by each check.
+type IdentitySeverityMapper struct{}
+
+func (m IdentitySeverityMapper) MapSeverity(_ string, severity Severity) Severity {
+	return severity
+}
+
+type SeverityMapperContextKey string
+
+const SeverityMapperKey = SeverityMapperContextKey("severityMapper")
+
+// SeverityMapperFromContext returns the SeverityMapper stored in the context,
+// or IdentitySeverityMapper if there isn't one.
+func SeverityMapperFromContext(ctx context.Context) SeverityMapper {
+	if m, ok := ctx.Value(SeverityMapperKey).(SeverityMapper); ok {
+		return m
+	}
+	return IdentitySeverityMapper{}
+}
+
 type Anchor uint8
 
 const (
@@ -109,10 +178,18 @@ const (
 	AnchorBefore
 )
 
+// QueryFragment points at the part of the rule query that caused a problem.
+type QueryFragment struct {
+	Text  string
+	Start int // Byte offset of the fragment start in the query.
+	End   int // Byte offset of the fragment end in the query.
+}
+
 type Problem struct {
 	Reporter string
 	Text     string
 	Details  string
+	Fragment QueryFragment // Part of the query causing this problem, if known.
 	Lines    parser.LineRange
 	Severity Severity
 	Anchor   Anchor
@@ -134,6 +211,7 @@ type RuleChecker interface {
 type exprProblem struct {
 	text     string
 	details  string
+	fragment QueryFragment
 	severity Severity
 }
 
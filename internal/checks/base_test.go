@@ -63,6 +63,31 @@ func TestParseSeverity(t *testing.T) {
 	}
 }
 
+type promoteSeverityMapper struct {
+	reporter string
+}
+
+func (m promoteSeverityMapper) MapSeverity(reporter string, severity checks.Severity) checks.Severity {
+	if reporter == m.reporter && severity == checks.Warning {
+		return checks.Bug
+	}
+	return severity
+}
+
+func TestSeverityMapperFromContext(t *testing.T) {
+	mapper := checks.SeverityMapperFromContext(context.Background())
+	require.Equal(t, checks.IdentitySeverityMapper{}, mapper)
+	for _, sev := range []checks.Severity{checks.Information, checks.Warning, checks.Bug, checks.Fatal} {
+		require.Equal(t, sev, mapper.MapSeverity("promql/series", sev))
+	}
+
+	ctx := context.WithValue(context.Background(), checks.SeverityMapperKey, promoteSeverityMapper{reporter: "promql/series"})
+	mapper = checks.SeverityMapperFromContext(ctx)
+	require.Equal(t, checks.Bug, mapper.MapSeverity("promql/series", checks.Warning))
+	require.Equal(t, checks.Information, mapper.MapSeverity("promql/series", checks.Information))
+	require.Equal(t, checks.Warning, mapper.MapSeverity("promql/rate", checks.Warning))
+}
+
 func simpleProm(name, uri string, timeout time.Duration, required bool) *promapi.FailoverGroup {
 	return promapi.NewFailoverGroup(
 		name,
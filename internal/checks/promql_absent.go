@@ -0,0 +1,105 @@
+package checks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/cloudflare/pint/internal/discovery"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/parser/utils"
+)
+
+const (
+	AbsentCheckName = "promql/absent"
+
+	AbsentCheckDetails = "The only labels you can get back from `absent()` are the ones you pass to it, and it will only return anything when there are no time series matching them.\n" +
+		"Passing instance specific labels to it means that it will only tell you when that one instance is missing.\n" +
+		"If you want to get alerts when any target is down then use the `up` metric instead, for example: `up == 0`."
+)
+
+var DefaultAbsentLabels = []string{"instance", "pod"}
+
+type PromqlAbsentSettings struct {
+	Labels []string `hcl:"labels,optional" json:"labels,omitempty"`
+}
+
+func (c *PromqlAbsentSettings) Validate() error {
+	for _, name := range c.Labels {
+		if name == "" {
+			return errors.New("labels cannot contain empty values")
+		}
+	}
+	if len(c.Labels) == 0 {
+		c.Labels = DefaultAbsentLabels
+	}
+	return nil
+}
+
+func NewAbsentCheck() AbsentCheck {
+	return AbsentCheck{}
+}
+
+type AbsentCheck struct{}
+
+func (c AbsentCheck) Meta() CheckMeta {
+	return CheckMeta{
+		States: []discovery.ChangeType{
+			discovery.Noop,
+			discovery.Added,
+			discovery.Modified,
+			discovery.Moved,
+		},
+		Online:        false,
+		AlwaysEnabled: false,
+	}
+}
+
+func (c AbsentCheck) String() string {
+	return AbsentCheckName
+}
+
+func (c AbsentCheck) Reporter() string {
+	return AbsentCheckName
+}
+
+func (c AbsentCheck) Check(ctx context.Context, _ discovery.Path, rule parser.Rule, _ []discovery.Entry) (problems []Problem) {
+	expr := rule.Expr()
+	if expr.SyntaxError != nil {
+		return problems
+	}
+
+	var settings *PromqlAbsentSettings
+	if s := ctx.Value(SettingsKey(c.Reporter())); s != nil {
+		settings = s.(*PromqlAbsentSettings)
+	}
+	if settings == nil {
+		settings = &PromqlAbsentSettings{}
+		_ = settings.Validate()
+	}
+
+	for _, src := range utils.CachedLabelsSource(ctx, expr.Value.Value, expr.Query.Expr) {
+		if src.Operation != "absent" && src.Operation != "absent_over_time" {
+			continue
+		}
+		if !src.FixedLabels {
+			continue
+		}
+		for _, name := range settings.Labels {
+			if !slices.Contains(src.IncludedLabels, name) {
+				continue
+			}
+			problems = append(problems, Problem{
+				Lines:    expr.Value.Lines,
+				Reporter: c.Reporter(),
+				Text: fmt.Sprintf("`%s()` is called with a selector using `%s` label, it will only report when time series for that specific `%s` are missing.",
+					src.Operation, name, name),
+				Details:  AbsentCheckDetails,
+				Severity: Warning,
+			})
+		}
+	}
+
+	return problems
+}
@@ -0,0 +1,139 @@
+package checks_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/promapi"
+)
+
+func newAbsentCheck(_ *promapi.FailoverGroup) checks.RuleChecker {
+	return checks.NewAbsentCheck()
+}
+
+func TestAbsentCheck(t *testing.T) {
+	testCases := []checkTest{
+		{
+			description: "ignores rules with syntax errors",
+			content:     "- alert: foo\n  expr: absent(foo{instance=\"x\"}\n",
+			checker:     newAbsentCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores absent() without instance labels",
+			content:     "- alert: foo\n  expr: absent(up{job=\"y\"})\n",
+			checker:     newAbsentCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores regexp matchers",
+			content:     "- alert: foo\n  expr: absent(up{instance=~\"x.+\"})\n",
+			checker:     newAbsentCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores instance labels outside of absent()",
+			content:     "- alert: foo\n  expr: up{instance=\"x\", job=\"y\"} == 0\n",
+			checker:     newAbsentCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "reports instance label",
+			content:     "- alert: foo\n  expr: absent(up{instance=\"x\", job=\"y\"})\n",
+			checker:     newAbsentCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.AbsentCheckName,
+						Text:     "`absent()` is called with a selector using `instance` label, it will only report when time series for that specific `instance` are missing.",
+						Details:  checks.AbsentCheckDetails,
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+		{
+			description: "reports absent_over_time()",
+			content:     "- alert: foo\n  expr: absent_over_time(foo{pod=\"x\"}[5m]) or absent(bar{instance=\"x\", pod=\"y\"})\n",
+			checker:     newAbsentCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.AbsentCheckName,
+						Text:     "`absent_over_time()` is called with a selector using `pod` label, it will only report when time series for that specific `pod` are missing.",
+						Details:  checks.AbsentCheckDetails,
+						Severity: checks.Warning,
+					},
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.AbsentCheckName,
+						Text:     "`absent()` is called with a selector using `instance` label, it will only report when time series for that specific `instance` are missing.",
+						Details:  checks.AbsentCheckDetails,
+						Severity: checks.Warning,
+					},
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.AbsentCheckName,
+						Text:     "`absent()` is called with a selector using `pod` label, it will only report when time series for that specific `pod` are missing.",
+						Details:  checks.AbsentCheckDetails,
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+		{
+			description: "custom labels",
+			content:     "- alert: foo\n  expr: absent(up{instance=\"x\", job=\"y\"})\n",
+			checker:     newAbsentCheck,
+			ctx: func(ctx context.Context, _ string) context.Context {
+				s := checks.PromqlAbsentSettings{
+					Labels: []string{"job"},
+				}
+				if err := s.Validate(); err != nil {
+					t.Error(err)
+					t.FailNow()
+				}
+				return context.WithValue(ctx, checks.SettingsKey(checks.AbsentCheckName), &s)
+			},
+			prometheus: noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.AbsentCheckName,
+						Text:     "`absent()` is called with a selector using `job` label, it will only report when time series for that specific `job` are missing.",
+						Details:  checks.AbsentCheckDetails,
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+	}
+
+	runTests(t, testCases)
+}
@@ -0,0 +1,106 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	promParser "github.com/prometheus/prometheus/promql/parser"
+
+	"github.com/cloudflare/pint/internal/discovery"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/parser/utils"
+)
+
+const (
+	AggregateEmptyCheckName    = "promql/aggregate_empty"
+	AggregateEmptyCheckDetails = "Aggregations without any grouping, or with an empty `by()`, will remove all labels from the results.\n" +
+		"Time series produced by recording rules without any labels are hard to use, since there's no way to tell where they are coming from.\n" +
+		"If you want to keep some labels then list them explicitly using `by(...)`."
+)
+
+func NewAggregateEmptyCheck() AggregateEmptyCheck {
+	return AggregateEmptyCheck{}
+}
+
+type AggregateEmptyCheck struct{}
+
+func (c AggregateEmptyCheck) Meta() CheckMeta {
+	return CheckMeta{
+		States: []discovery.ChangeType{
+			discovery.Noop,
+			discovery.Added,
+			discovery.Modified,
+			discovery.Moved,
+		},
+		Online:        false,
+		AlwaysEnabled: false,
+	}
+}
+
+func (c AggregateEmptyCheck) String() string {
+	return AggregateEmptyCheckName
+}
+
+func (c AggregateEmptyCheck) Reporter() string {
+	return AggregateEmptyCheckName
+}
+
+func (c AggregateEmptyCheck) Check(ctx context.Context, _ discovery.Path, rule parser.Rule, _ []discovery.Entry) (problems []Problem) {
+	if rule.RecordingRule == nil {
+		return problems
+	}
+
+	expr := rule.Expr()
+	if expr.SyntaxError != nil {
+		return problems
+	}
+
+	for _, node := range parser.WalkDownExpr[*promParser.AggregateExpr](expr.Query) {
+		n := node.Expr.(*promParser.AggregateExpr)
+		if n.Without || len(n.Grouping) > 0 {
+			continue
+		}
+		// These don't remove any labels.
+		if slices.Contains([]promParser.ItemType{promParser.TOPK, promParser.BOTTOMK, promParser.LIMITK, promParser.LIMIT_RATIO}, n.Op) {
+			continue
+		}
+
+		text := fmt.Sprintf("`%s` will remove all labels from the results.", n.String())
+		if names := guaranteedLabels(ctx, expr.Value.Value, n.Expr); len(names) > 0 {
+			rewrite := *n
+			rewrite.Grouping = names
+			text = fmt.Sprintf("`%s` will remove all labels from the results, use `%s` to keep labels guaranteed to be present on all time series.",
+				n.String(), rewrite.String())
+		}
+
+		problems = append(problems, Problem{
+			Lines:    expr.Value.Lines,
+			Reporter: c.Reporter(),
+			Text:     text,
+			Details:  AggregateEmptyCheckDetails,
+			Severity: Information,
+		})
+	}
+
+	return problems
+}
+
+// guaranteedLabels returns labels guaranteed to be present on all results of given query.
+func guaranteedLabels(ctx context.Context, expr string, node promParser.Node) (names []string) {
+	var found bool
+	for _, src := range utils.CachedLabelsSource(ctx, expr, node) {
+		if src.IsDead {
+			continue
+		}
+		if !found {
+			names = slices.Clone(src.GuaranteedLabels)
+			found = true
+			continue
+		}
+		names = slices.DeleteFunc(names, func(name string) bool {
+			return !slices.Contains(src.GuaranteedLabels, name)
+		})
+	}
+	return names
+}
@@ -0,0 +1,145 @@
+package checks_test
+
+import (
+	"testing"
+
+	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/promapi"
+)
+
+func newAggregateEmptyCheck(_ *promapi.FailoverGroup) checks.RuleChecker {
+	return checks.NewAggregateEmptyCheck()
+}
+
+func TestAggregateEmptyCheck(t *testing.T) {
+	testCases := []checkTest{
+		{
+			description: "ignores rules with syntax errors",
+			content:     "- record: foo\n  expr: sum(up\n",
+			checker:     newAggregateEmptyCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores alerting rules",
+			content:     "- alert: foo\n  expr: sum(up) == 0\n",
+			checker:     newAggregateEmptyCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores by()",
+			content:     "- record: foo\n  expr: sum(up) by(job)\n",
+			checker:     newAggregateEmptyCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores without()",
+			content:     "- record: foo\n  expr: sum(up) without(instance)\n",
+			checker:     newAggregateEmptyCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores topk()",
+			content:     "- record: foo\n  expr: topk(5, up)\n",
+			checker:     newAggregateEmptyCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "reports sum() without grouping",
+			content:     "- record: foo\n  expr: sum(up)\n",
+			checker:     newAggregateEmptyCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.AggregateEmptyCheckName,
+						Text:     "`sum(up)` will remove all labels from the results.",
+						Details:  checks.AggregateEmptyCheckDetails,
+						Severity: checks.Information,
+					},
+				}
+			},
+		},
+		{
+			description: "reports empty by()",
+			content:     "- record: foo\n  expr: count(up) by()\n",
+			checker:     newAggregateEmptyCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.AggregateEmptyCheckName,
+						Text:     "`count(up)` will remove all labels from the results.",
+						Details:  checks.AggregateEmptyCheckDetails,
+						Severity: checks.Information,
+					},
+				}
+			},
+		},
+		{
+			description: "suggests guaranteed labels",
+			content:     "- record: foo\n  expr: sum(rate(http_requests_total{job=\"api\", status=\"500\"}[5m])) / sum(rate(http_requests_total{job=\"api\"}[5m]))\n",
+			checker:     newAggregateEmptyCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.AggregateEmptyCheckName,
+						Text:     "`sum(rate(http_requests_total{job=\"api\",status=\"500\"}[5m]))` will remove all labels from the results, use `sum by (job, status) (rate(http_requests_total{job=\"api\",status=\"500\"}[5m]))` to keep labels guaranteed to be present on all time series.",
+						Details:  checks.AggregateEmptyCheckDetails,
+						Severity: checks.Information,
+					},
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.AggregateEmptyCheckName,
+						Text:     "`sum(rate(http_requests_total{job=\"api\"}[5m]))` will remove all labels from the results, use `sum by (job) (rate(http_requests_total{job=\"api\"}[5m]))` to keep labels guaranteed to be present on all time series.",
+						Details:  checks.AggregateEmptyCheckDetails,
+						Severity: checks.Information,
+					},
+				}
+			},
+		},
+		{
+			description: "suggests labels guaranteed on all sources",
+			content:     "- record: foo\n  expr: sum(foo{job=\"a\", env=\"prod\"} or bar{job=\"b\"})\n",
+			checker:     newAggregateEmptyCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.AggregateEmptyCheckName,
+						Text:     "`sum(foo{env=\"prod\",job=\"a\"} or bar{job=\"b\"})` will remove all labels from the results, use `sum by (job) (foo{env=\"prod\",job=\"a\"} or bar{job=\"b\"})` to keep labels guaranteed to be present on all time series.",
+						Details:  checks.AggregateEmptyCheckDetails,
+						Severity: checks.Information,
+					},
+				}
+			},
+		},
+	}
+
+	runTests(t, testCases)
+}
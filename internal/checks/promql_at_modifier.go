@@ -0,0 +1,96 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+
+	promParser "github.com/prometheus/prometheus/promql/parser"
+	"github.com/prometheus/prometheus/promql/parser/posrange"
+
+	"github.com/cloudflare/pint/internal/discovery"
+	"github.com/cloudflare/pint/internal/parser"
+)
+
+const (
+	AtModifierCheckName    = "promql/at_modifier"
+	AtModifierCheckDetails = "The [@ modifier](https://prometheus.io/docs/prometheus/latest/querying/basics/#modifier) changes the evaluation time of a selector or a subquery.\n" +
+		"Rules are evaluated as instant queries at regular intervals and should always use the most recent data."
+)
+
+func NewAtModifierCheck() AtModifierCheck {
+	return AtModifierCheck{}
+}
+
+type AtModifierCheck struct{}
+
+func (c AtModifierCheck) Meta() CheckMeta {
+	return CheckMeta{
+		States: []discovery.ChangeType{
+			discovery.Noop,
+			discovery.Added,
+			discovery.Modified,
+			discovery.Moved,
+		},
+		Online:        false,
+		AlwaysEnabled: false,
+	}
+}
+
+func (c AtModifierCheck) String() string {
+	return AtModifierCheckName
+}
+
+func (c AtModifierCheck) Reporter() string {
+	return AtModifierCheckName
+}
+
+func (c AtModifierCheck) Check(_ context.Context, _ discovery.Path, rule parser.Rule, _ []discovery.Entry) (problems []Problem) {
+	expr := rule.Expr()
+	if expr.SyntaxError != nil {
+		return problems
+	}
+
+	for _, node := range parser.WalkDownExpr[promParser.Node](expr.Query) {
+		var timestamp *int64
+		var startOrEnd promParser.ItemType
+		var pos posrange.PositionRange
+		switch n := node.Expr.(type) {
+		case *promParser.VectorSelector:
+			timestamp, startOrEnd, pos = n.Timestamp, n.StartOrEnd, n.PosRange
+			// Modifiers of a range selector are stored on the inner vector selector.
+			if node.Parent != nil {
+				if m, ok := node.Parent.Expr.(*promParser.MatrixSelector); ok {
+					pos = m.PositionRange()
+				}
+			}
+		case *promParser.SubqueryExpr:
+			timestamp, startOrEnd, pos = n.Timestamp, n.StartOrEnd, n.PositionRange()
+		default:
+			continue
+		}
+
+		fragment := expr.Value.Value[pos.Start:pos.End]
+		switch {
+		case timestamp != nil:
+			problems = append(problems, Problem{
+				Lines:    expr.Value.Lines,
+				Reporter: c.Reporter(),
+				Text: fmt.Sprintf("`%s` is using an absolute `@` timestamp, it will always return data from the same point in time instead of the time when this rule is evaluated.",
+					fragment),
+				Details:  AtModifierCheckDetails,
+				Severity: Bug,
+			})
+		case startOrEnd != 0 && rule.RecordingRule != nil:
+			problems = append(problems, Problem{
+				Lines:    expr.Value.Lines,
+				Reporter: c.Reporter(),
+				Text: fmt.Sprintf("`%s` is using `@ %s()` modifier, this has no effect in recording rules because `start()` and `end()` are both equal to the rule evaluation time.",
+					fragment, startOrEnd),
+				Details:  AtModifierCheckDetails,
+				Severity: Warning,
+			})
+		}
+	}
+
+	return problems
+}
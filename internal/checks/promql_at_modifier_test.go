@@ -0,0 +1,140 @@
+package checks_test
+
+import (
+	"testing"
+
+	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/promapi"
+)
+
+func newAtModifierCheck(_ *promapi.FailoverGroup) checks.RuleChecker {
+	return checks.NewAtModifierCheck()
+}
+
+func TestAtModifierCheck(t *testing.T) {
+	testCases := []checkTest{
+		{
+			description: "ignores rules with syntax errors",
+			content:     "- record: foo\n  expr: sum(foo @ 1700000000\n",
+			checker:     newAtModifierCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores selectors without @",
+			content:     "- record: foo\n  expr: sum(rate(foo[5m] offset 1h)) + bar offset 5m\n",
+			checker:     newAtModifierCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "reports absolute timestamp on a selector",
+			content:     "- record: foo\n  expr: sum(foo @ 1700000000)\n",
+			checker:     newAtModifierCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.AtModifierCheckName,
+						Text:     "`foo @ 1700000000` is using an absolute `@` timestamp, it will always return data from the same point in time instead of the time when this rule is evaluated.",
+						Details:  checks.AtModifierCheckDetails,
+						Severity: checks.Bug,
+					},
+				}
+			},
+		},
+		{
+			description: "reports absolute timestamp on a range selector",
+			content:     "- alert: foo\n  expr: rate(foo[5m] @ 1700000000.5 offset 1m) > 0\n",
+			checker:     newAtModifierCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.AtModifierCheckName,
+						Text:     "`foo[5m] @ 1700000000.5 offset 1m` is using an absolute `@` timestamp, it will always return data from the same point in time instead of the time when this rule is evaluated.",
+						Details:  checks.AtModifierCheckDetails,
+						Severity: checks.Bug,
+					},
+				}
+			},
+		},
+		{
+			description: "reports absolute timestamp on a subquery",
+			content:     "- record: foo\n  expr: max_over_time(rate(foo[5m])[1h:5m] @ 1700000000)\n",
+			checker:     newAtModifierCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.AtModifierCheckName,
+						Text:     "`rate(foo[5m])[1h:5m] @ 1700000000` is using an absolute `@` timestamp, it will always return data from the same point in time instead of the time when this rule is evaluated.",
+						Details:  checks.AtModifierCheckDetails,
+						Severity: checks.Bug,
+					},
+				}
+			},
+		},
+		{
+			description: "reports start() in recording rules",
+			content:     "- record: foo\n  expr: foo @ start()\n",
+			checker:     newAtModifierCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.AtModifierCheckName,
+						Text:     "`foo @ start()` is using `@ start()` modifier, this has no effect in recording rules because `start()` and `end()` are both equal to the rule evaluation time.",
+						Details:  checks.AtModifierCheckDetails,
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+		{
+			description: "reports end() in recording rules",
+			content:     "- record: foo\n  expr: rate(foo[5m] @ end())\n",
+			checker:     newAtModifierCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.AtModifierCheckName,
+						Text:     "`foo[5m] @ end()` is using `@ end()` modifier, this has no effect in recording rules because `start()` and `end()` are both equal to the rule evaluation time.",
+						Details:  checks.AtModifierCheckDetails,
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+		{
+			description: "ignores end() in alerting rules",
+			content:     "- alert: foo\n  expr: rate(foo[5m] @ end()) > 0\n",
+			checker:     newAtModifierCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+	}
+	runTests(t, testCases)
+}
@@ -0,0 +1,82 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+
+	promParser "github.com/prometheus/prometheus/promql/parser"
+
+	"github.com/cloudflare/pint/internal/discovery"
+	"github.com/cloudflare/pint/internal/parser"
+)
+
+const (
+	ByVsWithoutCheckName = "promql/by_vs_without"
+
+	ByVsWithoutCheckDetails = `Aggregations using ` + "`by()`" + ` with a long list of labels are usually trying to keep most labels from the source time series.
+Using ` + "`without()`" + ` to list only the labels that should be removed is often shorter and will keep working if new labels are added to the source time series.`
+)
+
+func NewByVsWithoutCheck(maxLabels int, comment string, severity Severity) ByVsWithoutCheck {
+	return ByVsWithoutCheck{
+		maxLabels: maxLabels,
+		comment:   comment,
+		severity:  severity,
+	}
+}
+
+type ByVsWithoutCheck struct {
+	comment   string
+	maxLabels int
+	severity  Severity
+}
+
+func (c ByVsWithoutCheck) Meta() CheckMeta {
+	return CheckMeta{
+		States: []discovery.ChangeType{
+			discovery.Noop,
+			discovery.Added,
+			discovery.Modified,
+			discovery.Moved,
+		},
+		Online:        false,
+		AlwaysEnabled: false,
+	}
+}
+
+func (c ByVsWithoutCheck) String() string {
+	return fmt.Sprintf("%s(%d)", ByVsWithoutCheckName, c.maxLabels)
+}
+
+func (c ByVsWithoutCheck) Reporter() string {
+	return ByVsWithoutCheckName
+}
+
+func (c ByVsWithoutCheck) Check(_ context.Context, _ discovery.Path, rule parser.Rule, _ []discovery.Entry) (problems []Problem) {
+	expr := rule.Expr()
+	if expr.SyntaxError != nil {
+		return problems
+	}
+
+	details := ByVsWithoutCheckDetails
+	if c.comment != "" {
+		details += "\n" + maybeComment(c.comment)
+	}
+
+	for _, node := range parser.WalkDownExpr[*promParser.AggregateExpr](expr.Query) {
+		n := node.Expr.(*promParser.AggregateExpr)
+		if n.Without || len(n.Grouping) <= c.maxLabels {
+			continue
+		}
+		problems = append(problems, Problem{
+			Lines:    expr.Value.Lines,
+			Reporter: c.Reporter(),
+			Text: fmt.Sprintf("`%s` aggregation is using `by()` with %d labels, consider using `without()` to list labels that should be removed instead.",
+				n.Op, len(n.Grouping)),
+			Details:  details,
+			Severity: c.severity,
+		})
+	}
+
+	return problems
+}
@@ -0,0 +1,83 @@
+package checks_test
+
+import (
+	"testing"
+
+	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/promapi"
+)
+
+func newByVsWithoutCheck(_ *promapi.FailoverGroup) checks.RuleChecker {
+	return checks.NewByVsWithoutCheck(3, "", checks.Information)
+}
+
+func TestByVsWithoutCheck(t *testing.T) {
+	testCases := []checkTest{
+		{
+			description: "ignores rules with syntax errors",
+			content:     "- record: foo\n  expr: sum(foo) by(\n",
+			checker:     newByVsWithoutCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores short by()",
+			content:     "- record: foo\n  expr: sum(foo) by(job, instance, cluster)\n",
+			checker:     newByVsWithoutCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores long without()",
+			content:     "- record: foo\n  expr: sum(foo) without(job, instance, cluster, rack)\n",
+			checker:     newByVsWithoutCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "reports long by()",
+			content:     "- record: foo\n  expr: sum(foo) by(job, instance, cluster, rack)\n",
+			checker:     newByVsWithoutCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.ByVsWithoutCheckName,
+						Text:     "`sum` aggregation is using `by()` with 4 labels, consider using `without()` to list labels that should be removed instead.",
+						Details:  checks.ByVsWithoutCheckDetails,
+						Severity: checks.Information,
+					},
+				}
+			},
+		},
+		{
+			description: "reports nested long by()",
+			content:     "- alert: foo\n  expr: max(rate(foo[5m])) by(job, instance, cluster, rack, dc) > sum(bar) by(job)\n",
+			checker: func(_ *promapi.FailoverGroup) checks.RuleChecker {
+				return checks.NewByVsWithoutCheck(3, "some text", checks.Warning)
+			},
+			prometheus: noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.ByVsWithoutCheckName,
+						Text:     "`max` aggregation is using `by()` with 5 labels, consider using `without()` to list labels that should be removed instead.",
+						Details:  checks.ByVsWithoutCheckDetails + "\nRule comment: some text",
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+	}
+
+	runTests(t, testCases)
+}
@@ -0,0 +1,118 @@
+package checks
+
+import (
+	"context"
+
+	promParser "github.com/prometheus/prometheus/promql/parser"
+
+	"github.com/cloudflare/pint/internal/discovery"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/parser/utils"
+)
+
+const (
+	ConstantCheckName = "promql/constant"
+
+	ConstantCheckDetails = `This query only uses constant values, like ` + "`vector(1)`" + `, and compares them in a way that is always false.
+The result of this comparison is known upfront and doesn't depend on any metric, so this query will never return anything.`
+)
+
+func NewConstantCheck() ConstantCheck {
+	return ConstantCheck{}
+}
+
+type ConstantCheck struct{}
+
+func (c ConstantCheck) Meta() CheckMeta {
+	return CheckMeta{
+		States: []discovery.ChangeType{
+			discovery.Noop,
+			discovery.Added,
+			discovery.Modified,
+			discovery.Moved,
+		},
+		Online:        false,
+		AlwaysEnabled: false,
+	}
+}
+
+func (c ConstantCheck) String() string {
+	return ConstantCheckName
+}
+
+func (c ConstantCheck) Reporter() string {
+	return ConstantCheckName
+}
+
+func (c ConstantCheck) Check(ctx context.Context, _ discovery.Path, rule parser.Rule, _ []discovery.Entry) (problems []Problem) {
+	// Alerting rules comparing constant values are reported by alerts/constant_value.
+	if rule.AlertingRule != nil {
+		return problems
+	}
+
+	expr := rule.Expr()
+	if expr.SyntaxError != nil {
+		return problems
+	}
+
+	isConstant, isAlive := constantComparison(ctx, expr.Value.Value, expr.Query.Expr)
+	if !isConstant || isAlive {
+		return problems
+	}
+
+	problems = append(problems, Problem{
+		Lines:    expr.Value.Lines,
+		Reporter: c.Reporter(),
+		Text:     "This query is comparing constant values and the result is always false, it will never return anything.",
+		Details:  ConstantCheckDetails,
+		Severity: Bug,
+	})
+
+	return problems
+}
+
+// constantComparison checks if given query is a comparison that only uses
+// constant values.
+// Functions like hour() always return a value, but we don't know what it is,
+// so only sources returning known numbers are treated as constant.
+// If it is then isAlive tells if that comparison is always true or always false.
+func constantComparison(ctx context.Context, expr string, node promParser.Node) (isConstant, isAlive bool) {
+	if !hasConstantComparison(node) {
+		return false, false
+	}
+
+	src := utils.CachedLabelsSource(ctx, expr, node)
+	if len(src) == 0 {
+		return false, false
+	}
+
+	for _, s := range src {
+		if !s.AlwaysReturns || len(s.ReturnedNumbers) == 0 {
+			return false, false
+		}
+		if !s.IsDead {
+			isAlive = true
+		}
+	}
+	return true, isAlive
+}
+
+// hasConstantComparison returns true if the query has a comparison
+// and none of the comparisons are using the bool modifier.
+func hasConstantComparison(node promParser.Node) bool {
+	var hasComparison, hasBool bool
+	var walk func(promParser.Node)
+	walk = func(n promParser.Node) {
+		if b, ok := n.(*promParser.BinaryExpr); ok && b.Op.IsComparisonOperator() {
+			hasComparison = true
+			if b.ReturnBool {
+				hasBool = true
+			}
+		}
+		for _, child := range promParser.Children(n) {
+			walk(child)
+		}
+	}
+	walk(node)
+	return hasComparison && !hasBool
+}
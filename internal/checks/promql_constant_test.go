@@ -0,0 +1,191 @@
+package checks_test
+
+import (
+	"testing"
+
+	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/promapi"
+)
+
+func newConstantCheck(_ *promapi.FailoverGroup) checks.RuleChecker {
+	return checks.NewConstantCheck()
+}
+
+func TestConstantCheck(t *testing.T) {
+	testCases := []checkTest{
+		{
+			description: "ignores rules with syntax errors",
+			content:     "- record: foo\n  expr: vector(1) >\n",
+			checker:     newConstantCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores queries without comparison",
+			content:     "- record: foo\n  expr: vector(1) + 2\n",
+			checker:     newConstantCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores comparisons that are always true",
+			content:     "- record: foo\n  expr: vector(1) > 0\n",
+			checker:     newConstantCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores bool comparisons",
+			content:     "- record: foo\n  expr: vector(1) > bool 2\n",
+			checker:     newConstantCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores selector on the left",
+			content:     "- record: foo\n  expr: foo > 2\n",
+			checker:     newConstantCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores selector on the right",
+			content:     "- record: foo\n  expr: (vector(1) + 2) > (foo * 1)\n",
+			checker:     newConstantCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores constant or selector",
+			content:     "- record: foo\n  expr: vector(1) > 2 or foo\n",
+			checker:     newConstantCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "reports recording rule that is always false",
+			content:     "- record: foo\n  expr: vector(1) > 2\n",
+			checker:     newConstantCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.ConstantCheckName,
+						Text:     "This query is comparing constant values and the result is always false, it will never return anything.",
+						Details:  checks.ConstantCheckDetails,
+						Severity: checks.Bug,
+					},
+				}
+			},
+		},
+		{
+			description: "ignores alerting rules",
+			content:     "- alert: foo\n  expr: vector(0) > 1\n",
+			checker:     newConstantCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "reports nested constant comparison",
+			content:     "- record: foo\n  expr: (vector(1) + 2) > (10 * 1)\n",
+			checker:     newConstantCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.ConstantCheckName,
+						Text:     "This query is comparing constant values and the result is always false, it will never return anything.",
+						Details:  checks.ConstantCheckDetails,
+						Severity: checks.Bug,
+					},
+				}
+			},
+		},
+		{
+			description: "reports constant comparison inside topk",
+			content:     "- record: foo\n  expr: topk(5, vector(1) > 2)\n",
+			checker:     newConstantCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.ConstantCheckName,
+						Text:     "This query is comparing constant values and the result is always false, it will never return anything.",
+						Details:  checks.ConstantCheckDetails,
+						Severity: checks.Bug,
+					},
+				}
+			},
+		},
+		{
+			description: "reports constant comparison inside bottomk",
+			content:     "- record: foo\n  expr: bottomk(5, vector(1) > 2)\n",
+			checker:     newConstantCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.ConstantCheckName,
+						Text:     "This query is comparing constant values and the result is always false, it will never return anything.",
+						Details:  checks.ConstantCheckDetails,
+						Severity: checks.Bug,
+					},
+				}
+			},
+		},
+		{
+			description: "ignores topk with constant comparison that is always true",
+			content:     "- record: foo\n  expr: topk(5, vector(1) > 0)\n",
+			checker:     newConstantCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores vector(time()) comparison",
+			content:     "- record: foo\n  expr: vector(time()) > 1000\n",
+			checker:     newConstantCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores hour() comparison",
+			content:     "- record: foo\n  expr: hour() < 0\n",
+			checker:     newConstantCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores vector(scalar()) comparison",
+			content:     "- record: foo\n  expr: vector(scalar(up)) > 10\n",
+			checker:     newConstantCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores topk with selector comparison",
+			content:     "- record: foo\n  expr: topk(5, foo > 1000)\n",
+			checker:     newConstantCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+	}
+
+	runTests(t, testCases)
+}
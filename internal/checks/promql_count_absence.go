@@ -0,0 +1,66 @@
+package checks
+
+import (
+	"context"
+
+	"github.com/cloudflare/pint/internal/discovery"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/parser/utils"
+)
+
+const (
+	CountAbsenceCheckName = "promql/count_absence"
+
+	CountAbsenceCheckDetails = "`count()` only returns results when there are time series to count, so the value it returns is always at least `1`.\n" +
+		"If there are no matching time series then `count()` will return nothing instead of `0`, so comparing it with a value lower than `1` will never match anything.\n" +
+		"To detect missing time series use `absent()` instead, for example: `absent(foo)` rather than `count(foo) == 0`."
+)
+
+func NewCountAbsenceCheck() CountAbsenceCheck {
+	return CountAbsenceCheck{}
+}
+
+type CountAbsenceCheck struct{}
+
+func (c CountAbsenceCheck) Meta() CheckMeta {
+	return CheckMeta{
+		States: []discovery.ChangeType{
+			discovery.Noop,
+			discovery.Added,
+			discovery.Modified,
+			discovery.Moved,
+		},
+		Online:        false,
+		AlwaysEnabled: false,
+	}
+}
+
+func (c CountAbsenceCheck) String() string {
+	return CountAbsenceCheckName
+}
+
+func (c CountAbsenceCheck) Reporter() string {
+	return CountAbsenceCheckName
+}
+
+func (c CountAbsenceCheck) Check(ctx context.Context, _ discovery.Path, rule parser.Rule, _ []discovery.Entry) (problems []Problem) {
+	expr := rule.Expr()
+	if expr.SyntaxError != nil {
+		return nil
+	}
+
+	for _, src := range utils.CachedLabelsSource(ctx, expr.Value.Value, expr.Query.Expr) {
+		if src.CountComparison != utils.CountRequiresAbsence {
+			continue
+		}
+		problems = append(problems, Problem{
+			Lines:    expr.Value.Lines,
+			Reporter: c.Reporter(),
+			Text:     "This query is trying to detect missing time series by comparing `count()` with a value lower than `1`, but `count()` never returns `0` and this comparison will never match anything.",
+			Details:  CountAbsenceCheckDetails,
+			Severity: Bug,
+		})
+	}
+
+	return problems
+}
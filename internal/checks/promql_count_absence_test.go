@@ -0,0 +1,106 @@
+package checks_test
+
+import (
+	"testing"
+
+	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/promapi"
+)
+
+func newCountAbsenceCheck(_ *promapi.FailoverGroup) checks.RuleChecker {
+	return checks.NewCountAbsenceCheck()
+}
+
+func countAbsenceText() string {
+	return "This query is trying to detect missing time series by comparing `count()` with a value lower than `1`, but `count()` never returns `0` and this comparison will never match anything."
+}
+
+func TestCountAbsenceCheck(t *testing.T) {
+	testCases := []checkTest{
+		{
+			description: "ignores rules with syntax errors",
+			content:     "- alert: foo\n  expr: count(foo) == 0 without(\n",
+			checker:     newCountAbsenceCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores count() >= 1",
+			content:     "- alert: foo\n  expr: count(foo) >= 1\n",
+			checker:     newCountAbsenceCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores count() > 5",
+			content:     "- alert: foo\n  expr: count(foo) by (job) > 5\n",
+			checker:     newCountAbsenceCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores count() == bool 0",
+			content:     "- record: foo\n  expr: count(foo) == bool 0\n",
+			checker:     newCountAbsenceCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores count() made dead by or",
+			content:     "- alert: foo\n  expr: vector(1) or count(foo)\n",
+			checker:     newCountAbsenceCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores sum() == 0",
+			content:     "- alert: foo\n  expr: sum(foo) == 0\n",
+			checker:     newCountAbsenceCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "reports count() == 0",
+			content:     "- alert: foo\n  expr: count(foo) == 0\n",
+			checker:     newCountAbsenceCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.CountAbsenceCheckName,
+						Text:     countAbsenceText(),
+						Details:  checks.CountAbsenceCheckDetails,
+						Severity: checks.Bug,
+					},
+				}
+			},
+		},
+		{
+			description: "reports 1 > count()",
+			content:     "- alert: foo\n  expr: 1 > count(foo{job=\"bar\"}) by (instance)\n",
+			checker:     newCountAbsenceCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.CountAbsenceCheckName,
+						Text:     countAbsenceText(),
+						Details:  checks.CountAbsenceCheckDetails,
+						Severity: checks.Bug,
+					},
+				}
+			},
+		},
+	}
+
+	runTests(t, testCases)
+}
@@ -0,0 +1,106 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/cloudflare/pint/internal/discovery"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/parser/utils"
+)
+
+const (
+	CountConfusionCheckName    = "promql/count_confusion"
+	CountConfusionCheckDetails = "`count_over_time()` returns the number of samples each time series has inside the given time range, it doesn't count time series.\n" +
+		"To count the number of time series use `count()` instead, for example: `count(foo)` rather than `count_over_time(foo[5m])`."
+
+	defaultCountConfusionRecord = ".*series.*"
+)
+
+type PromqlCountConfusionSettings struct {
+	Record   string `hcl:"record,optional" json:"record,omitempty"`
+	recordRe *regexp.Regexp
+}
+
+func (c *PromqlCountConfusionSettings) Validate() error {
+	record := c.Record
+	if record == "" {
+		record = defaultCountConfusionRecord
+	}
+	re, err := regexp.Compile("^" + record + "$")
+	if err != nil {
+		return err
+	}
+	c.recordRe = re
+	return nil
+}
+
+func NewCountConfusionCheck() CountConfusionCheck {
+	return CountConfusionCheck{}
+}
+
+type CountConfusionCheck struct{}
+
+func (c CountConfusionCheck) Meta() CheckMeta {
+	return CheckMeta{
+		States: []discovery.ChangeType{
+			discovery.Noop,
+			discovery.Added,
+			discovery.Modified,
+			discovery.Moved,
+		},
+		Online:        false,
+		AlwaysEnabled: false,
+	}
+}
+
+func (c CountConfusionCheck) String() string {
+	return CountConfusionCheckName
+}
+
+func (c CountConfusionCheck) Reporter() string {
+	return CountConfusionCheckName
+}
+
+func (c CountConfusionCheck) Check(ctx context.Context, _ discovery.Path, rule parser.Rule, _ []discovery.Entry) (problems []Problem) {
+	if rule.RecordingRule == nil {
+		return problems
+	}
+
+	expr := rule.Expr()
+	if expr.SyntaxError != nil {
+		return problems
+	}
+
+	var settings *PromqlCountConfusionSettings
+	if s := ctx.Value(SettingsKey(c.Reporter())); s != nil {
+		settings = s.(*PromqlCountConfusionSettings)
+	}
+	if settings == nil {
+		settings = &PromqlCountConfusionSettings{}
+		_ = settings.Validate()
+	}
+
+	name := rule.RecordingRule.Record.Value
+	if !settings.recordRe.MatchString(name) {
+		return problems
+	}
+
+	for _, src := range utils.CachedLabelsSource(ctx, expr.Value.Value, expr.Query.Expr) {
+		if src.IsDead || src.Type != utils.FuncSource || src.Operation != "count_over_time" || src.Call == nil {
+			continue
+		}
+		problems = append(problems, Problem{
+			Lines:    expr.Value.Lines,
+			Reporter: c.Reporter(),
+			Text: fmt.Sprintf("`%s` recording rule name suggests that it counts time series but `%s` counts samples of each time series, did you mean to use `count()`?",
+				name, expr.Value.Value[src.Call.PosRange.Start:src.Call.PosRange.End]),
+			Details:  CountConfusionCheckDetails,
+			Severity: Information,
+		})
+		break
+	}
+
+	return problems
+}
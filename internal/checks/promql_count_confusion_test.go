@@ -0,0 +1,121 @@
+package checks_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/promapi"
+)
+
+func newCountConfusionCheck(_ *promapi.FailoverGroup) checks.RuleChecker {
+	return checks.NewCountConfusionCheck()
+}
+
+func TestCountConfusionCheck(t *testing.T) {
+	testCases := []checkTest{
+		{
+			description: "ignores alerting rules",
+			content:     "- alert: series:count\n  expr: count_over_time(up[5m]) > 0\n",
+			checker:     newCountConfusionCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores rules with syntax errors",
+			content:     "- record: series:count\n  expr: count_over_time(up[5m]))\n",
+			checker:     newCountConfusionCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores names not matching the regexp",
+			content:     "- record: up:samples\n  expr: count_over_time(up[5m])\n",
+			checker:     newCountConfusionCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores count()",
+			content:     "- record: series:count\n  expr: count(up)\n",
+			checker:     newCountConfusionCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores count_over_time() inside other functions",
+			content:     "- record: series:count\n  expr: sum(count_over_time(up[5m]))\n",
+			checker:     newCountConfusionCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "reports count_over_time()",
+			content:     "- record: series:count\n  expr: count_over_time(up[5m])\n",
+			checker:     newCountConfusionCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.CountConfusionCheckName,
+						Text:     "`series:count` recording rule name suggests that it counts time series but `count_over_time(up[5m])` counts samples of each time series, did you mean to use `count()`?",
+						Details:  checks.CountConfusionCheckDetails,
+						Severity: checks.Information,
+					},
+				}
+			},
+		},
+		{
+			description: "reports count_over_time() with a comparison",
+			content:     "- record: job:up_series:count\n  expr: count_over_time(up{job=\"foo\"}[5m]) > 1\n",
+			checker:     newCountConfusionCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.CountConfusionCheckName,
+						Text:     "`job:up_series:count` recording rule name suggests that it counts time series but `count_over_time(up{job=\"foo\"}[5m])` counts samples of each time series, did you mean to use `count()`?",
+						Details:  checks.CountConfusionCheckDetails,
+						Severity: checks.Information,
+					},
+				}
+			},
+		},
+		{
+			description: "uses configured regexp",
+			content:     "- record: job:targets\n  expr: count_over_time(up[5m])\n",
+			checker:     newCountConfusionCheck,
+			prometheus:  noProm,
+			ctx: func(ctx context.Context, _ string) context.Context {
+				s := checks.PromqlCountConfusionSettings{Record: ".*:targets"}
+				_ = s.Validate()
+				return context.WithValue(ctx, checks.SettingsKey(checks.CountConfusionCheckName), &s)
+			},
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.CountConfusionCheckName,
+						Text:     "`job:targets` recording rule name suggests that it counts time series but `count_over_time(up[5m])` counts samples of each time series, did you mean to use `count()`?",
+						Details:  checks.CountConfusionCheckDetails,
+						Severity: checks.Information,
+					},
+				}
+			},
+		},
+	}
+
+	runTests(t, testCases)
+}
@@ -0,0 +1,104 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	promParser "github.com/prometheus/prometheus/promql/parser"
+
+	"github.com/cloudflare/pint/internal/discovery"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/parser/utils"
+)
+
+const (
+	CountValuesCheckName    = "promql/count_values"
+	CountValuesCheckDetails = "[count_values](https://prometheus.io/docs/prometheus/latest/querying/operators/#aggregation-operators) stores the value of each time series in the label passed to it.\n" +
+		"If that label is already present on the results then its original value will be overwritten."
+)
+
+func NewCountValuesCheck() CountValuesCheck {
+	return CountValuesCheck{}
+}
+
+type CountValuesCheck struct{}
+
+func (c CountValuesCheck) Meta() CheckMeta {
+	return CheckMeta{
+		States: []discovery.ChangeType{
+			discovery.Noop,
+			discovery.Added,
+			discovery.Modified,
+			discovery.Moved,
+		},
+		Online:        false,
+		AlwaysEnabled: false,
+	}
+}
+
+func (c CountValuesCheck) String() string {
+	return CountValuesCheckName
+}
+
+func (c CountValuesCheck) Reporter() string {
+	return CountValuesCheckName
+}
+
+func (c CountValuesCheck) Check(ctx context.Context, _ discovery.Path, rule parser.Rule, _ []discovery.Entry) (problems []Problem) {
+	expr := rule.Expr()
+	if expr.SyntaxError != nil {
+		return problems
+	}
+
+	for _, node := range parser.WalkDownExpr[*promParser.AggregateExpr](expr.Query) {
+		n := node.Expr.(*promParser.AggregateExpr)
+		if n.Op != promParser.COUNT_VALUES {
+			continue
+		}
+		param, ok := n.Param.(*promParser.StringLiteral)
+		if !ok {
+			continue
+		}
+		fragment := expr.Value.Value[n.PosRange.Start:n.PosRange.End]
+
+		var text string
+		switch {
+		case strings.HasPrefix(param.Val, "__"):
+			text = fmt.Sprintf("`%s` is using `%s` as the output label, labels starting with `__` are reserved for internal use.",
+				fragment, param.Val)
+		case countValuesKeepsLabel(ctx, expr.Value.Value, n, param.Val):
+			text = fmt.Sprintf("`%s` is using `%s` as the output label, but this label is already present on the results and its value will be overwritten.",
+				fragment, param.Val)
+		default:
+			continue
+		}
+		problems = append(problems, Problem{
+			Lines:    expr.Value.Lines,
+			Reporter: c.Reporter(),
+			Text:     text,
+			Details:  CountValuesCheckDetails,
+			Severity: Warning,
+		})
+	}
+
+	return problems
+}
+
+// countValuesKeepsLabel returns true if given label would be present
+// on the results of count_values() before the output label is added.
+func countValuesKeepsLabel(ctx context.Context, expr string, n *promParser.AggregateExpr, name string) bool {
+	if !n.Without {
+		return slices.Contains(n.Grouping, name)
+	}
+	if slices.Contains(n.Grouping, name) {
+		return false
+	}
+	for _, src := range utils.CachedLabelsSource(ctx, expr, n.Expr) {
+		if !src.IsDead && slices.Contains(src.GuaranteedLabels, name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,122 @@
+package checks_test
+
+import (
+	"testing"
+
+	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/promapi"
+)
+
+func newCountValuesCheck(_ *promapi.FailoverGroup) checks.RuleChecker {
+	return checks.NewCountValuesCheck()
+}
+
+func TestCountValuesCheck(t *testing.T) {
+	testCases := []checkTest{
+		{
+			description: "ignores rules with syntax errors",
+			content:     "- record: foo\n  expr: count_values(\"job\", up\n",
+			checker:     newCountValuesCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores other aggregations",
+			content:     "- record: foo\n  expr: count(up) by(job)\n",
+			checker:     newCountValuesCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores new label",
+			content:     "- record: foo\n  expr: count_values(\"version\", up)\n",
+			checker:     newCountValuesCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores label removed by aggregation",
+			content:     "- record: foo\n  expr: count_values(\"job\", up{job=\"foo\"})\n",
+			checker:     newCountValuesCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores new label with by()",
+			content:     "- record: foo\n  expr: count_values by(job) (\"version\", up)\n",
+			checker:     newCountValuesCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores label removed by without()",
+			content:     "- record: foo\n  expr: count_values without(job) (\"job\", up{job=\"foo\"})\n",
+			checker:     newCountValuesCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "reports label kept by by()",
+			content:     "- record: foo\n  expr: count_values by(job) (\"job\", up)\n",
+			checker:     newCountValuesCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.CountValuesCheckName,
+						Text:     "`count_values by(job) (\"job\", up)` is using `job` as the output label, but this label is already present on the results and its value will be overwritten.",
+						Details:  checks.CountValuesCheckDetails,
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+		{
+			description: "reports label kept by without()",
+			content:     "- record: foo\n  expr: count_values without(instance) (\"job\", up{job=\"foo\"})\n",
+			checker:     newCountValuesCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.CountValuesCheckName,
+						Text:     "`count_values without(instance) (\"job\", up{job=\"foo\"})` is using `job` as the output label, but this label is already present on the results and its value will be overwritten.",
+						Details:  checks.CountValuesCheckDetails,
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+		{
+			description: "reports reserved label",
+			content:     "- record: foo\n  expr: count_values(\"__name__\", up)\n",
+			checker:     newCountValuesCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.CountValuesCheckName,
+						Text:     "`count_values(\"__name__\", up)` is using `__name__` as the output label, labels starting with `__` are reserved for internal use.",
+						Details:  checks.CountValuesCheckDetails,
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+	}
+
+	runTests(t, testCases)
+}
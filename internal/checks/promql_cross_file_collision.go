@@ -0,0 +1,129 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cloudflare/pint/internal/discovery"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/promapi"
+)
+
+const (
+	CrossFileCollisionCheckName    = "promql/cross_file_collision"
+	CrossFileCollisionCheckDetails = "Recording rules with the same name and labels will produce time series with identical labels.\n" +
+		"If these rules are using different queries then each rule evaluation will overwrite results of the other one, " +
+		"and it will be impossible to tell which query produced any given sample.\n" +
+		"Rename one of these rules or add a static label to tell them apart."
+)
+
+// NewCrossFileCollisionCheck creates a new check comparing recording rules
+// deployed to given Prometheus server.
+// If prom is nil then only rules from files not deployed to any server are compared.
+func NewCrossFileCollisionCheck(prom *promapi.FailoverGroup) CrossFileCollisionCheck {
+	return CrossFileCollisionCheck{prom: prom}
+}
+
+type CrossFileCollisionCheck struct {
+	prom *promapi.FailoverGroup
+}
+
+func (c CrossFileCollisionCheck) Meta() CheckMeta {
+	return CheckMeta{
+		States: []discovery.ChangeType{
+			discovery.Noop,
+			discovery.Added,
+			discovery.Modified,
+			discovery.Moved,
+		},
+		Online:        false,
+		AlwaysEnabled: false,
+	}
+}
+
+func (c CrossFileCollisionCheck) String() string {
+	if c.prom == nil {
+		return CrossFileCollisionCheckName
+	}
+	return fmt.Sprintf("%s(%s)", CrossFileCollisionCheckName, c.prom.Name())
+}
+
+func (c CrossFileCollisionCheck) Reporter() string {
+	return CrossFileCollisionCheckName
+}
+
+func (c CrossFileCollisionCheck) Check(ctx context.Context, path discovery.Path, rule parser.Rule, entries []discovery.Entry) (problems []Problem) {
+	if rule.RecordingRule == nil || rule.RecordingRule.Expr.SyntaxError != nil {
+		return problems
+	}
+
+	if c.prom != nil && !c.prom.IsEnabledForPath(path.Name) {
+		return problems
+	}
+
+	ruleLabels := buildRuleLabels(rule.RecordingRule.Labels)
+	query := rule.RecordingRule.Expr.Query.Expr.String()
+
+	var others []string
+	for _, entry := range entries {
+		if entry.State == discovery.Removed {
+			continue
+		}
+		if entry.PathError != nil {
+			continue
+		}
+		if entry.Rule.Error.Err != nil {
+			continue
+		}
+		if entry.Rule.RecordingRule == nil || entry.Rule.RecordingRule.Expr.SyntaxError != nil {
+			continue
+		}
+		if entry.Path.SymlinkTarget == path.SymlinkTarget {
+			continue
+		}
+		if !c.isSameServer(ctx, entry.Path.Name) {
+			continue
+		}
+		if entry.Rule.RecordingRule.Record.Value != rule.RecordingRule.Record.Value {
+			continue
+		}
+		if buildRuleLabels(entry.Rule.RecordingRule.Labels).Hash() != ruleLabels.Hash() {
+			continue
+		}
+		if entry.Rule.RecordingRule.Expr.Query.Expr.String() == query {
+			// Identical rules are reported by rule/duplicate.
+			continue
+		}
+		others = append(others, fmt.Sprintf("`%s:%s`", entry.Path.SymlinkTarget, entry.Rule.Lines))
+	}
+
+	if len(others) > 0 {
+		problems = append(problems, Problem{
+			Lines:    rule.Lines,
+			Reporter: c.Reporter(),
+			Text: fmt.Sprintf("`%s` recording rule is also defined in other files using a different query: %s.",
+				rule.RecordingRule.Record.Value, strings.Join(others, ", ")),
+			Details:  CrossFileCollisionCheckDetails,
+			Severity: Bug,
+		})
+	}
+
+	return problems
+}
+
+// isSameServer returns true if given path is deployed to the same Prometheus
+// server as the rule being checked.
+func (c CrossFileCollisionCheck) isSameServer(ctx context.Context, path string) bool {
+	if c.prom != nil {
+		return c.prom.IsEnabledForPath(path)
+	}
+	if val := ctx.Value(promapi.AllPrometheusServers); val != nil {
+		for _, s := range val.([]*promapi.FailoverGroup) {
+			if s.IsEnabledForPath(path) {
+				return false
+			}
+		}
+	}
+	return true
+}
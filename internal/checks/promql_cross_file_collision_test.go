@@ -0,0 +1,211 @@
+package checks_test
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/discovery"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/promapi"
+)
+
+func newCrossFileCollisionCheck(prom *promapi.FailoverGroup) checks.RuleChecker {
+	return checks.NewCrossFileCollisionCheck(prom)
+}
+
+func newPathScopedProm(include ...string) func(string) *promapi.FailoverGroup {
+	return func(uri string) *promapi.FailoverGroup {
+		paths := make([]*regexp.Regexp, 0, len(include))
+		for _, re := range include {
+			paths = append(paths, regexp.MustCompile(re))
+		}
+		return promapi.NewFailoverGroup(
+			"prom",
+			uri,
+			[]*promapi.Prometheus{
+				promapi.NewPrometheus("prom", uri, "", map[string]string{}, time.Second, 4, 100, nil),
+			},
+			true,
+			"up",
+			paths,
+			nil,
+			nil,
+		)
+	}
+}
+
+func mustParseContentAt(content, path string) []discovery.Entry {
+	entries := mustParseContent(content)
+	for i := range entries {
+		entries[i].Path.Name = path
+		entries[i].Path.SymlinkTarget = path
+	}
+	return entries
+}
+
+func TestCrossFileCollisionCheck(t *testing.T) {
+	removed := mustParseContentAt("- record: foo\n  expr: sum(bar)\n", "removed.yml")
+	removed[0].State = discovery.Removed
+
+	testCases := []checkTest{
+		{
+			description: "ignores rules with syntax errors",
+			content:     "- record: foo\n  expr: sum(foo) without(\n",
+			checker:     newCrossFileCollisionCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+			entries:     mustParseContentAt("- record: foo\n  expr: sum(bar)\n", "other.yml"),
+		},
+		{
+			description: "ignores alerting rules",
+			content:     "- alert: foo\n  expr: up == 0\n",
+			checker:     newCrossFileCollisionCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+			entries:     mustParseContentAt("- alert: foo\n  expr: up == 1\n", "other.yml"),
+		},
+		{
+			description: "ignores broken entries",
+			content:     "- record: foo\n  expr: sum(foo)\n",
+			checker:     newCrossFileCollisionCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+			entries: append(
+				[]discovery.Entry{{PathError: errors.New("Mock error")}},
+				append(removed, mustParseContentAt("- record: foo\n  expr: sum(bar) without(\n", "other.yml")...)...,
+			),
+		},
+		{
+			description: "ignores rules from the same file",
+			content:     "- record: foo\n  expr: sum(foo)\n",
+			checker:     newCrossFileCollisionCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+			entries:     mustParseContent("- record: foo\n  expr: sum(foo)\n- record: foo\n  expr: sum(bar)\n"),
+		},
+		{
+			description: "ignores identical rules",
+			content:     "- record: foo\n  expr: sum(foo)\n",
+			checker:     newCrossFileCollisionCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+			entries:     mustParseContentAt("- record: foo\n  expr: sum( foo )\n", "other.yml"),
+		},
+		{
+			description: "ignores different names",
+			content:     "- record: foo\n  expr: sum(foo)\n",
+			checker:     newCrossFileCollisionCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+			entries:     mustParseContentAt("- record: bar\n  expr: sum(bar)\n", "other.yml"),
+		},
+		{
+			description: "ignores different labels",
+			content:     "- record: foo\n  expr: sum(foo)\n  labels:\n    job: foo\n",
+			checker:     newCrossFileCollisionCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+			entries:     mustParseContentAt("- record: foo\n  expr: sum(bar)\n  labels:\n    job: bar\n", "other.yml"),
+		},
+		{
+			description: "reports collision with one file",
+			content:     "- record: foo\n  expr: sum(foo)\n",
+			checker:     newCrossFileCollisionCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 1,
+							Last:  2,
+						},
+						Reporter: checks.CrossFileCollisionCheckName,
+						Text:     "`foo` recording rule is also defined in other files using a different query: `other.yml:1-2`.",
+						Details:  checks.CrossFileCollisionCheckDetails,
+						Severity: checks.Bug,
+					},
+				}
+			},
+			entries: mustParseContentAt("- record: foo\n  expr: sum(bar)\n", "other.yml"),
+		},
+		{
+			description: "reports collision with multiple files",
+			content:     "- record: foo\n  expr: sum(foo)\n",
+			checker:     newCrossFileCollisionCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 1,
+							Last:  2,
+						},
+						Reporter: checks.CrossFileCollisionCheckName,
+						Text:     "`foo` recording rule is also defined in other files using a different query: `a.yml:3-4`, `b.yml:1-2`.",
+						Details:  checks.CrossFileCollisionCheckDetails,
+						Severity: checks.Bug,
+					},
+				}
+			},
+			entries: append(
+				mustParseContentAt("- record: bar\n  expr: sum(bar)\n- record: foo\n  expr: sum(bar)\n", "a.yml"),
+				mustParseContentAt("- record: foo\n  expr: sum(foo) by(job)\n", "b.yml")...,
+			),
+		},
+		{
+			description: "reports collision with files deployed to the same Prometheus",
+			content:     "- record: foo\n  expr: sum(foo)\n",
+			checker:     newCrossFileCollisionCheck,
+			prometheus:  newPathScopedProm("^fake.yml$", "^a.yml$"),
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 1,
+							Last:  2,
+						},
+						Reporter: checks.CrossFileCollisionCheckName,
+						Text:     "`foo` recording rule is also defined in other files using a different query: `a.yml:1-2`.",
+						Details:  checks.CrossFileCollisionCheckDetails,
+						Severity: checks.Bug,
+					},
+				}
+			},
+			entries: append(
+				mustParseContentAt("- record: foo\n  expr: sum(bar)\n", "a.yml"),
+				mustParseContentAt("- record: foo\n  expr: sum(foo) by(job)\n", "b.yml")...,
+			),
+		},
+		{
+			description: "ignores files deployed to other Prometheus servers",
+			content:     "- record: foo\n  expr: sum(foo)\n",
+			checker:     newCrossFileCollisionCheck,
+			prometheus:  newPathScopedProm("^fake.yml$"),
+			problems:    noProblems,
+			entries:     mustParseContentAt("- record: foo\n  expr: sum(bar)\n", "other.yml"),
+		},
+		{
+			description: "ignores files deployed to any Prometheus server",
+			content:     "- record: foo\n  expr: sum(foo)\n",
+			checker:     newCrossFileCollisionCheck,
+			prometheus:  noProm,
+			otherProms: func(uri string) []*promapi.FailoverGroup {
+				return []*promapi.FailoverGroup{newPathScopedProm("^other.yml$")(uri)}
+			},
+			problems: noProblems,
+			entries:  mustParseContentAt("- record: foo\n  expr: sum(bar)\n", "other.yml"),
+		},
+		{
+			description: "ignores rules not deployed to this Prometheus server",
+			content:     "- record: foo\n  expr: sum(foo)\n",
+			checker:     newCrossFileCollisionCheck,
+			prometheus:  newPathScopedProm("^other.yml$"),
+			problems:    noProblems,
+			entries:     mustParseContentAt("- record: foo\n  expr: sum(bar)\n", "other.yml"),
+		},
+	}
+	runTests(t, testCases)
+}
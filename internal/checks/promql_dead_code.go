@@ -0,0 +1,67 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudflare/pint/internal/discovery"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/parser/utils"
+)
+
+const (
+	DeadCodeCheckName = "promql/dead_code"
+)
+
+func NewDeadCodeCheck() DeadCodeCheck {
+	return DeadCodeCheck{}
+}
+
+type DeadCodeCheck struct{}
+
+func (c DeadCodeCheck) Meta() CheckMeta {
+	return CheckMeta{
+		States: []discovery.ChangeType{
+			discovery.Noop,
+			discovery.Added,
+			discovery.Modified,
+			discovery.Moved,
+		},
+		Online:        false,
+		AlwaysEnabled: false,
+	}
+}
+
+func (c DeadCodeCheck) String() string {
+	return DeadCodeCheckName
+}
+
+func (c DeadCodeCheck) Reporter() string {
+	return DeadCodeCheckName
+}
+
+func (c DeadCodeCheck) Check(ctx context.Context, _ discovery.Path, rule parser.Rule, _ []discovery.Entry) (problems []Problem) {
+	expr := rule.Expr()
+	if expr.SyntaxError != nil {
+		return nil
+	}
+
+	done := map[string]struct{}{}
+	for _, src := range utils.CachedLabelsSource(ctx, expr.Value.Value, expr.Query.Expr) {
+		if !src.IsDead || src.DeadCode == nil {
+			continue
+		}
+		if _, ok := done[src.DeadCode.Fragment]; ok {
+			continue
+		}
+		done[src.DeadCode.Fragment] = struct{}{}
+		problems = append(problems, Problem{
+			Lines:    expr.Value.Lines,
+			Reporter: c.Reporter(),
+			Text:     fmt.Sprintf("`%s` can never contribute any results to this query. %s", src.DeadCode.Fragment, src.DeadCode.Reason),
+			Severity: Warning,
+		})
+	}
+
+	return problems
+}
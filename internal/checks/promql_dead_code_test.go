@@ -0,0 +1,197 @@
+package checks_test
+
+import (
+	"testing"
+
+	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/promapi"
+)
+
+func newDeadCodeCheck(_ *promapi.FailoverGroup) checks.RuleChecker {
+	return checks.NewDeadCodeCheck()
+}
+
+func TestDeadCodeCheck(t *testing.T) {
+	testCases := []checkTest{
+		{
+			description: "ignores rules with syntax errors",
+			content:     "- record: foo\n  expr: vector(1) or\n",
+			checker:     newDeadCodeCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores or with LHS that can be empty",
+			content:     "- record: foo\n  expr: foo or vector(0)\n",
+			checker:     newDeadCodeCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores and",
+			content:     "- record: foo\n  expr: vector(1) and foo\n",
+			checker:     newDeadCodeCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores vector(1) or foo",
+			content:     "- record: foo\n  expr: vector(1) or foo\n",
+			checker:     newDeadCodeCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "reports vector(1) or on() foo",
+			content:     "- record: foo\n  expr: vector(1) or on() foo\n",
+			checker:     newDeadCodeCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.DeadCodeCheckName,
+						Text:     "`foo` can never contribute any results to this query. The left hand side of `or` always returns results, so the right hand side will never be used.",
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+		{
+			description: "reports vector(1) or sum(foo)",
+			content:     "- record: foo\n  expr: vector(1) or sum(foo)\n",
+			checker:     newDeadCodeCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.DeadCodeCheckName,
+						Text:     "`sum(foo)` can never contribute any results to this query. The left hand side of `or` always returns results, so the right hand side will never be used.",
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+		{
+			description: "reports dead RHS with multiple sources only once",
+			content:     "- alert: foo\n  expr: vector(1) or on() (foo or bar)\n",
+			checker:     newDeadCodeCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.DeadCodeCheckName,
+						Text:     "`(foo or bar)` can never contribute any results to this query. The left hand side of `or` always returns results, so the right hand side will never be used.",
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+		{
+			description: "ignores clamp_max() compared with a lower value",
+			content:     "- alert: foo\n  expr: clamp_max(foo, 5) > 3\n",
+			checker:     newDeadCodeCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "reports clamp_max() compared with a higher value",
+			content:     "- alert: foo\n  expr: clamp_max(foo, 5) > 10\n",
+			checker:     newDeadCodeCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.DeadCodeCheckName,
+						Text:     "`clamp_max(foo, 5) > 10` can never contribute any results to this query. `clamp_max(foo, 5)` will never return values higher than 5, so this comparison will never match anything.",
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+		{
+			description: "reports foo and vector(1)",
+			content:     "- alert: foo\n  expr: foo and vector(1)\n",
+			checker:     newDeadCodeCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.DeadCodeCheckName,
+						Text:     "`foo` can never contribute any results to this query. The right hand side of `and` will never match any results from the left hand side, so this query will never return anything.",
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+		{
+			description: "ignores foo unless vector(1)",
+			content:     "- alert: foo\n  expr: foo unless vector(1)\n",
+			checker:     newDeadCodeCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "reports foo unless on() vector(1)",
+			content:     "- alert: foo\n  expr: foo unless on() vector(1)\n",
+			checker:     newDeadCodeCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.DeadCodeCheckName,
+						Text:     "`foo` can never contribute any results to this query. The right hand side of `unless` will always match all results from the left hand side, so this query will never return anything.",
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+		{
+			description: "ignores unless on() with hour() comparison",
+			content:     "- alert: foo\n  expr: up == 0 unless on() (hour() > 22)\n",
+			checker:     newDeadCodeCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores unless on() with day_of_week() comparison",
+			content:     "- alert: foo\n  expr: up == 0 unless on() (day_of_week() == 1)\n",
+			checker:     newDeadCodeCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores or on() after hour() comparison",
+			content:     "- record: foo\n  expr: (hour() > 22) or on() vector(0)\n",
+			checker:     newDeadCodeCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+	}
+
+	runTests(t, testCases)
+}
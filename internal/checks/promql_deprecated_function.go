@@ -0,0 +1,149 @@
+package checks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+
+	promParser "github.com/prometheus/prometheus/promql/parser"
+
+	"github.com/cloudflare/pint/internal/discovery"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/parser/utils"
+)
+
+const (
+	DeprecatedFunctionCheckName    = "promql/deprecated_function"
+	DeprecatedFunctionCheckDetails = "Some PromQL functions were renamed in newer Prometheus releases and the old names are no longer supported.\n" +
+		"Note that `double_exponential_smoothing` is an experimental function and it requires `--enable-feature=promql-experimental-functions` flag to be set on Prometheus."
+)
+
+var unknownFunctionRe = regexp.MustCompile(`unknown function with name "([a-zA-Z_]+)"`)
+
+type PromqlDeprecatedFunctionSettings struct {
+	Functions map[string]string `hcl:"functions,optional" json:"functions,omitempty"`
+	functions map[string]string
+}
+
+func (c *PromqlDeprecatedFunctionSettings) Validate() error {
+	c.functions = make(map[string]string, len(utils.DeprecatedFunctions)+len(c.Functions))
+	for name, replacement := range utils.DeprecatedFunctions {
+		c.functions[name] = replacement
+	}
+	for name, replacement := range c.Functions {
+		if name == "" {
+			return errors.New("deprecated function name cannot be empty")
+		}
+		if replacement == "" {
+			return fmt.Errorf("replacement for deprecated function %q cannot be empty", name)
+		}
+		c.functions[name] = replacement
+	}
+	return nil
+}
+
+func NewDeprecatedFunctionCheck() DeprecatedFunctionCheck {
+	return DeprecatedFunctionCheck{}
+}
+
+type DeprecatedFunctionCheck struct{}
+
+func (c DeprecatedFunctionCheck) Meta() CheckMeta {
+	return CheckMeta{
+		States: []discovery.ChangeType{
+			discovery.Noop,
+			discovery.Added,
+			discovery.Modified,
+			discovery.Moved,
+		},
+		Online:        false,
+		AlwaysEnabled: false,
+	}
+}
+
+func (c DeprecatedFunctionCheck) String() string {
+	return DeprecatedFunctionCheckName
+}
+
+func (c DeprecatedFunctionCheck) Reporter() string {
+	return DeprecatedFunctionCheckName
+}
+
+func (c DeprecatedFunctionCheck) Check(ctx context.Context, _ discovery.Path, rule parser.Rule, _ []discovery.Entry) (problems []Problem) {
+	expr := rule.Expr()
+
+	var settings *PromqlDeprecatedFunctionSettings
+	if s := ctx.Value(SettingsKey(c.Reporter())); s != nil {
+		settings = s.(*PromqlDeprecatedFunctionSettings)
+	}
+	if settings == nil {
+		settings = &PromqlDeprecatedFunctionSettings{}
+		_ = settings.Validate()
+	}
+
+	if expr.SyntaxError != nil {
+		// Prometheus parser doesn't know about removed functions, so
+		// all we get is a syntax error that's already reported by promql/syntax.
+		if m := unknownFunctionRe.FindStringSubmatch(expr.SyntaxError.Error()); m != nil {
+			if replacement, ok := settings.functions[m[1]]; ok {
+				problems = append(problems, c.problem(expr, m[1], replacement,
+					fmt.Sprintf("`%s()` function was renamed to `%s()`.", m[1], replacement)))
+			}
+		}
+		return problems
+	}
+
+	var done []string
+	report := func(name, replacement, fragment string) {
+		if slices.Contains(done, fragment) {
+			return
+		}
+		done = append(done, fragment)
+		problems = append(problems, c.problem(expr, name, replacement,
+			fmt.Sprintf("`%s` is using `%s()` function which was renamed to `%s()`.", fragment, name, replacement)))
+	}
+
+	for _, src := range utils.CachedLabelsSource(ctx, expr.Value.Value, expr.Query.Expr) {
+		if src.Deprecated != nil {
+			if replacement, ok := settings.functions[src.Deprecated.Name]; ok {
+				report(src.Deprecated.Name, replacement, src.Deprecated.Fragment)
+			}
+		}
+	}
+
+	// Functions deprecated via config are still known to the Prometheus parser,
+	// check every function call so we also find calls wrapped in other functions.
+	for _, node := range parser.WalkDownExpr[*promParser.Call](expr.Query) {
+		call := node.Expr.(*promParser.Call)
+		if _, ok := utils.DeprecatedFunctions[call.Func.Name]; ok {
+			continue
+		}
+		if replacement, ok := settings.functions[call.Func.Name]; ok {
+			report(call.Func.Name, replacement, expr.Value.Value[call.PosRange.Start:call.PosRange.End])
+		}
+	}
+
+	return problems
+}
+
+func (c DeprecatedFunctionCheck) problem(expr parser.PromQLExpr, name, replacement, text string) Problem {
+	return Problem{
+		Lines:    expr.Value.Lines,
+		Reporter: c.Reporter(),
+		Text:     text,
+		Details:  deprecatedFunctionDetails(name, replacement),
+		Severity: Warning,
+	}
+}
+
+// deprecatedFunctionDetails returns problem details with a suggested replacement
+// for given deprecated function.
+func deprecatedFunctionDetails(name, replacement string) string {
+	details := fmt.Sprintf("`%s()` is deprecated, use `%s()` instead.", name, replacement)
+	if _, ok := utils.DeprecatedFunctions[name]; ok {
+		details = DeprecatedFunctionCheckDetails + "\n" + details
+	}
+	return details
+}
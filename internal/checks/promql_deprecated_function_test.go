@@ -0,0 +1,204 @@
+package checks_test
+
+import (
+	"context"
+	"testing"
+
+	promParser "github.com/prometheus/prometheus/promql/parser"
+
+	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/promapi"
+)
+
+func newDeprecatedFunctionCheck(_ *promapi.FailoverGroup) checks.RuleChecker {
+	return checks.NewDeprecatedFunctionCheck()
+}
+
+const holtWintersDetails = checks.DeprecatedFunctionCheckDetails + "\n`holt_winters()` is deprecated, use `double_exponential_smoothing()` instead."
+
+func TestDeprecatedFunctionCheck(t *testing.T) {
+	testCases := []checkTest{
+		{
+			description: "ignores rules with unrelated syntax errors",
+			content:     "- record: foo\n  expr: sum(foo\n",
+			checker:     newDeprecatedFunctionCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores unknown functions that are not deprecated",
+			content:     "- record: foo\n  expr: bogus_func(foo)\n",
+			checker:     newDeprecatedFunctionCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "reports holt_winters syntax error",
+			content:     "- record: foo\n  expr: sum(holt_winters(foo[5m], 0.5, 0.5))\n",
+			checker:     newDeprecatedFunctionCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.DeprecatedFunctionCheckName,
+						Text:     "`holt_winters()` function was renamed to `double_exponential_smoothing()`.",
+						Details:  holtWintersDetails,
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+		{
+			description: "ignores queries without deprecated functions",
+			content:     "- record: foo\n  expr: sum(rate(foo[5m]))\n",
+			checker:     newDeprecatedFunctionCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "reports function deprecated via config",
+			content:     "- record: foo\n  expr: sum(irate(foo[5m]))\n",
+			checker:     newDeprecatedFunctionCheck,
+			prometheus:  noProm,
+			ctx: func(ctx context.Context, _ string) context.Context {
+				s := checks.PromqlDeprecatedFunctionSettings{Functions: map[string]string{"irate": "rate"}}
+				_ = s.Validate()
+				return context.WithValue(ctx, checks.SettingsKey(checks.DeprecatedFunctionCheckName), &s)
+			},
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.DeprecatedFunctionCheckName,
+						Text:     "`irate(foo[5m])` is using `irate()` function which was renamed to `rate()`.",
+						Details:  "`irate()` is deprecated, use `rate()` instead.",
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+		{
+			description: "reports unknown function deprecated via config",
+			content:     "- record: foo\n  expr: sum(old_rate(foo[5m]))\n",
+			checker:     newDeprecatedFunctionCheck,
+			prometheus:  noProm,
+			ctx: func(ctx context.Context, _ string) context.Context {
+				s := checks.PromqlDeprecatedFunctionSettings{Functions: map[string]string{"old_rate": "rate"}}
+				_ = s.Validate()
+				return context.WithValue(ctx, checks.SettingsKey(checks.DeprecatedFunctionCheckName), &s)
+			},
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.DeprecatedFunctionCheckName,
+						Text:     "`old_rate()` function was renamed to `rate()`.",
+						Details:  "`old_rate()` is deprecated, use `rate()` instead.",
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+		{
+			description: "uses replacement from config for holt_winters",
+			content:     "- record: foo\n  expr: sum(holt_winters(foo[5m], 0.5, 0.5))\n",
+			checker:     newDeprecatedFunctionCheck,
+			prometheus:  noProm,
+			ctx: func(ctx context.Context, _ string) context.Context {
+				s := checks.PromqlDeprecatedFunctionSettings{Functions: map[string]string{"holt_winters": "predict_linear"}}
+				_ = s.Validate()
+				return context.WithValue(ctx, checks.SettingsKey(checks.DeprecatedFunctionCheckName), &s)
+			},
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.DeprecatedFunctionCheckName,
+						Text:     "`holt_winters()` function was renamed to `predict_linear()`.",
+						Details:  checks.DeprecatedFunctionCheckDetails + "\n`holt_winters()` is deprecated, use `predict_linear()` instead.",
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+	}
+	runTests(t, testCases)
+}
+
+func TestDeprecatedFunctionCheckParsed(t *testing.T) {
+	// holt_winters was removed from the Prometheus parser, register it again
+	// to test rules parsed with older Prometheus versions.
+	def := *promParser.Functions["double_exponential_smoothing"]
+	def.Name = "holt_winters"
+	def.Experimental = false
+	promParser.Functions["holt_winters"] = &def
+	t.Cleanup(func() { delete(promParser.Functions, "holt_winters") })
+
+	testCases := []checkTest{
+		{
+			description: "reports holt_winters",
+			content:     "- record: foo\n  expr: holt_winters(foo[5m], 0.5, 0.5)\n",
+			checker:     newDeprecatedFunctionCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.DeprecatedFunctionCheckName,
+						Text:     "`holt_winters(foo[5m], 0.5, 0.5)` is using `holt_winters()` function which was renamed to `double_exponential_smoothing()`.",
+						Details:  holtWintersDetails,
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+		{
+			description: "reports holt_winters once per call",
+			content:     "- alert: foo\n  expr: sum(holt_winters(foo[5m], 0.5, 0.5)) > 1 or holt_winters(bar[5m], 0.1, 0.1) > 1\n",
+			checker:     newDeprecatedFunctionCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.DeprecatedFunctionCheckName,
+						Text:     "`holt_winters(foo[5m], 0.5, 0.5)` is using `holt_winters()` function which was renamed to `double_exponential_smoothing()`.",
+						Details:  holtWintersDetails,
+						Severity: checks.Warning,
+					},
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.DeprecatedFunctionCheckName,
+						Text:     "`holt_winters(bar[5m], 0.1, 0.1)` is using `holt_winters()` function which was renamed to `double_exponential_smoothing()`.",
+						Details:  holtWintersDetails,
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+	}
+	runTests(t, testCases)
+}
@@ -0,0 +1,144 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	promParser "github.com/prometheus/prometheus/promql/parser"
+
+	"github.com/cloudflare/pint/internal/discovery"
+	"github.com/cloudflare/pint/internal/parser"
+)
+
+const (
+	DoubleAggregateCheckName    = "promql/double_aggregate"
+	DoubleAggregateCheckDetails = "Aggregating results of another aggregation using the same operation, like `sum(sum(...))`, doesn't change the results if the outer aggregation doesn't keep any labels that the inner aggregation removed.\n" +
+		"Such queries can be replaced with a single aggregation which is easier to read and cheaper to evaluate."
+)
+
+// Aggregations where applying the same operation twice gives
+// the same results as applying it once.
+var doubleAggregateOps = []promParser.ItemType{
+	promParser.SUM,
+	promParser.MIN,
+	promParser.MAX,
+	promParser.GROUP,
+}
+
+func NewDoubleAggregateCheck() DoubleAggregateCheck {
+	return DoubleAggregateCheck{}
+}
+
+type DoubleAggregateCheck struct{}
+
+func (c DoubleAggregateCheck) Meta() CheckMeta {
+	return CheckMeta{
+		States: []discovery.ChangeType{
+			discovery.Noop,
+			discovery.Added,
+			discovery.Modified,
+			discovery.Moved,
+		},
+		Online:        false,
+		AlwaysEnabled: false,
+	}
+}
+
+func (c DoubleAggregateCheck) String() string {
+	return DoubleAggregateCheckName
+}
+
+func (c DoubleAggregateCheck) Reporter() string {
+	return DoubleAggregateCheckName
+}
+
+func (c DoubleAggregateCheck) Check(_ context.Context, _ discovery.Path, rule parser.Rule, _ []discovery.Entry) (problems []Problem) {
+	expr := rule.Expr()
+	if expr.SyntaxError != nil {
+		return problems
+	}
+
+	for _, node := range parser.WalkDownExpr[*promParser.AggregateExpr](expr.Query) {
+		outer := node.Expr.(*promParser.AggregateExpr)
+		if !slices.Contains(doubleAggregateOps, outer.Op) {
+			continue
+		}
+
+		inner, ok := unwrapParens(outer.Expr).(*promParser.AggregateExpr)
+		if !ok || inner.Op != outer.Op {
+			continue
+		}
+
+		if !isCompatibleGrouping(inner, outer) {
+			continue
+		}
+
+		rewrite := *outer
+		rewrite.Expr = inner.Expr
+		switch {
+		case inner.Without && outer.Without:
+			rewrite.Grouping = slices.Clone(inner.Grouping)
+			for _, name := range outer.Grouping {
+				if !slices.Contains(rewrite.Grouping, name) {
+					rewrite.Grouping = append(rewrite.Grouping, name)
+				}
+			}
+		case !inner.Without && outer.Without:
+			rewrite.Without = false
+			rewrite.Grouping = slices.DeleteFunc(slices.Clone(inner.Grouping), func(name string) bool {
+				return slices.Contains(outer.Grouping, name)
+			})
+		}
+
+		problems = append(problems, Problem{
+			Lines:    expr.Value.Lines,
+			Reporter: c.Reporter(),
+			Text: fmt.Sprintf("`%s` is using `%s` on the results of another `%s` aggregation, it can be replaced with `%s`.",
+				outer.String(), outer.Op, inner.Op, rewrite.String()),
+			Details:  DoubleAggregateCheckDetails,
+			Severity: Warning,
+		})
+	}
+
+	return problems
+}
+
+func unwrapParens(node promParser.Node) promParser.Node {
+	for {
+		pe, ok := node.(*promParser.ParenExpr)
+		if !ok {
+			return node
+		}
+		node = pe.Expr
+	}
+}
+
+// isCompatibleGrouping returns true if the outer aggregation doesn't need
+// any label that was removed by the inner aggregation.
+func isCompatibleGrouping(inner, outer *promParser.AggregateExpr) bool {
+	switch {
+	case !inner.Without && !outer.Without:
+		// sum(sum(foo) by(a, b)) by(a)
+		for _, name := range outer.Grouping {
+			if !slices.Contains(inner.Grouping, name) {
+				return false
+			}
+		}
+		return true
+	case inner.Without && outer.Without:
+		// sum(sum(foo) without(a)) without(b)
+		return true
+	case inner.Without && !outer.Without:
+		// sum(sum(foo) without(a)) by(b)
+		for _, name := range outer.Grouping {
+			if slices.Contains(inner.Grouping, name) {
+				return false
+			}
+		}
+		return true
+	default:
+		// sum(sum(foo) by(a, b)) without(b)
+		return true
+	}
+}
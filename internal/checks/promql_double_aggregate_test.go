@@ -0,0 +1,189 @@
+package checks_test
+
+import (
+	"testing"
+
+	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/promapi"
+)
+
+func newDoubleAggregateCheck(_ *promapi.FailoverGroup) checks.RuleChecker {
+	return checks.NewDoubleAggregateCheck()
+}
+
+func TestDoubleAggregateCheck(t *testing.T) {
+	testCases := []checkTest{
+		{
+			description: "ignores rules with syntax errors",
+			content:     "- record: foo\n  expr: sum(sum(foo) by(job)\n",
+			checker:     newDoubleAggregateCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores single aggregation",
+			content:     "- record: foo\n  expr: sum(foo) by(job)\n",
+			checker:     newDoubleAggregateCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores different operations",
+			content:     "- record: foo\n  expr: max(sum(foo) by(job))\n",
+			checker:     newDoubleAggregateCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores count of count",
+			content:     "- record: foo\n  expr: count(count(foo) by(job))\n",
+			checker:     newDoubleAggregateCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores aggregations with a function in between",
+			content:     "- record: foo\n  expr: sum(rate(sum(foo) by(job)[5m:]))\n",
+			checker:     newDoubleAggregateCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores outer by() using labels removed by inner by()",
+			content:     "- record: foo\n  expr: sum(sum(foo) by(job)) by(instance)\n",
+			checker:     newDoubleAggregateCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores outer by() using labels removed by inner without()",
+			content:     "- record: foo\n  expr: sum(sum(foo) without(job)) by(job)\n",
+			checker:     newDoubleAggregateCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "reports same by()",
+			content:     "- record: foo\n  expr: sum(sum(foo) by(job)) by(job)\n",
+			checker:     newDoubleAggregateCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.DoubleAggregateCheckName,
+						Text:     "`sum by (job) (sum by (job) (foo))` is using `sum` on the results of another `sum` aggregation, it can be replaced with `sum by (job) (foo)`.",
+						Details:  checks.DoubleAggregateCheckDetails,
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+		{
+			description: "reports outer by() using subset of labels",
+			content:     "- record: foo\n  expr: max((max(foo) by(job, instance))) by(job)\n",
+			checker:     newDoubleAggregateCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.DoubleAggregateCheckName,
+						Text:     "`max by (job) ((max by (job, instance) (foo)))` is using `max` on the results of another `max` aggregation, it can be replaced with `max by (job) (foo)`.",
+						Details:  checks.DoubleAggregateCheckDetails,
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+		{
+			description: "reports outer aggregation without grouping",
+			content:     "- record: foo\n  expr: sum(sum(foo) by(job))\n",
+			checker:     newDoubleAggregateCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.DoubleAggregateCheckName,
+						Text:     "`sum(sum by (job) (foo))` is using `sum` on the results of another `sum` aggregation, it can be replaced with `sum(foo)`.",
+						Details:  checks.DoubleAggregateCheckDetails,
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+		{
+			description: "reports without() twice",
+			content:     "- record: foo\n  expr: sum(sum(foo) without(instance)) without(job, instance)\n",
+			checker:     newDoubleAggregateCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.DoubleAggregateCheckName,
+						Text:     "`sum without (job, instance) (sum without (instance) (foo))` is using `sum` on the results of another `sum` aggregation, it can be replaced with `sum without (instance, job) (foo)`.",
+						Details:  checks.DoubleAggregateCheckDetails,
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+		{
+			description: "reports inner without() and outer by()",
+			content:     "- record: foo\n  expr: min(min(foo) without(instance)) by(job)\n",
+			checker:     newDoubleAggregateCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.DoubleAggregateCheckName,
+						Text:     "`min by (job) (min without (instance) (foo))` is using `min` on the results of another `min` aggregation, it can be replaced with `min by (job) (foo)`.",
+						Details:  checks.DoubleAggregateCheckDetails,
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+		{
+			description: "reports inner by() and outer without()",
+			content:     "- record: foo\n  expr: sum(sum(foo) by(job, instance)) without(instance)\n",
+			checker:     newDoubleAggregateCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.DoubleAggregateCheckName,
+						Text:     "`sum without (instance) (sum by (job, instance) (foo))` is using `sum` on the results of another `sum` aggregation, it can be replaced with `sum by (job) (foo)`.",
+						Details:  checks.DoubleAggregateCheckDetails,
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+	}
+
+	runTests(t, testCases)
+}
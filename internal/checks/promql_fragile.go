@@ -3,7 +3,9 @@ package checks
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"slices"
+	"strings"
 
 	promParser "github.com/prometheus/prometheus/promql/parser"
 
@@ -21,11 +23,13 @@ If the same alert query returns results that over time have different labels on
 This can happen when using one of the aggregation operation like topk or bottomk as they can return a different time series each time they are evaluated.`
 )
 
-func NewFragileCheck() FragileCheck {
-	return FragileCheck{}
+func NewFragileCheck(flagBy bool) FragileCheck {
+	return FragileCheck{flagBy: flagBy}
 }
 
-type FragileCheck struct{}
+type FragileCheck struct {
+	flagBy bool
+}
 
 func (c FragileCheck) Meta() CheckMeta {
 	return CheckMeta{
@@ -48,24 +52,25 @@ func (c FragileCheck) Reporter() string {
 	return FragileCheckName
 }
 
-func (c FragileCheck) Check(_ context.Context, _ discovery.Path, rule parser.Rule, _ []discovery.Entry) (problems []Problem) {
+func (c FragileCheck) Check(ctx context.Context, _ discovery.Path, rule parser.Rule, _ []discovery.Entry) (problems []Problem) {
 	expr := rule.Expr()
 	if expr.SyntaxError != nil {
 		return nil
 	}
 
-	for _, problem := range c.checkAggregation(expr.Value.Value, expr.Query) {
+	for _, problem := range c.checkAggregation(ctx, expr.Value.Value, expr.Query) {
 		problems = append(problems, Problem{
 			Lines:    expr.Value.Lines,
 			Reporter: c.Reporter(),
 			Text:     problem.text,
 			Details:  problem.details,
+			Fragment: problem.fragment,
 			Severity: problem.severity,
 		})
 	}
 
 	if rule.AlertingRule != nil {
-		for _, problem := range c.checkSampling(expr.Value.Value, expr.Query.Expr) {
+		for _, problem := range c.checkSampling(ctx, expr.Value.Value, expr.Query.Expr) {
 			problems = append(problems, Problem{
 				Lines:    expr.Value.Lines,
 				Reporter: c.Reporter(),
@@ -79,7 +84,7 @@ func (c FragileCheck) Check(_ context.Context, _ discovery.Path, rule parser.Rul
 	return problems
 }
 
-func (c FragileCheck) checkAggregation(query string, node *parser.PromQLNode) (problems []exprProblem) {
+func (c FragileCheck) checkAggregation(ctx context.Context, query string, node *parser.PromQLNode) (problems []exprProblem) {
 	if n := utils.HasOuterBinaryExpr(node); n != nil && n.Op != promParser.LOR && n.Op != promParser.LUNLESS {
 		if n.VectorMatching != nil && n.VectorMatching.On {
 			goto NEXT
@@ -90,15 +95,42 @@ func (c FragileCheck) checkAggregation(query string, node *parser.PromQLNode) (p
 		if _, ok := n.RHS.(*promParser.NumberLiteral); ok {
 			goto NEXT
 		}
-		var isFragile bool
+		var isFragile, isFragileBy bool
+		var fragment QueryFragment
+		grouping := make([][]string, 0, len(node.Children))
+		fingerprints := make([][]uint64, 0, len(node.Children))
 		for _, child := range node.Children {
-			for _, src := range utils.LabelsSource(query, child.Expr) {
+			var fps []uint64
+			for _, src := range utils.CachedLabelsSource(ctx, query, child.Expr) {
+				fps = append(fps, src.Fingerprint())
 				if src.Type == utils.AggregateSource && !src.FixedLabels {
+					if !isFragile {
+						fragment = withoutFragment(query, child.Expr, src)
+					}
 					isFragile = true
 				}
+				if src.Type == utils.AggregateSource && src.FixedLabels && len(src.IncludedLabels) > 0 {
+					grouping = append(grouping, src.IncludedLabels)
+				}
 			}
+			slices.Sort(fps)
+			fingerprints = append(fingerprints, fps)
 		}
-		if !isFragile {
+		if c.flagBy && !isFragile && len(grouping) == len(node.Children) && (n.VectorMatching == nil || len(n.VectorMatching.MatchingLabels) == 0) {
+			for _, labels := range grouping[1:] {
+				if !sameLabels(grouping[0], labels) {
+					isFragileBy = true
+				}
+			}
+		}
+		if !isFragile && !isFragileBy {
+			goto NEXT
+		}
+
+		// don't report any issues if both sides return identical time series
+		if len(fingerprints) > 1 && !slices.ContainsFunc(fingerprints[1:], func(fps []uint64) bool {
+			return !slices.Equal(fingerprints[0], fps)
+		}) {
 			goto NEXT
 		}
 
@@ -109,9 +141,17 @@ func (c FragileCheck) checkAggregation(query string, node *parser.PromQLNode) (p
 				series[vs.Name] = struct{}{}
 			}
 		}
+		if len(series) >= 2 && isFragileBy {
+			problems = append(problems, exprProblem{
+				text:     "Aggregation using `by()` with different sets of labels on each side of a binary expression can be fragile because both sides must have identical sets of labels to produce any results, consider aggregating both sides by the same labels.",
+				severity: Warning,
+			})
+			return problems
+		}
 		if len(series) >= 2 {
 			p := exprProblem{
 				text:     "Aggregation using `without()` can be fragile when used inside binary expression because both sides must have identical sets of labels to produce any results, adding or removing labels to metrics used here can easily break the query, consider aggregating using `by()` to ensure consistent labels.",
+				fragment: fragment,
 				severity: Warning,
 			}
 			problems = append(problems, p)
@@ -121,14 +161,14 @@ func (c FragileCheck) checkAggregation(query string, node *parser.PromQLNode) (p
 
 NEXT:
 	for _, child := range node.Children {
-		problems = append(problems, c.checkAggregation(query, child)...)
+		problems = append(problems, c.checkAggregation(ctx, query, child)...)
 	}
 
 	return problems
 }
 
-func (c FragileCheck) checkSampling(expr string, node promParser.Node) (problems []exprProblem) {
-	for _, src := range utils.LabelsSource(expr, node) {
+func (c FragileCheck) checkSampling(ctx context.Context, expr string, node promParser.Node) (problems []exprProblem) {
+	for _, src := range utils.CachedLabelsSource(ctx, expr, node) {
 		if src.Type != utils.AggregateSource {
 			continue
 		}
@@ -146,3 +186,40 @@ func (c FragileCheck) checkSampling(expr string, node promParser.Node) (problems
 	}
 	return problems
 }
+
+var withoutRe = regexp.MustCompile(`without\s*\([^)]*\)`)
+
+// withoutFragment returns the position of the `without(...)` clause
+// of the aggregation that removed labels from given source.
+func withoutFragment(query string, node promParser.Node, src utils.Source) (f QueryFragment) {
+	pr := node.PositionRange()
+	for _, name := range src.ExcludedLabels {
+		reason, ok := src.ExcludeReason[name]
+		if !ok || reason.Fragment == "" {
+			continue
+		}
+		idx := strings.Index(query[pr.Start:pr.End], reason.Fragment)
+		if idx < 0 {
+			continue
+		}
+		loc := withoutRe.FindAllStringIndex(reason.Fragment, -1)
+		if len(loc) == 0 {
+			continue
+		}
+		start := int(pr.Start) + idx + loc[len(loc)-1][0]
+		end := int(pr.Start) + idx + loc[len(loc)-1][1]
+		return QueryFragment{
+			Text:  query[start:end],
+			Start: start,
+			End:   end,
+		}
+	}
+	return f
+}
+
+func sameLabels(a, b []string) bool {
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(a, b)
+}
@@ -10,13 +10,25 @@ import (
 )
 
 func newFragileCheck(_ *promapi.FailoverGroup) checks.RuleChecker {
-	return checks.NewFragileCheck()
+	return checks.NewFragileCheck(false)
+}
+
+func newFragileByCheck(_ *promapi.FailoverGroup) checks.RuleChecker {
+	return checks.NewFragileCheck(true)
 }
 
 func fragileSampleFunc(s string) string {
 	return fmt.Sprintf("Using `%s` to select time series might return different set of time series on every query, which would cause flapping alerts.", s)
 }
 
+func withoutFragment(start, end int) checks.QueryFragment {
+	return checks.QueryFragment{
+		Text:  "without(job)",
+		Start: start,
+		End:   end,
+	}
+}
+
 func TestFragileCheck(t *testing.T) {
 	text := "Aggregation using `without()` can be fragile when used inside binary expression because both sides must have identical sets of labels to produce any results, adding or removing labels to metrics used here can easily break the query, consider aggregating using `by()` to ensure consistent labels."
 
@@ -70,6 +82,7 @@ func TestFragileCheck(t *testing.T) {
 						},
 						Reporter: checks.FragileCheckName,
 						Text:     text,
+						Fragment: withoutFragment(15, 27),
 						Severity: checks.Warning,
 					},
 				}
@@ -89,6 +102,7 @@ func TestFragileCheck(t *testing.T) {
 						},
 						Reporter: checks.FragileCheckName,
 						Text:     text,
+						Fragment: withoutFragment(9, 21),
 						Severity: checks.Warning,
 					},
 				}
@@ -108,6 +122,7 @@ func TestFragileCheck(t *testing.T) {
 						},
 						Reporter: checks.FragileCheckName,
 						Text:     text,
+						Fragment: withoutFragment(10, 22),
 						Severity: checks.Warning,
 					},
 				}
@@ -127,6 +142,7 @@ func TestFragileCheck(t *testing.T) {
 						},
 						Reporter: checks.FragileCheckName,
 						Text:     text,
+						Fragment: withoutFragment(16, 28),
 						Severity: checks.Warning,
 					},
 				}
@@ -146,6 +162,41 @@ func TestFragileCheck(t *testing.T) {
 						},
 						Reporter: checks.FragileCheckName,
 						Text:     text,
+						Fragment: withoutFragment(10, 22),
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+		{
+			description: "points at without() in a multi-line query",
+			content: `
+- record: foo
+  expr: |
+    sum by (instance) (
+      rate(http_requests_total[5m])
+    )
+    /
+    sum without (job) (
+      rate(http_errors_total[5m])
+    )
+`,
+			checker:    newFragileCheck,
+			prometheus: noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 3,
+							Last:  10,
+						},
+						Reporter: checks.FragileCheckName,
+						Text:     text,
+						Fragment: checks.QueryFragment{
+							Text:  "without (job)",
+							Start: 60,
+							End:   73,
+						},
 						Severity: checks.Warning,
 					},
 				}
@@ -298,6 +349,53 @@ func TestFragileCheck(t *testing.T) {
 			prometheus:  noProm,
 			problems:    noProblems,
 		},
+		{
+			description: "ignores by() with different labels by default",
+			content:     "- record: foo\n  expr: sum(foo) by(job) + sum(bar) by(instance)\n",
+			checker:     newFragileCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "warns about by() with different labels when flagBy is enabled",
+			content:     "- record: foo\n  expr: sum(foo) by(job) + sum(bar) by(instance)\n",
+			checker:     newFragileByCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.FragileCheckName,
+						Text:     "Aggregation using `by()` with different sets of labels on each side of a binary expression can be fragile because both sides must have identical sets of labels to produce any results, consider aggregating both sides by the same labels.",
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+		{
+			description: "ignores by() with identical labels when flagBy is enabled",
+			content:     "- record: foo\n  expr: sum(foo) by(job, instance) / sum(bar) by(instance, job)\n",
+			checker:     newFragileByCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores by() with on() when flagBy is enabled",
+			content:     "- record: foo\n  expr: sum(foo) by(job) + on(job) group_left() sum(bar) by(job, instance)\n",
+			checker:     newFragileByCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores by() with the same metric when flagBy is enabled",
+			content:     "- record: foo\n  expr: sum(foo) by(job) + sum(foo) by(instance)\n",
+			checker:     newFragileByCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
 	}
 
 	runTests(t, testCases)
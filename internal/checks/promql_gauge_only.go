@@ -0,0 +1,124 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	promParser "github.com/prometheus/prometheus/promql/parser"
+
+	"github.com/cloudflare/pint/internal/discovery"
+	"github.com/cloudflare/pint/internal/parser"
+)
+
+const (
+	GaugeOnlyCheckName    = "promql/gauge_only"
+	GaugeOnlyCheckDetails = "Functions like `deriv()` and `predict_linear()` only work correctly with gauges.\n" +
+		"[Prometheus naming conventions](https://prometheus.io/docs/practices/naming/) suggest that counter metric names should have a suffix like `_total`, " +
+		"this check is using metric names to guess if a metric is a counter and it might report false positives.\n" +
+		"For counter metrics use `rate()` or `increase()` instead."
+)
+
+var (
+	DefaultGaugeOnlySuffixes = []string{"_total", "_count"}
+
+	gaugeOnlyFunctions = []string{"deriv", "predict_linear", "holt_winters", "double_exponential_smoothing"}
+)
+
+func NewGaugeOnlyCheck(suffixes []string, comment string, severity Severity) GaugeOnlyCheck {
+	if len(suffixes) == 0 {
+		suffixes = DefaultGaugeOnlySuffixes
+	}
+	return GaugeOnlyCheck{
+		suffixes: suffixes,
+		comment:  comment,
+		severity: severity,
+	}
+}
+
+type GaugeOnlyCheck struct {
+	comment  string
+	suffixes []string
+	severity Severity
+}
+
+func (c GaugeOnlyCheck) Meta() CheckMeta {
+	return CheckMeta{
+		States: []discovery.ChangeType{
+			discovery.Noop,
+			discovery.Added,
+			discovery.Modified,
+			discovery.Moved,
+		},
+		Online:        false,
+		AlwaysEnabled: false,
+	}
+}
+
+func (c GaugeOnlyCheck) String() string {
+	return GaugeOnlyCheckName
+}
+
+func (c GaugeOnlyCheck) Reporter() string {
+	return GaugeOnlyCheckName
+}
+
+func (c GaugeOnlyCheck) Check(_ context.Context, _ discovery.Path, rule parser.Rule, _ []discovery.Entry) (problems []Problem) {
+	expr := rule.Expr()
+	if expr.SyntaxError != nil {
+		return problems
+	}
+
+	details := GaugeOnlyCheckDetails
+	if c.comment != "" {
+		details += "\n" + maybeComment(c.comment)
+	}
+
+	done := map[string]struct{}{}
+	for _, node := range parser.WalkDownExpr[*promParser.Call](expr.Query) {
+		n := node.Expr.(*promParser.Call)
+		if !slices.Contains(gaugeOnlyFunctions, n.Func.Name) {
+			continue
+		}
+		for _, arg := range n.Args {
+			m, ok := arg.(*promParser.MatrixSelector)
+			if !ok {
+				continue
+			}
+			vs, ok := m.VectorSelector.(*promParser.VectorSelector)
+			if !ok || vs.Name == "" {
+				// Metric name is unknown, it's selected using a regexp matcher.
+				continue
+			}
+			suffix, ok := c.counterSuffix(vs.Name)
+			if !ok {
+				continue
+			}
+			key := n.Func.Name + "/" + vs.Name
+			if _, ok := done[key]; ok {
+				continue
+			}
+			done[key] = struct{}{}
+			problems = append(problems, Problem{
+				Lines:    expr.Value.Lines,
+				Reporter: c.Reporter(),
+				Text: fmt.Sprintf("`%s()` should only be used with gauges but `%s` looks like a counter, counter metric names usually end with `%s`.",
+					n.Func.Name, vs.Name, suffix),
+				Details:  details,
+				Severity: c.severity,
+			})
+		}
+	}
+
+	return problems
+}
+
+func (c GaugeOnlyCheck) counterSuffix(name string) (string, bool) {
+	for _, suffix := range c.suffixes {
+		if strings.HasSuffix(name, suffix) {
+			return suffix, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,135 @@
+package checks_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/promapi"
+)
+
+func newGaugeOnlyCheck(_ *promapi.FailoverGroup) checks.RuleChecker {
+	return checks.NewGaugeOnlyCheck(nil, "", checks.Warning)
+}
+
+func gaugeOnlyText(fn, metric, suffix string) string {
+	return fmt.Sprintf("`%s()` should only be used with gauges but `%s` looks like a counter, counter metric names usually end with `%s`.", fn, metric, suffix)
+}
+
+func TestGaugeOnlyCheck(t *testing.T) {
+	testCases := []checkTest{
+		{
+			description: "ignores rules with syntax errors",
+			content:     "- record: foo\n  expr: deriv(foo_total[5m]\n",
+			checker:     newGaugeOnlyCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores gauges",
+			content:     "- record: foo\n  expr: deriv(temperature[5m])\n",
+			checker:     newGaugeOnlyCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores regexp names",
+			content:     "- record: foo\n  expr: deriv({__name__=~\"http_.+_total\"}[5m])\n",
+			checker:     newGaugeOnlyCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores rate() on counters",
+			content:     "- record: foo\n  expr: rate(http_requests_total[5m])\n",
+			checker:     newGaugeOnlyCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "reports deriv() on counter",
+			content:     "- record: foo\n  expr: deriv(http_requests_total[5m])\n",
+			checker:     newGaugeOnlyCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.GaugeOnlyCheckName,
+						Text:     gaugeOnlyText("deriv", "http_requests_total", "_total"),
+						Details:  checks.GaugeOnlyCheckDetails,
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+		{
+			description: "reports predict_linear() on counter",
+			content:     "- alert: foo\n  expr: predict_linear(jobs_count[1h], 3600) > 100\n",
+			checker:     newGaugeOnlyCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.GaugeOnlyCheckName,
+						Text:     gaugeOnlyText("predict_linear", "jobs_count", "_count"),
+						Details:  checks.GaugeOnlyCheckDetails,
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+		{
+			description: "reports nested calls once",
+			content:     "- record: foo\n  expr: sum(deriv(errors_total[10m])) / sum(deriv(errors_total[10m]))\n",
+			checker:     newGaugeOnlyCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.GaugeOnlyCheckName,
+						Text:     gaugeOnlyText("deriv", "errors_total", "_total"),
+						Details:  checks.GaugeOnlyCheckDetails,
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+		{
+			description: "custom suffixes, comment and severity",
+			content:     "- record: foo\n  expr: deriv(http_requests_total[5m]) + deriv(cpu_seconds[5m])\n",
+			checker: func(_ *promapi.FailoverGroup) checks.RuleChecker {
+				return checks.NewGaugeOnlyCheck([]string{"_seconds"}, "rule comment", checks.Bug)
+			},
+			prometheus: noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.GaugeOnlyCheckName,
+						Text:     gaugeOnlyText("deriv", "cpu_seconds", "_seconds"),
+						Details:  checks.GaugeOnlyCheckDetails + "\nRule comment: rule comment",
+						Severity: checks.Bug,
+					},
+				}
+			},
+		},
+	}
+
+	runTests(t, testCases)
+}
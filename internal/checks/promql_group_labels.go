@@ -0,0 +1,110 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	promParser "github.com/prometheus/prometheus/promql/parser"
+
+	"github.com/cloudflare/pint/internal/discovery"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/parser/utils"
+)
+
+const (
+	GroupLabelsCheckName = "promql/group_labels"
+)
+
+func NewGroupLabelsCheck() GroupLabelsCheck {
+	return GroupLabelsCheck{}
+}
+
+type GroupLabelsCheck struct{}
+
+func (c GroupLabelsCheck) Meta() CheckMeta {
+	return CheckMeta{
+		States: []discovery.ChangeType{
+			discovery.Noop,
+			discovery.Added,
+			discovery.Modified,
+			discovery.Moved,
+		},
+		Online:        false,
+		AlwaysEnabled: false,
+	}
+}
+
+func (c GroupLabelsCheck) String() string {
+	return GroupLabelsCheckName
+}
+
+func (c GroupLabelsCheck) Reporter() string {
+	return GroupLabelsCheckName
+}
+
+// Check reports labels listed in group_left() or group_right()
+// that are guaranteed to be removed from the "one" side of the join,
+// which means there's nothing to copy.
+func (c GroupLabelsCheck) Check(ctx context.Context, _ discovery.Path, rule parser.Rule, _ []discovery.Entry) (problems []Problem) {
+	expr := rule.Expr()
+	if expr.SyntaxError != nil {
+		return problems
+	}
+
+	for _, bn := range parser.WalkDownExpr[*promParser.BinaryExpr](expr.Query) {
+		n := bn.Expr.(*promParser.BinaryExpr)
+		if n.VectorMatching == nil || len(n.VectorMatching.Include) == 0 {
+			continue
+		}
+
+		var group, side string
+		var one promParser.Node
+		switch n.VectorMatching.Card {
+		case promParser.CardManyToOne:
+			group, side, one = "group_left", "right", n.RHS
+		case promParser.CardOneToMany:
+			group, side, one = "group_right", "left", n.LHS
+		default:
+			continue
+		}
+
+		oneQuery := expr.Value.Value[one.PositionRange().Start:one.PositionRange().End]
+		done := map[string]struct{}{}
+		for _, s := range utils.CachedLabelsSource(ctx, expr.Value.Value, one) {
+			if s.IsDead {
+				continue
+			}
+			for _, name := range n.VectorMatching.Include {
+				if _, ok := done[name]; ok {
+					continue
+				}
+				if !sourceRemovesLabel(s, name) {
+					continue
+				}
+				done[name] = struct{}{}
+				problems = append(problems, Problem{
+					Lines:    expr.Value.Lines,
+					Reporter: c.Reporter(),
+					Text: fmt.Sprintf("Using `%s(%s)` won't copy the `%s` label because it's removed from the results of the %s hand side of the query: `%s`.",
+						group, strings.Join(n.VectorMatching.Include, ", "), name, side, oneQuery),
+					Details:  excludeReasonDetails(expr.Value.Value, s.ExcludeReasons(name)),
+					Severity: Warning,
+				})
+			}
+		}
+	}
+
+	return problems
+}
+
+// sourceRemovesLabel returns true if given label is guaranteed to be removed from
+// the results of this source, either explicitly with without(...) or because
+// only a fixed set of labels, like those listed in by(...), is kept.
+func sourceRemovesLabel(s utils.Source, name string) bool {
+	if slices.Contains(s.ExcludedLabels, name) {
+		return true
+	}
+	return s.FixedLabels && !slices.Contains(s.IncludedLabels, name) && !slices.Contains(s.GuaranteedLabels, name)
+}
@@ -0,0 +1,114 @@
+package checks_test
+
+import (
+	"testing"
+
+	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/promapi"
+)
+
+func newGroupLabelsCheck(_ *promapi.FailoverGroup) checks.RuleChecker {
+	return checks.NewGroupLabelsCheck()
+}
+
+func TestGroupLabelsCheck(t *testing.T) {
+	testCases := []checkTest{
+		{
+			description: "ignores rules with syntax errors",
+			content:     "- record: foo\n  expr: foo * on(x) group_left(bar) sum(baz) without(bar))\n",
+			checker:     newGroupLabelsCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores queries without group_left or group_right",
+			content:     "- record: foo\n  expr: foo * on(x) sum(baz) without(bar)\n",
+			checker:     newGroupLabelsCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores group_left without labels",
+			content:     "- record: foo\n  expr: foo * on(x) group_left() sum(baz) without(bar)\n",
+			checker:     newGroupLabelsCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "group_left label removed by without()",
+			content:     "- record: foo\n  expr: foo * on(x) group_left(bar) sum(baz) without(bar)\n",
+			checker:     newGroupLabelsCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.GroupLabelsCheckName,
+						Text:     "Using `group_left(bar)` won't copy the `bar` label because it's removed from the results of the right hand side of the query: `sum(baz) without(bar)`.",
+						Details:  "Query is using aggregation with `without(bar)`, all labels included inside `without(...)` will be removed from the results.\nQuery fragment causing this problem: `sum(baz) without(bar)`.",
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+		{
+			description: "group_right label removed by without()",
+			content:     "- record: foo\n  expr: sum(baz) without(bar) * on(x) group_right(bar) foo\n",
+			checker:     newGroupLabelsCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.GroupLabelsCheckName,
+						Text:     "Using `group_right(bar)` won't copy the `bar` label because it's removed from the results of the left hand side of the query: `sum(baz) without(bar)`.",
+						Details:  "Query is using aggregation with `without(bar)`, all labels included inside `without(...)` will be removed from the results.\nQuery fragment causing this problem: `sum(baz) without(bar)`.",
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+		{
+			description: "group_left label removed by by()",
+			content:     "- record: foo\n  expr: foo * on(x) group_left(bar) sum(baz) by(x)\n",
+			checker:     newGroupLabelsCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.GroupLabelsCheckName,
+						Text:     "Using `group_left(bar)` won't copy the `bar` label because it's removed from the results of the right hand side of the query: `sum(baz) by(x)`.",
+						Details:  "Query is using aggregation with `by(x)`, only labels included inside `by(...)` will be present on the results.\nQuery fragment causing this problem: `sum(baz) by(x)`.",
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+		{
+			description: "group_left label kept by by()",
+			content:     "- record: foo\n  expr: foo * on(x) group_left(bar) sum(baz) by(x, bar)\n",
+			checker:     newGroupLabelsCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "group_left label not removed",
+			content:     "- record: foo\n  expr: foo * on(x) group_left(bar) sum(baz) without(job)\n",
+			checker:     newGroupLabelsCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+	}
+	runTests(t, testCases)
+}
@@ -0,0 +1,106 @@
+package checks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/cloudflare/pint/internal/discovery"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/parser/utils"
+)
+
+const (
+	HighChurnLabelCheckName = "promql/high_churn_label"
+
+	HighChurnLabelCheckDetails = "Each unique combination of labels on the alert query results is a separate alert.\n" +
+		"Values of some labels, like pod names, change every time a workload is restarted or rescheduled, so the old alert will resolve and a new one will fire.\n" +
+		"This causes alert flapping and it might make it harder to see that it's the same problem.\n" +
+		"Consider removing this label from the aggregation."
+)
+
+var DefaultHighChurnLabels = []string{"pod", "container_id", "replica"}
+
+type PromqlHighChurnLabelSettings struct {
+	Labels []string `hcl:"labels,optional" json:"labels,omitempty"`
+}
+
+func (c *PromqlHighChurnLabelSettings) Validate() error {
+	for _, name := range c.Labels {
+		if name == "" {
+			return errors.New("labels cannot contain empty values")
+		}
+	}
+	if len(c.Labels) == 0 {
+		c.Labels = DefaultHighChurnLabels
+	}
+	return nil
+}
+
+func NewHighChurnLabelCheck() HighChurnLabelCheck {
+	return HighChurnLabelCheck{}
+}
+
+type HighChurnLabelCheck struct{}
+
+func (c HighChurnLabelCheck) Meta() CheckMeta {
+	return CheckMeta{
+		States: []discovery.ChangeType{
+			discovery.Noop,
+			discovery.Added,
+			discovery.Modified,
+			discovery.Moved,
+		},
+		Online:        false,
+		AlwaysEnabled: false,
+	}
+}
+
+func (c HighChurnLabelCheck) String() string {
+	return HighChurnLabelCheckName
+}
+
+func (c HighChurnLabelCheck) Reporter() string {
+	return HighChurnLabelCheckName
+}
+
+func (c HighChurnLabelCheck) Check(ctx context.Context, _ discovery.Path, rule parser.Rule, _ []discovery.Entry) (problems []Problem) {
+	if rule.AlertingRule == nil || rule.AlertingRule.Expr.SyntaxError != nil {
+		return problems
+	}
+	expr := rule.AlertingRule.Expr
+
+	var settings *PromqlHighChurnLabelSettings
+	if s := ctx.Value(SettingsKey(c.Reporter())); s != nil {
+		settings = s.(*PromqlHighChurnLabelSettings)
+	}
+	if settings == nil {
+		settings = &PromqlHighChurnLabelSettings{}
+		_ = settings.Validate()
+	}
+
+	var done []string
+	for _, src := range utils.CachedLabelsSource(ctx, expr.Value.Value, expr.Query.Expr) {
+		// Only report queries that explicitly keep given label.
+		if src.IsDead || !src.FixedLabels {
+			continue
+		}
+		for _, name := range src.HighChurnLabels(settings.Labels) {
+			if slices.Contains(done, name) {
+				continue
+			}
+			done = append(done, name)
+			problems = append(problems, Problem{
+				Lines:    expr.Value.Lines,
+				Reporter: c.Reporter(),
+				Text: fmt.Sprintf("Alert query results will have the `%s` label, its values change frequently and every change will fire a new alert.",
+					name),
+				Details:  HighChurnLabelCheckDetails,
+				Severity: Information,
+			})
+		}
+	}
+
+	return problems
+}
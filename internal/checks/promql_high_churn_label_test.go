@@ -0,0 +1,130 @@
+package checks_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/promapi"
+)
+
+func newHighChurnLabelCheck(_ *promapi.FailoverGroup) checks.RuleChecker {
+	return checks.NewHighChurnLabelCheck()
+}
+
+func TestHighChurnLabelCheck(t *testing.T) {
+	testCases := []checkTest{
+		{
+			description: "ignores recording rules",
+			content:     "- record: foo\n  expr: sum(foo) by(pod)\n",
+			checker:     newHighChurnLabelCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores rules with syntax errors",
+			content:     "- alert: foo\n  expr: sum(foo) by(pod\n",
+			checker:     newHighChurnLabelCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores queries without aggregation",
+			content:     "- alert: foo\n  expr: up == 0\n",
+			checker:     newHighChurnLabelCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores grouping by other labels",
+			content:     "- alert: foo\n  expr: sum(rate(errors_total[5m])) by(job, namespace) > 0\n",
+			checker:     newHighChurnLabelCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores labels removed by outer aggregation",
+			content:     "- alert: foo\n  expr: max(sum(rate(errors_total[5m])) by(job, pod)) by(job) > 0\n",
+			checker:     newHighChurnLabelCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "reports grouping by pod",
+			content:     "- alert: foo\n  expr: sum(rate(errors_total[5m])) by(job, pod) > 0\n",
+			checker:     newHighChurnLabelCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.HighChurnLabelCheckName,
+						Text:     "Alert query results will have the `pod` label, its values change frequently and every change will fire a new alert.",
+						Details:  checks.HighChurnLabelCheckDetails,
+						Severity: checks.Information,
+					},
+				}
+			},
+		},
+		{
+			description: "reports each label once",
+			content:     "- alert: foo\n  expr: sum(foo) by(pod, replica) > 0 or count(bar) by(pod) > 1\n",
+			checker:     newHighChurnLabelCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.HighChurnLabelCheckName,
+						Text:     "Alert query results will have the `pod` label, its values change frequently and every change will fire a new alert.",
+						Details:  checks.HighChurnLabelCheckDetails,
+						Severity: checks.Information,
+					},
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.HighChurnLabelCheckName,
+						Text:     "Alert query results will have the `replica` label, its values change frequently and every change will fire a new alert.",
+						Details:  checks.HighChurnLabelCheckDetails,
+						Severity: checks.Information,
+					},
+				}
+			},
+		},
+		{
+			description: "uses configured labels",
+			content:     "- alert: foo\n  expr: sum(foo) by(pod, instance) > 0\n",
+			checker:     newHighChurnLabelCheck,
+			prometheus:  noProm,
+			ctx: func(ctx context.Context, _ string) context.Context {
+				s := checks.PromqlHighChurnLabelSettings{Labels: []string{"instance"}}
+				_ = s.Validate()
+				return context.WithValue(ctx, checks.SettingsKey(checks.HighChurnLabelCheckName), &s)
+			},
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.HighChurnLabelCheckName,
+						Text:     "Alert query results will have the `instance` label, its values change frequently and every change will fire a new alert.",
+						Details:  checks.HighChurnLabelCheckDetails,
+						Severity: checks.Information,
+					},
+				}
+			},
+		},
+	}
+	runTests(t, testCases)
+}
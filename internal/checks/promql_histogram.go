@@ -0,0 +1,107 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	promParser "github.com/prometheus/prometheus/promql/parser"
+
+	"github.com/cloudflare/pint/internal/discovery"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/parser/utils"
+)
+
+const (
+	HistogramCheckName    = "promql/histogram"
+	HistogramCheckDetails = "`histogram_quantile()` calculates quantiles from classic histograms using the `le` label of each `_bucket` time series.\n" +
+		"If `le` is removed, for example by aggregating with `by(...)` that doesn't include it, then `histogram_quantile()` will return no results.\n" +
+		"Native histograms don't use the `le` label and are not affected."
+)
+
+func NewHistogramCheck() HistogramCheck {
+	return HistogramCheck{}
+}
+
+type HistogramCheck struct{}
+
+func (c HistogramCheck) Meta() CheckMeta {
+	return CheckMeta{
+		States: []discovery.ChangeType{
+			discovery.Noop,
+			discovery.Added,
+			discovery.Modified,
+			discovery.Moved,
+		},
+		Online:        false,
+		AlwaysEnabled: false,
+	}
+}
+
+func (c HistogramCheck) String() string {
+	return HistogramCheckName
+}
+
+func (c HistogramCheck) Reporter() string {
+	return HistogramCheckName
+}
+
+func (c HistogramCheck) Check(ctx context.Context, _ discovery.Path, rule parser.Rule, _ []discovery.Entry) (problems []Problem) {
+	expr := rule.Expr()
+	if expr.SyntaxError != nil {
+		return problems
+	}
+
+	for _, node := range parser.WalkDownExpr[*promParser.Call](expr.Query) {
+		call := node.Expr.(*promParser.Call)
+		if call.Func.Name != "histogram_quantile" || len(call.Args) != 2 {
+			continue
+		}
+		arg := call.Args[1]
+		for _, src := range utils.CachedLabelsSource(ctx, expr.Value.Value, arg) {
+			if !histogramDropsLe(src) {
+				continue
+			}
+			details := HistogramCheckDetails
+			if reasons := src.ExcludeReasons("le"); len(reasons) > 0 {
+				details = fmt.Sprintf("%s\n%s", details, excludeReasonDetails(expr.Value.Value, reasons))
+			}
+			problems = append(problems, Problem{
+				Lines:    expr.Value.Lines,
+				Reporter: c.Reporter(),
+				Text: fmt.Sprintf("`%s` is passed to `histogram_quantile()` but it removes the `le` label from a classic histogram, this query will not return any results.",
+					expr.Value.Value[arg.PositionRange().Start:arg.PositionRange().End]),
+				Details:  details,
+				Severity: Warning,
+			})
+			break
+		}
+	}
+
+	return problems
+}
+
+// histogramDropsLe returns true if given source is reading a classic histogram,
+// which is detected using the `_bucket` suffix, and `le` label is removed from results.
+func histogramDropsLe(src utils.Source) bool {
+	if src.IsDead || !isBucketSource(src) {
+		return false
+	}
+	if slices.Contains(src.GuaranteedLabels, "le") {
+		return false
+	}
+	if slices.Contains(src.ExcludedLabels, "le") {
+		return true
+	}
+	return src.FixedLabels && !slices.Contains(src.IncludedLabels, "le")
+}
+
+func isBucketSource(src utils.Source) bool {
+	for _, vs := range src.Selectors {
+		if strings.HasSuffix(vs.Name, "_bucket") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,122 @@
+package checks_test
+
+import (
+	"testing"
+
+	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/promapi"
+)
+
+func newHistogramCheck(_ *promapi.FailoverGroup) checks.RuleChecker {
+	return checks.NewHistogramCheck()
+}
+
+func TestHistogramCheck(t *testing.T) {
+	testCases := []checkTest{
+		{
+			description: "ignores rules with syntax errors",
+			content:     "- record: foo\n  expr: histogram_quantile(0.9, sum(rate(foo_bucket[5m])) by(job)\n",
+			checker:     newHistogramCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores raw selectors",
+			content:     "- record: foo\n  expr: histogram_quantile(0.9, rate(foo_bucket[5m]))\n",
+			checker:     newHistogramCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores aggregation keeping le",
+			content:     "- record: foo\n  expr: histogram_quantile(0.9, sum(rate(foo_bucket[5m])) by(job, le))\n",
+			checker:     newHistogramCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores aggregation keeping le via without",
+			content:     "- record: foo\n  expr: histogram_quantile(0.9, sum(rate(foo_bucket[5m])) without(instance))\n",
+			checker:     newHistogramCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores native histograms",
+			content:     "- record: foo\n  expr: histogram_quantile(0.9, sum(rate(foo[5m])))\n",
+			checker:     newHistogramCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores other functions",
+			content:     "- record: foo\n  expr: histogram_count(sum(rate(foo_bucket[5m])))\n",
+			checker:     newHistogramCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "reports by() without le",
+			content:     "- record: foo\n  expr: histogram_quantile(0.9, sum(rate(foo_bucket[5m])) by(job))\n",
+			checker:     newHistogramCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.HistogramCheckName,
+						Text:     "`sum(rate(foo_bucket[5m])) by(job)` is passed to `histogram_quantile()` but it removes the `le` label from a classic histogram, this query will not return any results.",
+						Details:  checks.HistogramCheckDetails + "\nQuery is using aggregation with `by(job)`, only labels included inside `by(...)` will be present on the results.\nQuery fragment causing this problem: `sum(rate(foo_bucket[5m])) by(job)`.",
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+		{
+			description: "reports aggregation removing all labels",
+			content:     "- alert: foo\n  expr: histogram_quantile(0.9, sum by() (rate(foo_bucket[5m]))) > 1\n",
+			checker:     newHistogramCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.HistogramCheckName,
+						Text:     "`sum by() (rate(foo_bucket[5m]))` is passed to `histogram_quantile()` but it removes the `le` label from a classic histogram, this query will not return any results.",
+						Details:  checks.HistogramCheckDetails + "\nQuery is using aggregation that removes all labels.\nQuery fragment causing this problem: `sum by() (rate(foo_bucket[5m]))`.",
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+		{
+			description: "reports without(le)",
+			content:     "- record: foo\n  expr: histogram_quantile(0.9, sum without(le, instance) (rate(foo_bucket[5m])))\n",
+			checker:     newHistogramCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.HistogramCheckName,
+						Text:     "`sum without(le, instance) (rate(foo_bucket[5m]))` is passed to `histogram_quantile()` but it removes the `le` label from a classic histogram, this query will not return any results.",
+						Details:  checks.HistogramCheckDetails + "\nQuery is using aggregation with `without(le, instance)`, all labels included inside `without(...)` will be removed from the results.\nQuery fragment causing this problem: `sum without(le, instance) (rate(foo_bucket[5m]))`.",
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+	}
+
+	runTests(t, testCases)
+}
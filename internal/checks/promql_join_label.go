@@ -0,0 +1,111 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	promParser "github.com/prometheus/prometheus/promql/parser"
+
+	"github.com/cloudflare/pint/internal/discovery"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/parser/utils"
+)
+
+const (
+	JoinLabelCheckName    = "promql/join_label"
+	JoinLabelCheckDetails = "Labels passed to `on(...)` are used to find matching time series on both sides of a binary operation.\n" +
+		"If a label is removed from the time series on both sides then it can't be used to match them."
+)
+
+func NewJoinLabelCheck() JoinLabelCheck {
+	return JoinLabelCheck{}
+}
+
+type JoinLabelCheck struct{}
+
+func (c JoinLabelCheck) Meta() CheckMeta {
+	return CheckMeta{
+		States: []discovery.ChangeType{
+			discovery.Noop,
+			discovery.Added,
+			discovery.Modified,
+			discovery.Moved,
+		},
+		Online:        false,
+		AlwaysEnabled: false,
+	}
+}
+
+func (c JoinLabelCheck) String() string {
+	return JoinLabelCheckName
+}
+
+func (c JoinLabelCheck) Reporter() string {
+	return JoinLabelCheckName
+}
+
+func (c JoinLabelCheck) Check(ctx context.Context, _ discovery.Path, rule parser.Rule, _ []discovery.Entry) (problems []Problem) {
+	expr := rule.Expr()
+	if expr.SyntaxError != nil {
+		return problems
+	}
+
+	for _, node := range parser.WalkDownExpr[*promParser.BinaryExpr](expr.Query) {
+		n := node.Expr.(*promParser.BinaryExpr)
+		if n.VectorMatching == nil || !n.VectorMatching.On || len(n.VectorMatching.MatchingLabels) == 0 {
+			continue
+		}
+		if n.LHS.Type() != promParser.ValueTypeVector || n.RHS.Type() != promParser.ValueTypeVector {
+			continue
+		}
+
+		lhs := utils.CachedLabelsSource(ctx, expr.Value.Value, n.LHS)
+		rhs := utils.CachedLabelsSource(ctx, expr.Value.Value, n.RHS)
+		if !hasLiveSource(lhs) || !hasLiveSource(rhs) {
+			continue
+		}
+
+		for _, name := range n.VectorMatching.MatchingLabels {
+			if !sourcesRemoveLabel(lhs, name) || !sourcesRemoveLabel(rhs, name) {
+				continue
+			}
+			problems = append(problems, Problem{
+				Lines:    expr.Value.Lines,
+				Reporter: c.Reporter(),
+				Text: fmt.Sprintf("`%s` is using `on(%s)` but the `%s` label is removed from both sides of the query.",
+					expr.Value.Value[n.PositionRange().Start:n.PositionRange().End], name, name),
+				Details:  JoinLabelCheckDetails,
+				Severity: Warning,
+			})
+		}
+	}
+
+	return problems
+}
+
+func hasLiveSource(src []utils.Source) bool {
+	for _, s := range src {
+		if !s.IsDead {
+			return true
+		}
+	}
+	return false
+}
+
+// sourcesRemoveLabel returns true if all of given sources are known to remove given label.
+func sourcesRemoveLabel(src []utils.Source, name string) bool {
+	for _, s := range src {
+		if s.IsDead {
+			continue
+		}
+		if slices.Contains(s.ExcludedLabels, name) {
+			continue
+		}
+		if s.FixedLabels && !slices.Contains(s.GuaranteedLabels, name) && !slices.Contains(s.IncludedLabels, name) {
+			continue
+		}
+		return false
+	}
+	return true
+}
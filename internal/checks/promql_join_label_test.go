@@ -0,0 +1,130 @@
+package checks_test
+
+import (
+	"testing"
+
+	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/promapi"
+)
+
+func newJoinLabelCheck(_ *promapi.FailoverGroup) checks.RuleChecker {
+	return checks.NewJoinLabelCheck()
+}
+
+func TestJoinLabelCheck(t *testing.T) {
+	testCases := []checkTest{
+		{
+			description: "ignores rules with syntax errors",
+			content:     "- record: foo\n  expr: foo + on(job) bar)\n",
+			checker:     newJoinLabelCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores queries without on()",
+			content:     "- record: foo\n  expr: foo + bar\n",
+			checker:     newJoinLabelCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores empty on()",
+			content:     "- record: foo\n  expr: sum(foo) + on() sum(bar)\n",
+			checker:     newJoinLabelCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores ignoring()",
+			content:     "- record: foo\n  expr: foo + ignoring(instance) bar\n",
+			checker:     newJoinLabelCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores labels guaranteed on the left hand side",
+			content:     "- record: foo\n  expr: foo{job=\"a\"} + on(job) bar\n",
+			checker:     newJoinLabelCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores labels included on the right hand side",
+			content:     "- record: foo\n  expr: foo + on(job) sum(bar) by(job)\n",
+			checker:     newJoinLabelCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores dead code",
+			content:     "- record: foo\n  expr: foo + on(job) (count(bar) < 0)\n",
+			checker:     newJoinLabelCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores labels not removed from the left hand side",
+			content:     "- record: foo\n  expr: foo + on(nonexistent) sum(bar) by(job)\n",
+			checker:     newJoinLabelCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores group_left with info metric",
+			content:     "- record: foo\n  expr: rate(errors_total[5m]) * on(instance) group_left(version) build_info\n",
+			checker:     newJoinLabelCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores unless on() with comparisons",
+			content:     "- alert: foo\n  expr: kube_job_status_failed > 0 unless on(namespace, job_name) kube_job_status_succeeded > 0\n",
+			checker:     newJoinLabelCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "reports label removed by by() on both sides",
+			content:     "- record: foo\n  expr: sum(foo) by(job) + on(instance) sum(bar) by(job)\n",
+			checker:     newJoinLabelCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.JoinLabelCheckName,
+						Text:     "`sum(foo) by(job) + on(instance) sum(bar) by(job)` is using `on(instance)` but the `instance` label is removed from both sides of the query.",
+						Details:  checks.JoinLabelCheckDetails,
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+		{
+			description: "reports only labels removed from both sides",
+			content:     "- alert: foo\n  expr: sum(foo{job=\"a\"}) without(env) > on(job, env) group_left(team) sum(bar) by(job, team)\n",
+			checker:     newJoinLabelCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.JoinLabelCheckName,
+						Text:     "`sum(foo{job=\"a\"}) without(env) > on(job, env) group_left(team) sum(bar) by(job, team)` is using `on(env)` but the `env` label is removed from both sides of the query.",
+						Details:  checks.JoinLabelCheckDetails,
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+	}
+
+	runTests(t, testCases)
+}
@@ -0,0 +1,81 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	promParser "github.com/prometheus/prometheus/promql/parser"
+
+	"github.com/cloudflare/pint/internal/discovery"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/parser/utils"
+)
+
+const (
+	LabelReplaceOverwriteCheckName = "promql/label_replace_overwrite"
+
+	LabelReplaceOverwriteCheckDetails = "`label_replace()` will overwrite the value of the destination label if it's already present on the time series passed to it." + `
+If that's intended you can ignore this report, otherwise use a different destination label name to avoid losing the original value.`
+)
+
+func NewLabelReplaceOverwriteCheck() LabelReplaceOverwriteCheck {
+	return LabelReplaceOverwriteCheck{}
+}
+
+type LabelReplaceOverwriteCheck struct{}
+
+func (c LabelReplaceOverwriteCheck) Meta() CheckMeta {
+	return CheckMeta{
+		States: []discovery.ChangeType{
+			discovery.Noop,
+			discovery.Added,
+			discovery.Modified,
+			discovery.Moved,
+		},
+		Online:        false,
+		AlwaysEnabled: false,
+	}
+}
+
+func (c LabelReplaceOverwriteCheck) String() string {
+	return LabelReplaceOverwriteCheckName
+}
+
+func (c LabelReplaceOverwriteCheck) Reporter() string {
+	return LabelReplaceOverwriteCheckName
+}
+
+func (c LabelReplaceOverwriteCheck) Check(ctx context.Context, _ discovery.Path, rule parser.Rule, _ []discovery.Entry) (problems []Problem) {
+	expr := rule.Expr()
+	if expr.SyntaxError != nil {
+		return problems
+	}
+
+	for _, node := range parser.WalkDownExpr[*promParser.Call](expr.Query) {
+		n := node.Expr.(*promParser.Call)
+		if n.Func.Name != "label_replace" || len(n.Args) < 2 {
+			continue
+		}
+		dst, ok := n.Args[1].(*promParser.StringLiteral)
+		if !ok {
+			continue
+		}
+		for _, s := range utils.CachedLabelsSource(ctx, expr.Value.Value, n.Args[0]) {
+			if s.IsDead || !slices.Contains(s.GuaranteedLabels, dst.Val) {
+				continue
+			}
+			problems = append(problems, Problem{
+				Lines:    expr.Value.Lines,
+				Reporter: c.Reporter(),
+				Text: fmt.Sprintf("`label_replace()` is using `%s` as the destination label but `%s` already has this label, the existing value will be overwritten.",
+					dst.Val, n.Args[0]),
+				Details:  LabelReplaceOverwriteCheckDetails,
+				Severity: Information,
+			})
+			break
+		}
+	}
+
+	return problems
+}
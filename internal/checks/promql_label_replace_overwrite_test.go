@@ -0,0 +1,107 @@
+package checks_test
+
+import (
+	"testing"
+
+	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/promapi"
+)
+
+func newLabelReplaceOverwriteCheck(_ *promapi.FailoverGroup) checks.RuleChecker {
+	return checks.NewLabelReplaceOverwriteCheck()
+}
+
+func TestLabelReplaceOverwriteCheck(t *testing.T) {
+	testCases := []checkTest{
+		{
+			description: "ignores rules with syntax errors",
+			content:     "- record: foo\n  expr: label_replace(foo, \"job\", \n",
+			checker:     newLabelReplaceOverwriteCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores new labels",
+			content:     "- record: foo\n  expr: 'label_replace(foo{job=\"bar\"}, \"instance\", \"$1\", \"job\", \"(.*)\")'\n",
+			checker:     newLabelReplaceOverwriteCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores labels that might be present",
+			content:     "- record: foo\n  expr: 'label_replace(foo, \"job\", \"$1\", \"instance\", \"(.*)\")'\n",
+			checker:     newLabelReplaceOverwriteCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores labels removed by aggregation",
+			content:     "- record: foo\n  expr: 'label_replace(sum(foo{job=\"bar\"}) without(job), \"job\", \"$1\", \"instance\", \"(.*)\")'\n",
+			checker:     newLabelReplaceOverwriteCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "reports overwritten label from selector",
+			content:     "- record: foo\n  expr: 'label_replace(foo{job=\"bar\"}, \"job\", \"$1\", \"instance\", \"(.*)\")'\n",
+			checker:     newLabelReplaceOverwriteCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.LabelReplaceOverwriteCheckName,
+						Text:     "`label_replace()` is using `job` as the destination label but `foo{job=\"bar\"}` already has this label, the existing value will be overwritten.",
+						Details:  checks.LabelReplaceOverwriteCheckDetails,
+						Severity: checks.Information,
+					},
+				}
+			},
+		},
+		{
+			description: "reports overwritten label from aggregation",
+			content:     "- record: foo\n  expr: 'label_replace(sum(foo{job=\"bar\"}) by(job), \"job\", \"$1\", \"job\", \"(.*)-suffix\")'\n",
+			checker:     newLabelReplaceOverwriteCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.LabelReplaceOverwriteCheckName,
+						Text:     "`label_replace()` is using `job` as the destination label but `sum by (job) (foo{job=\"bar\"})` already has this label, the existing value will be overwritten.",
+						Details:  checks.LabelReplaceOverwriteCheckDetails,
+						Severity: checks.Information,
+					},
+				}
+			},
+		},
+		{
+			description: "reports nested label_replace",
+			content:     "- record: foo\n  expr: 'label_replace(label_replace(foo, \"dst\", \"$1\", \"src\", \"(.*)\"), \"dst\", \"$1\", \"src\", \"(.*)\")'\n",
+			checker:     newLabelReplaceOverwriteCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.LabelReplaceOverwriteCheckName,
+						Text:     "`label_replace()` is using `dst` as the destination label but `label_replace(foo, \"dst\", \"$1\", \"src\", \"(.*)\")` already has this label, the existing value will be overwritten.",
+						Details:  checks.LabelReplaceOverwriteCheckDetails,
+						Severity: checks.Information,
+					},
+				}
+			},
+		},
+	}
+	runTests(t, testCases)
+}
@@ -0,0 +1,76 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/cloudflare/pint/internal/discovery"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/parser/utils"
+)
+
+const (
+	LabelShadowCheckName = "promql/label_shadow"
+
+	LabelShadowCheckDetails = "When using `group_left(...)` or `group_right(...)` all labels listed there are copied from the `one` side of the query to the results.\n" +
+		"If a label is already present on the `many` side then its original value will be replaced with the value from the `one` side.\n" +
+		"Either remove that label from `group_left(...)` or `group_right(...)`, or rename it on one side of the query using `label_replace()`."
+)
+
+func NewLabelShadowCheck() LabelShadowCheck {
+	return LabelShadowCheck{}
+}
+
+type LabelShadowCheck struct{}
+
+func (c LabelShadowCheck) Meta() CheckMeta {
+	return CheckMeta{
+		States: []discovery.ChangeType{
+			discovery.Noop,
+			discovery.Added,
+			discovery.Modified,
+			discovery.Moved,
+		},
+		Online:        false,
+		AlwaysEnabled: false,
+	}
+}
+
+func (c LabelShadowCheck) String() string {
+	return LabelShadowCheckName
+}
+
+func (c LabelShadowCheck) Reporter() string {
+	return LabelShadowCheckName
+}
+
+func (c LabelShadowCheck) Check(ctx context.Context, _ discovery.Path, rule parser.Rule, _ []discovery.Entry) (problems []Problem) {
+	expr := rule.Expr()
+	if expr.SyntaxError != nil {
+		return problems
+	}
+
+	var done []string
+	for _, src := range utils.CachedLabelsSource(ctx, expr.Value.Value, expr.Query.Expr) {
+		if src.IsDead {
+			continue
+		}
+		for _, name := range src.Conflicts {
+			if slices.Contains(done, name) {
+				continue
+			}
+			done = append(done, name)
+			problems = append(problems, Problem{
+				Lines:    expr.Value.Lines,
+				Reporter: c.Reporter(),
+				Text: fmt.Sprintf("The `%s` label is already present on the results of this query, copying it from the other side with `group_left(...)` or `group_right(...)` will overwrite its original value.",
+					name),
+				Details:  LabelShadowCheckDetails,
+				Severity: Warning,
+			})
+		}
+	}
+
+	return problems
+}
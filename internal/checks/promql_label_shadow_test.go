@@ -0,0 +1,101 @@
+package checks_test
+
+import (
+	"testing"
+
+	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/promapi"
+)
+
+func newLabelShadowCheck(_ *promapi.FailoverGroup) checks.RuleChecker {
+	return checks.NewLabelShadowCheck()
+}
+
+func TestLabelShadowCheck(t *testing.T) {
+	testCases := []checkTest{
+		{
+			description: "ignores rules with syntax errors",
+			content:     "- record: foo\n  expr: sum(foo) by(job\n",
+			checker:     newLabelShadowCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores queries without joins",
+			content:     "- record: foo\n  expr: foo{job=\"a\"} * bar{job=\"b\"}\n",
+			checker:     newLabelShadowCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores labels not present on the many side",
+			content:     "- record: foo\n  expr: foo * on(x) group_left(job) bar{job=\"b\"}\n",
+			checker:     newLabelShadowCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "reports group_left label present on both sides",
+			content:     "- record: foo\n  expr: foo{job=\"a\"} * on(x) group_left(job) bar{job=\"b\"}\n",
+			checker:     newLabelShadowCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.LabelShadowCheckName,
+						Text:     "The `job` label is already present on the results of this query, copying it from the other side with `group_left(...)` or `group_right(...)` will overwrite its original value.",
+						Details:  checks.LabelShadowCheckDetails,
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+		{
+			description: "reports group_right label present on both sides",
+			content:     "- alert: foo\n  expr: foo{job=\"a\"} * on(x) group_right(job) bar{job=\"b\"} > 0\n",
+			checker:     newLabelShadowCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.LabelShadowCheckName,
+						Text:     "The `job` label is already present on the results of this query, copying it from the other side with `group_left(...)` or `group_right(...)` will overwrite its original value.",
+						Details:  checks.LabelShadowCheckDetails,
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+		{
+			description: "reports each label once",
+			content:     "- record: foo\n  expr: (foo{job=\"a\"} * on(x) group_left(job) bar) or (baz{job=\"c\"} * on(x) group_left(job) bar)\n",
+			checker:     newLabelShadowCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.LabelShadowCheckName,
+						Text:     "The `job` label is already present on the results of this query, copying it from the other side with `group_left(...)` or `group_right(...)` will overwrite its original value.",
+						Details:  checks.LabelShadowCheckDetails,
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+	}
+
+	runTests(t, testCases)
+}
@@ -0,0 +1,110 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	promParser "github.com/prometheus/prometheus/promql/parser"
+
+	"github.com/cloudflare/pint/internal/discovery"
+	"github.com/cloudflare/pint/internal/parser"
+)
+
+const (
+	NestedRateCheckName    = "promql/nested_rate"
+	NestedRateCheckDetails = "Functions like `rate()`, `irate()` and `increase()` should only be used with counters.\n" +
+		"Results of `rate()` and other functions calculating how fast something changes are not counters and passing them to these functions using a subquery will return bogus results.\n" +
+		"If you want to know how much the rate is changing then use `deriv()` instead."
+)
+
+var (
+	// Functions that only work correctly with counters.
+	nestedRateOuterFuncs = []string{"rate", "irate", "increase"}
+	// Functions that always return gauges calculated from range vectors.
+	nestedRateInnerFuncs = []string{"rate", "irate", "increase", "delta", "idelta", "deriv"}
+)
+
+func NewNestedRateCheck() NestedRateCheck {
+	return NestedRateCheck{}
+}
+
+type NestedRateCheck struct{}
+
+func (c NestedRateCheck) Meta() CheckMeta {
+	return CheckMeta{
+		States: []discovery.ChangeType{
+			discovery.Noop,
+			discovery.Added,
+			discovery.Modified,
+			discovery.Moved,
+		},
+		Online:        false,
+		AlwaysEnabled: false,
+	}
+}
+
+func (c NestedRateCheck) String() string {
+	return NestedRateCheckName
+}
+
+func (c NestedRateCheck) Reporter() string {
+	return NestedRateCheckName
+}
+
+func (c NestedRateCheck) Check(_ context.Context, _ discovery.Path, rule parser.Rule, _ []discovery.Entry) (problems []Problem) {
+	expr := rule.Expr()
+	if expr.SyntaxError != nil {
+		return problems
+	}
+
+	for _, node := range parser.WalkDownExpr[*promParser.Call](expr.Query) {
+		inner := node.Expr.(*promParser.Call)
+		if !slices.Contains(nestedRateInnerFuncs, inner.Func.Name) {
+			continue
+		}
+		outer, ok := enclosingRangeCall(node)
+		if !ok || !slices.Contains(nestedRateOuterFuncs, outer.Func.Name) {
+			continue
+		}
+		problems = append(problems, Problem{
+			Lines:    expr.Value.Lines,
+			Reporter: c.Reporter(),
+			Text: fmt.Sprintf("`%s` is calling `%s()` on the results of `%s`, this will return bogus results because `%s()` returns a gauge, not a counter.",
+				expr.Value.Value[outer.PosRange.Start:outer.PosRange.End], outer.Func.Name,
+				expr.Value.Value[inner.PosRange.Start:inner.PosRange.End], inner.Func.Name),
+			Details:  NestedRateCheckDetails,
+			Severity: Warning,
+		})
+	}
+
+	return problems
+}
+
+// enclosingRangeCall returns the function call that is using a subquery
+// with results of given node.
+func enclosingRangeCall(node *parser.PromQLNode) (*promParser.Call, bool) {
+	for child, parent := node, node.Parent; parent != nil; child, parent = parent, parent.Parent {
+		switch n := parent.Expr.(type) {
+		case *promParser.SubqueryExpr:
+			p := parent.Parent
+			for p != nil {
+				if _, ok := p.Expr.(*promParser.ParenExpr); !ok {
+					break
+				}
+				p = p.Parent
+			}
+			if p == nil {
+				return nil, false
+			}
+			call, ok := p.Expr.(*promParser.Call)
+			return call, ok
+		case *promParser.BinaryExpr:
+			// foo and rate(bar[5m]) > 0 is only used as a filter.
+			if (n.Op == promParser.LAND || n.Op == promParser.LUNLESS) && n.RHS == child.Expr {
+				return nil, false
+			}
+		}
+	}
+	return nil, false
+}
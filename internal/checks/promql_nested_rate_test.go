@@ -0,0 +1,141 @@
+package checks_test
+
+import (
+	"testing"
+
+	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/promapi"
+)
+
+func newNestedRateCheck(_ *promapi.FailoverGroup) checks.RuleChecker {
+	return checks.NewNestedRateCheck()
+}
+
+func TestNestedRateCheck(t *testing.T) {
+	testCases := []checkTest{
+		{
+			description: "ignores rules with syntax errors",
+			content:     "- record: foo\n  expr: rate(rate(foo[5m])[5m:]\n",
+			checker:     newNestedRateCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores rate() on counters",
+			content:     "- record: foo\n  expr: sum(rate(foo[5m]))\n",
+			checker:     newNestedRateCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores rate() on a subquery of a counter",
+			content:     "- record: foo\n  expr: rate(foo[5m:1m])\n",
+			checker:     newNestedRateCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores deriv() on rate()",
+			content:     "- record: foo\n  expr: deriv(rate(foo[5m])[30m:])\n",
+			checker:     newNestedRateCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores max_over_time() on rate()",
+			content:     "- record: foo\n  expr: max_over_time(rate(foo[5m])[1h:])\n",
+			checker:     newNestedRateCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores rate() used as a filter",
+			content:     "- record: foo\n  expr: rate((foo and rate(bar[5m]) > 0)[5m:])\n",
+			checker:     newNestedRateCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "reports rate(rate())",
+			content:     "- record: foo\n  expr: rate(rate(foo[5m])[5m:])\n",
+			checker:     newNestedRateCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.NestedRateCheckName,
+						Text:     "`rate(rate(foo[5m])[5m:])` is calling `rate()` on the results of `rate(foo[5m])`, this will return bogus results because `rate()` returns a gauge, not a counter.",
+						Details:  checks.NestedRateCheckDetails,
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+		{
+			description: "reports increase(rate())",
+			content:     "- alert: foo\n  expr: increase(rate(foo[5m])[1h:1m]) > 0\n",
+			checker:     newNestedRateCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.NestedRateCheckName,
+						Text:     "`increase(rate(foo[5m])[1h:1m])` is calling `increase()` on the results of `rate(foo[5m])`, this will return bogus results because `rate()` returns a gauge, not a counter.",
+						Details:  checks.NestedRateCheckDetails,
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+		{
+			description: "reports irate(sum(increase()))",
+			content:     "- record: foo\n  expr: sum(irate((sum(increase(foo[5m])) by(job))[5m:]))\n",
+			checker:     newNestedRateCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.NestedRateCheckName,
+						Text:     "`irate((sum(increase(foo[5m])) by(job))[5m:])` is calling `irate()` on the results of `increase(foo[5m])`, this will return bogus results because `increase()` returns a gauge, not a counter.",
+						Details:  checks.NestedRateCheckDetails,
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+		{
+			description: "reports rate(deriv() * 2)",
+			content:     "- record: foo\n  expr: rate((deriv(foo[5m]) * 2)[5m:])\n",
+			checker:     newNestedRateCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.NestedRateCheckName,
+						Text:     "`rate((deriv(foo[5m]) * 2)[5m:])` is calling `rate()` on the results of `deriv(foo[5m])`, this will return bogus results because `deriv()` returns a gauge, not a counter.",
+						Details:  checks.NestedRateCheckDetails,
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+	}
+	runTests(t, testCases)
+}
@@ -0,0 +1,91 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	promParser "github.com/prometheus/prometheus/promql/parser"
+
+	"github.com/cloudflare/pint/internal/discovery"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/parser/utils"
+)
+
+const (
+	QuantileCheckName    = "promql/quantile"
+	QuantileCheckDetails = "Quantile passed to `quantile()` or `quantile_over_time()` must be a value between 0 and 1.\n" +
+		"Prometheus will return `-Inf` for quantiles lower than 0 and `+Inf` for quantiles higher than 1.\n" +
+		"If you want to calculate the 99th percentile then use `0.99`, not `99`."
+)
+
+func NewQuantileCheck() QuantileCheck {
+	return QuantileCheck{}
+}
+
+type QuantileCheck struct{}
+
+func (c QuantileCheck) Meta() CheckMeta {
+	return CheckMeta{
+		States: []discovery.ChangeType{
+			discovery.Noop,
+			discovery.Added,
+			discovery.Modified,
+			discovery.Moved,
+		},
+		Online:        false,
+		AlwaysEnabled: false,
+	}
+}
+
+func (c QuantileCheck) String() string {
+	return QuantileCheckName
+}
+
+func (c QuantileCheck) Reporter() string {
+	return QuantileCheckName
+}
+
+func (c QuantileCheck) Check(ctx context.Context, _ discovery.Path, rule parser.Rule, _ []discovery.Entry) (problems []Problem) {
+	expr := rule.Expr()
+	if expr.SyntaxError != nil {
+		return problems
+	}
+
+	for _, node := range parser.WalkDownExpr[promParser.Node](expr.Query) {
+		if !isQuantileExpr(node.Expr) {
+			continue
+		}
+		for _, src := range utils.CachedLabelsSource(ctx, expr.Value.Value, node.Expr) {
+			if src.Quantile == nil || (*src.Quantile >= 0 && *src.Quantile <= 1) {
+				continue
+			}
+			result := "+Inf"
+			if *src.Quantile < 0 {
+				result = "-Inf"
+			}
+			problems = append(problems, Problem{
+				Lines:    expr.Value.Lines,
+				Reporter: c.Reporter(),
+				Text: fmt.Sprintf("`%s` is using %s as the quantile, which is outside of the valid range from 0 to 1, this query will always return `%s`.",
+					expr.Value.Value[node.Expr.PositionRange().Start:node.Expr.PositionRange().End],
+					strconv.FormatFloat(*src.Quantile, 'f', -1, 64), result),
+				Details:  QuantileCheckDetails,
+				Severity: Warning,
+			})
+			break
+		}
+	}
+
+	return problems
+}
+
+func isQuantileExpr(node promParser.Node) bool {
+	switch n := node.(type) {
+	case *promParser.AggregateExpr:
+		return n.Op == promParser.QUANTILE
+	case *promParser.Call:
+		return n.Func.Name == "quantile_over_time"
+	}
+	return false
+}
@@ -0,0 +1,145 @@
+package checks_test
+
+import (
+	"testing"
+
+	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/promapi"
+)
+
+func newQuantileCheck(_ *promapi.FailoverGroup) checks.RuleChecker {
+	return checks.NewQuantileCheck()
+}
+
+func TestQuantileCheck(t *testing.T) {
+	testCases := []checkTest{
+		{
+			description: "ignores rules with syntax errors",
+			content:     "- record: foo\n  expr: quantile(1.5, foo\n",
+			checker:     newQuantileCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores valid quantile()",
+			content:     "- record: foo\n  expr: quantile(0.9, foo)\n",
+			checker:     newQuantileCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores valid quantile_over_time()",
+			content:     "- record: foo\n  expr: quantile_over_time(0.99, foo[5m])\n",
+			checker:     newQuantileCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores quantile() with 0 and 1",
+			content:     "- record: foo\n  expr: quantile(0, foo) or quantile_over_time(1, foo[5m])\n",
+			checker:     newQuantileCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores quantile() with a non-literal value",
+			content:     "- record: foo\n  expr: quantile(scalar(bar), foo)\n",
+			checker:     newQuantileCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "reports quantile() higher than 1",
+			content:     "- record: foo\n  expr: quantile(1.5, foo)\n",
+			checker:     newQuantileCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.QuantileCheckName,
+						Text:     "`quantile(1.5, foo)` is using 1.5 as the quantile, which is outside of the valid range from 0 to 1, this query will always return `+Inf`.",
+						Details:  checks.QuantileCheckDetails,
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+		{
+			description: "reports quantile_over_time() higher than 1",
+			content:     "- record: foo\n  expr: quantile_over_time(99, foo[5m])\n",
+			checker:     newQuantileCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.QuantileCheckName,
+						Text:     "`quantile_over_time(99, foo[5m])` is using 99 as the quantile, which is outside of the valid range from 0 to 1, this query will always return `+Inf`.",
+						Details:  checks.QuantileCheckDetails,
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+		{
+			description: "reports quantile() lower than 0",
+			content:     "- alert: foo\n  expr: quantile by(job) (-0.5, foo) > 1\n",
+			checker:     newQuantileCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.QuantileCheckName,
+						Text:     "`quantile by(job) (-0.5, foo)` is using -0.5 as the quantile, which is outside of the valid range from 0 to 1, this query will always return `-Inf`.",
+						Details:  checks.QuantileCheckDetails,
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+		{
+			description: "reports nested quantiles",
+			content:     "- record: foo\n  expr: quantile(2, quantile_over_time(1.1, foo[5m]))\n",
+			checker:     newQuantileCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.QuantileCheckName,
+						Text:     "`quantile(2, quantile_over_time(1.1, foo[5m]))` is using 2 as the quantile, which is outside of the valid range from 0 to 1, this query will always return `+Inf`.",
+						Details:  checks.QuantileCheckDetails,
+						Severity: checks.Warning,
+					},
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.QuantileCheckName,
+						Text:     "`quantile_over_time(1.1, foo[5m])` is using 1.1 as the quantile, which is outside of the valid range from 0 to 1, this query will always return `+Inf`.",
+						Details:  checks.QuantileCheckDetails,
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+	}
+
+	runTests(t, testCases)
+}
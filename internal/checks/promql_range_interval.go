@@ -0,0 +1,95 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/prometheus/common/model"
+	promParser "github.com/prometheus/prometheus/promql/parser"
+
+	"github.com/cloudflare/pint/internal/discovery"
+	"github.com/cloudflare/pint/internal/output"
+	"github.com/cloudflare/pint/internal/parser"
+)
+
+const (
+	RangeIntervalCheckName    = "promql/range_interval"
+	RangeIntervalCheckDetails = "Functions like `rate()` need at least two samples inside the range window to calculate the result.\n" +
+		"If the range is shorter than two scrape intervals then a single missed scrape, or slightly delayed one, will leave only one sample in the window and the query will return nothing.\n" +
+		"It's recommended to use a range that's at least 4x the scrape interval, see [this blog post](https://www.robustperception.io/what-range-should-i-use-with-rate/) for details."
+)
+
+func NewRangeIntervalCheck(interval time.Duration, comment string, severity Severity) RangeIntervalCheck {
+	return RangeIntervalCheck{
+		interval: interval,
+		comment:  comment,
+		severity: severity,
+	}
+}
+
+type RangeIntervalCheck struct {
+	comment  string
+	interval time.Duration
+	severity Severity
+}
+
+func (c RangeIntervalCheck) Meta() CheckMeta {
+	return CheckMeta{
+		States: []discovery.ChangeType{
+			discovery.Noop,
+			discovery.Added,
+			discovery.Modified,
+			discovery.Moved,
+		},
+		Online:        false,
+		AlwaysEnabled: false,
+	}
+}
+
+func (c RangeIntervalCheck) String() string {
+	return RangeIntervalCheckName
+}
+
+func (c RangeIntervalCheck) Reporter() string {
+	return RangeIntervalCheckName
+}
+
+func (c RangeIntervalCheck) Check(_ context.Context, _ discovery.Path, rule parser.Rule, _ []discovery.Entry) (problems []Problem) {
+	expr := rule.Expr()
+	if expr.SyntaxError != nil {
+		return problems
+	}
+
+	details := RangeIntervalCheckDetails
+	if c.comment != "" {
+		details += "\n" + maybeComment(c.comment)
+	}
+
+	for _, node := range parser.WalkDownExpr[*promParser.Call](expr.Query) {
+		n := node.Expr.(*promParser.Call)
+		if !slices.Contains([]string{"rate", "irate", "increase", "delta"}, n.Func.Name) {
+			continue
+		}
+		for _, arg := range n.Args {
+			m, ok := arg.(*promParser.MatrixSelector)
+			if !ok {
+				continue
+			}
+			if m.Range >= c.interval*2 {
+				continue
+			}
+			problems = append(problems, Problem{
+				Lines:    expr.Value.Lines,
+				Reporter: c.Reporter(),
+				Text: fmt.Sprintf("`%s` is using `%s` range which is less than 2x the `%s` scrape interval, it might not return any results.",
+					n.String(), model.Duration(m.Range), output.HumanizeDuration(c.interval)),
+				Details:  details,
+				Severity: c.severity,
+			})
+		}
+	}
+
+	return problems
+}
@@ -0,0 +1,130 @@
+package checks_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/promapi"
+)
+
+func newRangeIntervalCheck(_ *promapi.FailoverGroup) checks.RuleChecker {
+	return checks.NewRangeIntervalCheck(time.Minute, "", checks.Warning)
+}
+
+func TestRangeIntervalCheck(t *testing.T) {
+	testCases := []checkTest{
+		{
+			description: "ignores rules with syntax errors",
+			content:     "- record: foo\n  expr: rate(foo[30s]\n",
+			checker:     newRangeIntervalCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores long ranges",
+			content:     "- record: foo\n  expr: rate(foo[5m])\n",
+			checker:     newRangeIntervalCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores ranges equal to 2x interval",
+			content:     "- record: foo\n  expr: increase(foo[2m])\n",
+			checker:     newRangeIntervalCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores other functions",
+			content:     "- record: foo\n  expr: avg_over_time(foo[30s])\n",
+			checker:     newRangeIntervalCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "reports short rate()",
+			content:     "- record: foo\n  expr: rate(foo[30s])\n",
+			checker:     newRangeIntervalCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.RangeIntervalCheckName,
+						Text:     "`rate(foo[30s])` is using `30s` range which is less than 2x the `1m` scrape interval, it might not return any results.",
+						Details:  checks.RangeIntervalCheckDetails,
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+		{
+			description: "reports nested irate() and delta()",
+			content:     "- alert: foo\n  expr: sum(irate(foo[1m])) > 0 or delta(bar[90s]) > 0\n",
+			checker:     newRangeIntervalCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.RangeIntervalCheckName,
+						Text:     "`irate(foo[1m])` is using `1m` range which is less than 2x the `1m` scrape interval, it might not return any results.",
+						Details:  checks.RangeIntervalCheckDetails,
+						Severity: checks.Warning,
+					},
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.RangeIntervalCheckName,
+						Text:     "`delta(bar[1m30s])` is using `1m30s` range which is less than 2x the `1m` scrape interval, it might not return any results.",
+						Details:  checks.RangeIntervalCheckDetails,
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+		{
+			description: "custom interval ignores long enough range",
+			content:     "- record: foo\n  expr: rate(foo[1m])\n",
+			checker: func(_ *promapi.FailoverGroup) checks.RuleChecker {
+				return checks.NewRangeIntervalCheck(time.Second*30, "rule comment", checks.Bug)
+			},
+			prometheus: noProm,
+			problems:   noProblems,
+		},
+		{
+			description: "custom interval reports short range",
+			content:     "- record: foo\n  expr: rate(foo[45s])\n",
+			checker: func(_ *promapi.FailoverGroup) checks.RuleChecker {
+				return checks.NewRangeIntervalCheck(time.Second*30, "rule comment", checks.Bug)
+			},
+			prometheus: noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.RangeIntervalCheckName,
+						Text:     "`rate(foo[45s])` is using `45s` range which is less than 2x the `30s` scrape interval, it might not return any results.",
+						Details:  checks.RangeIntervalCheckDetails + "\nRule comment: rule comment",
+						Severity: checks.Bug,
+					},
+				}
+			},
+		},
+	}
+
+	runTests(t, testCases)
+}
@@ -21,10 +21,10 @@ import (
 
 const (
 	RateCheckName    = "promql/rate"
-	RateCheckDetails = `Using [rate](https://prometheus.io/docs/prometheus/latest/querying/functions/#rate) and [irate](https://prometheus.io/docs/prometheus/latest/querying/functions/#irate) function comes with a few requirements:
+	RateCheckDetails = `Using [rate](https://prometheus.io/docs/prometheus/latest/querying/functions/#rate), [irate](https://prometheus.io/docs/prometheus/latest/querying/functions/#irate) and [increase](https://prometheus.io/docs/prometheus/latest/querying/functions/#increase) functions comes with a few requirements:
 
-- The metric you calculate (i)rate from must be a counter or native histograms.
-- The time window of the (i)rate function must have at least 2 samples.
+- The metric you calculate (i)rate or increase from must be a counter or native histograms.
+- The time window of the (i)rate or increase function must have at least 2 samples.
 
 The type of your metric is defined by the application that exports that metric.
 The number of samples depends on how often your application is being scraped by Prometheus.
@@ -99,7 +99,7 @@ func (c RateCheck) Check(ctx context.Context, _ discovery.Path, rule parser.Rule
 }
 
 func (c RateCheck) checkNode(ctx context.Context, node *parser.PromQLNode, entries []discovery.Entry, cfg *promapi.ConfigResult, done *completedList) (problems []exprProblem) {
-	if n, ok := node.Expr.(*promParser.Call); ok && (n.Func.Name == "rate" || n.Func.Name == "irate" || n.Func.Name == "deriv") {
+	if n, ok := node.Expr.(*promParser.Call); ok && (n.Func.Name == "rate" || n.Func.Name == "irate" || n.Func.Name == "increase" || n.Func.Name == "deriv") {
 		for _, arg := range n.Args {
 			m, ok := arg.(*promParser.MatrixSelector)
 			if !ok {
@@ -134,13 +134,18 @@ func (c RateCheck) checkNode(ctx context.Context, node *parser.PromQLNode, entri
 					})
 					continue
 				}
+				// increase() on a gauge is often used to get the delta, which is less likely to be a bug.
+				severity := Bug
+				if n.Func.Name == "increase" {
+					severity = Warning
+				}
 				for _, m := range metadata.Metadata {
 					if m.Type != v1.MetricTypeCounter && m.Type != v1.MetricTypeUnknown {
 						problems = append(problems, exprProblem{
 							text: fmt.Sprintf("`%s()` should only be used with counters but `%s` is a %s according to metrics metadata from %s.",
 								n.Func.Name, s.Name, m.Type, promText(c.prom.Name(), metadata.URI)),
 							details:  RateCheckDetails,
-							severity: Bug,
+							severity: severity,
 						})
 					}
 				}
@@ -153,7 +158,7 @@ func (c RateCheck) checkNode(ctx context.Context, node *parser.PromQLNode, entri
 						continue
 					}
 					if e.Rule.RecordingRule != nil && e.Rule.RecordingRule.Expr.SyntaxError == nil && e.Rule.RecordingRule.Record.Value == s.Name {
-						for _, src := range utils.LabelsSource(e.Rule.RecordingRule.Expr.Value.Value, e.Rule.RecordingRule.Expr.Query.Expr) {
+						for _, src := range utils.CachedLabelsSource(ctx, e.Rule.RecordingRule.Expr.Value.Value, e.Rule.RecordingRule.Expr.Query.Expr) {
 							if src.Type != utils.AggregateSource {
 								continue
 							}
@@ -185,13 +190,13 @@ func (c RateCheck) checkNode(ctx context.Context, node *parser.PromQLNode, entri
 									continue
 								}
 								problems = append(problems, exprProblem{
-									text: fmt.Sprintf("`rate(%s(counter))` chain detected, `%s` is called here on results of `%s(%s)`.",
-										src.Operation, node.Expr, src.Operation, vs),
+									text: fmt.Sprintf("`%s(%s(counter))` chain detected, `%s` is called here on results of `%s(%s)`.",
+										n.Func.Name, src.Operation, node.Expr, src.Operation, vs),
 									details: fmt.Sprintf(
-										"You can only calculate `rate()` directly from a counter metric. "+
-											"Calling `rate()` on `%s()` results will return bogus results because `%s()` will hide information on when each counter resets. "+
-											"You must first calculate `rate()` before calling any aggregation function. Always `sum(rate(counter))`, never `rate(sum(counter))`",
-										src.Operation, src.Operation),
+										"You can only calculate `%s()` directly from a counter metric. "+
+											"Calling `%s()` on `%s()` results will return bogus results because `%s()` will hide information on when each counter resets. "+
+											"You must first calculate `%s()` before calling any aggregation function. Always `sum(%s(counter))`, never `%s(sum(counter))`",
+										n.Func.Name, n.Func.Name, src.Operation, src.Operation, n.Func.Name, n.Func.Name, n.Func.Name),
 									severity: severity,
 								})
 							}
@@ -0,0 +1,130 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	promParser "github.com/prometheus/prometheus/promql/parser"
+
+	"github.com/cloudflare/pint/internal/discovery"
+	"github.com/cloudflare/pint/internal/parser"
+)
+
+const (
+	RateSuffixCheckName    = "promql/rate_suffix"
+	RateSuffixCheckDetails = "Functions like `rate()`, `irate()` and `increase()` only work correctly with counters.\n" +
+		"[Prometheus naming conventions](https://prometheus.io/docs/practices/naming/) suggest that counter metric names should have a suffix like `_total`, " +
+		"this check is using metric names to guess if a metric is a counter and it might report false positives.\n" +
+		"For gauge metrics use `delta()` or `deriv()` instead."
+)
+
+var DefaultRateSuffixes = []string{"_total", "_count", "_sum", "_bucket"}
+
+func NewRateSuffixCheck(suffixes []string, comment string, severity Severity) RateSuffixCheck {
+	if len(suffixes) == 0 {
+		suffixes = DefaultRateSuffixes
+	}
+	return RateSuffixCheck{
+		suffixes: suffixes,
+		comment:  comment,
+		severity: severity,
+	}
+}
+
+type RateSuffixCheck struct {
+	comment  string
+	suffixes []string
+	severity Severity
+}
+
+func (c RateSuffixCheck) Meta() CheckMeta {
+	return CheckMeta{
+		States: []discovery.ChangeType{
+			discovery.Noop,
+			discovery.Added,
+			discovery.Modified,
+			discovery.Moved,
+		},
+		Online:        false,
+		AlwaysEnabled: false,
+	}
+}
+
+func (c RateSuffixCheck) String() string {
+	return RateSuffixCheckName
+}
+
+func (c RateSuffixCheck) Reporter() string {
+	return RateSuffixCheckName
+}
+
+func (c RateSuffixCheck) Check(_ context.Context, _ discovery.Path, rule parser.Rule, _ []discovery.Entry) (problems []Problem) {
+	expr := rule.Expr()
+	if expr.SyntaxError != nil {
+		return problems
+	}
+
+	details := RateSuffixCheckDetails
+	if c.comment != "" {
+		details += "\n" + maybeComment(c.comment)
+	}
+
+	done := map[string]struct{}{}
+	for _, node := range parser.WalkDownExpr[*promParser.Call](expr.Query) {
+		n := node.Expr.(*promParser.Call)
+		if !slices.Contains([]string{"rate", "irate", "increase"}, n.Func.Name) {
+			continue
+		}
+		for _, arg := range n.Args {
+			m, ok := arg.(*promParser.MatrixSelector)
+			if !ok {
+				continue
+			}
+			vs, ok := m.VectorSelector.(*promParser.VectorSelector)
+			if !ok || vs.Name == "" {
+				// Metric name is unknown, it's selected using a regexp matcher.
+				continue
+			}
+			if c.hasCounterSuffix(vs.Name) {
+				continue
+			}
+			key := n.Func.Name + "/" + vs.Name
+			if _, ok := done[key]; ok {
+				continue
+			}
+			done[key] = struct{}{}
+			problems = append(problems, Problem{
+				Lines:    expr.Value.Lines,
+				Reporter: c.Reporter(),
+				Text: fmt.Sprintf("`%s()` should only be used with counters but `%s` doesn't look like one, counter metric names usually end with %s.",
+					n.Func.Name, vs.Name, c.suffixList()),
+				Details:  details,
+				Severity: c.severity,
+			})
+		}
+	}
+
+	return problems
+}
+
+func (c RateSuffixCheck) hasCounterSuffix(name string) bool {
+	for _, suffix := range c.suffixes {
+		if strings.HasSuffix(name, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c RateSuffixCheck) suffixList() string {
+	names := make([]string, 0, len(c.suffixes))
+	for _, suffix := range c.suffixes {
+		names = append(names, "`"+suffix+"`")
+	}
+	if len(names) == 1 {
+		return names[0]
+	}
+	return strings.Join(names[:len(names)-1], ", ") + " or " + names[len(names)-1]
+}
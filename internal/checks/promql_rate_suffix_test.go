@@ -0,0 +1,134 @@
+package checks_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/promapi"
+)
+
+func newRateSuffixCheck(_ *promapi.FailoverGroup) checks.RuleChecker {
+	return checks.NewRateSuffixCheck(nil, "", checks.Warning)
+}
+
+func rateSuffixText(fn, metric, suffixes string) string {
+	return fmt.Sprintf("`%s()` should only be used with counters but `%s` doesn't look like one, counter metric names usually end with %s.", fn, metric, suffixes)
+}
+
+const defaultRateSuffixes = "`_total`, `_count`, `_sum` or `_bucket`"
+
+func TestRateSuffixCheck(t *testing.T) {
+	testCases := []checkTest{
+		{
+			description: "ignores rules with syntax errors",
+			content:     "- record: foo\n  expr: rate(foo[5m]\n",
+			checker:     newRateSuffixCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores counters",
+			content:     "- record: foo\n  expr: rate(http_requests_total[5m])\n",
+			checker:     newRateSuffixCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores histograms",
+			content:     "- record: foo\n  expr: sum(rate(http_request_duration_seconds_bucket[5m])) by(le) / sum(increase(http_request_duration_seconds_count[5m]))\n",
+			checker:     newRateSuffixCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores regexp names",
+			content:     "- record: foo\n  expr: rate({__name__=~\"memory_.+\"}[5m])\n",
+			checker:     newRateSuffixCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores delta()",
+			content:     "- record: foo\n  expr: delta(memory_usage_bytes[5m])\n",
+			checker:     newRateSuffixCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "reports rate() on gauge",
+			content:     "- record: foo\n  expr: rate(memory_usage_bytes[5m])\n",
+			checker:     newRateSuffixCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.RateSuffixCheckName,
+						Text:     rateSuffixText("rate", "memory_usage_bytes", defaultRateSuffixes),
+						Details:  checks.RateSuffixCheckDetails,
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+		{
+			description: "reports nested irate() and increase()",
+			content:     "- alert: foo\n  expr: sum(irate(memory_usage_bytes[5m])) > 0 or increase(memory_usage_bytes[5m]) > 0 or irate(memory_usage_bytes[1m]) > 0\n",
+			checker:     newRateSuffixCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.RateSuffixCheckName,
+						Text:     rateSuffixText("irate", "memory_usage_bytes", defaultRateSuffixes),
+						Details:  checks.RateSuffixCheckDetails,
+						Severity: checks.Warning,
+					},
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.RateSuffixCheckName,
+						Text:     rateSuffixText("increase", "memory_usage_bytes", defaultRateSuffixes),
+						Details:  checks.RateSuffixCheckDetails,
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+		{
+			description: "custom suffixes",
+			content:     "- record: foo\n  expr: rate(http_requests_total[5m]) + rate(cpu_seconds[5m])\n",
+			checker: func(_ *promapi.FailoverGroup) checks.RuleChecker {
+				return checks.NewRateSuffixCheck([]string{"_seconds"}, "rule comment", checks.Bug)
+			},
+			prometheus: noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.RateSuffixCheckName,
+						Text:     rateSuffixText("rate", "http_requests_total", "`_seconds`"),
+						Details:  checks.RateSuffixCheckDetails + "\nRule comment: rule comment",
+						Severity: checks.Bug,
+					},
+				}
+			},
+		},
+	}
+
+	runTests(t, testCases)
+}
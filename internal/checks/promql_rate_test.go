@@ -644,6 +644,70 @@ func TestRateCheck(t *testing.T) {
 				},
 			},
 		},
+		{
+			description: "increase(gauge)",
+			content:     "- alert: foo\n  expr: increase(foo[5m]) > 0\n",
+			checker:     newRateCheck,
+			prometheus:  newSimpleProm,
+			problems: func(uri string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: "promql/rate",
+						Text:     notCounterText("prom", uri, "increase", "foo", "gauge"),
+						Details:  checks.RateCheckDetails,
+						Severity: checks.Warning,
+					},
+				}
+			},
+			mocks: []*prometheusMock{
+				{
+					conds: []requestCondition{requireConfigPath},
+					resp:  configResponse{yaml: "global:\n  scrape_interval: 1m\n"},
+				},
+				{
+					conds: []requestCondition{requireMetadataPath},
+					resp: metadataResponse{metadata: map[string][]v1.Metadata{
+						"foo": {{Type: "gauge"}},
+					}},
+				},
+			},
+		},
+		{
+			description: "increase(counter) < 2x scrape interval",
+			content:     "- alert: foo\n  expr: increase(foo[1m]) > 0\n",
+			checker:     newRateCheck,
+			prometheus:  newSimpleProm,
+			problems: func(uri string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: "promql/rate",
+						Text:     durationMustText("prom", uri, "increase", "2", "1m"),
+						Details:  checks.RateCheckDetails,
+						Severity: checks.Bug,
+					},
+				}
+			},
+			mocks: []*prometheusMock{
+				{
+					conds: []requestCondition{requireConfigPath},
+					resp:  configResponse{yaml: "global:\n  scrape_interval: 1m\n"},
+				},
+				{
+					conds: []requestCondition{requireMetadataPath},
+					resp: metadataResponse{metadata: map[string][]v1.Metadata{
+						"foo": {{Type: "counter"}},
+					}},
+				},
+			},
+		},
 		{
 			description: "rate(unknown)",
 			content:     "- record: foo\n  expr: rate(foo[2m])\n",
@@ -741,6 +805,49 @@ func TestRateCheck(t *testing.T) {
 				},
 			},
 		},
+		{
+			description: "increase_over_sum",
+			content:     "- alert: my alert\n  expr: increase(my:sum[5m])\n",
+			entries:     mustParseContent("- record: my:sum\n  expr: sum(foo)\n"),
+			checker:     newRateCheck,
+			prometheus:  newSimpleProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: "promql/rate",
+						Text:     "`increase(sum(counter))` chain detected, `increase(my:sum[5m])` is called here on results of `sum(foo)`.",
+						Details:  "You can only calculate `increase()` directly from a counter metric. Calling `increase()` on `sum()` results will return bogus results because `sum()` will hide information on when each counter resets. You must first calculate `increase()` before calling any aggregation function. Always `sum(increase(counter))`, never `increase(sum(counter))`",
+						Severity: checks.Bug,
+					},
+				}
+			},
+			mocks: []*prometheusMock{
+				{
+					conds: []requestCondition{requireConfigPath},
+					resp:  configResponse{yaml: "global:\n  scrape_interval: 1m\n"},
+				},
+				{
+					conds: []requestCondition{
+						requireMetadataPath,
+						formCond{"metric", "foo"},
+					},
+					resp: metadataResponse{metadata: map[string][]v1.Metadata{
+						"foo": {{Type: "counter"}},
+					}},
+				},
+				{
+					conds: []requestCondition{
+						requireMetadataPath,
+						formCond{"metric", "my:sum"},
+					},
+					resp: metadataResponse{metadata: map[string][]v1.Metadata{}},
+				},
+			},
+		},
 		{
 			description: "rate_over_sum_error",
 			content:     "- alert: my alert\n  expr: rate(my:sum[5m])\n",
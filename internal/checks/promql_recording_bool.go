@@ -0,0 +1,81 @@
+package checks
+
+import (
+	"context"
+
+	promParser "github.com/prometheus/prometheus/promql/parser"
+
+	"github.com/cloudflare/pint/internal/discovery"
+	"github.com/cloudflare/pint/internal/parser"
+)
+
+const (
+	RecordingBoolCheckName    = "promql/recording_bool"
+	RecordingBoolCheckDetails = "Comparisons using the [bool modifier](https://prometheus.io/docs/prometheus/latest/querying/operators/#comparison-binary-operators) don't filter anything, instead they return `1` or `0` depending on the comparison result.\n" +
+		"This is fine if you want to record the result of the comparison, but if you've copied this query from an alerting rule then it will record all time series with a constant value.\n" +
+		"Remove the `bool` modifier if you want to only record time series that match this comparison."
+)
+
+func NewRecordingBoolCheck() RecordingBoolCheck {
+	return RecordingBoolCheck{}
+}
+
+type RecordingBoolCheck struct{}
+
+func (c RecordingBoolCheck) Meta() CheckMeta {
+	return CheckMeta{
+		States: []discovery.ChangeType{
+			discovery.Noop,
+			discovery.Added,
+			discovery.Modified,
+			discovery.Moved,
+		},
+		Online:        false,
+		AlwaysEnabled: false,
+	}
+}
+
+func (c RecordingBoolCheck) String() string {
+	return RecordingBoolCheckName
+}
+
+func (c RecordingBoolCheck) Reporter() string {
+	return RecordingBoolCheckName
+}
+
+func (c RecordingBoolCheck) Check(_ context.Context, _ discovery.Path, rule parser.Rule, _ []discovery.Entry) (problems []Problem) {
+	if rule.RecordingRule == nil {
+		return problems
+	}
+
+	expr := rule.Expr()
+	if expr.SyntaxError != nil {
+		return problems
+	}
+
+	// Only look at the top level of the query, bool comparisons nested
+	// inside other operations, like sum(foo > bool 0), are used to count things.
+	root := expr.Query.Expr
+	for {
+		pe, ok := root.(*promParser.ParenExpr)
+		if !ok {
+			break
+		}
+		root = pe.Expr
+	}
+
+	n := hasComparision(root)
+	if n == nil || n != root || !n.ReturnBool {
+		return problems
+	}
+
+	problems = append(problems, Problem{
+		Lines:    expr.Value.Lines,
+		Reporter: c.Reporter(),
+		Text:     "Recording rule query uses `bool` modifier for comparison, this means it will record all time series with `0` or `1` value, instead of only recording time series matching this comparison.",
+		Details:  RecordingBoolCheckDetails,
+		Severity: Information,
+	})
+
+	return problems
+}
@@ -0,0 +1,95 @@
+package checks_test
+
+import (
+	"testing"
+
+	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/promapi"
+)
+
+func newRecordingBoolCheck(_ *promapi.FailoverGroup) checks.RuleChecker {
+	return checks.NewRecordingBoolCheck()
+}
+
+func TestRecordingBoolCheck(t *testing.T) {
+	testCases := []checkTest{
+		{
+			description: "ignores rules with syntax errors",
+			content:     "- record: foo\n  expr: up > bool\n",
+			checker:     newRecordingBoolCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores alerting rules",
+			content:     "- alert: foo\n  expr: up > bool 1\n",
+			checker:     newRecordingBoolCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores comparisons without bool",
+			content:     "- record: foo\n  expr: up > 1\n",
+			checker:     newRecordingBoolCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores nested bool comparisons",
+			content:     "- record: foo\n  expr: sum(up > bool 0)\n",
+			checker:     newRecordingBoolCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores bool comparisons with other operations",
+			content:     "- record: foo\n  expr: (up > bool 0) * 100\n",
+			checker:     newRecordingBoolCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "reports top level bool comparison",
+			content:     "- record: foo\n  expr: up > bool 1\n",
+			checker:     newRecordingBoolCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.RecordingBoolCheckName,
+						Text:     "Recording rule query uses `bool` modifier for comparison, this means it will record all time series with `0` or `1` value, instead of only recording time series matching this comparison.",
+						Details:  checks.RecordingBoolCheckDetails,
+						Severity: checks.Information,
+					},
+				}
+			},
+		},
+		{
+			description: "reports bool comparison wrapped in parens",
+			content:     "- record: foo\n  expr: (sum(up) by(job) == bool 0)\n",
+			checker:     newRecordingBoolCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.RecordingBoolCheckName,
+						Text:     "Recording rule query uses `bool` modifier for comparison, this means it will record all time series with `0` or `1` value, instead of only recording time series matching this comparison.",
+						Details:  checks.RecordingBoolCheckDetails,
+						Severity: checks.Information,
+					},
+				}
+			},
+		},
+	}
+
+	runTests(t, testCases)
+}
@@ -0,0 +1,85 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cloudflare/pint/internal/discovery"
+	"github.com/cloudflare/pint/internal/parser"
+)
+
+const (
+	RecordingNameCheckName    = "promql/recording_name"
+	RecordingNameCheckDetails = "Recording rule names should follow the `level:metric:operations` format recommended by [Prometheus documentation](https://prometheus.io/docs/practices/rules/#naming-and-aggregation).\n" +
+		"`level` is the aggregation level and the labels of the output, `metric` is the name of the source metric and `operations` is a list of operations applied to it."
+)
+
+func NewRecordingNameCheck(parts int, comment string, severity Severity) RecordingNameCheck {
+	return RecordingNameCheck{
+		parts:    parts,
+		comment:  comment,
+		severity: severity,
+	}
+}
+
+type RecordingNameCheck struct {
+	comment  string
+	parts    int
+	severity Severity
+}
+
+func (c RecordingNameCheck) Meta() CheckMeta {
+	return CheckMeta{
+		States: []discovery.ChangeType{
+			discovery.Noop,
+			discovery.Added,
+			discovery.Modified,
+			discovery.Moved,
+		},
+		Online:        false,
+		AlwaysEnabled: false,
+	}
+}
+
+func (c RecordingNameCheck) String() string {
+	return RecordingNameCheckName
+}
+
+func (c RecordingNameCheck) Reporter() string {
+	return RecordingNameCheckName
+}
+
+func (c RecordingNameCheck) Check(_ context.Context, _ discovery.Path, rule parser.Rule, _ []discovery.Entry) (problems []Problem) {
+	if rule.RecordingRule == nil {
+		return problems
+	}
+
+	name := rule.RecordingRule.Record.Value
+	parts := strings.Split(name, ":")
+
+	var text string
+	switch {
+	case len(parts) < 2:
+		text = fmt.Sprintf("`%s` doesn't follow the `level:metric:operations` naming convention for recording rules.", name)
+	case c.parts > 0 && len(parts) != c.parts:
+		text = fmt.Sprintf("`%s` has %d colon separated parts but recording rule names should have exactly %d.", name, len(parts), c.parts)
+	default:
+		return problems
+	}
+
+	details := RecordingNameCheckDetails
+	if c.comment != "" {
+		details += "\n" + maybeComment(c.comment)
+	}
+
+	problems = append(problems, Problem{
+		Lines:    rule.RecordingRule.Record.Lines,
+		Reporter: c.Reporter(),
+		Text:     text,
+		Details:  details,
+		Severity: c.severity,
+	})
+
+	return problems
+}
@@ -0,0 +1,127 @@
+package checks_test
+
+import (
+	"testing"
+
+	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/promapi"
+)
+
+func newRecordingNameCheck(_ *promapi.FailoverGroup) checks.RuleChecker {
+	return checks.NewRecordingNameCheck(0, "", checks.Information)
+}
+
+func TestRecordingNameCheck(t *testing.T) {
+	testCases := []checkTest{
+		{
+			description: "ignores alerting rules",
+			content:     "- alert: http_requests_rate\n  expr: up == 0\n",
+			checker:     newRecordingNameCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores valid name",
+			content:     "- record: job:http_requests:rate5m\n  expr: sum(rate(http_requests_total[5m])) by(job)\n",
+			checker:     newRecordingNameCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores name with two parts",
+			content:     "- record: job:http_requests\n  expr: sum(http_requests_total) by(job)\n",
+			checker:     newRecordingNameCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "reports name without colons",
+			content:     "- record: http_requests_rate\n  expr: sum(rate(http_requests_total[5m])) by(job)\n",
+			checker:     newRecordingNameCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 1,
+							Last:  1,
+						},
+						Reporter: checks.RecordingNameCheckName,
+						Text:     "`http_requests_rate` doesn't follow the `level:metric:operations` naming convention for recording rules.",
+						Details:  checks.RecordingNameCheckDetails,
+						Severity: checks.Information,
+					},
+				}
+			},
+		},
+		{
+			description: "reports wrong number of parts",
+			content:     "- record: job:http_requests\n  expr: sum(http_requests_total) by(job)\n",
+			checker: func(_ *promapi.FailoverGroup) checks.RuleChecker {
+				return checks.NewRecordingNameCheck(3, "", checks.Information)
+			},
+			prometheus: noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 1,
+							Last:  1,
+						},
+						Reporter: checks.RecordingNameCheckName,
+						Text:     "`job:http_requests` has 2 colon separated parts but recording rule names should have exactly 3.",
+						Details:  checks.RecordingNameCheckDetails,
+						Severity: checks.Information,
+					},
+				}
+			},
+		},
+		{
+			description: "uses configured severity",
+			content:     "- record: http_requests_rate\n  expr: sum(rate(http_requests_total[5m])) by(job)\n",
+			checker: func(_ *promapi.FailoverGroup) checks.RuleChecker {
+				return checks.NewRecordingNameCheck(0, "", checks.Warning)
+			},
+			prometheus: noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 1,
+							Last:  1,
+						},
+						Reporter: checks.RecordingNameCheckName,
+						Text:     "`http_requests_rate` doesn't follow the `level:metric:operations` naming convention for recording rules.",
+						Details:  checks.RecordingNameCheckDetails,
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+		{
+			description: "adds comment to details",
+			content:     "- record: http_requests_rate\n  expr: sum(rate(http_requests_total[5m])) by(job)\n",
+			checker: func(_ *promapi.FailoverGroup) checks.RuleChecker {
+				return checks.NewRecordingNameCheck(0, "See our naming guide.", checks.Information)
+			},
+			prometheus: noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 1,
+							Last:  1,
+						},
+						Reporter: checks.RecordingNameCheckName,
+						Text:     "`http_requests_rate` doesn't follow the `level:metric:operations` naming convention for recording rules.",
+						Details:  checks.RecordingNameCheckDetails + "\nRule comment: See our naming guide.",
+						Severity: checks.Information,
+					},
+				}
+			},
+		},
+	}
+
+	runTests(t, testCases)
+}
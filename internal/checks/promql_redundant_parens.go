@@ -0,0 +1,153 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+
+	promParser "github.com/prometheus/prometheus/promql/parser"
+
+	"github.com/cloudflare/pint/internal/discovery"
+	"github.com/cloudflare/pint/internal/parser"
+)
+
+const (
+	RedundantParensCheckName    = "promql/redundant_parens"
+	RedundantParensCheckDetails = "Parentheses are only needed when the order in which operators are evaluated would be different without them.\n" +
+		"See [operator precedence](https://prometheus.io/docs/prometheus/latest/querying/operators/#binary-operator-precedence) docs for details."
+)
+
+func NewRedundantParensCheck() RedundantParensCheck {
+	return RedundantParensCheck{}
+}
+
+type RedundantParensCheck struct{}
+
+func (c RedundantParensCheck) Meta() CheckMeta {
+	return CheckMeta{
+		States: []discovery.ChangeType{
+			discovery.Noop,
+			discovery.Added,
+			discovery.Modified,
+			discovery.Moved,
+		},
+		Online:        false,
+		AlwaysEnabled: false,
+	}
+}
+
+func (c RedundantParensCheck) String() string {
+	return RedundantParensCheckName
+}
+
+func (c RedundantParensCheck) Reporter() string {
+	return RedundantParensCheckName
+}
+
+func (c RedundantParensCheck) Check(_ context.Context, _ discovery.Path, rule parser.Rule, _ []discovery.Entry) (problems []Problem) {
+	expr := rule.Expr()
+	if expr.SyntaxError != nil {
+		return problems
+	}
+
+	for _, node := range parser.WalkDownExpr[*promParser.ParenExpr](expr.Query) {
+		if !isRedundantParen(node) {
+			continue
+		}
+		problems = append(problems, Problem{
+			Lines:    expr.Value.Lines,
+			Reporter: c.Reporter(),
+			Text: fmt.Sprintf("Parentheses around `%s` are redundant and can be removed without changing how this query is evaluated.",
+				node.Expr.(*promParser.ParenExpr).Expr),
+			Details:  RedundantParensCheckDetails,
+			Severity: Information,
+		})
+	}
+
+	return problems
+}
+
+func isRedundantParen(node *parser.PromQLNode) bool {
+	inner := node.Expr.(*promParser.ParenExpr).Expr
+
+	if node.Parent == nil {
+		// (foo + bar)
+		return true
+	}
+
+	switch parent := node.Parent.Expr.(type) {
+	case *promParser.ParenExpr:
+		// ((foo)), the outer ParenExpr will be reported.
+		return false
+	case promParser.Expressions, *promParser.Call, *promParser.AggregateExpr:
+		// Function and aggregation arguments are already delimited.
+		// rate((foo[5m])) or sum((foo + bar))
+		return true
+	case *promParser.BinaryExpr:
+		if isPrimaryExpr(inner) {
+			// (foo) + (bar)
+			return true
+		}
+		child, ok := inner.(*promParser.BinaryExpr)
+		if !ok {
+			return false
+		}
+		// Parentheses are often used to make it clear how set operators
+		// are combined, (foo and bar) or baz, so never report those.
+		if child.Op.IsSetOperator() || parent.Op.IsSetOperator() {
+			return false
+		}
+		cp, pp := operatorPrecedence(child.Op), operatorPrecedence(parent.Op)
+		if cp != pp {
+			return cp > pp
+		}
+		// Operators with the same precedence are left-associative,
+		// except for ^ which is right-associative.
+		if parent.Op == promParser.POW {
+			return parent.RHS == node.Expr
+		}
+		return parent.LHS == node.Expr
+	default:
+		// -(foo) or (foo)[5m:1m]
+		return isPrimaryExpr(inner)
+	}
+}
+
+// isPrimaryExpr returns true if given expression is never split
+// by operators, so it doesn't need to be wrapped in parentheses.
+func isPrimaryExpr(expr promParser.Expr) bool {
+	switch n := expr.(type) {
+	case *promParser.NumberLiteral:
+		// Negative numbers are parsed as literals but (-1) ^ 2 != -1 ^ 2.
+		return n.Val >= 0
+	case *promParser.VectorSelector,
+		*promParser.MatrixSelector,
+		*promParser.StringLiteral,
+		*promParser.Call,
+		*promParser.AggregateExpr,
+		*promParser.SubqueryExpr,
+		*promParser.ParenExpr:
+		return true
+	default:
+		return false
+	}
+}
+
+// operatorPrecedence returns the precedence of a binary operator,
+// operators with higher values are evaluated first.
+func operatorPrecedence(op promParser.ItemType) int {
+	// nolint:exhaustive
+	switch op {
+	case promParser.POW:
+		return 6
+	case promParser.MUL, promParser.DIV, promParser.MOD, promParser.ATAN2:
+		return 5
+	case promParser.ADD, promParser.SUB:
+		return 4
+	case promParser.EQLC, promParser.NEQ, promParser.LTE, promParser.LSS, promParser.GTE, promParser.GTR:
+		return 3
+	case promParser.LAND, promParser.LUNLESS:
+		return 2
+	default:
+		return 1
+	}
+}
@@ -0,0 +1,262 @@
+package checks_test
+
+import (
+	"testing"
+
+	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/promapi"
+)
+
+func newRedundantParensCheck(_ *promapi.FailoverGroup) checks.RuleChecker {
+	return checks.NewRedundantParensCheck()
+}
+
+func TestRedundantParensCheck(t *testing.T) {
+	testCases := []checkTest{
+		{
+			description: "ignores rules with syntax errors",
+			content:     "- record: foo\n  expr: ((foo)\n",
+			checker:     newRedundantParensCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores queries without parentheses",
+			content:     "- record: foo\n  expr: sum(foo) by(job) / 2 > 5\n",
+			checker:     newRedundantParensCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "reports whole query in parentheses",
+			content:     "- record: foo\n  expr: (foo + bar)\n",
+			checker:     newRedundantParensCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.RedundantParensCheckName,
+						Text:     "Parentheses around `foo + bar` are redundant and can be removed without changing how this query is evaluated.",
+						Details:  checks.RedundantParensCheckDetails,
+						Severity: checks.Information,
+					},
+				}
+			},
+		},
+		{
+			description: "reports double parentheses once",
+			content:     "- record: foo\n  expr: ((foo + bar)) * 2\n",
+			checker:     newRedundantParensCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.RedundantParensCheckName,
+						Text:     "Parentheses around `(foo + bar)` are redundant and can be removed without changing how this query is evaluated.",
+						Details:  checks.RedundantParensCheckDetails,
+						Severity: checks.Information,
+					},
+				}
+			},
+		},
+		{
+			description: "reports selectors in parentheses",
+			content:     "- record: foo\n  expr: (foo) + (bar)\n",
+			checker:     newRedundantParensCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.RedundantParensCheckName,
+						Text:     "Parentheses around `foo` are redundant and can be removed without changing how this query is evaluated.",
+						Details:  checks.RedundantParensCheckDetails,
+						Severity: checks.Information,
+					},
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.RedundantParensCheckName,
+						Text:     "Parentheses around `bar` are redundant and can be removed without changing how this query is evaluated.",
+						Details:  checks.RedundantParensCheckDetails,
+						Severity: checks.Information,
+					},
+				}
+			},
+		},
+		{
+			description: "reports function arguments",
+			content:     "- record: foo\n  expr: sum((rate(foo[5m]) + rate(bar[5m])))\n",
+			checker:     newRedundantParensCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.RedundantParensCheckName,
+						Text:     "Parentheses around `rate(foo[5m]) + rate(bar[5m])` are redundant and can be removed without changing how this query is evaluated.",
+						Details:  checks.RedundantParensCheckDetails,
+						Severity: checks.Information,
+					},
+				}
+			},
+		},
+		{
+			description: "reports higher precedence operators",
+			content:     "- record: foo\n  expr: foo + (bar * 2)\n",
+			checker:     newRedundantParensCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.RedundantParensCheckName,
+						Text:     "Parentheses around `bar * 2` are redundant and can be removed without changing how this query is evaluated.",
+						Details:  checks.RedundantParensCheckDetails,
+						Severity: checks.Information,
+					},
+				}
+			},
+		},
+		{
+			description: "reports left-associative operators",
+			content:     "- record: foo\n  expr: (foo / bar) * 100\n",
+			checker:     newRedundantParensCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.RedundantParensCheckName,
+						Text:     "Parentheses around `foo / bar` are redundant and can be removed without changing how this query is evaluated.",
+						Details:  checks.RedundantParensCheckDetails,
+						Severity: checks.Information,
+					},
+				}
+			},
+		},
+		{
+			description: "reports right-associative operators",
+			content:     "- record: foo\n  expr: foo ^ (bar ^ 2)\n",
+			checker:     newRedundantParensCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.RedundantParensCheckName,
+						Text:     "Parentheses around `bar ^ 2` are redundant and can be removed without changing how this query is evaluated.",
+						Details:  checks.RedundantParensCheckDetails,
+						Severity: checks.Information,
+					},
+				}
+			},
+		},
+		{
+			description: "reports unary and subquery",
+			content:     "- record: foo\n  expr: -(foo) + max_over_time((rate(bar[5m]))[1h:5m])\n",
+			checker:     newRedundantParensCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.RedundantParensCheckName,
+						Text:     "Parentheses around `foo` are redundant and can be removed without changing how this query is evaluated.",
+						Details:  checks.RedundantParensCheckDetails,
+						Severity: checks.Information,
+					},
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.RedundantParensCheckName,
+						Text:     "Parentheses around `rate(bar[5m])` are redundant and can be removed without changing how this query is evaluated.",
+						Details:  checks.RedundantParensCheckDetails,
+						Severity: checks.Information,
+					},
+				}
+			},
+		},
+		{
+			description: "ignores lower precedence operators",
+			content:     "- record: foo\n  expr: (foo + bar) * 2\n",
+			checker:     newRedundantParensCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores right hand side with the same precedence",
+			content:     "- record: foo\n  expr: foo - (bar + baz)\n",
+			checker:     newRedundantParensCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores left hand side of ^",
+			content:     "- record: foo\n  expr: (foo ^ 2) ^ 3\n",
+			checker:     newRedundantParensCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores comparison with bool",
+			content:     "- record: foo\n  expr: (foo > bool 5) * 2\n",
+			checker:     newRedundantParensCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores set operators",
+			content:     "- record: foo\n  expr: (foo and bar) or (baz unless bob) or (foo > 5 and bar)\n",
+			checker:     newRedundantParensCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores unary operators",
+			content:     "- record: foo\n  expr: -(foo + bar) + (-foo) ^ 2 + (-1) ^ 2\n",
+			checker:     newRedundantParensCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores subqueries on binary expressions",
+			content:     "- record: foo\n  expr: max_over_time((foo + bar)[1h:5m])\n",
+			checker:     newRedundantParensCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+	}
+	runTests(t, testCases)
+}
@@ -0,0 +1,121 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/cloudflare/pint/internal/discovery"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/parser/utils"
+)
+
+const (
+	ScopeCheckName    = "promql/scope"
+	ScopeCheckDetails = "When multiple tenants are sending metrics to the same Prometheus server a selector without any scoping label might match time series from all of them.\n" +
+		"Add a matcher for one of the scoping labels to ensure that this alert will only use time series it's meant to use."
+)
+
+var DefaultScopeLabels = []string{"job", "namespace", "cluster"}
+
+func NewScopeCheck(labels []string, comment string, severity Severity) ScopeCheck {
+	if len(labels) == 0 {
+		labels = DefaultScopeLabels
+	}
+	return ScopeCheck{
+		labels:   labels,
+		comment:  comment,
+		severity: severity,
+	}
+}
+
+type ScopeCheck struct {
+	comment  string
+	labels   []string
+	severity Severity
+}
+
+func (c ScopeCheck) Meta() CheckMeta {
+	return CheckMeta{
+		States: []discovery.ChangeType{
+			discovery.Noop,
+			discovery.Added,
+			discovery.Modified,
+			discovery.Moved,
+		},
+		Online:        false,
+		AlwaysEnabled: false,
+	}
+}
+
+func (c ScopeCheck) String() string {
+	return ScopeCheckName
+}
+
+func (c ScopeCheck) Reporter() string {
+	return ScopeCheckName
+}
+
+func (c ScopeCheck) Check(_ context.Context, _ discovery.Path, rule parser.Rule, _ []discovery.Entry) (problems []Problem) {
+	if rule.AlertingRule == nil {
+		return problems
+	}
+
+	expr := rule.Expr()
+	if expr.SyntaxError != nil {
+		return problems
+	}
+
+	details := ScopeCheckDetails
+	if c.comment != "" {
+		details += "\n" + maybeComment(c.comment)
+	}
+
+	// Sources only carry selectors that labels of the results are coming from,
+	// so the right hand side of binary expressions would be skipped, check all
+	// selectors instead.
+	var done []string
+	for _, vs := range utils.CollectSelectors(expr.Query.Expr) {
+		selector := vs.String()
+		if slices.Contains(done, selector) {
+			continue
+		}
+		done = append(done, selector)
+
+		if c.isScoped(utils.GuaranteedLabels(vs)) {
+			continue
+		}
+
+		problems = append(problems, Problem{
+			Lines:    expr.Value.Lines,
+			Reporter: c.Reporter(),
+			Text: fmt.Sprintf("`%s` selector doesn't filter on any scoping label, add a matcher for %s.",
+				selector, c.labelList()),
+			Details:  details,
+			Severity: c.severity,
+		})
+	}
+
+	return problems
+}
+
+func (c ScopeCheck) isScoped(guaranteed []string) bool {
+	for _, name := range c.labels {
+		if slices.Contains(guaranteed, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c ScopeCheck) labelList() string {
+	names := make([]string, 0, len(c.labels))
+	for _, name := range c.labels {
+		names = append(names, "`"+name+"`")
+	}
+	if len(names) == 1 {
+		return names[0]
+	}
+	return strings.Join(names[:len(names)-1], ", ") + " or " + names[len(names)-1]
+}
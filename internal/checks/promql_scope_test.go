@@ -0,0 +1,135 @@
+package checks_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/promapi"
+)
+
+func newScopeCheck(_ *promapi.FailoverGroup) checks.RuleChecker {
+	return checks.NewScopeCheck([]string{"job", "namespace"}, "", checks.Warning)
+}
+
+func scopeText(selector, labels string) string {
+	return fmt.Sprintf("`%s` selector doesn't filter on any scoping label, add a matcher for %s.", selector, labels)
+}
+
+func TestScopeCheck(t *testing.T) {
+	testCases := []checkTest{
+		{
+			description: "ignores rules with syntax errors",
+			content:     "- alert: foo\n  expr: rate(http_requests_total[5m]\n",
+			checker:     newScopeCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores recording rules",
+			content:     "- record: foo\n  expr: rate(http_requests_total[5m])\n",
+			checker:     newScopeCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores scoped selectors",
+			content:     "- alert: foo\n  expr: rate(http_requests_total{job=\"api\"}[5m]) > 0\n",
+			checker:     newScopeCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "any scoping label is enough",
+			content:     "- alert: foo\n  expr: up{namespace=~\"prod-.+\"} == 0\n",
+			checker:     newScopeCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "reports unscoped selector",
+			content:     "- alert: foo\n  expr: rate(http_requests_total[5m]) > 0\n",
+			checker:     newScopeCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.ScopeCheckName,
+						Text:     scopeText("http_requests_total", "`job` or `namespace`"),
+						Details:  checks.ScopeCheckDetails,
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+		{
+			description: "negative matchers are not scoping",
+			content:     "- alert: foo\n  expr: up{job!=\"dev\", namespace=~\".*\"} == 0\n",
+			checker:     newScopeCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.ScopeCheckName,
+						Text:     scopeText(`up{job!="dev",namespace=~".*"}`, "`job` or `namespace`"),
+						Details:  checks.ScopeCheckDetails,
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+		{
+			description: "reports only unscoped side of binary expression",
+			content:     "- alert: foo\n  expr: errors_total{job=\"api\"} / requests_total > 0.1\n",
+			checker:     newScopeCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.ScopeCheckName,
+						Text:     scopeText("requests_total", "`job` or `namespace`"),
+						Details:  checks.ScopeCheckDetails,
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+		{
+			description: "custom comment and severity",
+			content:     "- alert: foo\n  expr: up == 0\n",
+			checker: func(_ *promapi.FailoverGroup) checks.RuleChecker {
+				return checks.NewScopeCheck([]string{"cluster"}, "rule comment", checks.Bug)
+			},
+			prometheus: noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.ScopeCheckName,
+						Text:     scopeText("up", "`cluster`"),
+						Details:  checks.ScopeCheckDetails + "\nRule comment: rule comment",
+						Severity: checks.Bug,
+					},
+				}
+			},
+		},
+	}
+
+	runTests(t, testCases)
+}
@@ -0,0 +1,92 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/labels"
+	promParser "github.com/prometheus/prometheus/promql/parser"
+
+	"github.com/cloudflare/pint/internal/discovery"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/parser/utils"
+)
+
+const (
+	SelfReferenceCheckName    = "promql/self_reference"
+	SelfReferenceCheckDetails = "A recording rule that selects the same metric it records will read its own results on every evaluation.\n" +
+		"This creates a feedback loop where each new value depends on the previous one, which is almost never intended."
+)
+
+func NewSelfReferenceCheck() SelfReferenceCheck {
+	return SelfReferenceCheck{}
+}
+
+type SelfReferenceCheck struct{}
+
+func (c SelfReferenceCheck) Meta() CheckMeta {
+	return CheckMeta{
+		States: []discovery.ChangeType{
+			discovery.Noop,
+			discovery.Added,
+			discovery.Modified,
+			discovery.Moved,
+		},
+		Online:        false,
+		AlwaysEnabled: false,
+	}
+}
+
+func (c SelfReferenceCheck) String() string {
+	return SelfReferenceCheckName
+}
+
+func (c SelfReferenceCheck) Reporter() string {
+	return SelfReferenceCheckName
+}
+
+func (c SelfReferenceCheck) Check(ctx context.Context, _ discovery.Path, rule parser.Rule, _ []discovery.Entry) (problems []Problem) {
+	if rule.RecordingRule == nil {
+		return problems
+	}
+
+	expr := rule.Expr()
+	if expr.SyntaxError != nil {
+		return problems
+	}
+
+	name := rule.RecordingRule.Record.Value
+	for _, src := range utils.CachedLabelsSource(ctx, expr.Value.Value, expr.Query.Expr) {
+		if src.IsDead {
+			continue
+		}
+		for _, vs := range src.Selectors {
+			if !selectsMetric(vs, name) {
+				continue
+			}
+			problems = append(problems, Problem{
+				Lines:    expr.Value.Lines,
+				Reporter: c.Reporter(),
+				Text: fmt.Sprintf("This recording rule records `%s` but `%s` selector in the query is also reading `%s`, which creates a feedback loop.",
+					name, vs.String(), name),
+				Details:  SelfReferenceCheckDetails,
+				Severity: Warning,
+			})
+			return problems
+		}
+	}
+
+	return problems
+}
+
+// selectsMetric returns true if given selector has a `__name__` matcher
+// that requires time series to have given metric name.
+func selectsMetric(vs *promParser.VectorSelector, name string) bool {
+	for _, lm := range vs.LabelMatchers {
+		if lm.Name == model.MetricNameLabel && lm.Type == labels.MatchEqual && lm.Value == name {
+			return true
+		}
+	}
+	return false
+}
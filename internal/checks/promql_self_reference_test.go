@@ -0,0 +1,95 @@
+package checks_test
+
+import (
+	"testing"
+
+	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/promapi"
+)
+
+func newSelfReferenceCheck(_ *promapi.FailoverGroup) checks.RuleChecker {
+	return checks.NewSelfReferenceCheck()
+}
+
+func TestSelfReferenceCheck(t *testing.T) {
+	testCases := []checkTest{
+		{
+			description: "ignores alerting rules",
+			content:     "- alert: foo\n  expr: foo > 0\n",
+			checker:     newSelfReferenceCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores rules with syntax errors",
+			content:     "- record: foo\n  expr: foo + 1)\n",
+			checker:     newSelfReferenceCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores rules selecting other metrics",
+			content:     "- record: foo\n  expr: bar + 1\n",
+			checker:     newSelfReferenceCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores metrics with a common prefix",
+			content:     "- record: foo\n  expr: sum(foo_total)\n",
+			checker:     newSelfReferenceCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores negative name matchers",
+			content:     "- record: foo\n  expr: '{__name__!=\"foo\", job=\"bar\"}'\n",
+			checker:     newSelfReferenceCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "reports self reference",
+			content:     "- record: foo\n  expr: foo + 1\n",
+			checker:     newSelfReferenceCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.SelfReferenceCheckName,
+						Text:     "This recording rule records `foo` but `foo` selector in the query is also reading `foo`, which creates a feedback loop.",
+						Details:  checks.SelfReferenceCheckDetails,
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+		{
+			description: "reports self reference via __name__ matcher",
+			content:     "- record: foo:sum\n  expr: sum(rate({__name__=\"foo:sum\", job=\"bar\"}[5m]))\n",
+			checker:     newSelfReferenceCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.SelfReferenceCheckName,
+						Text:     "This recording rule records `foo:sum` but `{__name__=\"foo:sum\",job=\"bar\"}` selector in the query is also reading `foo:sum`, which creates a feedback loop.",
+						Details:  checks.SelfReferenceCheckDetails,
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+	}
+
+	runTests(t, testCases)
+}
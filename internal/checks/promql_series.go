@@ -689,7 +689,7 @@ func (c SeriesCheck) instantSeriesCount(ctx context.Context, query string) (int,
 func (c SeriesCheck) getMinAge(rule parser.Rule, selector promParser.VectorSelector) (minAge time.Duration, problems []Problem) {
 	minAge = time.Hour * 2
 	for _, ruleSet := range comments.Only[comments.RuleSet](rule.Comments, comments.RuleSetType) {
-		matcher, key, value := parseRuleSet(ruleSet.Value)
+		matcher, key, value := parseRuleSet(ruleSet)
 		if key != "min-age" {
 			continue
 		}
@@ -728,7 +728,7 @@ func (c SeriesCheck) isLabelValueIgnored(settings *PromqlSeriesSettings, rule pa
 		}
 	}
 	for _, ruleSet := range comments.Only[comments.RuleSet](rule.Comments, comments.RuleSetType) {
-		matcher, key, value := parseRuleSet(ruleSet.Value)
+		matcher, key, value := parseRuleSet(ruleSet)
 		if key != "ignore/label-value" {
 			continue
 		}
@@ -848,21 +848,20 @@ func matchSelectorToMetric(selector promParser.VectorSelector, metric string) (b
 	return false, true
 }
 
-func parseRuleSet(s string) (matcher, key, value string) {
-	if strings.HasPrefix(s, SeriesCheckName+"(") {
-		matcher = strings.TrimPrefix(s[:strings.LastIndex(s, ")")], SeriesCheckName+"(")
-		s = s[strings.LastIndex(s, ")")+1:]
-	} else {
-		s = strings.TrimPrefix(s, SeriesCheckName)
+// parseRuleSet returns the selector, option name and option value
+// from a rule/set comment, or empty values if it's not for this check.
+func parseRuleSet(ruleSet comments.RuleSet) (matcher, key, value string) {
+	if ruleSet.Check() != SeriesCheckName {
+		return "", "", ""
 	}
-	parts := strings.Fields(s)
-	if len(parts) > 0 {
-		key = parts[0]
+	params := ruleSet.Params()
+	if len(params) > 0 {
+		key = params[0]
 	}
-	if len(parts) > 1 {
-		value = strings.Join(parts[1:], " ")
+	if len(params) > 1 {
+		value = strings.Join(params[1:], " ")
 	}
-	return matcher, key, value
+	return ruleSet.Match(), key, value
 }
 
 func orphanedDisableComments(ctx context.Context, rule parser.Rule, selectors []promParser.VectorSelector) (orhpaned []comments.Disable) {
@@ -888,6 +887,12 @@ func orphanedDisableComments(ctx context.Context, rule parser.Rule, selectors []
 		if !strings.HasPrefix(disable.Match, SeriesCheckName+"(") || !strings.HasSuffix(disable.Match, ")") {
 			continue
 		}
+		// Disable comments for a metric name only apply to rules using
+		// that metric, so it's orphaned if we got here.
+		if disable.Metric != "" {
+			orhpaned = append(orhpaned, disable)
+			continue
+		}
 		for _, selector := range selectors {
 			isMatch, ok := matchSelectorToMetric(selector, match)
 			if !ok {
@@ -909,7 +914,7 @@ func orphanedDisableComments(ctx context.Context, rule parser.Rule, selectors []
 func orphanedRuleSetComments(rule parser.Rule, selectors []promParser.VectorSelector) (orhpaned []comments.RuleSet) {
 	for _, ruleSet := range comments.Only[comments.RuleSet](rule.Comments, comments.RuleSetType) {
 		var wasUsed bool
-		matcher, key, value := parseRuleSet(ruleSet.Value)
+		matcher, key, value := parseRuleSet(ruleSet)
 		for _, selector := range selectors {
 			if matcher != "" {
 				isMatch, _ := matchSelectorToMetric(selector, matcher)
@@ -0,0 +1,82 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/prometheus/common/model"
+	promParser "github.com/prometheus/prometheus/promql/parser"
+
+	"github.com/cloudflare/pint/internal/discovery"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/parser/utils"
+)
+
+const (
+	SubqueryCheckName    = "promql/subquery"
+	SubqueryCheckDetails = "[Subqueries](https://prometheus.io/docs/prometheus/latest/querying/basics/#subquery) evaluate the inner query at every `step` within the `range` window.\n" +
+		"If the step is larger than the range then the inner query will be evaluated at most once, so the outer function will only ever see a single sample.\n" +
+		"If the step is omitted then Prometheus will use the global evaluation interval, which might be different between servers."
+)
+
+func NewSubqueryCheck() SubqueryCheck {
+	return SubqueryCheck{}
+}
+
+type SubqueryCheck struct{}
+
+func (c SubqueryCheck) Meta() CheckMeta {
+	return CheckMeta{
+		States: []discovery.ChangeType{
+			discovery.Noop,
+			discovery.Added,
+			discovery.Modified,
+			discovery.Moved,
+		},
+		Online:        false,
+		AlwaysEnabled: false,
+	}
+}
+
+func (c SubqueryCheck) String() string {
+	return SubqueryCheckName
+}
+
+func (c SubqueryCheck) Reporter() string {
+	return SubqueryCheckName
+}
+
+func (c SubqueryCheck) Check(_ context.Context, _ discovery.Path, rule parser.Rule, _ []discovery.Entry) (problems []Problem) {
+	expr := rule.Expr()
+	if expr.SyntaxError != nil {
+		return problems
+	}
+
+	for _, node := range utils.FindAllNodes(expr.Query.Expr, isSubquery) {
+		sq := node.(*promParser.SubqueryExpr)
+		var text string
+		switch {
+		case sq.Step == 0:
+			text = fmt.Sprintf("`%s` subquery doesn't set any step, it will use the global evaluation interval.", sq.String())
+		case sq.Step > sq.Range:
+			text = fmt.Sprintf("`%s` subquery step `%s` is larger than its range `%s`, the inner query will be evaluated at most once.",
+				sq.String(), model.Duration(sq.Step), model.Duration(sq.Range))
+		default:
+			continue
+		}
+		problems = append(problems, Problem{
+			Lines:    expr.Value.Lines,
+			Reporter: c.Reporter(),
+			Text:     text,
+			Details:  SubqueryCheckDetails,
+			Severity: Warning,
+		})
+	}
+
+	return problems
+}
+
+func isSubquery(node promParser.Node) bool {
+	_, ok := node.(*promParser.SubqueryExpr)
+	return ok
+}
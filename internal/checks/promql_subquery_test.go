@@ -0,0 +1,118 @@
+package checks_test
+
+import (
+	"testing"
+
+	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/promapi"
+)
+
+func newSubqueryCheck(_ *promapi.FailoverGroup) checks.RuleChecker {
+	return checks.NewSubqueryCheck()
+}
+
+func TestSubqueryCheck(t *testing.T) {
+	testCases := []checkTest{
+		{
+			description: "ignores rules with syntax errors",
+			content:     "- record: foo\n  expr: max_over_time(foo[1h]\n",
+			checker:     newSubqueryCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores queries without subqueries",
+			content:     "- record: foo\n  expr: rate(foo[5m])\n",
+			checker:     newSubqueryCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores step smaller than range",
+			content:     "- record: foo\n  expr: max_over_time(rate(foo[5m])[1h:10s])\n",
+			checker:     newSubqueryCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores step equal to range",
+			content:     "- alert: foo\n  expr: max_over_time(foo[1h:1h]) > 0\n",
+			checker:     newSubqueryCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "reports step larger than range",
+			content:     "- record: foo\n  expr: max_over_time(foo[1h:2h])\n",
+			checker:     newSubqueryCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.SubqueryCheckName,
+						Text:     "`foo[1h:2h]` subquery step `2h` is larger than its range `1h`, the inner query will be evaluated at most once.",
+						Details:  checks.SubqueryCheckDetails,
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+		{
+			description: "reports missing step",
+			content:     "- record: foo\n  expr: max_over_time(foo[1h:])\n",
+			checker:     newSubqueryCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.SubqueryCheckName,
+						Text:     "`foo[1h:]` subquery doesn't set any step, it will use the global evaluation interval.",
+						Details:  checks.SubqueryCheckDetails,
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+		{
+			description: "reports nested subqueries",
+			content:     "- alert: foo\n  expr: min_over_time(max_over_time(rate(foo[5m])[30m:])[5m:10m]) > 0\n",
+			checker:     newSubqueryCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.SubqueryCheckName,
+						Text:     "`max_over_time(rate(foo[5m])[30m:])[5m:10m]` subquery step `10m` is larger than its range `5m`, the inner query will be evaluated at most once.",
+						Details:  checks.SubqueryCheckDetails,
+						Severity: checks.Warning,
+					},
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.SubqueryCheckName,
+						Text:     "`rate(foo[5m])[30m:]` subquery doesn't set any step, it will use the global evaluation interval.",
+						Details:  checks.SubqueryCheckDetails,
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+	}
+
+	runTests(t, testCases)
+}
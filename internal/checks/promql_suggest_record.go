@@ -0,0 +1,252 @@
+package checks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/prometheus/prometheus/model/labels"
+	promParser "github.com/prometheus/prometheus/promql/parser"
+
+	"github.com/cloudflare/pint/internal/discovery"
+	"github.com/cloudflare/pint/internal/parser"
+)
+
+const (
+	SuggestRecordCheckName    = "promql/suggest_record"
+	SuggestRecordCheckDetails = "Every alerting rule is evaluated independently, so the same expensive query fragment used in multiple rules will be evaluated multiple times.\n" +
+		"[Recording rules](https://prometheus.io/docs/prometheus/latest/configuration/recording_rules/) allow you to pre-compute it once and reuse the results in all alerting rules."
+)
+
+const DefaultSuggestRecordMaxCount = 2
+
+type PromqlSuggestRecordSettings struct {
+	MaxCount int `hcl:"maxCount,optional" json:"maxCount,omitempty"`
+}
+
+func (c *PromqlSuggestRecordSettings) Validate() error {
+	if c.MaxCount < 0 {
+		return errors.New("maxCount cannot be negative")
+	}
+	if c.MaxCount == 0 {
+		c.MaxCount = DefaultSuggestRecordMaxCount
+	}
+	return nil
+}
+
+type SuggestRecordCacheContextKey string
+
+const SuggestRecordCacheKey = SuggestRecordCacheContextKey("suggestRecordCache")
+
+// NewSuggestRecordCache creates a cache for query fragments of each rule,
+// so they are only computed once per lint run rather than once for every
+// other rule in the same file.
+func NewSuggestRecordCache() *SuggestRecordCache {
+	return &SuggestRecordCache{
+		entries: map[*parser.PromQLNode][]string{},
+	}
+}
+
+type SuggestRecordCache struct {
+	entries map[*parser.PromQLNode][]string
+	mu      sync.Mutex
+}
+
+func (sc *SuggestRecordCache) fragments(node *parser.PromQLNode, fn func(*parser.PromQLNode) []string) []string {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	if fragments, ok := sc.entries[node]; ok {
+		return fragments
+	}
+	fragments := fn(node)
+	sc.entries[node] = fragments
+	return fragments
+}
+
+func NewSuggestRecordCheck() SuggestRecordCheck {
+	return SuggestRecordCheck{}
+}
+
+type SuggestRecordCheck struct{}
+
+func (c SuggestRecordCheck) Meta() CheckMeta {
+	return CheckMeta{
+		States: []discovery.ChangeType{
+			discovery.Noop,
+			discovery.Added,
+			discovery.Modified,
+			discovery.Moved,
+		},
+		Online:        false,
+		AlwaysEnabled: false,
+	}
+}
+
+func (c SuggestRecordCheck) String() string {
+	return SuggestRecordCheckName
+}
+
+func (c SuggestRecordCheck) Reporter() string {
+	return SuggestRecordCheckName
+}
+
+func (c SuggestRecordCheck) Check(ctx context.Context, path discovery.Path, rule parser.Rule, entries []discovery.Entry) (problems []Problem) {
+	if rule.AlertingRule == nil || rule.AlertingRule.Expr.SyntaxError != nil {
+		return problems
+	}
+
+	var settings *PromqlSuggestRecordSettings
+	if s := ctx.Value(SettingsKey(c.Reporter())); s != nil {
+		settings = s.(*PromqlSuggestRecordSettings)
+	}
+	if settings == nil {
+		settings = &PromqlSuggestRecordSettings{}
+		_ = settings.Validate()
+	}
+
+	cache, ok := ctx.Value(SuggestRecordCacheKey).(*SuggestRecordCache)
+	if !ok {
+		cache = NewSuggestRecordCache()
+	}
+
+	type otherRule struct {
+		name      string
+		fragments []string
+	}
+	var alerts []otherRule
+	var records []otherRule
+	for _, entry := range entries {
+		if entry.State == discovery.Removed || entry.PathError != nil || entry.Rule.Error.Err != nil {
+			continue
+		}
+		if entry.Path.SymlinkTarget != path.SymlinkTarget {
+			continue
+		}
+		if entry.Rule.IsSame(rule) {
+			continue
+		}
+		switch {
+		case entry.Rule.AlertingRule != nil && entry.Rule.AlertingRule.Expr.SyntaxError == nil:
+			alerts = append(alerts, otherRule{
+				name:      entry.Rule.AlertingRule.Alert.Value,
+				fragments: cache.fragments(entry.Rule.AlertingRule.Expr.Query, expensiveFragments),
+			})
+		case entry.Rule.RecordingRule != nil && entry.Rule.RecordingRule.Expr.SyntaxError == nil:
+			records = append(records, otherRule{
+				name:      entry.Rule.RecordingRule.Record.Value,
+				fragments: cache.fragments(entry.Rule.RecordingRule.Expr.Query, recordFragments),
+			})
+		}
+	}
+
+	var reported []*parser.PromQLNode
+	var done []string
+	for _, node := range parser.WalkDownExpr[promParser.Node](rule.AlertingRule.Expr.Query) {
+		if !isExpensiveExpr(node.Expr) {
+			continue
+		}
+		if slices.ContainsFunc(reported, func(r *parser.PromQLNode) bool { return isAncestor(r, node) }) {
+			continue
+		}
+
+		fragment := normalizeExpr(node.Expr)
+		if slices.Contains(done, fragment) {
+			continue
+		}
+
+		count := 1
+		var names []string
+		for _, other := range alerts {
+			if !slices.Contains(other.fragments, fragment) {
+				continue
+			}
+			count++
+			if !slices.Contains(names, other.name) {
+				names = append(names, other.name)
+			}
+		}
+		if count <= settings.MaxCount {
+			continue
+		}
+		reported = append(reported, node)
+		done = append(done, fragment)
+
+		quoted := make([]string, 0, len(names))
+		for _, name := range names {
+			quoted = append(quoted, "`"+name+"`")
+		}
+		details := fmt.Sprintf("%s\nOther alerting rules using it: %s.", SuggestRecordCheckDetails, strings.Join(quoted, ", "))
+
+		text := fmt.Sprintf("`%s` is used in %d alerting rules in this file, consider moving it to a recording rule.",
+			node.Expr, count)
+		for _, record := range records {
+			if slices.Contains(record.fragments, fragment) {
+				text = fmt.Sprintf("`%s` is used in %d alerting rules in this file, consider using `%s` recording rule instead.",
+					node.Expr, count, record.name)
+				break
+			}
+		}
+
+		problems = append(problems, Problem{
+			Lines:    rule.AlertingRule.Expr.Value.Lines,
+			Reporter: c.Reporter(),
+			Text:     text,
+			Details:  details,
+			Severity: Information,
+		})
+	}
+
+	return problems
+}
+
+// isExpensiveExpr returns true for aggregations.
+// Other expressions, like rate(), are cheap enough to use in multiple rules.
+func isExpensiveExpr(expr promParser.Node) bool {
+	_, ok := expr.(*promParser.AggregateExpr)
+	return ok
+}
+
+func isAncestor(parent, node *parser.PromQLNode) bool {
+	for n := node.Parent; n != nil; n = n.Parent {
+		if n == parent {
+			return true
+		}
+	}
+	return false
+}
+
+func expensiveFragments(node *parser.PromQLNode) (fragments []string) {
+	for _, n := range parser.WalkDownExpr[promParser.Node](node) {
+		if isExpensiveExpr(n.Expr) {
+			fragments = append(fragments, normalizeExpr(n.Expr))
+		}
+	}
+	return fragments
+}
+
+func recordFragments(node *parser.PromQLNode) []string {
+	return []string{normalizeExpr(node.Expr)}
+}
+
+// normalizeExpr returns given expression formatted in a way that doesn't depend
+// on whitespace or the order of label matchers.
+func normalizeExpr(node promParser.Node) string {
+	// Parse it again so we don't modify the original AST.
+	expr, err := promParser.ParseExpr(node.String())
+	if err != nil {
+		return node.String()
+	}
+	promParser.Inspect(expr, func(n promParser.Node, _ []promParser.Node) error {
+		if vs, ok := n.(*promParser.VectorSelector); ok {
+			slices.SortFunc(vs.LabelMatchers, func(a, b *labels.Matcher) int {
+				return strings.Compare(a.String(), b.String())
+			})
+		}
+		return nil
+	})
+	return expr.String()
+}
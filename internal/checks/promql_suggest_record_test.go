@@ -0,0 +1,203 @@
+package checks_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/promapi"
+)
+
+func newSuggestRecordCheck(_ *promapi.FailoverGroup) checks.RuleChecker {
+	return checks.NewSuggestRecordCheck()
+}
+
+func TestSuggestRecordCheck(t *testing.T) {
+	testCases := []checkTest{
+		{
+			description: "ignores recording rules",
+			content:     "- record: foo\n  expr: sum(rate(errors_total[5m]))\n",
+			checker:     newSuggestRecordCheck,
+			prometheus:  noProm,
+			entries:     mustParseContent("- alert: Foo\n  expr: sum(rate(errors_total[5m])) > 0\n"),
+			problems:    noProblems,
+		},
+		{
+			description: "ignores rules with syntax errors",
+			content:     "- alert: Foo\n  expr: sum(rate(errors_total[5m]) > 0\n",
+			checker:     newSuggestRecordCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores fragments used once",
+			content:     "- alert: Foo\n  expr: sum(rate(errors_total[5m])) > 0\n",
+			checker:     newSuggestRecordCheck,
+			prometheus:  noProm,
+			entries:     mustParseContent("- alert: Foo\n  expr: sum(rate(errors_total[5m])) > 0\n- alert: Bar\n  expr: sum(rate(requests_total[5m])) > 0\n"),
+			problems:    noProblems,
+		},
+		{
+			description: "ignores cheap fragments",
+			content:     "- alert: Foo\n  expr: up == 0\n",
+			checker:     newSuggestRecordCheck,
+			prometheus:  noProm,
+			entries:     mustParseContent("- alert: Foo\n  expr: up == 0\n- alert: Bar\n  expr: up == 0\n"),
+			problems:    noProblems,
+		},
+		{
+			description: "ignores fragments used in other files",
+			content:     "- alert: Foo\n  expr: sum(rate(errors_total[5m])) > 0\n",
+			checker:     newSuggestRecordCheck,
+			prometheus:  noProm,
+			entries:     mustParseContentAt("- alert: Bar\n  expr: sum(rate(errors_total[5m])) > 10\n", "other.yml"),
+			problems:    noProblems,
+		},
+		{
+			description: "ignores fragments used in two alerts",
+			content:     "- alert: Foo\n  expr: sum(rate(errors_total[5m])) > 0\n",
+			checker:     newSuggestRecordCheck,
+			prometheus:  noProm,
+			entries: mustParseContent(
+				"- alert: Foo\n  expr: sum(rate(errors_total[5m])) > 0\n" +
+					"- alert: Bar\n  expr: sum(rate(errors_total[5m])) > 10\n",
+			),
+			problems: noProblems,
+		},
+		{
+			description: "ignores functions on range vectors",
+			content:     "- alert: Foo\n  expr: rate(errors_total[5m]) > 0\n",
+			checker:     newSuggestRecordCheck,
+			prometheus:  noProm,
+			entries: mustParseContent(
+				"- alert: Foo\n  expr: rate(errors_total[5m]) > 0\n" +
+					"- alert: Bar\n  expr: rate(errors_total[5m]) > 10\n" +
+					"- alert: Baz\n  expr: rate(errors_total[5m]) > 100\n",
+			),
+			problems: noProblems,
+		},
+		{
+			description: "reports fragment repeated across three alerts",
+			content:     "- alert: Foo\n  expr: sum(rate(errors_total{job=\"a\", env=\"prod\"}[5m])) by(job) > 0\n",
+			checker:     newSuggestRecordCheck,
+			prometheus:  noProm,
+			entries: mustParseContent(
+				"- alert: Foo\n  expr: sum(rate(errors_total{job=\"a\", env=\"prod\"}[5m])) by(job) > 0\n" +
+					"- alert: Bar\n  expr: sum by(job) (rate(errors_total{env=\"prod\",job=\"a\"}[5m])) > 100\n" +
+					"- alert: Baz\n  expr: sum by(job) (rate(errors_total{env=\"prod\",job=\"a\"}[5m])) > 1000\n",
+			),
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.SuggestRecordCheckName,
+						Text:     "`sum by (job) (rate(errors_total{env=\"prod\",job=\"a\"}[5m]))` is used in 3 alerting rules in this file, consider moving it to a recording rule.",
+						Details:  checks.SuggestRecordCheckDetails + "\nOther alerting rules using it: `Bar`, `Baz`.",
+						Severity: checks.Information,
+					},
+				}
+			},
+		},
+		{
+			description: "reports inner fragment repeated across alerts",
+			content:     "- alert: Foo\n  expr: max(sum(rate(errors_total[5m])) by(job)) > 0\n",
+			checker:     newSuggestRecordCheck,
+			prometheus:  noProm,
+			entries: mustParseContent(
+				"- alert: Foo\n  expr: max(sum(rate(errors_total[5m])) by(job)) > 0\n" +
+					"- alert: Bar\n  expr: min(sum(rate(errors_total[5m])) by(job)) > 0\n" +
+					"- alert: Baz\n  expr: avg(sum(rate(errors_total[5m])) by(job)) > 0\n",
+			),
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.SuggestRecordCheckName,
+						Text:     "`sum by (job) (rate(errors_total[5m]))` is used in 3 alerting rules in this file, consider moving it to a recording rule.",
+						Details:  checks.SuggestRecordCheckDetails + "\nOther alerting rules using it: `Bar`, `Baz`.",
+						Severity: checks.Information,
+					},
+				}
+			},
+		},
+		{
+			description: "suggests existing recording rule",
+			content:     "- alert: Foo\n  expr: sum(rate(errors_total[5m])) > 0\n",
+			checker:     newSuggestRecordCheck,
+			prometheus:  noProm,
+			entries: mustParseContent(
+				"- alert: Foo\n  expr: sum(rate(errors_total[5m])) > 0\n" +
+					"- alert: Bar\n  expr: sum(rate(errors_total[5m])) > 10\n" +
+					"- alert: Baz\n  expr: sum(rate(errors_total[5m])) > 100\n" +
+					"- record: errors:rate5m\n  expr: sum(rate(errors_total[5m]))\n",
+			),
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.SuggestRecordCheckName,
+						Text:     "`sum(rate(errors_total[5m]))` is used in 3 alerting rules in this file, consider using `errors:rate5m` recording rule instead.",
+						Details:  checks.SuggestRecordCheckDetails + "\nOther alerting rules using it: `Bar`, `Baz`.",
+						Severity: checks.Information,
+					},
+				}
+			},
+		},
+		{
+			description: "respects maxCount",
+			content:     "- alert: Foo\n  expr: sum(rate(errors_total[5m])) > 0\n",
+			checker:     newSuggestRecordCheck,
+			prometheus:  noProm,
+			ctx: func(ctx context.Context, _ string) context.Context {
+				s := checks.PromqlSuggestRecordSettings{MaxCount: 3}
+				_ = s.Validate()
+				return context.WithValue(ctx, checks.SettingsKey(checks.SuggestRecordCheckName), &s)
+			},
+			entries: mustParseContent(
+				"- alert: Foo\n  expr: sum(rate(errors_total[5m])) > 0\n" +
+					"- alert: Bar\n  expr: sum(rate(errors_total[5m])) > 10\n" +
+					"- alert: Baz\n  expr: sum(rate(errors_total[5m])) > 100\n",
+			),
+			problems: noProblems,
+		},
+		{
+			description: "uses fragments cache from context",
+			content:     "- alert: Foo\n  expr: sum(rate(errors_total[5m])) > 0\n",
+			checker:     newSuggestRecordCheck,
+			prometheus:  noProm,
+			ctx: func(ctx context.Context, _ string) context.Context {
+				return context.WithValue(ctx, checks.SuggestRecordCacheKey, checks.NewSuggestRecordCache())
+			},
+			entries: mustParseContent(
+				"- alert: Foo\n  expr: sum(rate(errors_total[5m])) > 0\n" +
+					"- alert: Bar\n  expr: sum(rate(errors_total[5m])) > 10\n" +
+					"- alert: Baz\n  expr: sum(rate(errors_total[5m])) > 100\n",
+			),
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.SuggestRecordCheckName,
+						Text:     "`sum(rate(errors_total[5m]))` is used in 3 alerting rules in this file, consider moving it to a recording rule.",
+						Details:  checks.SuggestRecordCheckDetails + "\nOther alerting rules using it: `Bar`, `Baz`.",
+						Severity: checks.Information,
+					},
+				}
+			},
+		},
+	}
+	runTests(t, testCases)
+}
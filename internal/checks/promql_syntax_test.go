@@ -68,6 +68,26 @@ func TestSyntaxCheck(t *testing.T) {
 				}
 			},
 		},
+		{
+			description: "binary expression on strings",
+			content:     "- record: foo\n  expr: '\"foo\" + \"bar\"'\n",
+			checker:     newSyntaxCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: "promql/syntax",
+						Text:     "Prometheus failed to parse the query with this PromQL error: binary expression must contain only scalar and instant vector types.",
+						Details:  checks.SyntaxCheckDetails,
+						Severity: checks.Fatal,
+					},
+				}
+			},
+		},
 	}
 	runTests(t, testCases)
 }
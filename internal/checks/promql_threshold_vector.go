@@ -0,0 +1,113 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	promParser "github.com/prometheus/prometheus/promql/parser"
+
+	"github.com/cloudflare/pint/internal/discovery"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/parser/utils"
+)
+
+const (
+	ThresholdVectorCheckName    = "promql/threshold_vector"
+	ThresholdVectorCheckDetails = "Comparing two vectors will only return results from the left hand side that have a matching time series with the same labels on the right hand side.\n" +
+		"This is valid PromQL, but it's often a mistake when the right hand side was meant to be a constant threshold like `5`.\n" +
+		"If you do want to compare two vectors then use `on(...)` or `ignoring(...)` to make it explicit which labels should be matched."
+)
+
+var vectorMatchingRe = regexp.MustCompile(`\b(on|ignoring)\s*\(`)
+
+func NewThresholdVectorCheck() ThresholdVectorCheck {
+	return ThresholdVectorCheck{}
+}
+
+type ThresholdVectorCheck struct{}
+
+func (c ThresholdVectorCheck) Meta() CheckMeta {
+	return CheckMeta{
+		States: []discovery.ChangeType{
+			discovery.Noop,
+			discovery.Added,
+			discovery.Modified,
+			discovery.Moved,
+		},
+		Online:        false,
+		AlwaysEnabled: false,
+	}
+}
+
+func (c ThresholdVectorCheck) String() string {
+	return ThresholdVectorCheckName
+}
+
+func (c ThresholdVectorCheck) Reporter() string {
+	return ThresholdVectorCheckName
+}
+
+func (c ThresholdVectorCheck) Check(ctx context.Context, _ discovery.Path, rule parser.Rule, _ []discovery.Entry) (problems []Problem) {
+	expr := rule.Expr()
+	if expr.SyntaxError != nil {
+		return problems
+	}
+
+	for _, node := range parser.WalkDownExpr[*promParser.BinaryExpr](expr.Query) {
+		n := node.Expr.(*promParser.BinaryExpr)
+		if !n.Op.IsComparisonOperator() || n.ReturnBool || n.VectorMatching == nil {
+			continue
+		}
+		if n.VectorMatching.Card != promParser.CardOneToOne || n.VectorMatching.On || len(n.VectorMatching.MatchingLabels) > 0 {
+			continue
+		}
+		// ignoring() with no labels is not visible in the AST.
+		if vectorMatchingRe.MatchString(expr.Value.Value[n.LHS.PositionRange().End:n.RHS.PositionRange().Start]) {
+			continue
+		}
+		if !isThresholdSelector(n.RHS) || !returnsVector(ctx, expr.Value.Value, n.LHS) {
+			continue
+		}
+		problems = append(problems, Problem{
+			Lines:    expr.Value.Lines,
+			Reporter: c.Reporter(),
+			Text: fmt.Sprintf("`%s` is comparing results with `%s` time series instead of a number, if that's intended then use `on(...)` or `ignoring(...)` to make it explicit.",
+				expr.Value.Value[n.PositionRange().Start:n.PositionRange().End],
+				expr.Value.Value[n.RHS.PositionRange().Start:n.RHS.PositionRange().End]),
+			Details:  ThresholdVectorCheckDetails,
+			Severity: Information,
+		})
+	}
+
+	return problems
+}
+
+// isThresholdSelector returns true if given node is a plain vector selector,
+// optionally wrapped in parentheses, used in place where a threshold would usually be.
+// Arithmetic like `foo * 0.1` is a computed threshold and doesn't count.
+func isThresholdSelector(node promParser.Node) bool {
+	for {
+		switch n := node.(type) {
+		case *promParser.ParenExpr:
+			node = n.Expr
+		case *promParser.VectorSelector:
+			return true
+		default:
+			return false
+		}
+	}
+}
+
+func returnsVector(ctx context.Context, expr string, node promParser.Node) bool {
+	src := utils.CachedLabelsSource(ctx, expr, node)
+	if len(src) == 0 {
+		return false
+	}
+	for _, s := range src {
+		if s.Returns != promParser.ValueTypeVector {
+			return false
+		}
+	}
+	return true
+}
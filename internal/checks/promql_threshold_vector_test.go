@@ -0,0 +1,144 @@
+package checks_test
+
+import (
+	"testing"
+
+	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/promapi"
+)
+
+func newThresholdVectorCheck(_ *promapi.FailoverGroup) checks.RuleChecker {
+	return checks.NewThresholdVectorCheck()
+}
+
+func TestThresholdVectorCheck(t *testing.T) {
+	testCases := []checkTest{
+		{
+			description: "ignores rules with syntax errors",
+			content:     "- alert: foo\n  expr: foo > bar)\n",
+			checker:     newThresholdVectorCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores comparison with a number",
+			content:     "- alert: foo\n  expr: foo > 5\n",
+			checker:     newThresholdVectorCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores comparison with a scalar",
+			content:     "- alert: foo\n  expr: foo > scalar(bar)\n",
+			checker:     newThresholdVectorCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores bool comparison",
+			content:     "- record: foo\n  expr: foo > bool bar\n",
+			checker:     newThresholdVectorCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores comparison with on()",
+			content:     "- alert: foo\n  expr: foo > on(instance) bar\n",
+			checker:     newThresholdVectorCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores comparison with ignoring()",
+			content:     "- alert: foo\n  expr: foo > ignoring(job) bar\n",
+			checker:     newThresholdVectorCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores comparison with empty ignoring()",
+			content:     "- alert: foo\n  expr: foo > ignoring () bar\n",
+			checker:     newThresholdVectorCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores comparison with group_left()",
+			content:     "- alert: foo\n  expr: foo > on(instance) group_left() bar\n",
+			checker:     newThresholdVectorCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores comparison with an aggregation",
+			content:     "- alert: foo\n  expr: foo > sum(bar)\n",
+			checker:     newThresholdVectorCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores comparison with arithmetic on a selector",
+			content:     "- alert: foo\n  expr: node_filesystem_avail_bytes < node_filesystem_size_bytes * 0.1\n",
+			checker:     newThresholdVectorCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores comparison with a function call",
+			content:     "- alert: foo\n  expr: foo > rate(bar[5m])\n",
+			checker:     newThresholdVectorCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores binary operations that are not comparisons",
+			content:     "- record: foo\n  expr: foo / bar\n",
+			checker:     newThresholdVectorCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "reports comparison with a selector",
+			content:     "- alert: foo\n  expr: foo > bar\n",
+			checker:     newThresholdVectorCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.ThresholdVectorCheckName,
+						Text:     "`foo > bar` is comparing results with `bar` time series instead of a number, if that's intended then use `on(...)` or `ignoring(...)` to make it explicit.",
+						Details:  checks.ThresholdVectorCheckDetails,
+						Severity: checks.Information,
+					},
+				}
+			},
+		},
+		{
+			description: "reports comparison with a selector inside aggregation",
+			content:     "- alert: foo\n  expr: sum(rate(errors_total[5m])) by(job) >= (threshold{name=\"errors\"})\n",
+			checker:     newThresholdVectorCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: checks.ThresholdVectorCheckName,
+						Text:     "`sum(rate(errors_total[5m])) by(job) >= (threshold{name=\"errors\"})` is comparing results with `(threshold{name=\"errors\"})` time series instead of a number, if that's intended then use `on(...)` or `ignoring(...)` to make it explicit.",
+						Details:  checks.ThresholdVectorCheckDetails,
+						Severity: checks.Information,
+					},
+				}
+			},
+		},
+	}
+
+	runTests(t, testCases)
+}
@@ -0,0 +1,86 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudflare/pint/internal/discovery"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/parser/utils"
+)
+
+const (
+	UnusedRecordCheckName = "promql/unused_record"
+
+	UnusedRecordCheckDetails = `This check can only see rules that pint was asked to check.
+If the metric generated by this rule is used by dashboards or other external consumers then it's safe to ignore this problem.`
+)
+
+func NewUnusedRecordCheck(comment string, severity Severity) UnusedRecordCheck {
+	return UnusedRecordCheck{
+		comment:  comment,
+		severity: severity,
+	}
+}
+
+type UnusedRecordCheck struct {
+	comment  string
+	severity Severity
+}
+
+func (c UnusedRecordCheck) Meta() CheckMeta {
+	return CheckMeta{
+		States: []discovery.ChangeType{
+			discovery.Noop,
+			discovery.Added,
+			discovery.Modified,
+			discovery.Moved,
+		},
+		Online:        false,
+		AlwaysEnabled: false,
+	}
+}
+
+func (c UnusedRecordCheck) String() string {
+	return UnusedRecordCheckName
+}
+
+func (c UnusedRecordCheck) Reporter() string {
+	return UnusedRecordCheckName
+}
+
+func (c UnusedRecordCheck) Check(_ context.Context, path discovery.Path, rule parser.Rule, entries []discovery.Entry) (problems []Problem) {
+	if rule.RecordingRule == nil || rule.RecordingRule.Expr.SyntaxError != nil {
+		return problems
+	}
+
+	name := rule.RecordingRule.Record.Value
+	for _, entry := range nonRemovedEntries(entries) {
+		if entry.Path.Name == path.Name && entry.Rule.Lines.First == rule.Lines.First {
+			continue
+		}
+		expr := entry.Rule.Expr()
+		if expr.SyntaxError != nil {
+			continue
+		}
+		for _, vs := range utils.HasVectorSelector(expr.Query) {
+			if vs.Name == name {
+				return problems
+			}
+		}
+	}
+
+	details := UnusedRecordCheckDetails
+	if c.comment != "" {
+		details += "\n" + maybeComment(c.comment)
+	}
+	problems = append(problems, Problem{
+		Lines:    rule.RecordingRule.Record.Lines,
+		Reporter: c.Reporter(),
+		Text:     fmt.Sprintf("Metric `%s` generated by this recording rule is not used by any other rule.", name),
+		Details:  details,
+		Severity: c.severity,
+	})
+
+	return problems
+}
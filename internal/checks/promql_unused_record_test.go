@@ -0,0 +1,131 @@
+package checks_test
+
+import (
+	"testing"
+
+	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/promapi"
+)
+
+func newUnusedRecordCheck(_ *promapi.FailoverGroup) checks.RuleChecker {
+	return checks.NewUnusedRecordCheck("", checks.Information)
+}
+
+func TestUnusedRecordCheck(t *testing.T) {
+	testCases := []checkTest{
+		{
+			description: "ignores alerting rules",
+			content:     "- alert: foo\n  expr: up == 0\n",
+			checker:     newUnusedRecordCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores rules with syntax errors",
+			content:     "- record: foo\n  expr: sum(foo) without(\n",
+			checker:     newUnusedRecordCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "reports unused record with no other entries",
+			content:     "- record: foo\n  expr: sum(up)\n",
+			checker:     newUnusedRecordCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 1,
+							Last:  1,
+						},
+						Reporter: checks.UnusedRecordCheckName,
+						Text:     "Metric `foo` generated by this recording rule is not used by any other rule.",
+						Details:  checks.UnusedRecordCheckDetails,
+						Severity: checks.Information,
+					},
+				}
+			},
+		},
+		{
+			description: "reports record only used by its own query",
+			content:     "- record: foo\n  expr: sum(foo)\n",
+			checker:     newUnusedRecordCheck,
+			prometheus:  noProm,
+			entries:     mustParseContent("- record: foo\n  expr: sum(foo)\n"),
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 1,
+							Last:  1,
+						},
+						Reporter: checks.UnusedRecordCheckName,
+						Text:     "Metric `foo` generated by this recording rule is not used by any other rule.",
+						Details:  checks.UnusedRecordCheckDetails,
+						Severity: checks.Information,
+					},
+				}
+			},
+		},
+		{
+			description: "reports unreferenced record",
+			content:     "- record: foo\n  expr: sum(up)\n",
+			checker: func(_ *promapi.FailoverGroup) checks.RuleChecker {
+				return checks.NewUnusedRecordCheck("some text", checks.Warning)
+			},
+			prometheus: noProm,
+			entries: mustParseContent(`
+- record: foo
+  expr: sum(up)
+- record: bar
+  expr: sum(up) by(job)
+- alert: baz
+  expr: bar > 0
+`),
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Lines: parser.LineRange{
+							First: 1,
+							Last:  1,
+						},
+						Reporter: checks.UnusedRecordCheckName,
+						Text:     "Metric `foo` generated by this recording rule is not used by any other rule.",
+						Details:  checks.UnusedRecordCheckDetails + "\nRule comment: some text",
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+		{
+			description: "record used by an alert",
+			content:     "- record: foo\n  expr: sum(up)\n",
+			checker:     newUnusedRecordCheck,
+			prometheus:  noProm,
+			entries: mustParseContent(`
+- record: foo
+  expr: sum(up)
+- alert: baz
+  expr: foo > 0
+`),
+			problems: noProblems,
+		},
+		{
+			description: "record used by another record",
+			content:     "- record: foo\n  expr: sum(up)\n",
+			checker:     newUnusedRecordCheck,
+			prometheus:  noProm,
+			entries: mustParseContent(`
+- record: foo
+  expr: sum(up)
+- record: bar
+  expr: rate(foo[5m])
+`),
+			problems: noProblems,
+		},
+	}
+
+	runTests(t, testCases)
+}
@@ -3,9 +3,15 @@ package comments
 import (
 	"bufio"
 	"fmt"
+	"net/url"
+	"strconv"
 	"strings"
 	"time"
 	"unicode"
+	"unicode/utf8"
+
+	"github.com/prometheus/prometheus/model/labels"
+	promParser "github.com/prometheus/prometheus/promql/parser"
 )
 
 type Type uint8
@@ -25,11 +31,18 @@ const (
 	FileSnoozeType     // file/snooze
 	SnoozeType         // snooze
 	RuleSetType        // rule/set
+	RuleLinkType       // rule/link
+	GroupDisableType   // group/disable
+	SeveritySetType    // severity/set
+	IgnoreBlockType    // ignore/block
 )
 
 var (
 	Prefix = "pint"
 
+	// DefaultMarkers is the list of comment markers used by Parse.
+	DefaultMarkers = []string{"#"}
+
 	IgnoreFileComment     = "ignore/file"
 	IgnoreLineComment     = "ignore/line"
 	IgnoreBeginComment    = "ignore/begin"
@@ -42,6 +55,10 @@ var (
 	FileSnoozeComment     = "file/snooze"
 	SnoozeComment         = "snooze"
 	RuleSetComment        = "rule/set"
+	RuleLinkComment       = "rule/link"
+	GroupDisableComment   = "group/disable"
+	SeveritySetComment    = "severity/set"
+	IgnoreBlockComment    = "ignore/block"
 )
 
 type CommentValue interface {
@@ -80,14 +97,23 @@ func parseType(s string) Type {
 		return SnoozeType
 	case RuleSetComment:
 		return RuleSetType
+	case RuleLinkComment:
+		return RuleLinkType
+	case GroupDisableComment:
+		return GroupDisableType
+	case SeveritySetComment:
+		return SeveritySetType
+	case IgnoreBlockComment:
+		return IgnoreBlockType
 	default:
 		return UnknownType
 	}
 }
 
 type CommentError struct {
-	Err  error
-	Line int
+	Err    error
+	Line   int
+	Column int // Byte offset within the line where the invalid part of the comment starts.
 }
 
 func (ce CommentError) Error() string {
@@ -121,13 +147,120 @@ func (o Owner) String() string {
 }
 
 type Disable struct {
-	Match string
+	Match  string
+	Check  string // Check name when using `check(metric=name)` form.
+	Metric string // Metric name when using `check(metric=name)` form.
 }
 
 func (d Disable) String() string {
 	return d.Match
 }
 
+// Matches returns true if this comment disables given reporter for a rule
+// using given selectors.
+// If the comment is using `check(metric=name)` form then the reporter must
+// be equal to the check name and one of the selectors must be for that metric.
+func (d Disable) Matches(reporter string, selectors []*promParser.VectorSelector) bool {
+	if d.Metric == "" {
+		return d.Match == reporter
+	}
+	if d.Check != reporter {
+		return false
+	}
+	for _, vs := range selectors {
+		if vs.Name == d.Metric {
+			return true
+		}
+		for _, lm := range vs.LabelMatchers {
+			if lm.Name == labels.MetricName && lm.Type == labels.MatchEqual && lm.Value == d.Metric {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func parseDisable(s string) (Disable, error) {
+	d := Disable{Match: s}
+	check, rest, ok := strings.Cut(s, "(metric=")
+	if !ok || !strings.HasSuffix(rest, ")") {
+		return d, nil
+	}
+	d.Metric = strings.TrimSpace(strings.TrimSuffix(rest, ")"))
+	if d.Metric == "" {
+		return d, fmt.Errorf("missing metric name in %s value: %s", DisableComment, s)
+	}
+	d.Check = check
+	return d, nil
+}
+
+type GroupDisable struct {
+	Group string
+	Match string
+}
+
+func (gd GroupDisable) String() string {
+	return fmt.Sprintf("%s %s", gd.Group, gd.Match)
+}
+
+func parseGroupDisable(s string) (GroupDisable, error) {
+	group, match, ok := splitValue(s)
+	if !ok {
+		return GroupDisable{}, fmt.Errorf("invalid %s comment, expected '$GROUP $MATCH' got %q", GroupDisableComment, s)
+	}
+	return GroupDisable{Group: group, Match: match}, nil
+}
+
+type SeveritySet struct {
+	Match    string
+	Severity string // One of: bug, warning or info.
+}
+
+func (ss SeveritySet) String() string {
+	return fmt.Sprintf("%s %s", ss.Match, ss.Severity)
+}
+
+func parseSeveritySet(s string) (SeveritySet, error) {
+	match, severity, ok := splitValue(s)
+	if !ok {
+		return SeveritySet{}, fmt.Errorf("invalid %s comment, expected '$MATCH $SEVERITY' got %q", SeveritySetComment, s)
+	}
+	switch severity {
+	case "bug", "warning", "info":
+	case "information":
+		severity = "info"
+	default:
+		return SeveritySet{}, fmt.Errorf("invalid %s severity, expected one of bug, warning or info, got %q", SeveritySetComment, severity)
+	}
+	return SeveritySet{Match: match, Severity: severity}, nil
+}
+
+// splitValue splits comment value on the first whitespace character,
+// any whitespace around the second part is removed.
+func splitValue(s string) (head, tail string, ok bool) {
+	idx := strings.IndexFunc(s, unicode.IsSpace)
+	if idx < 0 {
+		return s, "", false
+	}
+	return s[:idx], strings.TrimSpace(s[idx:]), true
+}
+
+type IgnoreBlock struct {
+	Lines int // Number of lines after the comment that should be ignored.
+}
+
+func (ib IgnoreBlock) String() string {
+	return strconv.Itoa(ib.Lines)
+}
+
+func parseIgnoreBlock(s string) (IgnoreBlock, error) {
+	lines, err := strconv.Atoi(s)
+	if err != nil || lines <= 0 {
+		return IgnoreBlock{}, fmt.Errorf("invalid %s value, expected a positive number of lines, got %q", IgnoreBlockComment, s)
+	}
+	return IgnoreBlock{Lines: lines}, nil
+}
+
 type Snooze struct {
 	Until time.Time
 	Match string
@@ -145,16 +278,95 @@ func (r RuleSet) String() string {
 	return r.Value
 }
 
-func parseSnooze(s string) (snz Snooze, err error) {
-	parts := strings.SplitN(s, " ", 2)
-	if len(parts) != 2 {
+// Check returns the name of the check this comment is for,
+// `promql/series` for `promql/series(found) min-age 1w`.
+func (r RuleSet) Check() string {
+	check, _, _ := splitRuleSet(r.Value)
+	return check
+}
+
+// Match returns the value passed in parentheses after the check name,
+// `found` for `promql/series(found) min-age 1w`.
+func (r RuleSet) Match() string {
+	_, match, _ := splitRuleSet(r.Value)
+	return match
+}
+
+// Params returns all whitespace separated tokens after the check name,
+// `min-age` and `1w` for `promql/series(found) min-age 1w`.
+func (r RuleSet) Params() []string {
+	_, _, params := splitRuleSet(r.Value)
+	return params
+}
+
+// splitRuleSet splits rule/set comment value into `$check($match) $params...` parts.
+// Parentheses after the check name can contain whitespace and other parentheses.
+func splitRuleSet(s string) (check, match string, params []string) {
+	head, tail, _ := splitValue(s)
+	open := strings.IndexRune(head, '(')
+	if open < 0 {
+		check = head
+	} else {
+		check, match, tail = s[:open], s[open+1:], ""
+		var depth int
+		for i, r := range s[open:] {
+			if r == '(' {
+				depth++
+			}
+			if r == ')' {
+				depth--
+			}
+			if depth == 0 {
+				match, tail = s[open+1:open+i], s[open+i+1:]
+				break
+			}
+		}
+	}
+	if fields := strings.Fields(tail); len(fields) > 0 {
+		params = fields
+	}
+	return check, match, params
+}
+
+func parseRuleSet(s string) (RuleSet, error) {
+	rs := RuleSet{Value: s}
+	if rs.Check() == "" {
+		return RuleSet{}, fmt.Errorf("invalid %s value, missing check name in %q", RuleSetComment, s)
+	}
+	return rs, nil
+}
+
+type Link struct {
+	URL  string
+	Line int
+}
+
+func (l Link) String() string {
+	return l.URL
+}
+
+func parseLink(s string, line int) (Link, error) {
+	u, err := url.Parse(s)
+	if err != nil {
+		return Link{}, fmt.Errorf("invalid %s value: %w", RuleLinkComment, err)
+	}
+	if !u.IsAbs() || u.Host == "" {
+		return Link{}, fmt.Errorf("invalid %s value, expected an absolute URL, got %q", RuleLinkComment, s)
+	}
+	return Link{URL: s, Line: line}, nil
+}
+
+func parseSnooze(s string, loc *time.Location) (snz Snooze, err error) {
+	until, match, ok := splitValue(s)
+	if !ok {
 		return Snooze{}, fmt.Errorf("invalid snooze comment, expected '$TIME $MATCH' got %q", s)
 	}
 
-	snz.Match = parts[1]
-	snz.Until, err = time.Parse(time.RFC3339, parts[0])
+	snz.Match = match
+	snz.Until, err = time.Parse(time.RFC3339, until)
 	if err != nil {
-		snz.Until, err = time.Parse("2006-01-02", parts[0])
+		// Dates without time are midnight in given location.
+		snz.Until, err = time.ParseInLocation("2006-01-02", until, loc)
 	}
 	if err != nil {
 		return snz, fmt.Errorf("invalid snooze timestamp: %w", err)
@@ -162,7 +374,7 @@ func parseSnooze(s string) (snz Snooze, err error) {
 	return snz, nil
 }
 
-func parseValue(typ Type, s string, line int) (CommentValue, error) {
+func parseValue(typ Type, s string, line int, loc *time.Location) (CommentValue, error) {
 	switch typ {
 	case IgnoreFileType, IgnoreLineType, IgnoreBeginType, IgnoreEndType, IgnoreNextLineType:
 		if s != "" {
@@ -187,22 +399,42 @@ func parseValue(typ Type, s string, line int) (CommentValue, error) {
 		if s == "" {
 			return nil, fmt.Errorf("missing %s value", DisableComment)
 		}
-		return Disable{Match: s}, nil
+		return parseDisable(s)
 	case FileSnoozeType:
 		if s == "" {
 			return nil, fmt.Errorf("missing %s value", FileSnoozeComment)
 		}
-		return parseSnooze(s)
+		return parseSnooze(s, loc)
 	case SnoozeType:
 		if s == "" {
 			return nil, fmt.Errorf("missing %s value", SnoozeComment)
 		}
-		return parseSnooze(s)
+		return parseSnooze(s, loc)
 	case RuleSetType:
 		if s == "" {
 			return nil, fmt.Errorf("missing %s value", RuleSetComment)
 		}
-		return RuleSet{Value: s}, nil
+		return parseRuleSet(s)
+	case RuleLinkType:
+		if s == "" {
+			return nil, fmt.Errorf("missing %s value", RuleLinkComment)
+		}
+		return parseLink(s, line)
+	case GroupDisableType:
+		if s == "" {
+			return nil, fmt.Errorf("missing %s value", GroupDisableComment)
+		}
+		return parseGroupDisable(s)
+	case SeveritySetType:
+		if s == "" {
+			return nil, fmt.Errorf("missing %s value", SeveritySetComment)
+		}
+		return parseSeveritySet(s)
+	case IgnoreBlockType:
+		if s == "" {
+			return nil, fmt.Errorf("missing %s value", IgnoreBlockComment)
+		}
+		return parseIgnoreBlock(s)
 	case UnknownType, InvalidComment:
 		// pass
 	}
@@ -219,23 +451,43 @@ const (
 	readsValue
 )
 
-func parseComment(s string, line int) (parsed []Comment, err error) {
+// matchMarker returns the number of runes in the comment marker
+// found at given position of s, or zero if there's no marker there.
+func matchMarker(s string, i int, markers []string) int {
+	for _, m := range markers {
+		if m != "" && strings.HasPrefix(s[i:], m) {
+			return utf8.RuneCountInString(m)
+		}
+	}
+	return 0
+}
+
+func parseComment(s string, line int, loc *time.Location, markers []string) (parsed []Comment, column int, err error) {
 	var buf strings.Builder
 	var c Comment
+	var valueOffset, skip int
 
 	state := needsHash
 	for i, r := range s + "\n" {
+		if skip > 0 {
+			skip--
+			continue
+		}
 	READRUNE:
 		switch state {
 		case needsHash:
-			if r != '#' {
+			size := matchMarker(s, i, markers)
+			if size == 0 {
 				goto NEXT
 			}
+			// Skip the remaining runes of multi-character markers like //.
+			skip = size - 1
 			state = needsPrefix
 			buf.Reset()
 			c.Type = UnknownType
 			c.Value = nil
 			c.Offset = i
+			valueOffset = i
 		case needsPrefix:
 			if unicode.IsSpace(r) {
 				goto NEXT
@@ -261,7 +513,7 @@ func parseComment(s string, line int) (parsed []Comment, err error) {
 			// Invalid character in the prefix, ignore this comment.
 			state = needsHash
 		case needsType:
-			if r == '#' {
+			if matchMarker(s, i, markers) > 0 {
 				state = needsHash
 				goto READRUNE
 			}
@@ -289,6 +541,7 @@ func parseComment(s string, line int) (parsed []Comment, err error) {
 			if unicode.IsSpace(r) {
 				goto NEXT
 			}
+			valueOffset = i
 			state = readsValue
 			goto READRUNE
 		case readsValue:
@@ -301,23 +554,44 @@ func parseComment(s string, line int) (parsed []Comment, err error) {
 	}
 
 	if c.Type != UnknownType {
-		c.Value, err = parseValue(c.Type, strings.TrimSpace(buf.String()), line)
+		c.Value, err = parseValue(c.Type, strings.TrimSpace(buf.String()), line, loc)
 		parsed = append(parsed, c)
 	}
 
-	return parsed, err
+	return parsed, valueOffset, err
 }
 
+// Parse returns all pint comments found in given text.
+// Snooze comments with only a date and no time will expire at midnight UTC.
 func Parse(lineno int, text string) (comments []Comment) {
+	return ParseInLocation(lineno, text, time.UTC)
+}
+
+// ParseInLocation works like Parse but snooze comments with only a date
+// and no time will expire at midnight in given location.
+// It's only meant for code using this package directly, pint itself
+// always calls Parse() and there's no config option to change the location.
+func ParseInLocation(lineno int, text string, loc *time.Location) (comments []Comment) {
+	return parseLines(lineno, text, loc, DefaultMarkers)
+}
+
+// ParseWithMarkers works like Parse but recognises pint comments starting
+// with any of the given markers instead of only #, for example // or ;.
+// Offset of each returned comment points at the start of its marker.
+func ParseWithMarkers(lineno int, text string, markers []string) (comments []Comment) {
+	return parseLines(lineno, text, time.UTC, markers)
+}
+
+func parseLines(lineno int, text string, loc *time.Location, markers []string) (comments []Comment) {
 	sc := bufio.NewScanner(strings.NewReader(text))
 	var index int
 	for sc.Scan() {
 		line := sc.Text()
-		parsed, err := parseComment(line, lineno+index)
+		parsed, column, err := parseComment(line, lineno+index, loc, markers)
 		if err != nil {
 			comments = append(comments, Comment{
 				Type:   InvalidComment,
-				Value:  Invalid{Err: CommentError{Line: lineno + index, Err: err}},
+				Value:  Invalid{Err: CommentError{Line: lineno + index, Column: column, Err: err}},
 				Offset: 0,
 			})
 			continue
@@ -342,7 +616,7 @@ func Only[T any](src []Comment, typ Type) []T {
 func IsRuleComment(typ Type) bool {
 	// nolint:exhaustive
 	switch typ {
-	case RuleOwnerType, DisableType, SnoozeType, RuleSetType:
+	case RuleOwnerType, DisableType, SnoozeType, RuleSetType, RuleLinkType, SeveritySetType:
 		return true
 	}
 	return false
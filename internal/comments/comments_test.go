@@ -3,11 +3,14 @@ package comments_test
 import (
 	"errors"
 	"fmt"
+	"net/url"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/cloudflare/pint/internal/comments"
 
+	promParser "github.com/prometheus/prometheus/promql/parser"
 	"github.com/stretchr/testify/require"
 )
 
@@ -29,6 +32,12 @@ func TestParse(t *testing.T) {
 		return err
 	}
 
+	errURL := func(s string) error {
+		_, err := url.Parse(s)
+		require.Error(t, err)
+		return err
+	}
+
 	testCases := []testCaseT{
 		{
 			input: "code\n",
@@ -54,8 +63,9 @@ func TestParse(t *testing.T) {
 				{
 					Type: comments.InvalidComment,
 					Value: comments.Invalid{Err: comments.CommentError{
-						Line: 1,
-						Err:  errors.New(`unexpected comment suffix: "this file"`),
+						Line:   1,
+						Column: 21,
+						Err:    errors.New(`unexpected comment suffix: "this file"`),
 					}},
 				},
 			},
@@ -72,8 +82,9 @@ func TestParse(t *testing.T) {
 				{
 					Type: comments.InvalidComment,
 					Value: comments.Invalid{Err: comments.CommentError{
-						Line: 1,
-						Err:  errors.New(`unexpected comment suffix: "this line"`),
+						Line:   1,
+						Column: 19,
+						Err:    errors.New(`unexpected comment suffix: "this line"`),
 					}},
 				},
 			},
@@ -84,14 +95,28 @@ func TestParse(t *testing.T) {
 				{Type: comments.IgnoreLineType},
 			},
 		},
+		{
+			input: "code # pint ignore/line junk",
+			output: []comments.Comment{
+				{
+					Type: comments.InvalidComment,
+					Value: comments.Invalid{Err: comments.CommentError{
+						Line:   1,
+						Column: len("code # pint ignore/line "),
+						Err:    errors.New(`unexpected comment suffix: "junk"`),
+					}},
+				},
+			},
+		},
 		{
 			input: "# pint ignore/begin here",
 			output: []comments.Comment{
 				{
 					Type: comments.InvalidComment,
 					Value: comments.Invalid{Err: comments.CommentError{
-						Line: 1,
-						Err:  errors.New(`unexpected comment suffix: "here"`),
+						Line:   1,
+						Column: 20,
+						Err:    errors.New(`unexpected comment suffix: "here"`),
 					}},
 				},
 			},
@@ -108,8 +133,9 @@ func TestParse(t *testing.T) {
 				{
 					Type: comments.InvalidComment,
 					Value: comments.Invalid{Err: comments.CommentError{
-						Line: 1,
-						Err:  errors.New(`unexpected comment suffix: "here"`),
+						Line:   1,
+						Column: 18,
+						Err:    errors.New(`unexpected comment suffix: "here"`),
 					}},
 				},
 			},
@@ -126,8 +152,9 @@ func TestParse(t *testing.T) {
 				{
 					Type: comments.InvalidComment,
 					Value: comments.Invalid{Err: comments.CommentError{
-						Line: 1,
-						Err:  errors.New(`unexpected comment suffix: "here"`),
+						Line:   1,
+						Column: 26,
+						Err:    errors.New(`unexpected comment suffix: "here"`),
 					}},
 				},
 			},
@@ -218,6 +245,32 @@ func TestParse(t *testing.T) {
 				},
 			},
 		},
+		{
+			input: "# pint disable promql/series(metric=http_requests_total)",
+			output: []comments.Comment{
+				{
+					Type: comments.DisableType,
+					Value: comments.Disable{
+						Match:  "promql/series(metric=http_requests_total)",
+						Check:  "promql/series",
+						Metric: "http_requests_total",
+					},
+				},
+			},
+		},
+		{
+			input: "# pint disable promql/series(metric=)",
+			output: []comments.Comment{
+				{
+					Type: comments.InvalidComment,
+					Value: comments.Invalid{Err: comments.CommentError{
+						Line:   1,
+						Column: 15,
+						Err:    errors.New("missing metric name in disable value: promql/series(metric=)"),
+					}},
+				},
+			},
+		},
 		{
 			input: `# pint disable promql/series(http_errors_total{label="this has spaces"})`,
 			output: []comments.Comment{
@@ -254,8 +307,9 @@ func TestParse(t *testing.T) {
 				{
 					Type: comments.InvalidComment,
 					Value: comments.Invalid{Err: comments.CommentError{
-						Line: 1,
-						Err:  errors.New(`invalid snooze comment, expected '$TIME $MATCH' got "2023-12-31"`),
+						Line:   1,
+						Column: 21,
+						Err:    errors.New(`invalid snooze comment, expected '$TIME $MATCH' got "2023-12-31"`),
 					}},
 				},
 			},
@@ -266,8 +320,9 @@ func TestParse(t *testing.T) {
 				{
 					Type: comments.InvalidComment,
 					Value: comments.Invalid{Err: comments.CommentError{
-						Line: 1,
-						Err:  errors.New(`invalid snooze comment, expected '$TIME $MATCH' got "abc"`),
+						Line:   1,
+						Column: 21,
+						Err:    errors.New(`invalid snooze comment, expected '$TIME $MATCH' got "abc"`),
 					}},
 				},
 			},
@@ -278,8 +333,9 @@ func TestParse(t *testing.T) {
 				{
 					Type: comments.InvalidComment,
 					Value: comments.Invalid{Err: comments.CommentError{
-						Line: 1,
-						Err:  fmt.Errorf("invalid snooze timestamp: %w", errUntil("2023-1231")),
+						Line:   1,
+						Column: 19,
+						Err:    fmt.Errorf("invalid snooze timestamp: %w", errUntil("2023-1231")),
 					}},
 				},
 			},
@@ -296,6 +352,43 @@ func TestParse(t *testing.T) {
 				},
 			},
 		},
+		{
+			input: `# pint file/snooze 2023-12-31T14:00:00Z promql/series`,
+			output: []comments.Comment{
+				{
+					Type: comments.FileSnoozeType,
+					Value: comments.Snooze{
+						Until: parseUntil("2023-12-31T14:00:00Z"),
+						Match: "promql/series",
+					},
+				},
+			},
+		},
+		{
+			input: `# pint file/snooze 2023-12-31T14:30:15+02:00 promql/series`,
+			output: []comments.Comment{
+				{
+					Type: comments.FileSnoozeType,
+					Value: comments.Snooze{
+						Until: parseUntil("2023-12-31T14:30:15+02:00"),
+						Match: "promql/series",
+					},
+				},
+			},
+		},
+		{
+			input: "#   pint file/snooze 2023-12-31T14:00:00 promql/series",
+			output: []comments.Comment{
+				{
+					Type: comments.InvalidComment,
+					Value: comments.Invalid{Err: comments.CommentError{
+						Line:   1,
+						Column: 21,
+						Err:    fmt.Errorf("invalid snooze timestamp: %w", errUntil("2023-12-31T14:00:00")),
+					}},
+				},
+			},
+		},
 		{
 			input: "#   pint snooze",
 			output: []comments.Comment{
@@ -314,8 +407,9 @@ func TestParse(t *testing.T) {
 				{
 					Type: comments.InvalidComment,
 					Value: comments.Invalid{Err: comments.CommentError{
-						Line: 1,
-						Err:  errors.New(`invalid snooze comment, expected '$TIME $MATCH' got "2023-12-31"`),
+						Line:   1,
+						Column: 16,
+						Err:    errors.New(`invalid snooze comment, expected '$TIME $MATCH' got "2023-12-31"`),
 					}},
 				},
 			},
@@ -326,8 +420,9 @@ func TestParse(t *testing.T) {
 				{
 					Type: comments.InvalidComment,
 					Value: comments.Invalid{Err: comments.CommentError{
-						Line: 1,
-						Err:  errors.New(`invalid snooze comment, expected '$TIME $MATCH' got "abc"`),
+						Line:   1,
+						Column: 16,
+						Err:    errors.New(`invalid snooze comment, expected '$TIME $MATCH' got "abc"`),
 					}},
 				},
 			},
@@ -338,8 +433,9 @@ func TestParse(t *testing.T) {
 				{
 					Type: comments.InvalidComment,
 					Value: comments.Invalid{Err: comments.CommentError{
-						Line: 1,
-						Err:  fmt.Errorf("invalid snooze timestamp: %w", errUntil("2023-1231")),
+						Line:   1,
+						Column: 14,
+						Err:    fmt.Errorf("invalid snooze timestamp: %w", errUntil("2023-1231")),
 					}},
 				},
 			},
@@ -356,6 +452,18 @@ func TestParse(t *testing.T) {
 				},
 			},
 		},
+		{
+			input: `# pint snooze 2023-12-31T23:59:59Z promql/series`,
+			output: []comments.Comment{
+				{
+					Type: comments.SnoozeType,
+					Value: comments.Snooze{
+						Until: parseUntil("2023-12-31T23:59:59Z"),
+						Match: "promql/series",
+					},
+				},
+			},
+		},
 		{
 			input: `# pint snooze 2023-12-31 promql/series(http_errors_total{label="this has    spaces"})`,
 			output: []comments.Comment{
@@ -389,6 +497,247 @@ func TestParse(t *testing.T) {
 				},
 			},
 		},
+		{
+			input: "# pint rule/link",
+			output: []comments.Comment{
+				{
+					Type: comments.InvalidComment,
+					Value: comments.Invalid{Err: comments.CommentError{
+						Line: 1,
+						Err:  errors.New("missing rule/link value"),
+					}},
+				},
+			},
+		},
+		{
+			input: "# pint rule/link https://example.com/runbooks/foo.html#bar",
+			output: []comments.Comment{
+				{
+					Type:  comments.RuleLinkType,
+					Value: comments.Link{URL: "https://example.com/runbooks/foo.html#bar", Line: 1},
+				},
+			},
+		},
+		{
+			input: "# pint rule/link example.com/runbooks",
+			output: []comments.Comment{
+				{
+					Type: comments.InvalidComment,
+					Value: comments.Invalid{Err: comments.CommentError{
+						Line:   1,
+						Column: 17,
+						Err:    errors.New(`invalid rule/link value, expected an absolute URL, got "example.com/runbooks"`),
+					}},
+				},
+			},
+		},
+		{
+			input: "# pint rule/link mailto:bob@example.com",
+			output: []comments.Comment{
+				{
+					Type: comments.InvalidComment,
+					Value: comments.Invalid{Err: comments.CommentError{
+						Line:   1,
+						Column: 17,
+						Err:    errors.New(`invalid rule/link value, expected an absolute URL, got "mailto:bob@example.com"`),
+					}},
+				},
+			},
+		},
+		{
+			input: "# pint rule/link http://[::1",
+			output: []comments.Comment{
+				{
+					Type: comments.InvalidComment,
+					Value: comments.Invalid{Err: comments.CommentError{
+						Line:   1,
+						Column: 17,
+						Err:    fmt.Errorf("invalid rule/link value: %w", errURL("http://[::1")),
+					}},
+				},
+			},
+		},
+		{
+			input: "# pint group/disable",
+			output: []comments.Comment{
+				{
+					Type: comments.InvalidComment,
+					Value: comments.Invalid{Err: comments.CommentError{
+						Line: 1,
+						Err:  errors.New("missing group/disable value"),
+					}},
+				},
+			},
+		},
+		{
+			input: "# pint group/disable foo",
+			output: []comments.Comment{
+				{
+					Type: comments.InvalidComment,
+					Value: comments.Invalid{Err: comments.CommentError{
+						Line:   1,
+						Column: 21,
+						Err:    errors.New(`invalid group/disable comment, expected '$GROUP $MATCH' got "foo"`),
+					}},
+				},
+			},
+		},
+		{
+			input: `# pint group/disable foo promql/series(bar{job="a b"})`,
+			output: []comments.Comment{
+				{
+					Type:  comments.GroupDisableType,
+					Value: comments.GroupDisable{Group: "foo", Match: `promql/series(bar{job="a b"})`},
+				},
+			},
+		},
+		{
+			input: "# pint severity/set",
+			output: []comments.Comment{
+				{
+					Type: comments.InvalidComment,
+					Value: comments.Invalid{Err: comments.CommentError{
+						Line: 1,
+						Err:  errors.New("missing severity/set value"),
+					}},
+				},
+			},
+		},
+		{
+			input: "# pint severity/set promql/series",
+			output: []comments.Comment{
+				{
+					Type: comments.InvalidComment,
+					Value: comments.Invalid{Err: comments.CommentError{
+						Line:   1,
+						Column: 20,
+						Err:    errors.New(`invalid severity/set comment, expected '$MATCH $SEVERITY' got "promql/series"`),
+					}},
+				},
+			},
+		},
+		{
+			input: "# pint severity/set promql/series fatal",
+			output: []comments.Comment{
+				{
+					Type: comments.InvalidComment,
+					Value: comments.Invalid{Err: comments.CommentError{
+						Line:   1,
+						Column: 20,
+						Err:    errors.New(`invalid severity/set severity, expected one of bug, warning or info, got "fatal"`),
+					}},
+				},
+			},
+		},
+		{
+			input: "# pint severity/set promql/series critical",
+			output: []comments.Comment{
+				{
+					Type: comments.InvalidComment,
+					Value: comments.Invalid{Err: comments.CommentError{
+						Line:   1,
+						Column: 20,
+						Err:    errors.New(`invalid severity/set severity, expected one of bug, warning or info, got "critical"`),
+					}},
+				},
+			},
+		},
+		{
+			input: "# pint severity/set promql/series(+prod) bug",
+			output: []comments.Comment{
+				{
+					Type:  comments.SeveritySetType,
+					Value: comments.SeveritySet{Match: "promql/series(+prod)", Severity: "bug"},
+				},
+			},
+		},
+		{
+			input: "# pint severity/set promql/series warning",
+			output: []comments.Comment{
+				{
+					Type:  comments.SeveritySetType,
+					Value: comments.SeveritySet{Match: "promql/series", Severity: "warning"},
+				},
+			},
+		},
+		{
+			input: "# pint severity/set\tpromql/series\t\tinformation ",
+			output: []comments.Comment{
+				{
+					Type:  comments.SeveritySetType,
+					Value: comments.SeveritySet{Match: "promql/series", Severity: "info"},
+				},
+			},
+		},
+		{
+			input: "# pint severity/set promql/series info",
+			output: []comments.Comment{
+				{
+					Type:  comments.SeveritySetType,
+					Value: comments.SeveritySet{Match: "promql/series", Severity: "info"},
+				},
+			},
+		},
+		{
+			input: "# pint ignore/block",
+			output: []comments.Comment{
+				{
+					Type: comments.InvalidComment,
+					Value: comments.Invalid{Err: comments.CommentError{
+						Line: 1,
+						Err:  errors.New("missing ignore/block value"),
+					}},
+				},
+			},
+		},
+		{
+			input: "# pint ignore/block abc",
+			output: []comments.Comment{
+				{
+					Type: comments.InvalidComment,
+					Value: comments.Invalid{Err: comments.CommentError{
+						Line:   1,
+						Column: 20,
+						Err:    errors.New(`invalid ignore/block value, expected a positive number of lines, got "abc"`),
+					}},
+				},
+			},
+		},
+		{
+			input: "# pint ignore/block 0",
+			output: []comments.Comment{
+				{
+					Type: comments.InvalidComment,
+					Value: comments.Invalid{Err: comments.CommentError{
+						Line:   1,
+						Column: 20,
+						Err:    errors.New(`invalid ignore/block value, expected a positive number of lines, got "0"`),
+					}},
+				},
+			},
+		},
+		{
+			input: "# pint ignore/block -2",
+			output: []comments.Comment{
+				{
+					Type: comments.InvalidComment,
+					Value: comments.Invalid{Err: comments.CommentError{
+						Line:   1,
+						Column: 20,
+						Err:    errors.New(`invalid ignore/block value, expected a positive number of lines, got "-2"`),
+					}},
+				},
+			},
+		},
+		{
+			input: "# pint ignore/block 3",
+			output: []comments.Comment{
+				{
+					Type:  comments.IgnoreBlockType,
+					Value: comments.IgnoreBlock{Lines: 3},
+				},
+			},
+		},
 		{
 			input: "code # pint disable xxx  \ncode # alice\n",
 			output: []comments.Comment{
@@ -417,6 +766,97 @@ func TestParse(t *testing.T) {
 				},
 			},
 		},
+		{
+			input: "# pint disable x\t",
+			output: []comments.Comment{
+				{
+					Type:  comments.DisableType,
+					Value: comments.Disable{Match: "x"},
+				},
+			},
+		},
+		{
+			input: "# pint file/owner\tbob",
+			output: []comments.Comment{
+				{
+					Type: comments.FileOwnerType,
+					Value: comments.Owner{
+						Name: "bob",
+						Line: 1,
+					},
+				},
+			},
+		},
+		{
+			input: "#\tpint\tignore/line\t ",
+			output: []comments.Comment{
+				{Type: comments.IgnoreLineType},
+			},
+		},
+		{
+			input: "# pint rule/owner \t bob\t",
+			output: []comments.Comment{
+				{
+					Type:  comments.RuleOwnerType,
+					Value: comments.Owner{Name: "bob"},
+				},
+			},
+		},
+		{
+			input: "# pint snooze\t2023-12-31T23:59:59Z\tpromql/series\t",
+			output: []comments.Comment{
+				{
+					Type: comments.SnoozeType,
+					Value: comments.Snooze{
+						Until: parseUntil("2023-12-31T23:59:59Z"),
+						Match: "promql/series",
+					},
+				},
+			},
+		},
+		{
+			input: "# pint file/snooze 2023-12-31T23:59:59Z \t promql/series",
+			output: []comments.Comment{
+				{
+					Type: comments.FileSnoozeType,
+					Value: comments.Snooze{
+						Until: parseUntil("2023-12-31T23:59:59Z"),
+						Match: "promql/series",
+					},
+				},
+			},
+		},
+		{
+			input: "# pint group/disable\tfoo\t\tpromql/series ",
+			output: []comments.Comment{
+				{
+					Type:  comments.GroupDisableType,
+					Value: comments.GroupDisable{Group: "foo", Match: "promql/series"},
+				},
+			},
+		},
+		{
+			input: "# pint rule/link\thttps://example.com/runbook\t",
+			output: []comments.Comment{
+				{
+					Type:  comments.RuleLinkType,
+					Value: comments.Link{URL: "https://example.com/runbook", Line: 1},
+				},
+			},
+		},
+		{
+			input: "# pint rule/set (found) min-age foo",
+			output: []comments.Comment{
+				{
+					Type: comments.InvalidComment,
+					Value: comments.Invalid{Err: comments.CommentError{
+						Line:   1,
+						Column: 16,
+						Err:    errors.New(`invalid rule/set value, missing check name in "(found) min-age foo"`),
+					}},
+				},
+			},
+		},
 		{
 			input: "# pint rule/set promql/series(found) min-age foo",
 			output: []comments.Comment{
@@ -459,8 +899,9 @@ func TestParse(t *testing.T) {
 				{
 					Type: comments.InvalidComment,
 					Value: comments.Invalid{Err: comments.CommentError{
-						Line: 1,
-						Err:  errors.New(`unexpected comment suffix: "# pint ignore/file"`),
+						Line:   1,
+						Column: 33,
+						Err:    errors.New(`unexpected comment suffix: "# pint ignore/file"`),
 					}},
 				},
 			},
@@ -484,6 +925,215 @@ func TestParse(t *testing.T) {
 	}
 }
 
+func TestParseSnoozeLocation(t *testing.T) {
+	tokyo := time.FixedZone("JST", 9*60*60)
+
+	type testCaseT struct {
+		loc   *time.Location
+		input string
+		until time.Time
+	}
+
+	testCases := []testCaseT{
+		{
+			input: "# pint snooze 2023-12-31 promql/series",
+			loc:   time.UTC,
+			until: time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			input: "# pint snooze 2023-12-31T00:00:00+09:00 promql/series",
+			loc:   time.UTC,
+			until: time.Date(2023, 12, 30, 15, 0, 0, 0, time.UTC),
+		},
+		{
+			input: "# pint snooze 2023-12-31 promql/series",
+			loc:   tokyo,
+			until: time.Date(2023, 12, 30, 15, 0, 0, 0, time.UTC),
+		},
+		{
+			input: "# pint file/snooze 2023-12-31 promql/series",
+			loc:   tokyo,
+			until: time.Date(2023, 12, 30, 15, 0, 0, 0, time.UTC),
+		},
+		{
+			input: "# pint snooze 2023-12-31T10:00:00Z promql/series",
+			loc:   tokyo,
+			until: time.Date(2023, 12, 31, 10, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			output := comments.ParseInLocation(1, tc.input, tc.loc)
+			require.Len(t, output, 1)
+			snooze, ok := output[0].Value.(comments.Snooze)
+			require.True(t, ok, "expected a snooze comment, got %v", output[0].Value)
+			require.Equal(t, "promql/series", snooze.Match)
+			require.True(t, tc.until.Equal(snooze.Until), "expected %s, got %s", tc.until, snooze.Until)
+		})
+	}
+}
+
+func TestParseWithMarkers(t *testing.T) {
+	type testCaseT struct {
+		input   string
+		markers []string
+		output  []comments.Comment
+	}
+
+	testCases := []testCaseT{
+		{
+			input:   "// pint ignore/line",
+			markers: comments.DefaultMarkers,
+		},
+		{
+			input:   "# pint ignore/line",
+			markers: comments.DefaultMarkers,
+			output: []comments.Comment{
+				{Type: comments.IgnoreLineType, Offset: 0},
+			},
+		},
+		{
+			input:   "// pint ignore/line",
+			markers: []string{"//"},
+			output: []comments.Comment{
+				{Type: comments.IgnoreLineType, Offset: 0},
+			},
+		},
+		{
+			input:   "foo: bar // pint ignore/line",
+			markers: []string{"//"},
+			output: []comments.Comment{
+				{Type: comments.IgnoreLineType, Offset: 9},
+			},
+		},
+		{
+			input:   "/ pint ignore/line",
+			markers: []string{"//"},
+		},
+		{
+			input:   "# pint ignore/line",
+			markers: []string{"//"},
+		},
+		{
+			input:   "foo ; pint disable promql/series",
+			markers: []string{";"},
+			output: []comments.Comment{
+				{
+					Type:   comments.DisableType,
+					Value:  comments.Disable{Match: "promql/series"},
+					Offset: 4,
+				},
+			},
+		},
+		{
+			input:   "; pint rule/owner bob",
+			markers: []string{"//", ";", "#"},
+			output: []comments.Comment{
+				{
+					Type:   comments.RuleOwnerType,
+					Value:  comments.Owner{Name: "bob"},
+					Offset: 0,
+				},
+			},
+		},
+		{
+			input:   "// pint // pint ignore/line",
+			markers: []string{"//"},
+			output: []comments.Comment{
+				{Type: comments.IgnoreLineType, Offset: 8},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			output := comments.ParseWithMarkers(1, tc.input, tc.markers)
+			require.Equal(t, tc.output, output)
+		})
+	}
+}
+
+func TestDisableMatches(t *testing.T) {
+	type testCaseT struct {
+		disable  string
+		reporter string
+		expr     string
+		matches  bool
+	}
+
+	testCases := []testCaseT{
+		{
+			disable:  "promql/series",
+			reporter: "promql/series",
+			expr:     "foo",
+			matches:  true,
+		},
+		{
+			disable:  "promql/series",
+			reporter: "promql/rate",
+			expr:     "foo",
+			matches:  false,
+		},
+		{
+			disable:  "promql/series(metric=foo)",
+			reporter: "promql/series",
+			expr:     "sum(rate(foo[5m])) / sum(rate(bar[5m]))",
+			matches:  true,
+		},
+		{
+			disable:  "promql/series(metric=foo)",
+			reporter: "promql/series",
+			expr:     `{__name__="foo", job="bar"}`,
+			matches:  true,
+		},
+		{
+			disable:  "promql/series(metric=foo)",
+			reporter: "promql/series",
+			expr:     "sum(rate(bar[5m]))",
+			matches:  false,
+		},
+		{
+			disable:  "promql/series(metric=foo)",
+			reporter: "promql/series",
+			expr:     `{__name__=~"foo|bar"}`,
+			matches:  false,
+		},
+		{
+			disable:  "promql/series(metric=foo)",
+			reporter: "promql/rate",
+			expr:     "rate(foo[5m])",
+			matches:  false,
+		},
+		{
+			disable:  "promql/series(metric=foo)",
+			reporter: "promql/series(metric=foo)",
+			expr:     "bar",
+			matches:  false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.disable+"/"+tc.expr, func(t *testing.T) {
+			output := comments.Parse(1, "# pint disable "+tc.disable)
+			require.Len(t, output, 1)
+			disable, ok := output[0].Value.(comments.Disable)
+			require.True(t, ok, "expected a disable comment, got %v", output[0].Value)
+
+			expr, err := promParser.ParseExpr(tc.expr)
+			require.NoError(t, err)
+			var selectors []*promParser.VectorSelector
+			promParser.Inspect(expr, func(node promParser.Node, _ []promParser.Node) error {
+				if vs, ok := node.(*promParser.VectorSelector); ok {
+					selectors = append(selectors, vs)
+				}
+				return nil
+			})
+			require.Equal(t, tc.matches, disable.Matches(tc.reporter, selectors))
+		})
+	}
+}
+
 func TestCommentValueString(t *testing.T) {
 	type testCaseT struct {
 		comment  comments.CommentValue
@@ -511,6 +1161,14 @@ func TestCommentValueString(t *testing.T) {
 			}},
 			expected: "foo bar",
 		},
+		{
+			comment: comments.Invalid{Err: comments.CommentError{
+				Line:   1,
+				Column: 24,
+				Err:    errors.New(`unexpected comment suffix: "junk"`),
+			}},
+			expected: `unexpected comment suffix: "junk"`,
+		},
 		{
 			comment:  comments.Owner{Name: "bob & alice"},
 			expected: "bob & alice",
@@ -523,10 +1181,22 @@ func TestCommentValueString(t *testing.T) {
 			comment:  comments.RuleSet{Value: "bob & alice"},
 			expected: "bob & alice",
 		},
+		{
+			comment:  comments.GroupDisable{Group: "foo", Match: "promql/series"},
+			expected: "foo promql/series",
+		},
+		{
+			comment:  comments.Link{URL: "https://example.com/runbook", Line: 1},
+			expected: "https://example.com/runbook",
+		},
 		{
 			comment:  comments.Snooze{Match: `promql/series({code="500"})`, Until: parseUntil("2023-11-28T00:00:00Z")},
 			expected: `2023-11-28T00:00:00Z promql/series({code="500"})`,
 		},
+		{
+			comment:  comments.Snooze{Match: `promql/series({code="500"})`, Until: parseUntil("2023-11-28T14:05:00Z")},
+			expected: `2023-11-28T14:05:00Z promql/series({code="500"})`,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -535,3 +1205,71 @@ func TestCommentValueString(t *testing.T) {
 		})
 	}
 }
+
+func TestRuleSet(t *testing.T) {
+	type testCaseT struct {
+		input  string
+		check  string
+		match  string
+		params []string
+	}
+
+	testCases := []testCaseT{
+		{
+			input:  "promql/series(found) min-age 1w",
+			check:  "promql/series",
+			match:  "found",
+			params: []string{"min-age", "1w"},
+		},
+		{
+			input:  "promql/series min-age 1w",
+			check:  "promql/series",
+			params: []string{"min-age", "1w"},
+		},
+		{
+			input:  "promql/series   ignore/label-value   code  ",
+			check:  "promql/series",
+			params: []string{"ignore/label-value", "code"},
+		},
+		{
+			input:  `promql/series(foo{job=~"(a|b) c"}) ignore/label-value code`,
+			check:  "promql/series",
+			match:  `foo{job=~"(a|b) c"}`,
+			params: []string{"ignore/label-value", "code"},
+		},
+		{
+			input: "promql/series",
+			check: "promql/series",
+		},
+		{
+			input: "promql/series()",
+			check: "promql/series",
+		},
+		{
+			input: "promql/series(foo min-age 1w",
+			check: "promql/series",
+			match: "foo min-age 1w",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			parsed := comments.Parse(1, "# pint rule/set "+tc.input)
+			require.Len(t, parsed, 1)
+			require.Equal(t, comments.RuleSetType, parsed[0].Type)
+			rs := parsed[0].Value.(comments.RuleSet)
+			require.Equal(t, strings.TrimSpace(tc.input), rs.Value)
+			require.Equal(t, tc.check, rs.Check())
+			require.Equal(t, tc.match, rs.Match())
+			require.Equal(t, tc.params, rs.Params())
+		})
+	}
+}
+
+func TestOnlyLink(t *testing.T) {
+	parsed := comments.Parse(3, "# pint rule/owner bob\n# pint rule/link https://example.com/runbook\n")
+	require.Equal(t,
+		[]comments.Link{{URL: "https://example.com/runbook", Line: 4}},
+		comments.Only[comments.Link](parsed, comments.RuleLinkType),
+	)
+}
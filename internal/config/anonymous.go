@@ -0,0 +1,27 @@
+package config
+
+import (
+	"github.com/cloudflare/pint/internal/checks"
+)
+
+type AnonymousSettings struct {
+	Comment  string `hcl:"comment,optional" json:"comment,omitempty"`
+	Severity string `hcl:"severity,optional" json:"severity,omitempty"`
+}
+
+func (as AnonymousSettings) validate() error {
+	if as.Severity != "" {
+		if _, err := checks.ParseSeverity(as.Severity); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (as AnonymousSettings) getSeverity(fallback checks.Severity) checks.Severity {
+	if as.Severity != "" {
+		sev, _ := checks.ParseSeverity(as.Severity)
+		return sev
+	}
+	return fallback
+}
@@ -0,0 +1,40 @@
+package config
+
+import (
+	"errors"
+
+	"github.com/cloudflare/pint/internal/checks"
+)
+
+type ByVsWithoutSettings struct {
+	Comment   string `hcl:"comment,optional" json:"comment,omitempty"`
+	Severity  string `hcl:"severity,optional" json:"severity,omitempty"`
+	MaxLabels *int   `hcl:"maxLabels,optional" json:"maxLabels,omitempty"`
+}
+
+func (bs ByVsWithoutSettings) validate() error {
+	if bs.Severity != "" {
+		if _, err := checks.ParseSeverity(bs.Severity); err != nil {
+			return err
+		}
+	}
+	if bs.MaxLabels != nil && *bs.MaxLabels < 1 {
+		return errors.New("maxLabels value must be >= 1")
+	}
+	return nil
+}
+
+func (bs ByVsWithoutSettings) getSeverity(fallback checks.Severity) checks.Severity {
+	if bs.Severity != "" {
+		sev, _ := checks.ParseSeverity(bs.Severity)
+		return sev
+	}
+	return fallback
+}
+
+func (bs ByVsWithoutSettings) getMaxLabels() int {
+	if bs.MaxLabels != nil {
+		return *bs.MaxLabels
+	}
+	return 5
+}
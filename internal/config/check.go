@@ -29,6 +29,16 @@ func (c Check) Decode() (s CheckSettings, err error) {
 		s = &checks.PromqlSeriesSettings{}
 	case checks.RegexpCheckName:
 		s = &checks.PromqlRegexpSettings{}
+	case checks.AbsentCheckName:
+		s = &checks.PromqlAbsentSettings{}
+	case checks.SuggestRecordCheckName:
+		s = &checks.PromqlSuggestRecordSettings{}
+	case checks.HighChurnLabelCheckName:
+		s = &checks.PromqlHighChurnLabelSettings{}
+	case checks.DeprecatedFunctionCheckName:
+		s = &checks.PromqlDeprecatedFunctionSettings{}
+	case checks.CountConfusionCheckName:
+		s = &checks.PromqlCountConfusionSettings{}
 	default:
 		return nil, fmt.Errorf("unknown check %q", c.Name)
 	}
@@ -196,6 +196,138 @@ func TestGetChecksForRule(t *testing.T) {
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
 				checks.RegexpCheckName,
+				checks.CountAbsenceCheckName,
+				checks.DeadCodeCheckName,
+				checks.AlertsConstantValueCheckName,
+				checks.ConstantCheckName,
+				checks.LabelReplaceOverwriteCheckName,
+				checks.AbsentCheckName,
+				checks.RedundantParensCheckName,
+				checks.AtModifierCheckName,
+				checks.LabelLifecycleCheckName,
+				checks.DeprecatedFunctionCheckName,
+				checks.SuggestRecordCheckName,
+				checks.NestedRateCheckName,
+				checks.HighChurnLabelCheckName,
+				checks.ThresholdVectorCheckName,
+				checks.QuantileCheckName,
+				checks.CountValuesCheckName,
+				checks.AlertForIntervalCheckName,
+				checks.LabelShadowCheckName,
+				checks.RecordingBoolCheckName,
+				checks.SubqueryCheckName,
+				checks.AlertsOrLabelsCheckName,
+				checks.AggregateEmptyCheckName,
+				checks.DoubleAggregateCheckName,
+				checks.HistogramCheckName,
+				checks.ComparisonLabelsCheckName,
+				checks.LabelCollisionCheckName,
+				checks.SelfReferenceCheckName,
+				checks.JoinLabelCheckName,
+				checks.CountConfusionCheckName,
+				checks.GroupLabelsCheckName,
+				checks.CrossFileCollisionCheckName,
+			},
+		},
+		{
+			title:  "disable check for a metric via comment",
+			config: "",
+			entry: discovery.Entry{
+				State: discovery.Modified,
+				Path: discovery.Path{
+					Name:          "rules.yml",
+					SymlinkTarget: "rules.yml",
+				},
+				Rule: newRule(t, "# pint disable promql/fragile(metric=foo)\n- record: foo\n  expr: sum(foo) + sum(bar)\n"),
+			},
+			checks: []string{
+				checks.SyntaxCheckName,
+				checks.AlertForCheckName,
+				checks.ComparisonCheckName,
+				checks.TemplateCheckName,
+				checks.RegexpCheckName,
+				checks.CountAbsenceCheckName,
+				checks.DeadCodeCheckName,
+				checks.AlertsConstantValueCheckName,
+				checks.ConstantCheckName,
+				checks.LabelReplaceOverwriteCheckName,
+				checks.AbsentCheckName,
+				checks.RedundantParensCheckName,
+				checks.AtModifierCheckName,
+				checks.LabelLifecycleCheckName,
+				checks.DeprecatedFunctionCheckName,
+				checks.SuggestRecordCheckName,
+				checks.NestedRateCheckName,
+				checks.HighChurnLabelCheckName,
+				checks.ThresholdVectorCheckName,
+				checks.QuantileCheckName,
+				checks.CountValuesCheckName,
+				checks.AlertForIntervalCheckName,
+				checks.LabelShadowCheckName,
+				checks.RecordingBoolCheckName,
+				checks.SubqueryCheckName,
+				checks.AlertsOrLabelsCheckName,
+				checks.AggregateEmptyCheckName,
+				checks.DoubleAggregateCheckName,
+				checks.HistogramCheckName,
+				checks.ComparisonLabelsCheckName,
+				checks.LabelCollisionCheckName,
+				checks.SelfReferenceCheckName,
+				checks.JoinLabelCheckName,
+				checks.CountConfusionCheckName,
+				checks.GroupLabelsCheckName,
+				checks.CrossFileCollisionCheckName,
+			},
+		},
+		{
+			title:  "disable check for a metric via comment / metric not used",
+			config: "",
+			entry: discovery.Entry{
+				State: discovery.Modified,
+				Path: discovery.Path{
+					Name:          "rules.yml",
+					SymlinkTarget: "rules.yml",
+				},
+				Rule: newRule(t, "# pint disable promql/fragile(metric=foo)\n- record: foo\n  expr: sum(bar)\n"),
+			},
+			checks: []string{
+				checks.SyntaxCheckName,
+				checks.AlertForCheckName,
+				checks.ComparisonCheckName,
+				checks.TemplateCheckName,
+				checks.FragileCheckName,
+				checks.RegexpCheckName,
+				checks.CountAbsenceCheckName,
+				checks.DeadCodeCheckName,
+				checks.AlertsConstantValueCheckName,
+				checks.ConstantCheckName,
+				checks.LabelReplaceOverwriteCheckName,
+				checks.AbsentCheckName,
+				checks.RedundantParensCheckName,
+				checks.AtModifierCheckName,
+				checks.LabelLifecycleCheckName,
+				checks.DeprecatedFunctionCheckName,
+				checks.SuggestRecordCheckName,
+				checks.NestedRateCheckName,
+				checks.HighChurnLabelCheckName,
+				checks.ThresholdVectorCheckName,
+				checks.QuantileCheckName,
+				checks.CountValuesCheckName,
+				checks.AlertForIntervalCheckName,
+				checks.LabelShadowCheckName,
+				checks.RecordingBoolCheckName,
+				checks.SubqueryCheckName,
+				checks.AlertsOrLabelsCheckName,
+				checks.AggregateEmptyCheckName,
+				checks.DoubleAggregateCheckName,
+				checks.HistogramCheckName,
+				checks.ComparisonLabelsCheckName,
+				checks.LabelCollisionCheckName,
+				checks.SelfReferenceCheckName,
+				checks.JoinLabelCheckName,
+				checks.CountConfusionCheckName,
+				checks.GroupLabelsCheckName,
+				checks.CrossFileCollisionCheckName,
 			},
 		},
 		{
@@ -221,6 +353,36 @@ prometheus "prom" {
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
 				checks.RegexpCheckName,
+				checks.CountAbsenceCheckName,
+				checks.DeadCodeCheckName,
+				checks.AlertsConstantValueCheckName,
+				checks.ConstantCheckName,
+				checks.LabelReplaceOverwriteCheckName,
+				checks.AbsentCheckName,
+				checks.RedundantParensCheckName,
+				checks.AtModifierCheckName,
+				checks.LabelLifecycleCheckName,
+				checks.DeprecatedFunctionCheckName,
+				checks.SuggestRecordCheckName,
+				checks.NestedRateCheckName,
+				checks.HighChurnLabelCheckName,
+				checks.ThresholdVectorCheckName,
+				checks.QuantileCheckName,
+				checks.CountValuesCheckName,
+				checks.AlertForIntervalCheckName,
+				checks.LabelShadowCheckName,
+				checks.RecordingBoolCheckName,
+				checks.SubqueryCheckName,
+				checks.AlertsOrLabelsCheckName,
+				checks.AggregateEmptyCheckName,
+				checks.DoubleAggregateCheckName,
+				checks.HistogramCheckName,
+				checks.ComparisonLabelsCheckName,
+				checks.LabelCollisionCheckName,
+				checks.SelfReferenceCheckName,
+				checks.JoinLabelCheckName,
+				checks.CountConfusionCheckName,
+				checks.GroupLabelsCheckName,
 				checks.RateCheckName + "(prom)",
 				checks.SeriesCheckName + "(prom)",
 				checks.VectorMatchingCheckName + "(prom)",
@@ -230,6 +392,7 @@ prometheus "prom" {
 				checks.AlertsExternalLabelsCheckName + "(prom)",
 				checks.CounterCheckName + "(prom)",
 				checks.AlertsAbsentCheckName + "(prom)",
+				checks.CrossFileCollisionCheckName + "(prom)",
 			},
 		},
 		{
@@ -256,6 +419,36 @@ prometheus "prom" {
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
 				checks.RegexpCheckName,
+				checks.CountAbsenceCheckName,
+				checks.DeadCodeCheckName,
+				checks.AlertsConstantValueCheckName,
+				checks.ConstantCheckName,
+				checks.LabelReplaceOverwriteCheckName,
+				checks.AbsentCheckName,
+				checks.RedundantParensCheckName,
+				checks.AtModifierCheckName,
+				checks.LabelLifecycleCheckName,
+				checks.DeprecatedFunctionCheckName,
+				checks.SuggestRecordCheckName,
+				checks.NestedRateCheckName,
+				checks.HighChurnLabelCheckName,
+				checks.ThresholdVectorCheckName,
+				checks.QuantileCheckName,
+				checks.CountValuesCheckName,
+				checks.AlertForIntervalCheckName,
+				checks.LabelShadowCheckName,
+				checks.RecordingBoolCheckName,
+				checks.SubqueryCheckName,
+				checks.AlertsOrLabelsCheckName,
+				checks.AggregateEmptyCheckName,
+				checks.DoubleAggregateCheckName,
+				checks.HistogramCheckName,
+				checks.ComparisonLabelsCheckName,
+				checks.LabelCollisionCheckName,
+				checks.SelfReferenceCheckName,
+				checks.JoinLabelCheckName,
+				checks.CountConfusionCheckName,
+				checks.GroupLabelsCheckName,
 				checks.RateCheckName + "(prom)",
 				checks.SeriesCheckName + "(prom)",
 				checks.VectorMatchingCheckName + "(prom)",
@@ -265,6 +458,7 @@ prometheus "prom" {
 				checks.AlertsExternalLabelsCheckName + "(prom)",
 				checks.CounterCheckName + "(prom)",
 				checks.AlertsAbsentCheckName + "(prom)",
+				checks.CrossFileCollisionCheckName + "(prom)",
 			},
 		},
 		{
@@ -307,6 +501,38 @@ checks {
 				checks.ComparisonCheckName,
 				checks.FragileCheckName,
 				checks.RegexpCheckName,
+				checks.CountAbsenceCheckName,
+				checks.DeadCodeCheckName,
+				checks.AlertsConstantValueCheckName,
+				checks.ConstantCheckName,
+				checks.LabelReplaceOverwriteCheckName,
+				checks.AbsentCheckName,
+				checks.RedundantParensCheckName,
+				checks.AtModifierCheckName,
+				checks.LabelLifecycleCheckName,
+				checks.DeprecatedFunctionCheckName,
+				checks.SuggestRecordCheckName,
+				checks.NestedRateCheckName,
+				checks.HighChurnLabelCheckName,
+				checks.ThresholdVectorCheckName,
+				checks.QuantileCheckName,
+				checks.CountValuesCheckName,
+				checks.AlertForIntervalCheckName,
+				checks.LabelShadowCheckName,
+				checks.RecordingBoolCheckName,
+				checks.SubqueryCheckName,
+				checks.AlertsOrLabelsCheckName,
+				checks.AggregateEmptyCheckName,
+				checks.DoubleAggregateCheckName,
+				checks.HistogramCheckName,
+				checks.ComparisonLabelsCheckName,
+				checks.LabelCollisionCheckName,
+				checks.SelfReferenceCheckName,
+				checks.JoinLabelCheckName,
+				checks.CountConfusionCheckName,
+				checks.GroupLabelsCheckName,
+				checks.CrossFileCollisionCheckName + "(prom1)",
+				checks.CrossFileCollisionCheckName + "(prom2)",
 			},
 		},
 		{
@@ -333,6 +559,37 @@ prometheus "prom" {
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
 				checks.RegexpCheckName,
+				checks.CountAbsenceCheckName,
+				checks.DeadCodeCheckName,
+				checks.AlertsConstantValueCheckName,
+				checks.ConstantCheckName,
+				checks.LabelReplaceOverwriteCheckName,
+				checks.AbsentCheckName,
+				checks.RedundantParensCheckName,
+				checks.AtModifierCheckName,
+				checks.LabelLifecycleCheckName,
+				checks.DeprecatedFunctionCheckName,
+				checks.SuggestRecordCheckName,
+				checks.NestedRateCheckName,
+				checks.HighChurnLabelCheckName,
+				checks.ThresholdVectorCheckName,
+				checks.QuantileCheckName,
+				checks.CountValuesCheckName,
+				checks.AlertForIntervalCheckName,
+				checks.LabelShadowCheckName,
+				checks.RecordingBoolCheckName,
+				checks.SubqueryCheckName,
+				checks.AlertsOrLabelsCheckName,
+				checks.AggregateEmptyCheckName,
+				checks.DoubleAggregateCheckName,
+				checks.HistogramCheckName,
+				checks.ComparisonLabelsCheckName,
+				checks.LabelCollisionCheckName,
+				checks.SelfReferenceCheckName,
+				checks.JoinLabelCheckName,
+				checks.CountConfusionCheckName,
+				checks.GroupLabelsCheckName,
+				checks.CrossFileCollisionCheckName,
 			},
 		},
 		{
@@ -360,6 +617,37 @@ prometheus "prom" {
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
 				checks.RegexpCheckName,
+				checks.CountAbsenceCheckName,
+				checks.DeadCodeCheckName,
+				checks.AlertsConstantValueCheckName,
+				checks.ConstantCheckName,
+				checks.LabelReplaceOverwriteCheckName,
+				checks.AbsentCheckName,
+				checks.RedundantParensCheckName,
+				checks.AtModifierCheckName,
+				checks.LabelLifecycleCheckName,
+				checks.DeprecatedFunctionCheckName,
+				checks.SuggestRecordCheckName,
+				checks.NestedRateCheckName,
+				checks.HighChurnLabelCheckName,
+				checks.ThresholdVectorCheckName,
+				checks.QuantileCheckName,
+				checks.CountValuesCheckName,
+				checks.AlertForIntervalCheckName,
+				checks.LabelShadowCheckName,
+				checks.RecordingBoolCheckName,
+				checks.SubqueryCheckName,
+				checks.AlertsOrLabelsCheckName,
+				checks.AggregateEmptyCheckName,
+				checks.DoubleAggregateCheckName,
+				checks.HistogramCheckName,
+				checks.ComparisonLabelsCheckName,
+				checks.LabelCollisionCheckName,
+				checks.SelfReferenceCheckName,
+				checks.JoinLabelCheckName,
+				checks.CountConfusionCheckName,
+				checks.GroupLabelsCheckName,
+				checks.CrossFileCollisionCheckName,
 			},
 		},
 		{
@@ -386,6 +674,37 @@ prometheus "prom" {
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
 				checks.RegexpCheckName,
+				checks.CountAbsenceCheckName,
+				checks.DeadCodeCheckName,
+				checks.AlertsConstantValueCheckName,
+				checks.ConstantCheckName,
+				checks.LabelReplaceOverwriteCheckName,
+				checks.AbsentCheckName,
+				checks.RedundantParensCheckName,
+				checks.AtModifierCheckName,
+				checks.LabelLifecycleCheckName,
+				checks.DeprecatedFunctionCheckName,
+				checks.SuggestRecordCheckName,
+				checks.NestedRateCheckName,
+				checks.HighChurnLabelCheckName,
+				checks.ThresholdVectorCheckName,
+				checks.QuantileCheckName,
+				checks.CountValuesCheckName,
+				checks.AlertForIntervalCheckName,
+				checks.LabelShadowCheckName,
+				checks.RecordingBoolCheckName,
+				checks.SubqueryCheckName,
+				checks.AlertsOrLabelsCheckName,
+				checks.AggregateEmptyCheckName,
+				checks.DoubleAggregateCheckName,
+				checks.HistogramCheckName,
+				checks.ComparisonLabelsCheckName,
+				checks.LabelCollisionCheckName,
+				checks.SelfReferenceCheckName,
+				checks.JoinLabelCheckName,
+				checks.CountConfusionCheckName,
+				checks.GroupLabelsCheckName,
+				checks.CrossFileCollisionCheckName,
 			},
 		},
 		{
@@ -412,6 +731,36 @@ prometheus "prom" {
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
 				checks.RegexpCheckName,
+				checks.CountAbsenceCheckName,
+				checks.DeadCodeCheckName,
+				checks.AlertsConstantValueCheckName,
+				checks.ConstantCheckName,
+				checks.LabelReplaceOverwriteCheckName,
+				checks.AbsentCheckName,
+				checks.RedundantParensCheckName,
+				checks.AtModifierCheckName,
+				checks.LabelLifecycleCheckName,
+				checks.DeprecatedFunctionCheckName,
+				checks.SuggestRecordCheckName,
+				checks.NestedRateCheckName,
+				checks.HighChurnLabelCheckName,
+				checks.ThresholdVectorCheckName,
+				checks.QuantileCheckName,
+				checks.CountValuesCheckName,
+				checks.AlertForIntervalCheckName,
+				checks.LabelShadowCheckName,
+				checks.RecordingBoolCheckName,
+				checks.SubqueryCheckName,
+				checks.AlertsOrLabelsCheckName,
+				checks.AggregateEmptyCheckName,
+				checks.DoubleAggregateCheckName,
+				checks.HistogramCheckName,
+				checks.ComparisonLabelsCheckName,
+				checks.LabelCollisionCheckName,
+				checks.SelfReferenceCheckName,
+				checks.JoinLabelCheckName,
+				checks.CountConfusionCheckName,
+				checks.GroupLabelsCheckName,
 				checks.RateCheckName + "(prom)",
 				checks.SeriesCheckName + "(prom)",
 				checks.VectorMatchingCheckName + "(prom)",
@@ -421,6 +770,7 @@ prometheus "prom" {
 				checks.AlertsExternalLabelsCheckName + "(prom)",
 				checks.CounterCheckName + "(prom)",
 				checks.AlertsAbsentCheckName + "(prom)",
+				checks.CrossFileCollisionCheckName + "(prom)",
 			},
 		},
 		{
@@ -452,6 +802,36 @@ prometheus "ignore" {
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
 				checks.RegexpCheckName,
+				checks.CountAbsenceCheckName,
+				checks.DeadCodeCheckName,
+				checks.AlertsConstantValueCheckName,
+				checks.ConstantCheckName,
+				checks.LabelReplaceOverwriteCheckName,
+				checks.AbsentCheckName,
+				checks.RedundantParensCheckName,
+				checks.AtModifierCheckName,
+				checks.LabelLifecycleCheckName,
+				checks.DeprecatedFunctionCheckName,
+				checks.SuggestRecordCheckName,
+				checks.NestedRateCheckName,
+				checks.HighChurnLabelCheckName,
+				checks.ThresholdVectorCheckName,
+				checks.QuantileCheckName,
+				checks.CountValuesCheckName,
+				checks.AlertForIntervalCheckName,
+				checks.LabelShadowCheckName,
+				checks.RecordingBoolCheckName,
+				checks.SubqueryCheckName,
+				checks.AlertsOrLabelsCheckName,
+				checks.AggregateEmptyCheckName,
+				checks.DoubleAggregateCheckName,
+				checks.HistogramCheckName,
+				checks.ComparisonLabelsCheckName,
+				checks.LabelCollisionCheckName,
+				checks.SelfReferenceCheckName,
+				checks.JoinLabelCheckName,
+				checks.CountConfusionCheckName,
+				checks.GroupLabelsCheckName,
 				checks.RateCheckName + "(prom)",
 				checks.SeriesCheckName + "(prom)",
 				checks.VectorMatchingCheckName + "(prom)",
@@ -461,6 +841,7 @@ prometheus "ignore" {
 				checks.AlertsExternalLabelsCheckName + "(prom)",
 				checks.CounterCheckName + "(prom)",
 				checks.AlertsAbsentCheckName + "(prom)",
+				checks.CrossFileCollisionCheckName + "(prom)",
 			},
 		},
 		{
@@ -481,6 +862,37 @@ prometheus "ignore" {
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
 				checks.RegexpCheckName,
+				checks.CountAbsenceCheckName,
+				checks.DeadCodeCheckName,
+				checks.AlertsConstantValueCheckName,
+				checks.ConstantCheckName,
+				checks.LabelReplaceOverwriteCheckName,
+				checks.AbsentCheckName,
+				checks.RedundantParensCheckName,
+				checks.AtModifierCheckName,
+				checks.LabelLifecycleCheckName,
+				checks.DeprecatedFunctionCheckName,
+				checks.SuggestRecordCheckName,
+				checks.NestedRateCheckName,
+				checks.HighChurnLabelCheckName,
+				checks.ThresholdVectorCheckName,
+				checks.QuantileCheckName,
+				checks.CountValuesCheckName,
+				checks.AlertForIntervalCheckName,
+				checks.LabelShadowCheckName,
+				checks.RecordingBoolCheckName,
+				checks.SubqueryCheckName,
+				checks.AlertsOrLabelsCheckName,
+				checks.AggregateEmptyCheckName,
+				checks.DoubleAggregateCheckName,
+				checks.HistogramCheckName,
+				checks.ComparisonLabelsCheckName,
+				checks.LabelCollisionCheckName,
+				checks.SelfReferenceCheckName,
+				checks.JoinLabelCheckName,
+				checks.CountConfusionCheckName,
+				checks.GroupLabelsCheckName,
+				checks.CrossFileCollisionCheckName,
 			},
 		},
 		{
@@ -511,6 +923,37 @@ rule {
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
 				checks.RegexpCheckName,
+				checks.CountAbsenceCheckName,
+				checks.DeadCodeCheckName,
+				checks.AlertsConstantValueCheckName,
+				checks.ConstantCheckName,
+				checks.LabelReplaceOverwriteCheckName,
+				checks.AbsentCheckName,
+				checks.RedundantParensCheckName,
+				checks.AtModifierCheckName,
+				checks.LabelLifecycleCheckName,
+				checks.DeprecatedFunctionCheckName,
+				checks.SuggestRecordCheckName,
+				checks.NestedRateCheckName,
+				checks.HighChurnLabelCheckName,
+				checks.ThresholdVectorCheckName,
+				checks.QuantileCheckName,
+				checks.CountValuesCheckName,
+				checks.AlertForIntervalCheckName,
+				checks.LabelShadowCheckName,
+				checks.RecordingBoolCheckName,
+				checks.SubqueryCheckName,
+				checks.AlertsOrLabelsCheckName,
+				checks.AggregateEmptyCheckName,
+				checks.DoubleAggregateCheckName,
+				checks.HistogramCheckName,
+				checks.ComparisonLabelsCheckName,
+				checks.LabelCollisionCheckName,
+				checks.SelfReferenceCheckName,
+				checks.JoinLabelCheckName,
+				checks.CountConfusionCheckName,
+				checks.GroupLabelsCheckName,
+				checks.CrossFileCollisionCheckName,
 				checks.AggregationCheckName + "(job:true)",
 				checks.AggregationCheckName + "(instance:false)",
 				checks.AggregationCheckName + "(rack:false)",
@@ -549,6 +992,37 @@ rule {
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
 				checks.RegexpCheckName,
+				checks.CountAbsenceCheckName,
+				checks.DeadCodeCheckName,
+				checks.AlertsConstantValueCheckName,
+				checks.ConstantCheckName,
+				checks.LabelReplaceOverwriteCheckName,
+				checks.AbsentCheckName,
+				checks.RedundantParensCheckName,
+				checks.AtModifierCheckName,
+				checks.LabelLifecycleCheckName,
+				checks.DeprecatedFunctionCheckName,
+				checks.SuggestRecordCheckName,
+				checks.NestedRateCheckName,
+				checks.HighChurnLabelCheckName,
+				checks.ThresholdVectorCheckName,
+				checks.QuantileCheckName,
+				checks.CountValuesCheckName,
+				checks.AlertForIntervalCheckName,
+				checks.LabelShadowCheckName,
+				checks.RecordingBoolCheckName,
+				checks.SubqueryCheckName,
+				checks.AlertsOrLabelsCheckName,
+				checks.AggregateEmptyCheckName,
+				checks.DoubleAggregateCheckName,
+				checks.HistogramCheckName,
+				checks.ComparisonLabelsCheckName,
+				checks.LabelCollisionCheckName,
+				checks.SelfReferenceCheckName,
+				checks.JoinLabelCheckName,
+				checks.CountConfusionCheckName,
+				checks.GroupLabelsCheckName,
+				checks.CrossFileCollisionCheckName,
 				checks.AggregationCheckName + "(job:true)",
 				checks.AggregationCheckName + "(rack:false)",
 			},
@@ -579,6 +1053,37 @@ rule {
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
 				checks.RegexpCheckName,
+				checks.CountAbsenceCheckName,
+				checks.DeadCodeCheckName,
+				checks.AlertsConstantValueCheckName,
+				checks.ConstantCheckName,
+				checks.LabelReplaceOverwriteCheckName,
+				checks.AbsentCheckName,
+				checks.RedundantParensCheckName,
+				checks.AtModifierCheckName,
+				checks.LabelLifecycleCheckName,
+				checks.DeprecatedFunctionCheckName,
+				checks.SuggestRecordCheckName,
+				checks.NestedRateCheckName,
+				checks.HighChurnLabelCheckName,
+				checks.ThresholdVectorCheckName,
+				checks.QuantileCheckName,
+				checks.CountValuesCheckName,
+				checks.AlertForIntervalCheckName,
+				checks.LabelShadowCheckName,
+				checks.RecordingBoolCheckName,
+				checks.SubqueryCheckName,
+				checks.AlertsOrLabelsCheckName,
+				checks.AggregateEmptyCheckName,
+				checks.DoubleAggregateCheckName,
+				checks.HistogramCheckName,
+				checks.ComparisonLabelsCheckName,
+				checks.LabelCollisionCheckName,
+				checks.SelfReferenceCheckName,
+				checks.JoinLabelCheckName,
+				checks.CountConfusionCheckName,
+				checks.GroupLabelsCheckName,
+				checks.CrossFileCollisionCheckName,
 			},
 		},
 		{
@@ -624,17 +1129,49 @@ prometheus "prom2" {
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
 				checks.RegexpCheckName,
+				checks.CountAbsenceCheckName,
+				checks.DeadCodeCheckName,
+				checks.AlertsConstantValueCheckName,
+				checks.ConstantCheckName,
+				checks.LabelReplaceOverwriteCheckName,
+				checks.AbsentCheckName,
+				checks.RedundantParensCheckName,
+				checks.AtModifierCheckName,
+				checks.LabelLifecycleCheckName,
+				checks.DeprecatedFunctionCheckName,
+				checks.SuggestRecordCheckName,
+				checks.NestedRateCheckName,
+				checks.HighChurnLabelCheckName,
+				checks.ThresholdVectorCheckName,
+				checks.QuantileCheckName,
+				checks.CountValuesCheckName,
+				checks.AlertForIntervalCheckName,
+				checks.LabelShadowCheckName,
+				checks.RecordingBoolCheckName,
+				checks.SubqueryCheckName,
+				checks.AlertsOrLabelsCheckName,
+				checks.AggregateEmptyCheckName,
+				checks.DoubleAggregateCheckName,
+				checks.HistogramCheckName,
+				checks.ComparisonLabelsCheckName,
+				checks.LabelCollisionCheckName,
+				checks.SelfReferenceCheckName,
+				checks.JoinLabelCheckName,
+				checks.CountConfusionCheckName,
+				checks.GroupLabelsCheckName,
 				checks.RateCheckName + "(prom1)",
 				checks.RangeQueryCheckName + "(prom1)",
 				checks.LabelsConflictCheckName + "(prom1)",
 				checks.AlertsExternalLabelsCheckName + "(prom1)",
 				checks.AlertsAbsentCheckName + "(prom1)",
+				checks.CrossFileCollisionCheckName + "(prom1)",
 				checks.SeriesCheckName + "(prom2)",
 				checks.VectorMatchingCheckName + "(prom2)",
 				checks.RangeQueryCheckName + "(prom2)",
 				checks.RuleDuplicateCheckName + "(prom2)",
 				checks.CounterCheckName + "(prom2)",
 				checks.AlertsAbsentCheckName + "(prom2)",
+				checks.CrossFileCollisionCheckName + "(prom2)",
 				checks.CostCheckName + "(prom1)",
 			},
 		},
@@ -696,6 +1233,37 @@ rule {
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
 				checks.RegexpCheckName,
+				checks.CountAbsenceCheckName,
+				checks.DeadCodeCheckName,
+				checks.AlertsConstantValueCheckName,
+				checks.ConstantCheckName,
+				checks.LabelReplaceOverwriteCheckName,
+				checks.AbsentCheckName,
+				checks.RedundantParensCheckName,
+				checks.AtModifierCheckName,
+				checks.LabelLifecycleCheckName,
+				checks.DeprecatedFunctionCheckName,
+				checks.SuggestRecordCheckName,
+				checks.NestedRateCheckName,
+				checks.HighChurnLabelCheckName,
+				checks.ThresholdVectorCheckName,
+				checks.QuantileCheckName,
+				checks.CountValuesCheckName,
+				checks.AlertForIntervalCheckName,
+				checks.LabelShadowCheckName,
+				checks.RecordingBoolCheckName,
+				checks.SubqueryCheckName,
+				checks.AlertsOrLabelsCheckName,
+				checks.AggregateEmptyCheckName,
+				checks.DoubleAggregateCheckName,
+				checks.HistogramCheckName,
+				checks.ComparisonLabelsCheckName,
+				checks.LabelCollisionCheckName,
+				checks.SelfReferenceCheckName,
+				checks.JoinLabelCheckName,
+				checks.CountConfusionCheckName,
+				checks.GroupLabelsCheckName,
+				checks.CrossFileCollisionCheckName,
 				checks.LabelCheckName + "(team:true)",
 				checks.AnnotationCheckName + "(summary:true)",
 				checks.LabelCheckName + "(team:false)",
@@ -761,8 +1329,40 @@ rule {
 				checks.AlertForCheckName,
 				checks.ComparisonCheckName,
 				checks.TemplateCheckName,
+				checks.CountAbsenceCheckName,
+				checks.DeadCodeCheckName,
+				checks.AlertsConstantValueCheckName,
+				checks.ConstantCheckName,
+				checks.LabelReplaceOverwriteCheckName,
+				checks.AbsentCheckName,
+				checks.RedundantParensCheckName,
+				checks.AtModifierCheckName,
+				checks.LabelLifecycleCheckName,
+				checks.DeprecatedFunctionCheckName,
+				checks.SuggestRecordCheckName,
+				checks.NestedRateCheckName,
+				checks.HighChurnLabelCheckName,
+				checks.ThresholdVectorCheckName,
+				checks.QuantileCheckName,
+				checks.CountValuesCheckName,
+				checks.AlertForIntervalCheckName,
+				checks.LabelShadowCheckName,
+				checks.RecordingBoolCheckName,
+				checks.SubqueryCheckName,
+				checks.AlertsOrLabelsCheckName,
+				checks.AggregateEmptyCheckName,
+				checks.DoubleAggregateCheckName,
+				checks.HistogramCheckName,
+				checks.ComparisonLabelsCheckName,
+				checks.LabelCollisionCheckName,
+				checks.SelfReferenceCheckName,
+				checks.JoinLabelCheckName,
+				checks.CountConfusionCheckName,
+				checks.GroupLabelsCheckName,
 				checks.AlertsAbsentCheckName + "(prom1)",
+				checks.CrossFileCollisionCheckName + "(prom1)",
 				checks.AlertsAbsentCheckName + "(prom2)",
+				checks.CrossFileCollisionCheckName + "(prom2)",
 				checks.CostCheckName + "(prom1)",
 				checks.CostCheckName + "(prom2)",
 				checks.CostCheckName + "(prom1:10000)",
@@ -806,6 +1406,37 @@ rule {
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
 				checks.RegexpCheckName,
+				checks.CountAbsenceCheckName,
+				checks.DeadCodeCheckName,
+				checks.AlertsConstantValueCheckName,
+				checks.ConstantCheckName,
+				checks.LabelReplaceOverwriteCheckName,
+				checks.AbsentCheckName,
+				checks.RedundantParensCheckName,
+				checks.AtModifierCheckName,
+				checks.LabelLifecycleCheckName,
+				checks.DeprecatedFunctionCheckName,
+				checks.SuggestRecordCheckName,
+				checks.NestedRateCheckName,
+				checks.HighChurnLabelCheckName,
+				checks.ThresholdVectorCheckName,
+				checks.QuantileCheckName,
+				checks.CountValuesCheckName,
+				checks.AlertForIntervalCheckName,
+				checks.LabelShadowCheckName,
+				checks.RecordingBoolCheckName,
+				checks.SubqueryCheckName,
+				checks.AlertsOrLabelsCheckName,
+				checks.AggregateEmptyCheckName,
+				checks.DoubleAggregateCheckName,
+				checks.HistogramCheckName,
+				checks.ComparisonLabelsCheckName,
+				checks.LabelCollisionCheckName,
+				checks.SelfReferenceCheckName,
+				checks.JoinLabelCheckName,
+				checks.CountConfusionCheckName,
+				checks.GroupLabelsCheckName,
+				checks.CrossFileCollisionCheckName,
 				checks.RejectCheckName + "(key=~'^http://.+$')",
 				checks.RejectCheckName + "(val=~'^http://.+$')",
 				checks.RejectCheckName + "(key=~'^.* +.*$')",
@@ -844,6 +1475,37 @@ rule {
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
 				checks.RegexpCheckName,
+				checks.CountAbsenceCheckName,
+				checks.DeadCodeCheckName,
+				checks.AlertsConstantValueCheckName,
+				checks.ConstantCheckName,
+				checks.LabelReplaceOverwriteCheckName,
+				checks.AbsentCheckName,
+				checks.RedundantParensCheckName,
+				checks.AtModifierCheckName,
+				checks.LabelLifecycleCheckName,
+				checks.DeprecatedFunctionCheckName,
+				checks.SuggestRecordCheckName,
+				checks.NestedRateCheckName,
+				checks.HighChurnLabelCheckName,
+				checks.ThresholdVectorCheckName,
+				checks.QuantileCheckName,
+				checks.CountValuesCheckName,
+				checks.AlertForIntervalCheckName,
+				checks.LabelShadowCheckName,
+				checks.RecordingBoolCheckName,
+				checks.SubqueryCheckName,
+				checks.AlertsOrLabelsCheckName,
+				checks.AggregateEmptyCheckName,
+				checks.DoubleAggregateCheckName,
+				checks.HistogramCheckName,
+				checks.ComparisonLabelsCheckName,
+				checks.LabelCollisionCheckName,
+				checks.SelfReferenceCheckName,
+				checks.JoinLabelCheckName,
+				checks.CountConfusionCheckName,
+				checks.GroupLabelsCheckName,
+				checks.CrossFileCollisionCheckName,
 			},
 		},
 		{
@@ -878,6 +1540,37 @@ rule {
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
 				checks.RegexpCheckName,
+				checks.CountAbsenceCheckName,
+				checks.DeadCodeCheckName,
+				checks.AlertsConstantValueCheckName,
+				checks.ConstantCheckName,
+				checks.LabelReplaceOverwriteCheckName,
+				checks.AbsentCheckName,
+				checks.RedundantParensCheckName,
+				checks.AtModifierCheckName,
+				checks.LabelLifecycleCheckName,
+				checks.DeprecatedFunctionCheckName,
+				checks.SuggestRecordCheckName,
+				checks.NestedRateCheckName,
+				checks.HighChurnLabelCheckName,
+				checks.ThresholdVectorCheckName,
+				checks.QuantileCheckName,
+				checks.CountValuesCheckName,
+				checks.AlertForIntervalCheckName,
+				checks.LabelShadowCheckName,
+				checks.RecordingBoolCheckName,
+				checks.SubqueryCheckName,
+				checks.AlertsOrLabelsCheckName,
+				checks.AggregateEmptyCheckName,
+				checks.DoubleAggregateCheckName,
+				checks.HistogramCheckName,
+				checks.ComparisonLabelsCheckName,
+				checks.LabelCollisionCheckName,
+				checks.SelfReferenceCheckName,
+				checks.JoinLabelCheckName,
+				checks.CountConfusionCheckName,
+				checks.GroupLabelsCheckName,
+				checks.CrossFileCollisionCheckName,
 			},
 		},
 		{
@@ -912,6 +1605,37 @@ rule {
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
 				checks.RegexpCheckName,
+				checks.CountAbsenceCheckName,
+				checks.DeadCodeCheckName,
+				checks.AlertsConstantValueCheckName,
+				checks.ConstantCheckName,
+				checks.LabelReplaceOverwriteCheckName,
+				checks.AbsentCheckName,
+				checks.RedundantParensCheckName,
+				checks.AtModifierCheckName,
+				checks.LabelLifecycleCheckName,
+				checks.DeprecatedFunctionCheckName,
+				checks.SuggestRecordCheckName,
+				checks.NestedRateCheckName,
+				checks.HighChurnLabelCheckName,
+				checks.ThresholdVectorCheckName,
+				checks.QuantileCheckName,
+				checks.CountValuesCheckName,
+				checks.AlertForIntervalCheckName,
+				checks.LabelShadowCheckName,
+				checks.RecordingBoolCheckName,
+				checks.SubqueryCheckName,
+				checks.AlertsOrLabelsCheckName,
+				checks.AggregateEmptyCheckName,
+				checks.DoubleAggregateCheckName,
+				checks.HistogramCheckName,
+				checks.ComparisonLabelsCheckName,
+				checks.LabelCollisionCheckName,
+				checks.SelfReferenceCheckName,
+				checks.JoinLabelCheckName,
+				checks.CountConfusionCheckName,
+				checks.GroupLabelsCheckName,
+				checks.CrossFileCollisionCheckName,
 			},
 		},
 		{
@@ -946,6 +1670,37 @@ rule {
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
 				checks.RegexpCheckName,
+				checks.CountAbsenceCheckName,
+				checks.DeadCodeCheckName,
+				checks.AlertsConstantValueCheckName,
+				checks.ConstantCheckName,
+				checks.LabelReplaceOverwriteCheckName,
+				checks.AbsentCheckName,
+				checks.RedundantParensCheckName,
+				checks.AtModifierCheckName,
+				checks.LabelLifecycleCheckName,
+				checks.DeprecatedFunctionCheckName,
+				checks.SuggestRecordCheckName,
+				checks.NestedRateCheckName,
+				checks.HighChurnLabelCheckName,
+				checks.ThresholdVectorCheckName,
+				checks.QuantileCheckName,
+				checks.CountValuesCheckName,
+				checks.AlertForIntervalCheckName,
+				checks.LabelShadowCheckName,
+				checks.RecordingBoolCheckName,
+				checks.SubqueryCheckName,
+				checks.AlertsOrLabelsCheckName,
+				checks.AggregateEmptyCheckName,
+				checks.DoubleAggregateCheckName,
+				checks.HistogramCheckName,
+				checks.ComparisonLabelsCheckName,
+				checks.LabelCollisionCheckName,
+				checks.SelfReferenceCheckName,
+				checks.JoinLabelCheckName,
+				checks.CountConfusionCheckName,
+				checks.GroupLabelsCheckName,
+				checks.CrossFileCollisionCheckName,
 				checks.LabelCheckName + "(priority=~^(1|2|3|4|5)$:true)",
 			},
 		},
@@ -981,6 +1736,37 @@ rule {
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
 				checks.RegexpCheckName,
+				checks.CountAbsenceCheckName,
+				checks.DeadCodeCheckName,
+				checks.AlertsConstantValueCheckName,
+				checks.ConstantCheckName,
+				checks.LabelReplaceOverwriteCheckName,
+				checks.AbsentCheckName,
+				checks.RedundantParensCheckName,
+				checks.AtModifierCheckName,
+				checks.LabelLifecycleCheckName,
+				checks.DeprecatedFunctionCheckName,
+				checks.SuggestRecordCheckName,
+				checks.NestedRateCheckName,
+				checks.HighChurnLabelCheckName,
+				checks.ThresholdVectorCheckName,
+				checks.QuantileCheckName,
+				checks.CountValuesCheckName,
+				checks.AlertForIntervalCheckName,
+				checks.LabelShadowCheckName,
+				checks.RecordingBoolCheckName,
+				checks.SubqueryCheckName,
+				checks.AlertsOrLabelsCheckName,
+				checks.AggregateEmptyCheckName,
+				checks.DoubleAggregateCheckName,
+				checks.HistogramCheckName,
+				checks.ComparisonLabelsCheckName,
+				checks.LabelCollisionCheckName,
+				checks.SelfReferenceCheckName,
+				checks.JoinLabelCheckName,
+				checks.CountConfusionCheckName,
+				checks.GroupLabelsCheckName,
+				checks.CrossFileCollisionCheckName,
 			},
 		},
 		{
@@ -1015,6 +1801,37 @@ rule {
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
 				checks.RegexpCheckName,
+				checks.CountAbsenceCheckName,
+				checks.DeadCodeCheckName,
+				checks.AlertsConstantValueCheckName,
+				checks.ConstantCheckName,
+				checks.LabelReplaceOverwriteCheckName,
+				checks.AbsentCheckName,
+				checks.RedundantParensCheckName,
+				checks.AtModifierCheckName,
+				checks.LabelLifecycleCheckName,
+				checks.DeprecatedFunctionCheckName,
+				checks.SuggestRecordCheckName,
+				checks.NestedRateCheckName,
+				checks.HighChurnLabelCheckName,
+				checks.ThresholdVectorCheckName,
+				checks.QuantileCheckName,
+				checks.CountValuesCheckName,
+				checks.AlertForIntervalCheckName,
+				checks.LabelShadowCheckName,
+				checks.RecordingBoolCheckName,
+				checks.SubqueryCheckName,
+				checks.AlertsOrLabelsCheckName,
+				checks.AggregateEmptyCheckName,
+				checks.DoubleAggregateCheckName,
+				checks.HistogramCheckName,
+				checks.ComparisonLabelsCheckName,
+				checks.LabelCollisionCheckName,
+				checks.SelfReferenceCheckName,
+				checks.JoinLabelCheckName,
+				checks.CountConfusionCheckName,
+				checks.GroupLabelsCheckName,
+				checks.CrossFileCollisionCheckName,
 			},
 		},
 		{
@@ -1051,6 +1868,37 @@ rule {
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
 				checks.RegexpCheckName,
+				checks.CountAbsenceCheckName,
+				checks.DeadCodeCheckName,
+				checks.AlertsConstantValueCheckName,
+				checks.ConstantCheckName,
+				checks.LabelReplaceOverwriteCheckName,
+				checks.AbsentCheckName,
+				checks.RedundantParensCheckName,
+				checks.AtModifierCheckName,
+				checks.LabelLifecycleCheckName,
+				checks.DeprecatedFunctionCheckName,
+				checks.SuggestRecordCheckName,
+				checks.NestedRateCheckName,
+				checks.HighChurnLabelCheckName,
+				checks.ThresholdVectorCheckName,
+				checks.QuantileCheckName,
+				checks.CountValuesCheckName,
+				checks.AlertForIntervalCheckName,
+				checks.LabelShadowCheckName,
+				checks.RecordingBoolCheckName,
+				checks.SubqueryCheckName,
+				checks.AlertsOrLabelsCheckName,
+				checks.AggregateEmptyCheckName,
+				checks.DoubleAggregateCheckName,
+				checks.HistogramCheckName,
+				checks.ComparisonLabelsCheckName,
+				checks.LabelCollisionCheckName,
+				checks.SelfReferenceCheckName,
+				checks.JoinLabelCheckName,
+				checks.CountConfusionCheckName,
+				checks.GroupLabelsCheckName,
+				checks.CrossFileCollisionCheckName,
 				checks.LabelCheckName + "(priority=~^(1|2|3|4|5)$:true)",
 			},
 		},
@@ -1100,7 +1948,38 @@ prometheus "prom1" {
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
 				checks.RegexpCheckName,
+				checks.CountAbsenceCheckName,
+				checks.DeadCodeCheckName,
+				checks.AlertsConstantValueCheckName,
+				checks.ConstantCheckName,
+				checks.LabelReplaceOverwriteCheckName,
+				checks.AbsentCheckName,
+				checks.RedundantParensCheckName,
+				checks.AtModifierCheckName,
+				checks.LabelLifecycleCheckName,
+				checks.DeprecatedFunctionCheckName,
+				checks.SuggestRecordCheckName,
+				checks.NestedRateCheckName,
+				checks.HighChurnLabelCheckName,
+				checks.ThresholdVectorCheckName,
+				checks.QuantileCheckName,
+				checks.CountValuesCheckName,
+				checks.AlertForIntervalCheckName,
+				checks.LabelShadowCheckName,
+				checks.RecordingBoolCheckName,
+				checks.SubqueryCheckName,
+				checks.AlertsOrLabelsCheckName,
+				checks.AggregateEmptyCheckName,
+				checks.DoubleAggregateCheckName,
+				checks.HistogramCheckName,
+				checks.ComparisonLabelsCheckName,
+				checks.LabelCollisionCheckName,
+				checks.SelfReferenceCheckName,
+				checks.JoinLabelCheckName,
+				checks.CountConfusionCheckName,
+				checks.GroupLabelsCheckName,
 				checks.AlertsExternalLabelsCheckName + "(prom1)",
+				checks.CrossFileCollisionCheckName + "(prom1)",
 				checks.AlertsCheckName + "(prom1)",
 			},
 		},
@@ -1141,6 +2020,36 @@ prometheus "prom1" {
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
 				checks.RegexpCheckName,
+				checks.CountAbsenceCheckName,
+				checks.DeadCodeCheckName,
+				checks.AlertsConstantValueCheckName,
+				checks.ConstantCheckName,
+				checks.LabelReplaceOverwriteCheckName,
+				checks.AbsentCheckName,
+				checks.RedundantParensCheckName,
+				checks.AtModifierCheckName,
+				checks.LabelLifecycleCheckName,
+				checks.DeprecatedFunctionCheckName,
+				checks.SuggestRecordCheckName,
+				checks.NestedRateCheckName,
+				checks.HighChurnLabelCheckName,
+				checks.ThresholdVectorCheckName,
+				checks.QuantileCheckName,
+				checks.CountValuesCheckName,
+				checks.AlertForIntervalCheckName,
+				checks.LabelShadowCheckName,
+				checks.RecordingBoolCheckName,
+				checks.SubqueryCheckName,
+				checks.AlertsOrLabelsCheckName,
+				checks.AggregateEmptyCheckName,
+				checks.DoubleAggregateCheckName,
+				checks.HistogramCheckName,
+				checks.ComparisonLabelsCheckName,
+				checks.LabelCollisionCheckName,
+				checks.SelfReferenceCheckName,
+				checks.JoinLabelCheckName,
+				checks.CountConfusionCheckName,
+				checks.GroupLabelsCheckName,
 				checks.RateCheckName + "(prom1)",
 				checks.SeriesCheckName + "(prom1)",
 				checks.VectorMatchingCheckName + "(prom1)",
@@ -1150,6 +2059,7 @@ prometheus "prom1" {
 				checks.AlertsExternalLabelsCheckName + "(prom1)",
 				checks.CounterCheckName + "(prom1)",
 				checks.AlertsAbsentCheckName + "(prom1)",
+				checks.CrossFileCollisionCheckName + "(prom1)",
 				checks.AlertsCheckName + "(prom1)",
 			},
 		},
@@ -1299,6 +2209,37 @@ rule {
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
 				checks.RegexpCheckName,
+				checks.CountAbsenceCheckName,
+				checks.DeadCodeCheckName,
+				checks.AlertsConstantValueCheckName,
+				checks.ConstantCheckName,
+				checks.LabelReplaceOverwriteCheckName,
+				checks.AbsentCheckName,
+				checks.RedundantParensCheckName,
+				checks.AtModifierCheckName,
+				checks.LabelLifecycleCheckName,
+				checks.DeprecatedFunctionCheckName,
+				checks.SuggestRecordCheckName,
+				checks.NestedRateCheckName,
+				checks.HighChurnLabelCheckName,
+				checks.ThresholdVectorCheckName,
+				checks.QuantileCheckName,
+				checks.CountValuesCheckName,
+				checks.AlertForIntervalCheckName,
+				checks.LabelShadowCheckName,
+				checks.RecordingBoolCheckName,
+				checks.SubqueryCheckName,
+				checks.AlertsOrLabelsCheckName,
+				checks.AggregateEmptyCheckName,
+				checks.DoubleAggregateCheckName,
+				checks.HistogramCheckName,
+				checks.ComparisonLabelsCheckName,
+				checks.LabelCollisionCheckName,
+				checks.SelfReferenceCheckName,
+				checks.JoinLabelCheckName,
+				checks.CountConfusionCheckName,
+				checks.GroupLabelsCheckName,
+				checks.CrossFileCollisionCheckName,
 				checks.AnnotationCheckName + "(summary:true)",
 			},
 		},
@@ -1330,6 +2271,37 @@ rule {
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
 				checks.RegexpCheckName,
+				checks.CountAbsenceCheckName,
+				checks.DeadCodeCheckName,
+				checks.AlertsConstantValueCheckName,
+				checks.ConstantCheckName,
+				checks.LabelReplaceOverwriteCheckName,
+				checks.AbsentCheckName,
+				checks.RedundantParensCheckName,
+				checks.AtModifierCheckName,
+				checks.LabelLifecycleCheckName,
+				checks.DeprecatedFunctionCheckName,
+				checks.SuggestRecordCheckName,
+				checks.NestedRateCheckName,
+				checks.HighChurnLabelCheckName,
+				checks.ThresholdVectorCheckName,
+				checks.QuantileCheckName,
+				checks.CountValuesCheckName,
+				checks.AlertForIntervalCheckName,
+				checks.LabelShadowCheckName,
+				checks.RecordingBoolCheckName,
+				checks.SubqueryCheckName,
+				checks.AlertsOrLabelsCheckName,
+				checks.AggregateEmptyCheckName,
+				checks.DoubleAggregateCheckName,
+				checks.HistogramCheckName,
+				checks.ComparisonLabelsCheckName,
+				checks.LabelCollisionCheckName,
+				checks.SelfReferenceCheckName,
+				checks.JoinLabelCheckName,
+				checks.CountConfusionCheckName,
+				checks.GroupLabelsCheckName,
+				checks.CrossFileCollisionCheckName,
 			},
 		},
 		{
@@ -1359,6 +2331,37 @@ rule {
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
 				checks.RegexpCheckName,
+				checks.CountAbsenceCheckName,
+				checks.DeadCodeCheckName,
+				checks.AlertsConstantValueCheckName,
+				checks.ConstantCheckName,
+				checks.LabelReplaceOverwriteCheckName,
+				checks.AbsentCheckName,
+				checks.RedundantParensCheckName,
+				checks.AtModifierCheckName,
+				checks.LabelLifecycleCheckName,
+				checks.DeprecatedFunctionCheckName,
+				checks.SuggestRecordCheckName,
+				checks.NestedRateCheckName,
+				checks.HighChurnLabelCheckName,
+				checks.ThresholdVectorCheckName,
+				checks.QuantileCheckName,
+				checks.CountValuesCheckName,
+				checks.AlertForIntervalCheckName,
+				checks.LabelShadowCheckName,
+				checks.RecordingBoolCheckName,
+				checks.SubqueryCheckName,
+				checks.AlertsOrLabelsCheckName,
+				checks.AggregateEmptyCheckName,
+				checks.DoubleAggregateCheckName,
+				checks.HistogramCheckName,
+				checks.ComparisonLabelsCheckName,
+				checks.LabelCollisionCheckName,
+				checks.SelfReferenceCheckName,
+				checks.JoinLabelCheckName,
+				checks.CountConfusionCheckName,
+				checks.GroupLabelsCheckName,
+				checks.CrossFileCollisionCheckName,
 				checks.AnnotationCheckName + "(summary:true)",
 			},
 		},
@@ -1389,6 +2392,37 @@ rule {
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
 				checks.RegexpCheckName,
+				checks.CountAbsenceCheckName,
+				checks.DeadCodeCheckName,
+				checks.AlertsConstantValueCheckName,
+				checks.ConstantCheckName,
+				checks.LabelReplaceOverwriteCheckName,
+				checks.AbsentCheckName,
+				checks.RedundantParensCheckName,
+				checks.AtModifierCheckName,
+				checks.LabelLifecycleCheckName,
+				checks.DeprecatedFunctionCheckName,
+				checks.SuggestRecordCheckName,
+				checks.NestedRateCheckName,
+				checks.HighChurnLabelCheckName,
+				checks.ThresholdVectorCheckName,
+				checks.QuantileCheckName,
+				checks.CountValuesCheckName,
+				checks.AlertForIntervalCheckName,
+				checks.LabelShadowCheckName,
+				checks.RecordingBoolCheckName,
+				checks.SubqueryCheckName,
+				checks.AlertsOrLabelsCheckName,
+				checks.AggregateEmptyCheckName,
+				checks.DoubleAggregateCheckName,
+				checks.HistogramCheckName,
+				checks.ComparisonLabelsCheckName,
+				checks.LabelCollisionCheckName,
+				checks.SelfReferenceCheckName,
+				checks.JoinLabelCheckName,
+				checks.CountConfusionCheckName,
+				checks.GroupLabelsCheckName,
+				checks.CrossFileCollisionCheckName,
 			},
 		},
 		{
@@ -1418,6 +2452,37 @@ rule {
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
 				checks.RegexpCheckName,
+				checks.CountAbsenceCheckName,
+				checks.DeadCodeCheckName,
+				checks.AlertsConstantValueCheckName,
+				checks.ConstantCheckName,
+				checks.LabelReplaceOverwriteCheckName,
+				checks.AbsentCheckName,
+				checks.RedundantParensCheckName,
+				checks.AtModifierCheckName,
+				checks.LabelLifecycleCheckName,
+				checks.DeprecatedFunctionCheckName,
+				checks.SuggestRecordCheckName,
+				checks.NestedRateCheckName,
+				checks.HighChurnLabelCheckName,
+				checks.ThresholdVectorCheckName,
+				checks.QuantileCheckName,
+				checks.CountValuesCheckName,
+				checks.AlertForIntervalCheckName,
+				checks.LabelShadowCheckName,
+				checks.RecordingBoolCheckName,
+				checks.SubqueryCheckName,
+				checks.AlertsOrLabelsCheckName,
+				checks.AggregateEmptyCheckName,
+				checks.DoubleAggregateCheckName,
+				checks.HistogramCheckName,
+				checks.ComparisonLabelsCheckName,
+				checks.LabelCollisionCheckName,
+				checks.SelfReferenceCheckName,
+				checks.JoinLabelCheckName,
+				checks.CountConfusionCheckName,
+				checks.GroupLabelsCheckName,
+				checks.CrossFileCollisionCheckName,
 			},
 		},
 		{
@@ -1447,6 +2512,37 @@ rule {
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
 				checks.RegexpCheckName,
+				checks.CountAbsenceCheckName,
+				checks.DeadCodeCheckName,
+				checks.AlertsConstantValueCheckName,
+				checks.ConstantCheckName,
+				checks.LabelReplaceOverwriteCheckName,
+				checks.AbsentCheckName,
+				checks.RedundantParensCheckName,
+				checks.AtModifierCheckName,
+				checks.LabelLifecycleCheckName,
+				checks.DeprecatedFunctionCheckName,
+				checks.SuggestRecordCheckName,
+				checks.NestedRateCheckName,
+				checks.HighChurnLabelCheckName,
+				checks.ThresholdVectorCheckName,
+				checks.QuantileCheckName,
+				checks.CountValuesCheckName,
+				checks.AlertForIntervalCheckName,
+				checks.LabelShadowCheckName,
+				checks.RecordingBoolCheckName,
+				checks.SubqueryCheckName,
+				checks.AlertsOrLabelsCheckName,
+				checks.AggregateEmptyCheckName,
+				checks.DoubleAggregateCheckName,
+				checks.HistogramCheckName,
+				checks.ComparisonLabelsCheckName,
+				checks.LabelCollisionCheckName,
+				checks.SelfReferenceCheckName,
+				checks.JoinLabelCheckName,
+				checks.CountConfusionCheckName,
+				checks.GroupLabelsCheckName,
+				checks.CrossFileCollisionCheckName,
 			},
 		},
 		{
@@ -1476,6 +2572,37 @@ rule {
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
 				checks.RegexpCheckName,
+				checks.CountAbsenceCheckName,
+				checks.DeadCodeCheckName,
+				checks.AlertsConstantValueCheckName,
+				checks.ConstantCheckName,
+				checks.LabelReplaceOverwriteCheckName,
+				checks.AbsentCheckName,
+				checks.RedundantParensCheckName,
+				checks.AtModifierCheckName,
+				checks.LabelLifecycleCheckName,
+				checks.DeprecatedFunctionCheckName,
+				checks.SuggestRecordCheckName,
+				checks.NestedRateCheckName,
+				checks.HighChurnLabelCheckName,
+				checks.ThresholdVectorCheckName,
+				checks.QuantileCheckName,
+				checks.CountValuesCheckName,
+				checks.AlertForIntervalCheckName,
+				checks.LabelShadowCheckName,
+				checks.RecordingBoolCheckName,
+				checks.SubqueryCheckName,
+				checks.AlertsOrLabelsCheckName,
+				checks.AggregateEmptyCheckName,
+				checks.DoubleAggregateCheckName,
+				checks.HistogramCheckName,
+				checks.ComparisonLabelsCheckName,
+				checks.LabelCollisionCheckName,
+				checks.SelfReferenceCheckName,
+				checks.JoinLabelCheckName,
+				checks.CountConfusionCheckName,
+				checks.GroupLabelsCheckName,
+				checks.CrossFileCollisionCheckName,
 				checks.AnnotationCheckName + "(summary:true)",
 			},
 		},
@@ -1507,6 +2634,37 @@ rule {
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
 				checks.RegexpCheckName,
+				checks.CountAbsenceCheckName,
+				checks.DeadCodeCheckName,
+				checks.AlertsConstantValueCheckName,
+				checks.ConstantCheckName,
+				checks.LabelReplaceOverwriteCheckName,
+				checks.AbsentCheckName,
+				checks.RedundantParensCheckName,
+				checks.AtModifierCheckName,
+				checks.LabelLifecycleCheckName,
+				checks.DeprecatedFunctionCheckName,
+				checks.SuggestRecordCheckName,
+				checks.NestedRateCheckName,
+				checks.HighChurnLabelCheckName,
+				checks.ThresholdVectorCheckName,
+				checks.QuantileCheckName,
+				checks.CountValuesCheckName,
+				checks.AlertForIntervalCheckName,
+				checks.LabelShadowCheckName,
+				checks.RecordingBoolCheckName,
+				checks.SubqueryCheckName,
+				checks.AlertsOrLabelsCheckName,
+				checks.AggregateEmptyCheckName,
+				checks.DoubleAggregateCheckName,
+				checks.HistogramCheckName,
+				checks.ComparisonLabelsCheckName,
+				checks.LabelCollisionCheckName,
+				checks.SelfReferenceCheckName,
+				checks.JoinLabelCheckName,
+				checks.CountConfusionCheckName,
+				checks.GroupLabelsCheckName,
+				checks.CrossFileCollisionCheckName,
 			},
 		},
 		{
@@ -1536,6 +2694,37 @@ rule {
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
 				checks.RegexpCheckName,
+				checks.CountAbsenceCheckName,
+				checks.DeadCodeCheckName,
+				checks.AlertsConstantValueCheckName,
+				checks.ConstantCheckName,
+				checks.LabelReplaceOverwriteCheckName,
+				checks.AbsentCheckName,
+				checks.RedundantParensCheckName,
+				checks.AtModifierCheckName,
+				checks.LabelLifecycleCheckName,
+				checks.DeprecatedFunctionCheckName,
+				checks.SuggestRecordCheckName,
+				checks.NestedRateCheckName,
+				checks.HighChurnLabelCheckName,
+				checks.ThresholdVectorCheckName,
+				checks.QuantileCheckName,
+				checks.CountValuesCheckName,
+				checks.AlertForIntervalCheckName,
+				checks.LabelShadowCheckName,
+				checks.RecordingBoolCheckName,
+				checks.SubqueryCheckName,
+				checks.AlertsOrLabelsCheckName,
+				checks.AggregateEmptyCheckName,
+				checks.DoubleAggregateCheckName,
+				checks.HistogramCheckName,
+				checks.ComparisonLabelsCheckName,
+				checks.LabelCollisionCheckName,
+				checks.SelfReferenceCheckName,
+				checks.JoinLabelCheckName,
+				checks.CountConfusionCheckName,
+				checks.GroupLabelsCheckName,
+				checks.CrossFileCollisionCheckName,
 				checks.AnnotationCheckName + "(summary:true)",
 			},
 		},
@@ -1569,6 +2758,37 @@ rule {
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
 				checks.RegexpCheckName,
+				checks.CountAbsenceCheckName,
+				checks.DeadCodeCheckName,
+				checks.AlertsConstantValueCheckName,
+				checks.ConstantCheckName,
+				checks.LabelReplaceOverwriteCheckName,
+				checks.AbsentCheckName,
+				checks.RedundantParensCheckName,
+				checks.AtModifierCheckName,
+				checks.LabelLifecycleCheckName,
+				checks.DeprecatedFunctionCheckName,
+				checks.SuggestRecordCheckName,
+				checks.NestedRateCheckName,
+				checks.HighChurnLabelCheckName,
+				checks.ThresholdVectorCheckName,
+				checks.QuantileCheckName,
+				checks.CountValuesCheckName,
+				checks.AlertForIntervalCheckName,
+				checks.LabelShadowCheckName,
+				checks.RecordingBoolCheckName,
+				checks.SubqueryCheckName,
+				checks.AlertsOrLabelsCheckName,
+				checks.AggregateEmptyCheckName,
+				checks.DoubleAggregateCheckName,
+				checks.HistogramCheckName,
+				checks.ComparisonLabelsCheckName,
+				checks.LabelCollisionCheckName,
+				checks.SelfReferenceCheckName,
+				checks.JoinLabelCheckName,
+				checks.CountConfusionCheckName,
+				checks.GroupLabelsCheckName,
+				checks.CrossFileCollisionCheckName,
 				checks.RuleLinkCheckName + "(^https?://(.+)$)",
 			},
 		},
@@ -1594,6 +2814,37 @@ rule {
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
 				checks.RegexpCheckName,
+				checks.CountAbsenceCheckName,
+				checks.DeadCodeCheckName,
+				checks.AlertsConstantValueCheckName,
+				checks.ConstantCheckName,
+				checks.LabelReplaceOverwriteCheckName,
+				checks.AbsentCheckName,
+				checks.RedundantParensCheckName,
+				checks.AtModifierCheckName,
+				checks.LabelLifecycleCheckName,
+				checks.DeprecatedFunctionCheckName,
+				checks.SuggestRecordCheckName,
+				checks.NestedRateCheckName,
+				checks.HighChurnLabelCheckName,
+				checks.ThresholdVectorCheckName,
+				checks.QuantileCheckName,
+				checks.CountValuesCheckName,
+				checks.AlertForIntervalCheckName,
+				checks.LabelShadowCheckName,
+				checks.RecordingBoolCheckName,
+				checks.SubqueryCheckName,
+				checks.AlertsOrLabelsCheckName,
+				checks.AggregateEmptyCheckName,
+				checks.DoubleAggregateCheckName,
+				checks.HistogramCheckName,
+				checks.ComparisonLabelsCheckName,
+				checks.LabelCollisionCheckName,
+				checks.SelfReferenceCheckName,
+				checks.JoinLabelCheckName,
+				checks.CountConfusionCheckName,
+				checks.GroupLabelsCheckName,
+				checks.CrossFileCollisionCheckName,
 				checks.RuleNameCheckName + "(^total:.+$)",
 			},
 		},
@@ -1634,6 +2885,38 @@ checks {
 				checks.ComparisonCheckName,
 				checks.FragileCheckName,
 				checks.RegexpCheckName,
+				checks.CountAbsenceCheckName,
+				checks.DeadCodeCheckName,
+				checks.AlertsConstantValueCheckName,
+				checks.ConstantCheckName,
+				checks.LabelReplaceOverwriteCheckName,
+				checks.AbsentCheckName,
+				checks.RedundantParensCheckName,
+				checks.AtModifierCheckName,
+				checks.LabelLifecycleCheckName,
+				checks.DeprecatedFunctionCheckName,
+				checks.SuggestRecordCheckName,
+				checks.NestedRateCheckName,
+				checks.HighChurnLabelCheckName,
+				checks.ThresholdVectorCheckName,
+				checks.QuantileCheckName,
+				checks.CountValuesCheckName,
+				checks.AlertForIntervalCheckName,
+				checks.LabelShadowCheckName,
+				checks.RecordingBoolCheckName,
+				checks.SubqueryCheckName,
+				checks.AlertsOrLabelsCheckName,
+				checks.AggregateEmptyCheckName,
+				checks.DoubleAggregateCheckName,
+				checks.HistogramCheckName,
+				checks.ComparisonLabelsCheckName,
+				checks.LabelCollisionCheckName,
+				checks.SelfReferenceCheckName,
+				checks.JoinLabelCheckName,
+				checks.CountConfusionCheckName,
+				checks.GroupLabelsCheckName,
+				checks.CrossFileCollisionCheckName + "(prom1)",
+				checks.CrossFileCollisionCheckName + "(prom2)",
 			},
 		},
 		{
@@ -1677,11 +2960,43 @@ checks {
 				checks.AlertForCheckName,
 				checks.ComparisonCheckName,
 				checks.FragileCheckName,
+				checks.CountAbsenceCheckName,
+				checks.DeadCodeCheckName,
+				checks.AlertsConstantValueCheckName,
+				checks.ConstantCheckName,
+				checks.LabelReplaceOverwriteCheckName,
+				checks.AbsentCheckName,
+				checks.RedundantParensCheckName,
+				checks.AtModifierCheckName,
+				checks.LabelLifecycleCheckName,
+				checks.DeprecatedFunctionCheckName,
+				checks.SuggestRecordCheckName,
+				checks.NestedRateCheckName,
+				checks.HighChurnLabelCheckName,
+				checks.ThresholdVectorCheckName,
+				checks.QuantileCheckName,
+				checks.CountValuesCheckName,
+				checks.AlertForIntervalCheckName,
+				checks.LabelShadowCheckName,
+				checks.RecordingBoolCheckName,
+				checks.SubqueryCheckName,
+				checks.AlertsOrLabelsCheckName,
+				checks.AggregateEmptyCheckName,
+				checks.DoubleAggregateCheckName,
+				checks.HistogramCheckName,
+				checks.ComparisonLabelsCheckName,
+				checks.LabelCollisionCheckName,
+				checks.SelfReferenceCheckName,
+				checks.JoinLabelCheckName,
+				checks.CountConfusionCheckName,
+				checks.GroupLabelsCheckName,
 				checks.LabelsConflictCheckName + "(prom1)",
 				checks.AlertsExternalLabelsCheckName + "(prom1)",
+				checks.CrossFileCollisionCheckName + "(prom1)",
 				checks.SeriesCheckName + "(prom2)",
 				checks.LabelsConflictCheckName + "(prom2)",
 				checks.AlertsExternalLabelsCheckName + "(prom2)",
+				checks.CrossFileCollisionCheckName + "(prom2)",
 			},
 		},
 		{
@@ -1721,6 +3036,36 @@ checks {
 				checks.AlertForCheckName,
 				checks.ComparisonCheckName,
 				checks.FragileCheckName,
+				checks.CountAbsenceCheckName,
+				checks.DeadCodeCheckName,
+				checks.AlertsConstantValueCheckName,
+				checks.ConstantCheckName,
+				checks.LabelReplaceOverwriteCheckName,
+				checks.AbsentCheckName,
+				checks.RedundantParensCheckName,
+				checks.AtModifierCheckName,
+				checks.LabelLifecycleCheckName,
+				checks.DeprecatedFunctionCheckName,
+				checks.SuggestRecordCheckName,
+				checks.NestedRateCheckName,
+				checks.HighChurnLabelCheckName,
+				checks.ThresholdVectorCheckName,
+				checks.QuantileCheckName,
+				checks.CountValuesCheckName,
+				checks.AlertForIntervalCheckName,
+				checks.LabelShadowCheckName,
+				checks.RecordingBoolCheckName,
+				checks.SubqueryCheckName,
+				checks.AlertsOrLabelsCheckName,
+				checks.AggregateEmptyCheckName,
+				checks.DoubleAggregateCheckName,
+				checks.HistogramCheckName,
+				checks.ComparisonLabelsCheckName,
+				checks.LabelCollisionCheckName,
+				checks.SelfReferenceCheckName,
+				checks.JoinLabelCheckName,
+				checks.CountConfusionCheckName,
+				checks.GroupLabelsCheckName,
 				checks.SeriesCheckName + "(prom1)",
 				checks.VectorMatchingCheckName + "(prom1)",
 				checks.RangeQueryCheckName + "(prom1)",
@@ -1729,6 +3074,7 @@ checks {
 				checks.AlertsExternalLabelsCheckName + "(prom1)",
 				checks.CounterCheckName + "(prom1)",
 				checks.AlertsAbsentCheckName + "(prom1)",
+				checks.CrossFileCollisionCheckName + "(prom1)",
 				checks.SeriesCheckName + "(prom2)",
 				checks.VectorMatchingCheckName + "(prom2)",
 				checks.RangeQueryCheckName + "(prom2)",
@@ -1737,6 +3083,7 @@ checks {
 				checks.AlertsExternalLabelsCheckName + "(prom2)",
 				checks.CounterCheckName + "(prom2)",
 				checks.AlertsAbsentCheckName + "(prom2)",
+				checks.CrossFileCollisionCheckName + "(prom2)",
 			},
 		},
 		{
@@ -1783,7 +3130,7 @@ prometheus "prom3" {
 				checks.ComparisonCheckName,
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
-				checks.RegexpCheckName, checks.RateCheckName + "(prom2)",
+				checks.RegexpCheckName, checks.CountAbsenceCheckName, checks.DeadCodeCheckName, checks.AlertsConstantValueCheckName, checks.ConstantCheckName, checks.LabelReplaceOverwriteCheckName, checks.AbsentCheckName, checks.RedundantParensCheckName, checks.AtModifierCheckName, checks.LabelLifecycleCheckName, checks.DeprecatedFunctionCheckName, checks.SuggestRecordCheckName, checks.NestedRateCheckName, checks.HighChurnLabelCheckName, checks.ThresholdVectorCheckName, checks.QuantileCheckName, checks.CountValuesCheckName, checks.AlertForIntervalCheckName, checks.LabelShadowCheckName, checks.RecordingBoolCheckName, checks.SubqueryCheckName, checks.AlertsOrLabelsCheckName, checks.AggregateEmptyCheckName, checks.DoubleAggregateCheckName, checks.HistogramCheckName, checks.ComparisonLabelsCheckName, checks.LabelCollisionCheckName, checks.SelfReferenceCheckName, checks.JoinLabelCheckName, checks.CountConfusionCheckName, checks.GroupLabelsCheckName, checks.CrossFileCollisionCheckName + "(prom1)", checks.RateCheckName + "(prom2)",
 				checks.SeriesCheckName + "(prom2)",
 				checks.VectorMatchingCheckName + "(prom2)",
 				checks.RangeQueryCheckName + "(prom2)",
@@ -1792,6 +3139,7 @@ prometheus "prom3" {
 				checks.AlertsExternalLabelsCheckName + "(prom2)",
 				checks.CounterCheckName + "(prom2)",
 				checks.AlertsAbsentCheckName + "(prom2)",
+				checks.CrossFileCollisionCheckName + "(prom2)",
 				checks.RateCheckName + "(prom3)",
 				checks.SeriesCheckName + "(prom3)",
 				checks.VectorMatchingCheckName + "(prom3)",
@@ -1801,6 +3149,7 @@ prometheus "prom3" {
 				checks.AlertsExternalLabelsCheckName + "(prom3)",
 				checks.CounterCheckName + "(prom3)",
 				checks.AlertsAbsentCheckName + "(prom3)",
+				checks.CrossFileCollisionCheckName + "(prom3)",
 			},
 		},
 		{
@@ -1848,6 +3197,37 @@ prometheus "prom3" {
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
 				checks.RegexpCheckName,
+				checks.CountAbsenceCheckName,
+				checks.DeadCodeCheckName,
+				checks.AlertsConstantValueCheckName,
+				checks.ConstantCheckName,
+				checks.LabelReplaceOverwriteCheckName,
+				checks.AbsentCheckName,
+				checks.RedundantParensCheckName,
+				checks.AtModifierCheckName,
+				checks.LabelLifecycleCheckName,
+				checks.DeprecatedFunctionCheckName,
+				checks.SuggestRecordCheckName,
+				checks.NestedRateCheckName,
+				checks.HighChurnLabelCheckName,
+				checks.ThresholdVectorCheckName,
+				checks.QuantileCheckName,
+				checks.CountValuesCheckName,
+				checks.AlertForIntervalCheckName,
+				checks.LabelShadowCheckName,
+				checks.RecordingBoolCheckName,
+				checks.SubqueryCheckName,
+				checks.AlertsOrLabelsCheckName,
+				checks.AggregateEmptyCheckName,
+				checks.DoubleAggregateCheckName,
+				checks.HistogramCheckName,
+				checks.ComparisonLabelsCheckName,
+				checks.LabelCollisionCheckName,
+				checks.SelfReferenceCheckName,
+				checks.JoinLabelCheckName,
+				checks.CountConfusionCheckName,
+				checks.GroupLabelsCheckName,
+				checks.CrossFileCollisionCheckName + "(prom1)",
 				checks.RateCheckName + "(prom2)",
 				checks.SeriesCheckName + "(prom2)",
 				checks.VectorMatchingCheckName + "(prom2)",
@@ -1857,6 +3237,7 @@ prometheus "prom3" {
 				checks.AlertsExternalLabelsCheckName + "(prom2)",
 				checks.CounterCheckName + "(prom2)",
 				checks.AlertsAbsentCheckName + "(prom2)",
+				checks.CrossFileCollisionCheckName + "(prom2)",
 				checks.RateCheckName + "(prom3)",
 				checks.SeriesCheckName + "(prom3)",
 				checks.VectorMatchingCheckName + "(prom3)",
@@ -1866,6 +3247,7 @@ prometheus "prom3" {
 				checks.AlertsExternalLabelsCheckName + "(prom3)",
 				checks.CounterCheckName + "(prom3)",
 				checks.AlertsAbsentCheckName + "(prom3)",
+				checks.CrossFileCollisionCheckName + "(prom3)",
 			},
 		},
 		{
@@ -1898,7 +3280,7 @@ rule {
 				checks.ComparisonCheckName,
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
-				checks.RegexpCheckName, checks.RateCheckName + "(prom)",
+				checks.RegexpCheckName, checks.CountAbsenceCheckName, checks.DeadCodeCheckName, checks.AlertsConstantValueCheckName, checks.ConstantCheckName, checks.LabelReplaceOverwriteCheckName, checks.AbsentCheckName, checks.RedundantParensCheckName, checks.AtModifierCheckName, checks.LabelLifecycleCheckName, checks.DeprecatedFunctionCheckName, checks.SuggestRecordCheckName, checks.NestedRateCheckName, checks.HighChurnLabelCheckName, checks.ThresholdVectorCheckName, checks.QuantileCheckName, checks.CountValuesCheckName, checks.AlertForIntervalCheckName, checks.LabelShadowCheckName, checks.RecordingBoolCheckName, checks.SubqueryCheckName, checks.AlertsOrLabelsCheckName, checks.AggregateEmptyCheckName, checks.DoubleAggregateCheckName, checks.HistogramCheckName, checks.ComparisonLabelsCheckName, checks.LabelCollisionCheckName, checks.SelfReferenceCheckName, checks.JoinLabelCheckName, checks.CountConfusionCheckName, checks.GroupLabelsCheckName, checks.RateCheckName + "(prom)",
 				checks.SeriesCheckName + "(prom)",
 				checks.VectorMatchingCheckName + "(prom)",
 				checks.RangeQueryCheckName + "(prom)",
@@ -1907,6 +3289,7 @@ rule {
 				checks.AlertsExternalLabelsCheckName + "(prom)",
 				checks.CounterCheckName + "(prom)",
 				checks.AlertsAbsentCheckName + "(prom)",
+				checks.CrossFileCollisionCheckName + "(prom)",
 				checks.AlertsCheckName + "(prom)",
 			},
 		},
@@ -1943,7 +3326,7 @@ rule {
 				checks.ComparisonCheckName,
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
-				checks.RegexpCheckName, checks.RateCheckName + "(prom)",
+				checks.RegexpCheckName, checks.CountAbsenceCheckName, checks.DeadCodeCheckName, checks.AlertsConstantValueCheckName, checks.ConstantCheckName, checks.LabelReplaceOverwriteCheckName, checks.AbsentCheckName, checks.RedundantParensCheckName, checks.AtModifierCheckName, checks.LabelLifecycleCheckName, checks.DeprecatedFunctionCheckName, checks.SuggestRecordCheckName, checks.NestedRateCheckName, checks.HighChurnLabelCheckName, checks.ThresholdVectorCheckName, checks.QuantileCheckName, checks.CountValuesCheckName, checks.AlertForIntervalCheckName, checks.LabelShadowCheckName, checks.RecordingBoolCheckName, checks.SubqueryCheckName, checks.AlertsOrLabelsCheckName, checks.AggregateEmptyCheckName, checks.DoubleAggregateCheckName, checks.HistogramCheckName, checks.ComparisonLabelsCheckName, checks.LabelCollisionCheckName, checks.SelfReferenceCheckName, checks.JoinLabelCheckName, checks.CountConfusionCheckName, checks.GroupLabelsCheckName, checks.RateCheckName + "(prom)",
 				checks.SeriesCheckName + "(prom)",
 				checks.VectorMatchingCheckName + "(prom)",
 				checks.RangeQueryCheckName + "(prom)",
@@ -1952,6 +3335,7 @@ rule {
 				checks.AlertsExternalLabelsCheckName + "(prom)",
 				checks.CounterCheckName + "(prom)",
 				checks.AlertsAbsentCheckName + "(prom)",
+				checks.CrossFileCollisionCheckName + "(prom)",
 				checks.AlertsCheckName + "(prom)",
 			},
 		},
@@ -1978,6 +3362,37 @@ rule {
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
 				checks.RegexpCheckName,
+				checks.CountAbsenceCheckName,
+				checks.DeadCodeCheckName,
+				checks.AlertsConstantValueCheckName,
+				checks.ConstantCheckName,
+				checks.LabelReplaceOverwriteCheckName,
+				checks.AbsentCheckName,
+				checks.RedundantParensCheckName,
+				checks.AtModifierCheckName,
+				checks.LabelLifecycleCheckName,
+				checks.DeprecatedFunctionCheckName,
+				checks.SuggestRecordCheckName,
+				checks.NestedRateCheckName,
+				checks.HighChurnLabelCheckName,
+				checks.ThresholdVectorCheckName,
+				checks.QuantileCheckName,
+				checks.CountValuesCheckName,
+				checks.AlertForIntervalCheckName,
+				checks.LabelShadowCheckName,
+				checks.RecordingBoolCheckName,
+				checks.SubqueryCheckName,
+				checks.AlertsOrLabelsCheckName,
+				checks.AggregateEmptyCheckName,
+				checks.DoubleAggregateCheckName,
+				checks.HistogramCheckName,
+				checks.ComparisonLabelsCheckName,
+				checks.LabelCollisionCheckName,
+				checks.SelfReferenceCheckName,
+				checks.JoinLabelCheckName,
+				checks.CountConfusionCheckName,
+				checks.GroupLabelsCheckName,
+				checks.CrossFileCollisionCheckName,
 				checks.RangeQueryCheckName + "(1h)",
 			},
 		},
@@ -2020,6 +3435,37 @@ rule {
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
 				checks.RegexpCheckName,
+				checks.CountAbsenceCheckName,
+				checks.DeadCodeCheckName,
+				checks.AlertsConstantValueCheckName,
+				checks.ConstantCheckName,
+				checks.LabelReplaceOverwriteCheckName,
+				checks.AbsentCheckName,
+				checks.RedundantParensCheckName,
+				checks.AtModifierCheckName,
+				checks.LabelLifecycleCheckName,
+				checks.DeprecatedFunctionCheckName,
+				checks.SuggestRecordCheckName,
+				checks.NestedRateCheckName,
+				checks.HighChurnLabelCheckName,
+				checks.ThresholdVectorCheckName,
+				checks.QuantileCheckName,
+				checks.CountValuesCheckName,
+				checks.AlertForIntervalCheckName,
+				checks.LabelShadowCheckName,
+				checks.RecordingBoolCheckName,
+				checks.SubqueryCheckName,
+				checks.AlertsOrLabelsCheckName,
+				checks.AggregateEmptyCheckName,
+				checks.DoubleAggregateCheckName,
+				checks.HistogramCheckName,
+				checks.ComparisonLabelsCheckName,
+				checks.LabelCollisionCheckName,
+				checks.SelfReferenceCheckName,
+				checks.JoinLabelCheckName,
+				checks.CountConfusionCheckName,
+				checks.GroupLabelsCheckName,
+				checks.CrossFileCollisionCheckName,
 			},
 		},
 		{
@@ -2061,6 +3507,37 @@ rule {
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
 				checks.RegexpCheckName,
+				checks.CountAbsenceCheckName,
+				checks.DeadCodeCheckName,
+				checks.AlertsConstantValueCheckName,
+				checks.ConstantCheckName,
+				checks.LabelReplaceOverwriteCheckName,
+				checks.AbsentCheckName,
+				checks.RedundantParensCheckName,
+				checks.AtModifierCheckName,
+				checks.LabelLifecycleCheckName,
+				checks.DeprecatedFunctionCheckName,
+				checks.SuggestRecordCheckName,
+				checks.NestedRateCheckName,
+				checks.HighChurnLabelCheckName,
+				checks.ThresholdVectorCheckName,
+				checks.QuantileCheckName,
+				checks.CountValuesCheckName,
+				checks.AlertForIntervalCheckName,
+				checks.LabelShadowCheckName,
+				checks.RecordingBoolCheckName,
+				checks.SubqueryCheckName,
+				checks.AlertsOrLabelsCheckName,
+				checks.AggregateEmptyCheckName,
+				checks.DoubleAggregateCheckName,
+				checks.HistogramCheckName,
+				checks.ComparisonLabelsCheckName,
+				checks.LabelCollisionCheckName,
+				checks.SelfReferenceCheckName,
+				checks.JoinLabelCheckName,
+				checks.CountConfusionCheckName,
+				checks.GroupLabelsCheckName,
+				checks.CrossFileCollisionCheckName,
 				checks.AggregationCheckName + "(job:true)",
 				checks.AggregationCheckName + "(instance:false)",
 				checks.AggregationCheckName + "(rack:false)",
@@ -2105,9 +3582,40 @@ rule {
 				checks.ComparisonCheckName,
 				checks.FragileCheckName,
 				checks.RegexpCheckName,
+				checks.CountAbsenceCheckName,
+				checks.DeadCodeCheckName,
+				checks.AlertsConstantValueCheckName,
+				checks.ConstantCheckName,
+				checks.LabelReplaceOverwriteCheckName,
+				checks.AbsentCheckName,
+				checks.RedundantParensCheckName,
+				checks.AtModifierCheckName,
+				checks.LabelLifecycleCheckName,
+				checks.DeprecatedFunctionCheckName,
+				checks.SuggestRecordCheckName,
+				checks.NestedRateCheckName,
+				checks.HighChurnLabelCheckName,
+				checks.ThresholdVectorCheckName,
+				checks.QuantileCheckName,
+				checks.CountValuesCheckName,
+				checks.AlertForIntervalCheckName,
+				checks.LabelShadowCheckName,
+				checks.RecordingBoolCheckName,
+				checks.SubqueryCheckName,
+				checks.AlertsOrLabelsCheckName,
+				checks.AggregateEmptyCheckName,
+				checks.DoubleAggregateCheckName,
+				checks.HistogramCheckName,
+				checks.ComparisonLabelsCheckName,
+				checks.LabelCollisionCheckName,
+				checks.SelfReferenceCheckName,
+				checks.JoinLabelCheckName,
+				checks.CountConfusionCheckName,
+				checks.GroupLabelsCheckName,
 				checks.SeriesCheckName + "(prom)",
 				checks.LabelsConflictCheckName + "(prom)",
 				checks.CounterCheckName + "(prom)",
+				checks.CrossFileCollisionCheckName + "(prom)",
 			},
 		},
 		{
@@ -2138,6 +3646,37 @@ rule {
 				checks.ComparisonCheckName,
 				checks.FragileCheckName,
 				checks.RegexpCheckName,
+				checks.CountAbsenceCheckName,
+				checks.DeadCodeCheckName,
+				checks.AlertsConstantValueCheckName,
+				checks.ConstantCheckName,
+				checks.LabelReplaceOverwriteCheckName,
+				checks.AbsentCheckName,
+				checks.RedundantParensCheckName,
+				checks.AtModifierCheckName,
+				checks.LabelLifecycleCheckName,
+				checks.DeprecatedFunctionCheckName,
+				checks.SuggestRecordCheckName,
+				checks.NestedRateCheckName,
+				checks.HighChurnLabelCheckName,
+				checks.ThresholdVectorCheckName,
+				checks.QuantileCheckName,
+				checks.CountValuesCheckName,
+				checks.AlertForIntervalCheckName,
+				checks.LabelShadowCheckName,
+				checks.RecordingBoolCheckName,
+				checks.SubqueryCheckName,
+				checks.AlertsOrLabelsCheckName,
+				checks.AggregateEmptyCheckName,
+				checks.DoubleAggregateCheckName,
+				checks.HistogramCheckName,
+				checks.ComparisonLabelsCheckName,
+				checks.LabelCollisionCheckName,
+				checks.SelfReferenceCheckName,
+				checks.JoinLabelCheckName,
+				checks.CountConfusionCheckName,
+				checks.GroupLabelsCheckName,
+				checks.CrossFileCollisionCheckName,
 			},
 		},
 		{
@@ -2179,10 +3718,42 @@ rule {
 				checks.AlertForCheckName,
 				checks.ComparisonCheckName,
 				checks.FragileCheckName,
+				checks.CountAbsenceCheckName,
+				checks.DeadCodeCheckName,
+				checks.AlertsConstantValueCheckName,
+				checks.ConstantCheckName,
+				checks.LabelReplaceOverwriteCheckName,
+				checks.AbsentCheckName,
+				checks.RedundantParensCheckName,
+				checks.AtModifierCheckName,
+				checks.LabelLifecycleCheckName,
+				checks.DeprecatedFunctionCheckName,
+				checks.SuggestRecordCheckName,
+				checks.NestedRateCheckName,
+				checks.HighChurnLabelCheckName,
+				checks.ThresholdVectorCheckName,
+				checks.QuantileCheckName,
+				checks.CountValuesCheckName,
+				checks.AlertForIntervalCheckName,
+				checks.LabelShadowCheckName,
+				checks.RecordingBoolCheckName,
+				checks.SubqueryCheckName,
+				checks.AlertsOrLabelsCheckName,
+				checks.AggregateEmptyCheckName,
+				checks.DoubleAggregateCheckName,
+				checks.HistogramCheckName,
+				checks.ComparisonLabelsCheckName,
+				checks.LabelCollisionCheckName,
+				checks.SelfReferenceCheckName,
+				checks.JoinLabelCheckName,
+				checks.CountConfusionCheckName,
+				checks.GroupLabelsCheckName,
 				checks.LabelsConflictCheckName + "(prom1)",
 				checks.AlertsExternalLabelsCheckName + "(prom1)",
+				checks.CrossFileCollisionCheckName + "(prom1)",
 				checks.LabelsConflictCheckName + "(prom2)",
 				checks.AlertsExternalLabelsCheckName + "(prom2)",
+				checks.CrossFileCollisionCheckName + "(prom2)",
 			},
 		},
 		{
@@ -2213,9 +3784,620 @@ rule {
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
 				checks.RegexpCheckName,
+				checks.CountAbsenceCheckName,
+				checks.DeadCodeCheckName,
+				checks.AlertsConstantValueCheckName,
+				checks.ConstantCheckName,
+				checks.LabelReplaceOverwriteCheckName,
+				checks.AbsentCheckName,
+				checks.RedundantParensCheckName,
+				checks.AtModifierCheckName,
+				checks.LabelLifecycleCheckName,
+				checks.DeprecatedFunctionCheckName,
+				checks.SuggestRecordCheckName,
+				checks.NestedRateCheckName,
+				checks.HighChurnLabelCheckName,
+				checks.ThresholdVectorCheckName,
+				checks.QuantileCheckName,
+				checks.CountValuesCheckName,
+				checks.AlertForIntervalCheckName,
+				checks.LabelShadowCheckName,
+				checks.RecordingBoolCheckName,
+				checks.SubqueryCheckName,
+				checks.AlertsOrLabelsCheckName,
+				checks.AggregateEmptyCheckName,
+				checks.DoubleAggregateCheckName,
+				checks.HistogramCheckName,
+				checks.ComparisonLabelsCheckName,
+				checks.LabelCollisionCheckName,
+				checks.SelfReferenceCheckName,
+				checks.JoinLabelCheckName,
+				checks.CountConfusionCheckName,
+				checks.GroupLabelsCheckName,
+				checks.CrossFileCollisionCheckName,
 				checks.ReportCheckName,
 			},
 		},
+		{
+			title: "unused record",
+			config: `
+rule {
+  unused_record {
+    severity = "warning"
+  }
+}
+`,
+			entry: discovery.Entry{
+				State: discovery.Modified,
+				Path: discovery.Path{
+					Name:          "rules.yml",
+					SymlinkTarget: "rules.yml",
+				},
+				Rule: newRule(t, "- record: foo\n  expr: sum(foo)\n"),
+			},
+			checks: []string{
+				checks.SyntaxCheckName,
+				checks.AlertForCheckName,
+				checks.ComparisonCheckName,
+				checks.TemplateCheckName,
+				checks.FragileCheckName,
+				checks.RegexpCheckName,
+				checks.CountAbsenceCheckName,
+				checks.DeadCodeCheckName,
+				checks.AlertsConstantValueCheckName,
+				checks.ConstantCheckName,
+				checks.LabelReplaceOverwriteCheckName,
+				checks.AbsentCheckName,
+				checks.RedundantParensCheckName,
+				checks.AtModifierCheckName,
+				checks.LabelLifecycleCheckName,
+				checks.DeprecatedFunctionCheckName,
+				checks.SuggestRecordCheckName,
+				checks.NestedRateCheckName,
+				checks.HighChurnLabelCheckName,
+				checks.ThresholdVectorCheckName,
+				checks.QuantileCheckName,
+				checks.CountValuesCheckName,
+				checks.AlertForIntervalCheckName,
+				checks.LabelShadowCheckName,
+				checks.RecordingBoolCheckName,
+				checks.SubqueryCheckName,
+				checks.AlertsOrLabelsCheckName,
+				checks.AggregateEmptyCheckName,
+				checks.DoubleAggregateCheckName,
+				checks.HistogramCheckName,
+				checks.ComparisonLabelsCheckName,
+				checks.LabelCollisionCheckName,
+				checks.SelfReferenceCheckName,
+				checks.JoinLabelCheckName,
+				checks.CountConfusionCheckName,
+				checks.GroupLabelsCheckName,
+				checks.CrossFileCollisionCheckName,
+				checks.UnusedRecordCheckName,
+			},
+		},
+		{
+			title: "by vs without",
+			config: `
+rule {
+  by_vs_without {
+    maxLabels = 3
+  }
+}
+`,
+			entry: discovery.Entry{
+				State: discovery.Modified,
+				Path: discovery.Path{
+					Name:          "rules.yml",
+					SymlinkTarget: "rules.yml",
+				},
+				Rule: newRule(t, "- record: foo\n  expr: sum(foo)\n"),
+			},
+			checks: []string{
+				checks.SyntaxCheckName,
+				checks.AlertForCheckName,
+				checks.ComparisonCheckName,
+				checks.TemplateCheckName,
+				checks.FragileCheckName,
+				checks.RegexpCheckName,
+				checks.CountAbsenceCheckName,
+				checks.DeadCodeCheckName,
+				checks.AlertsConstantValueCheckName,
+				checks.ConstantCheckName,
+				checks.LabelReplaceOverwriteCheckName,
+				checks.AbsentCheckName,
+				checks.RedundantParensCheckName,
+				checks.AtModifierCheckName,
+				checks.LabelLifecycleCheckName,
+				checks.DeprecatedFunctionCheckName,
+				checks.SuggestRecordCheckName,
+				checks.NestedRateCheckName,
+				checks.HighChurnLabelCheckName,
+				checks.ThresholdVectorCheckName,
+				checks.QuantileCheckName,
+				checks.CountValuesCheckName,
+				checks.AlertForIntervalCheckName,
+				checks.LabelShadowCheckName,
+				checks.RecordingBoolCheckName,
+				checks.SubqueryCheckName,
+				checks.AlertsOrLabelsCheckName,
+				checks.AggregateEmptyCheckName,
+				checks.DoubleAggregateCheckName,
+				checks.HistogramCheckName,
+				checks.ComparisonLabelsCheckName,
+				checks.LabelCollisionCheckName,
+				checks.SelfReferenceCheckName,
+				checks.JoinLabelCheckName,
+				checks.CountConfusionCheckName,
+				checks.GroupLabelsCheckName,
+				checks.CrossFileCollisionCheckName,
+				checks.ByVsWithoutCheckName + "(3)",
+			},
+		},
+		{
+			title: "recording name",
+			config: `
+rule {
+  recording_name {
+    parts = 3
+  }
+}
+`,
+			entry: discovery.Entry{
+				State: discovery.Modified,
+				Path: discovery.Path{
+					Name:          "rules.yml",
+					SymlinkTarget: "rules.yml",
+				},
+				Rule: newRule(t, "- record: foo\n  expr: sum(foo)\n"),
+			},
+			checks: []string{
+				checks.SyntaxCheckName,
+				checks.AlertForCheckName,
+				checks.ComparisonCheckName,
+				checks.TemplateCheckName,
+				checks.FragileCheckName,
+				checks.RegexpCheckName,
+				checks.CountAbsenceCheckName,
+				checks.DeadCodeCheckName,
+				checks.AlertsConstantValueCheckName,
+				checks.ConstantCheckName,
+				checks.LabelReplaceOverwriteCheckName,
+				checks.AbsentCheckName,
+				checks.RedundantParensCheckName,
+				checks.AtModifierCheckName,
+				checks.LabelLifecycleCheckName,
+				checks.DeprecatedFunctionCheckName,
+				checks.SuggestRecordCheckName,
+				checks.NestedRateCheckName,
+				checks.HighChurnLabelCheckName,
+				checks.ThresholdVectorCheckName,
+				checks.QuantileCheckName,
+				checks.CountValuesCheckName,
+				checks.AlertForIntervalCheckName,
+				checks.LabelShadowCheckName,
+				checks.RecordingBoolCheckName,
+				checks.SubqueryCheckName,
+				checks.AlertsOrLabelsCheckName,
+				checks.AggregateEmptyCheckName,
+				checks.DoubleAggregateCheckName,
+				checks.HistogramCheckName,
+				checks.ComparisonLabelsCheckName,
+				checks.LabelCollisionCheckName,
+				checks.SelfReferenceCheckName,
+				checks.JoinLabelCheckName,
+				checks.CountConfusionCheckName,
+				checks.GroupLabelsCheckName,
+				checks.CrossFileCollisionCheckName,
+				checks.RecordingNameCheckName,
+			},
+		},
+		{
+			title: "anonymous",
+			config: `
+rule {
+  anonymous {
+    severity = "bug"
+  }
+}
+`,
+			entry: discovery.Entry{
+				State: discovery.Modified,
+				Path: discovery.Path{
+					Name:          "rules.yml",
+					SymlinkTarget: "rules.yml",
+				},
+				Rule: newRule(t, "- alert: foo\n  expr: sum(foo) > 0\n"),
+			},
+			checks: []string{
+				checks.SyntaxCheckName,
+				checks.AlertForCheckName,
+				checks.ComparisonCheckName,
+				checks.TemplateCheckName,
+				checks.FragileCheckName,
+				checks.RegexpCheckName,
+				checks.CountAbsenceCheckName,
+				checks.DeadCodeCheckName,
+				checks.AlertsConstantValueCheckName,
+				checks.ConstantCheckName,
+				checks.LabelReplaceOverwriteCheckName,
+				checks.AbsentCheckName,
+				checks.RedundantParensCheckName,
+				checks.AtModifierCheckName,
+				checks.LabelLifecycleCheckName,
+				checks.DeprecatedFunctionCheckName,
+				checks.SuggestRecordCheckName,
+				checks.NestedRateCheckName,
+				checks.HighChurnLabelCheckName,
+				checks.ThresholdVectorCheckName,
+				checks.QuantileCheckName,
+				checks.CountValuesCheckName,
+				checks.AlertForIntervalCheckName,
+				checks.LabelShadowCheckName,
+				checks.RecordingBoolCheckName,
+				checks.SubqueryCheckName,
+				checks.AlertsOrLabelsCheckName,
+				checks.AggregateEmptyCheckName,
+				checks.DoubleAggregateCheckName,
+				checks.HistogramCheckName,
+				checks.ComparisonLabelsCheckName,
+				checks.LabelCollisionCheckName,
+				checks.SelfReferenceCheckName,
+				checks.JoinLabelCheckName,
+				checks.CountConfusionCheckName,
+				checks.GroupLabelsCheckName,
+				checks.CrossFileCollisionCheckName,
+				checks.AnonymousCheckName,
+			},
+		},
+		{
+			title: "rate suffix",
+			config: `
+rule {
+  rate_suffix {
+    suffixes = ["_total"]
+  }
+}
+`,
+			entry: discovery.Entry{
+				State: discovery.Modified,
+				Path: discovery.Path{
+					Name:          "rules.yml",
+					SymlinkTarget: "rules.yml",
+				},
+				Rule: newRule(t, "- record: foo\n  expr: sum(foo)\n"),
+			},
+			checks: []string{
+				checks.SyntaxCheckName,
+				checks.AlertForCheckName,
+				checks.ComparisonCheckName,
+				checks.TemplateCheckName,
+				checks.FragileCheckName,
+				checks.RegexpCheckName,
+				checks.CountAbsenceCheckName,
+				checks.DeadCodeCheckName,
+				checks.AlertsConstantValueCheckName,
+				checks.ConstantCheckName,
+				checks.LabelReplaceOverwriteCheckName,
+				checks.AbsentCheckName,
+				checks.RedundantParensCheckName,
+				checks.AtModifierCheckName,
+				checks.LabelLifecycleCheckName,
+				checks.DeprecatedFunctionCheckName,
+				checks.SuggestRecordCheckName,
+				checks.NestedRateCheckName,
+				checks.HighChurnLabelCheckName,
+				checks.ThresholdVectorCheckName,
+				checks.QuantileCheckName,
+				checks.CountValuesCheckName,
+				checks.AlertForIntervalCheckName,
+				checks.LabelShadowCheckName,
+				checks.RecordingBoolCheckName,
+				checks.SubqueryCheckName,
+				checks.AlertsOrLabelsCheckName,
+				checks.AggregateEmptyCheckName,
+				checks.DoubleAggregateCheckName,
+				checks.HistogramCheckName,
+				checks.ComparisonLabelsCheckName,
+				checks.LabelCollisionCheckName,
+				checks.SelfReferenceCheckName,
+				checks.JoinLabelCheckName,
+				checks.CountConfusionCheckName,
+				checks.GroupLabelsCheckName,
+				checks.CrossFileCollisionCheckName,
+				checks.RateSuffixCheckName,
+			},
+		},
+		{
+			title: "scope",
+			config: `
+rule {
+  scope {
+    labels = ["job", "namespace"]
+  }
+}
+`,
+			entry: discovery.Entry{
+				State: discovery.Modified,
+				Path: discovery.Path{
+					Name:          "rules.yml",
+					SymlinkTarget: "rules.yml",
+				},
+				Rule: newRule(t, "- alert: foo\n  expr: up == 0\n"),
+			},
+			checks: []string{
+				checks.SyntaxCheckName,
+				checks.AlertForCheckName,
+				checks.ComparisonCheckName,
+				checks.TemplateCheckName,
+				checks.FragileCheckName,
+				checks.RegexpCheckName,
+				checks.CountAbsenceCheckName,
+				checks.DeadCodeCheckName,
+				checks.AlertsConstantValueCheckName,
+				checks.ConstantCheckName,
+				checks.LabelReplaceOverwriteCheckName,
+				checks.AbsentCheckName,
+				checks.RedundantParensCheckName,
+				checks.AtModifierCheckName,
+				checks.LabelLifecycleCheckName,
+				checks.DeprecatedFunctionCheckName,
+				checks.SuggestRecordCheckName,
+				checks.NestedRateCheckName,
+				checks.HighChurnLabelCheckName,
+				checks.ThresholdVectorCheckName,
+				checks.QuantileCheckName,
+				checks.CountValuesCheckName,
+				checks.AlertForIntervalCheckName,
+				checks.LabelShadowCheckName,
+				checks.RecordingBoolCheckName,
+				checks.SubqueryCheckName,
+				checks.AlertsOrLabelsCheckName,
+				checks.AggregateEmptyCheckName,
+				checks.DoubleAggregateCheckName,
+				checks.HistogramCheckName,
+				checks.ComparisonLabelsCheckName,
+				checks.LabelCollisionCheckName,
+				checks.SelfReferenceCheckName,
+				checks.JoinLabelCheckName,
+				checks.CountConfusionCheckName,
+				checks.GroupLabelsCheckName,
+				checks.CrossFileCollisionCheckName,
+				checks.ScopeCheckName,
+			},
+		},
+		{
+			title: "range interval",
+			config: `
+rule {
+  range_interval {
+    interval = "1m"
+  }
+}
+`,
+			entry: discovery.Entry{
+				State: discovery.Modified,
+				Path: discovery.Path{
+					Name:          "rules.yml",
+					SymlinkTarget: "rules.yml",
+				},
+				Rule: newRule(t, "- alert: foo\n  expr: up == 0\n"),
+			},
+			checks: []string{
+				checks.SyntaxCheckName,
+				checks.AlertForCheckName,
+				checks.ComparisonCheckName,
+				checks.TemplateCheckName,
+				checks.FragileCheckName,
+				checks.RegexpCheckName,
+				checks.CountAbsenceCheckName,
+				checks.DeadCodeCheckName,
+				checks.AlertsConstantValueCheckName,
+				checks.ConstantCheckName,
+				checks.LabelReplaceOverwriteCheckName,
+				checks.AbsentCheckName,
+				checks.RedundantParensCheckName,
+				checks.AtModifierCheckName,
+				checks.LabelLifecycleCheckName,
+				checks.DeprecatedFunctionCheckName,
+				checks.SuggestRecordCheckName,
+				checks.NestedRateCheckName,
+				checks.HighChurnLabelCheckName,
+				checks.ThresholdVectorCheckName,
+				checks.QuantileCheckName,
+				checks.CountValuesCheckName,
+				checks.AlertForIntervalCheckName,
+				checks.LabelShadowCheckName,
+				checks.RecordingBoolCheckName,
+				checks.SubqueryCheckName,
+				checks.AlertsOrLabelsCheckName,
+				checks.AggregateEmptyCheckName,
+				checks.DoubleAggregateCheckName,
+				checks.HistogramCheckName,
+				checks.ComparisonLabelsCheckName,
+				checks.LabelCollisionCheckName,
+				checks.SelfReferenceCheckName,
+				checks.JoinLabelCheckName,
+				checks.CountConfusionCheckName,
+				checks.GroupLabelsCheckName,
+				checks.CrossFileCollisionCheckName,
+				checks.RangeIntervalCheckName,
+			},
+		},
+		{
+			title: "gauge only",
+			config: `
+rule {
+  gauge_only {
+    suffixes = ["_total"]
+  }
+}
+`,
+			entry: discovery.Entry{
+				State: discovery.Modified,
+				Path: discovery.Path{
+					Name:          "rules.yml",
+					SymlinkTarget: "rules.yml",
+				},
+				Rule: newRule(t, "- alert: foo\n  expr: up == 0\n"),
+			},
+			checks: []string{
+				checks.SyntaxCheckName,
+				checks.AlertForCheckName,
+				checks.ComparisonCheckName,
+				checks.TemplateCheckName,
+				checks.FragileCheckName,
+				checks.RegexpCheckName,
+				checks.CountAbsenceCheckName,
+				checks.DeadCodeCheckName,
+				checks.AlertsConstantValueCheckName,
+				checks.ConstantCheckName,
+				checks.LabelReplaceOverwriteCheckName,
+				checks.AbsentCheckName,
+				checks.RedundantParensCheckName,
+				checks.AtModifierCheckName,
+				checks.LabelLifecycleCheckName,
+				checks.DeprecatedFunctionCheckName,
+				checks.SuggestRecordCheckName,
+				checks.NestedRateCheckName,
+				checks.HighChurnLabelCheckName,
+				checks.ThresholdVectorCheckName,
+				checks.QuantileCheckName,
+				checks.CountValuesCheckName,
+				checks.AlertForIntervalCheckName,
+				checks.LabelShadowCheckName,
+				checks.RecordingBoolCheckName,
+				checks.SubqueryCheckName,
+				checks.AlertsOrLabelsCheckName,
+				checks.AggregateEmptyCheckName,
+				checks.DoubleAggregateCheckName,
+				checks.HistogramCheckName,
+				checks.ComparisonLabelsCheckName,
+				checks.LabelCollisionCheckName,
+				checks.SelfReferenceCheckName,
+				checks.JoinLabelCheckName,
+				checks.CountConfusionCheckName,
+				checks.GroupLabelsCheckName,
+				checks.CrossFileCollisionCheckName,
+				checks.GaugeOnlyCheckName,
+			},
+		},
+		{
+			title: "for missing",
+			config: `
+rule {
+  for_missing {
+    functions = ["rate", "increase"]
+  }
+}
+`,
+			entry: discovery.Entry{
+				State: discovery.Modified,
+				Path: discovery.Path{
+					Name:          "rules.yml",
+					SymlinkTarget: "rules.yml",
+				},
+				Rule: newRule(t, "- alert: foo\n  expr: up == 0\n"),
+			},
+			checks: []string{
+				checks.SyntaxCheckName,
+				checks.AlertForCheckName,
+				checks.ComparisonCheckName,
+				checks.TemplateCheckName,
+				checks.FragileCheckName,
+				checks.RegexpCheckName,
+				checks.CountAbsenceCheckName,
+				checks.DeadCodeCheckName,
+				checks.AlertsConstantValueCheckName,
+				checks.ConstantCheckName,
+				checks.LabelReplaceOverwriteCheckName,
+				checks.AbsentCheckName,
+				checks.RedundantParensCheckName,
+				checks.AtModifierCheckName,
+				checks.LabelLifecycleCheckName,
+				checks.DeprecatedFunctionCheckName,
+				checks.SuggestRecordCheckName,
+				checks.NestedRateCheckName,
+				checks.HighChurnLabelCheckName,
+				checks.ThresholdVectorCheckName,
+				checks.QuantileCheckName,
+				checks.CountValuesCheckName,
+				checks.AlertForIntervalCheckName,
+				checks.LabelShadowCheckName,
+				checks.RecordingBoolCheckName,
+				checks.SubqueryCheckName,
+				checks.AlertsOrLabelsCheckName,
+				checks.AggregateEmptyCheckName,
+				checks.DoubleAggregateCheckName,
+				checks.HistogramCheckName,
+				checks.ComparisonLabelsCheckName,
+				checks.LabelCollisionCheckName,
+				checks.SelfReferenceCheckName,
+				checks.JoinLabelCheckName,
+				checks.CountConfusionCheckName,
+				checks.GroupLabelsCheckName,
+				checks.CrossFileCollisionCheckName,
+				checks.ForMissingCheckName,
+			},
+		},
+		{
+			title: "required annotations",
+			config: `
+rule {
+  required_annotations {
+    names = ["summary"]
+  }
+}
+`,
+			entry: discovery.Entry{
+				State: discovery.Modified,
+				Path: discovery.Path{
+					Name:          "rules.yml",
+					SymlinkTarget: "rules.yml",
+				},
+				Rule: newRule(t, "- alert: foo\n  expr: up == 0\n"),
+			},
+			checks: []string{
+				checks.SyntaxCheckName,
+				checks.AlertForCheckName,
+				checks.ComparisonCheckName,
+				checks.TemplateCheckName,
+				checks.FragileCheckName,
+				checks.RegexpCheckName,
+				checks.CountAbsenceCheckName,
+				checks.DeadCodeCheckName,
+				checks.AlertsConstantValueCheckName,
+				checks.ConstantCheckName,
+				checks.LabelReplaceOverwriteCheckName,
+				checks.AbsentCheckName,
+				checks.RedundantParensCheckName,
+				checks.AtModifierCheckName,
+				checks.LabelLifecycleCheckName,
+				checks.DeprecatedFunctionCheckName,
+				checks.SuggestRecordCheckName,
+				checks.NestedRateCheckName,
+				checks.HighChurnLabelCheckName,
+				checks.ThresholdVectorCheckName,
+				checks.QuantileCheckName,
+				checks.CountValuesCheckName,
+				checks.AlertForIntervalCheckName,
+				checks.LabelShadowCheckName,
+				checks.RecordingBoolCheckName,
+				checks.SubqueryCheckName,
+				checks.AlertsOrLabelsCheckName,
+				checks.AggregateEmptyCheckName,
+				checks.DoubleAggregateCheckName,
+				checks.HistogramCheckName,
+				checks.ComparisonLabelsCheckName,
+				checks.LabelCollisionCheckName,
+				checks.SelfReferenceCheckName,
+				checks.JoinLabelCheckName,
+				checks.CountConfusionCheckName,
+				checks.GroupLabelsCheckName,
+				checks.CrossFileCollisionCheckName,
+				checks.RequiredAnnotationsCheckName,
+			},
+		},
 		{
 			title: "multiple checks and disable comment / locked rule",
 			config: `
@@ -2253,6 +4435,37 @@ rule {
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
 				checks.RegexpCheckName,
+				checks.CountAbsenceCheckName,
+				checks.DeadCodeCheckName,
+				checks.AlertsConstantValueCheckName,
+				checks.ConstantCheckName,
+				checks.LabelReplaceOverwriteCheckName,
+				checks.AbsentCheckName,
+				checks.RedundantParensCheckName,
+				checks.AtModifierCheckName,
+				checks.LabelLifecycleCheckName,
+				checks.DeprecatedFunctionCheckName,
+				checks.SuggestRecordCheckName,
+				checks.NestedRateCheckName,
+				checks.HighChurnLabelCheckName,
+				checks.ThresholdVectorCheckName,
+				checks.QuantileCheckName,
+				checks.CountValuesCheckName,
+				checks.AlertForIntervalCheckName,
+				checks.LabelShadowCheckName,
+				checks.RecordingBoolCheckName,
+				checks.SubqueryCheckName,
+				checks.AlertsOrLabelsCheckName,
+				checks.AggregateEmptyCheckName,
+				checks.DoubleAggregateCheckName,
+				checks.HistogramCheckName,
+				checks.ComparisonLabelsCheckName,
+				checks.LabelCollisionCheckName,
+				checks.SelfReferenceCheckName,
+				checks.JoinLabelCheckName,
+				checks.CountConfusionCheckName,
+				checks.GroupLabelsCheckName,
+				checks.CrossFileCollisionCheckName,
 				checks.AggregationCheckName + "(instance:false)",
 				checks.AggregationCheckName + "(rack:false)",
 			},
@@ -2294,6 +4507,37 @@ rule {
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
 				checks.RegexpCheckName,
+				checks.CountAbsenceCheckName,
+				checks.DeadCodeCheckName,
+				checks.AlertsConstantValueCheckName,
+				checks.ConstantCheckName,
+				checks.LabelReplaceOverwriteCheckName,
+				checks.AbsentCheckName,
+				checks.RedundantParensCheckName,
+				checks.AtModifierCheckName,
+				checks.LabelLifecycleCheckName,
+				checks.DeprecatedFunctionCheckName,
+				checks.SuggestRecordCheckName,
+				checks.NestedRateCheckName,
+				checks.HighChurnLabelCheckName,
+				checks.ThresholdVectorCheckName,
+				checks.QuantileCheckName,
+				checks.CountValuesCheckName,
+				checks.AlertForIntervalCheckName,
+				checks.LabelShadowCheckName,
+				checks.RecordingBoolCheckName,
+				checks.SubqueryCheckName,
+				checks.AlertsOrLabelsCheckName,
+				checks.AggregateEmptyCheckName,
+				checks.DoubleAggregateCheckName,
+				checks.HistogramCheckName,
+				checks.ComparisonLabelsCheckName,
+				checks.LabelCollisionCheckName,
+				checks.SelfReferenceCheckName,
+				checks.JoinLabelCheckName,
+				checks.CountConfusionCheckName,
+				checks.GroupLabelsCheckName,
+				checks.CrossFileCollisionCheckName,
 				checks.AggregationCheckName + "(instance:false)",
 				checks.AggregationCheckName + "(rack:false)",
 			},
@@ -2712,6 +4956,167 @@ func TestConfigErrors(t *testing.T) {
 }`,
 			err: "unknown severity: xxx",
 		},
+		{
+			config: `rule {
+  unused_record {
+	severity = "xxx"
+  }
+}`,
+			err: "unknown severity: xxx",
+		},
+		{
+			config: `rule {
+  by_vs_without {
+	severity = "xxx"
+  }
+}`,
+			err: "unknown severity: xxx",
+		},
+		{
+			config: `rule {
+  by_vs_without {
+	maxLabels = -1
+  }
+}`,
+			err: "maxLabels value must be >= 1",
+		},
+		{
+			config: `rule {
+  by_vs_without {
+	maxLabels = 0
+  }
+}`,
+			err: "maxLabels value must be >= 1",
+		},
+		{
+			config: `rule {
+  recording_name {
+	severity = "xxx"
+  }
+}`,
+			err: "unknown severity: xxx",
+		},
+		{
+			config: `rule {
+  recording_name {
+	parts = 1
+  }
+}`,
+			err: "parts must be at least 2",
+		},
+		{
+			config: `rule {
+  anonymous {
+	severity = "xxx"
+  }
+}`,
+			err: "unknown severity: xxx",
+		},
+		{
+			config: `rule {
+  rate_suffix {
+	severity = "xxx"
+  }
+}`,
+			err: "unknown severity: xxx",
+		},
+		{
+			config: `rule {
+  rate_suffix {
+	suffixes = ["_total", ""]
+  }
+}`,
+			err: "suffixes cannot contain empty values",
+		},
+		{
+			config: `rule {
+  for_missing {
+	severity = "xxx"
+  }
+}`,
+			err: "unknown severity: xxx",
+		},
+		{
+			config: `rule {
+  for_missing {
+	functions = ["rate", ""]
+  }
+}`,
+			err: "functions cannot contain empty values",
+		},
+		{
+			config: `rule {
+  required_annotations {
+	severity = "xxx"
+  }
+}`,
+			err: "unknown severity: xxx",
+		},
+		{
+			config: `rule {
+  required_annotations {
+	names = ["summary", ""]
+  }
+}`,
+			err: "names cannot contain empty values",
+		},
+		{
+			config: `rule {
+  scope {
+	severity = "xxx"
+  }
+}`,
+			err: "unknown severity: xxx",
+		},
+		{
+			config: `rule {
+  scope {
+	labels = ["job", ""]
+  }
+}`,
+			err: "labels cannot contain empty values",
+		},
+		{
+			config: `rule {
+  range_interval {
+	interval = "1m"
+	severity = "xxx"
+  }
+}`,
+			err: "unknown severity: xxx",
+		},
+		{
+			config: `rule {
+  range_interval {
+	interval = "0s"
+  }
+}`,
+			err: "range_interval interval value cannot be zero",
+		},
+		{
+			config: `rule {
+  range_interval {
+	interval = "foo"
+  }
+}`,
+			err: `not a valid duration string: "foo"`,
+		},
+		{
+			config: `rule {
+  gauge_only {
+	severity = "xxx"
+  }
+}`,
+			err: "unknown severity: xxx",
+		},
+		{
+			config: `rule {
+  gauge_only {
+	suffixes = ["_total", ""]
+  }
+}`,
+			err: "suffixes cannot contain empty values",
+		},
 	}
 
 	dir := t.TempDir()
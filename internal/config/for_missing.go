@@ -0,0 +1,35 @@
+package config
+
+import (
+	"errors"
+
+	"github.com/cloudflare/pint/internal/checks"
+)
+
+type ForMissingSettings struct {
+	Comment   string   `hcl:"comment,optional" json:"comment,omitempty"`
+	Severity  string   `hcl:"severity,optional" json:"severity,omitempty"`
+	Functions []string `hcl:"functions,optional" json:"functions,omitempty"`
+}
+
+func (fs ForMissingSettings) validate() error {
+	if fs.Severity != "" {
+		if _, err := checks.ParseSeverity(fs.Severity); err != nil {
+			return err
+		}
+	}
+	for _, name := range fs.Functions {
+		if name == "" {
+			return errors.New("functions cannot contain empty values")
+		}
+	}
+	return nil
+}
+
+func (fs ForMissingSettings) getSeverity(fallback checks.Severity) checks.Severity {
+	if fs.Severity != "" {
+		sev, _ := checks.ParseSeverity(fs.Severity)
+		return sev
+	}
+	return fallback
+}
@@ -0,0 +1,35 @@
+package config
+
+import (
+	"errors"
+
+	"github.com/cloudflare/pint/internal/checks"
+)
+
+type GaugeOnlySettings struct {
+	Comment  string   `hcl:"comment,optional" json:"comment,omitempty"`
+	Severity string   `hcl:"severity,optional" json:"severity,omitempty"`
+	Suffixes []string `hcl:"suffixes,optional" json:"suffixes,omitempty"`
+}
+
+func (gs GaugeOnlySettings) validate() error {
+	if gs.Severity != "" {
+		if _, err := checks.ParseSeverity(gs.Severity); err != nil {
+			return err
+		}
+	}
+	for _, suffix := range gs.Suffixes {
+		if suffix == "" {
+			return errors.New("suffixes cannot contain empty values")
+		}
+	}
+	return nil
+}
+
+func (gs GaugeOnlySettings) getSeverity(fallback checks.Severity) checks.Severity {
+	if gs.Severity != "" {
+		sev, _ := checks.ParseSeverity(gs.Severity)
+		return sev
+	}
+	return fallback
+}
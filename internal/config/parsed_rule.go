@@ -121,8 +121,38 @@ func baseRules(proms []*promapi.FailoverGroup, match []Match) (rules []parsedRul
 		baseParsedRule(match, checks.AlertForCheckName, checks.NewAlertsForCheck(), nil),
 		baseParsedRule(match, checks.ComparisonCheckName, checks.NewComparisonCheck(), nil),
 		baseParsedRule(match, checks.TemplateCheckName, checks.NewTemplateCheck(), nil),
-		baseParsedRule(match, checks.FragileCheckName, checks.NewFragileCheck(), nil),
+		baseParsedRule(match, checks.FragileCheckName, checks.NewFragileCheck(false), nil),
 		baseParsedRule(match, checks.RegexpCheckName, checks.NewRegexpCheck(), nil),
+		baseParsedRule(match, checks.CountAbsenceCheckName, checks.NewCountAbsenceCheck(), nil),
+		baseParsedRule(match, checks.DeadCodeCheckName, checks.NewDeadCodeCheck(), nil),
+		baseParsedRule(match, checks.AlertsConstantValueCheckName, checks.NewAlertsConstantValueCheck(), nil),
+		baseParsedRule(match, checks.ConstantCheckName, checks.NewConstantCheck(), nil),
+		baseParsedRule(match, checks.LabelReplaceOverwriteCheckName, checks.NewLabelReplaceOverwriteCheck(), nil),
+		baseParsedRule(match, checks.AbsentCheckName, checks.NewAbsentCheck(), nil),
+		baseParsedRule(match, checks.RedundantParensCheckName, checks.NewRedundantParensCheck(), nil),
+		baseParsedRule(match, checks.AtModifierCheckName, checks.NewAtModifierCheck(), nil),
+		baseParsedRule(match, checks.LabelLifecycleCheckName, checks.NewLabelLifecycleCheck(), nil),
+		baseParsedRule(match, checks.DeprecatedFunctionCheckName, checks.NewDeprecatedFunctionCheck(), nil),
+		baseParsedRule(match, checks.SuggestRecordCheckName, checks.NewSuggestRecordCheck(), nil),
+		baseParsedRule(match, checks.NestedRateCheckName, checks.NewNestedRateCheck(), nil),
+		baseParsedRule(match, checks.HighChurnLabelCheckName, checks.NewHighChurnLabelCheck(), nil),
+		baseParsedRule(match, checks.ThresholdVectorCheckName, checks.NewThresholdVectorCheck(), nil),
+		baseParsedRule(match, checks.QuantileCheckName, checks.NewQuantileCheck(), nil),
+		baseParsedRule(match, checks.CountValuesCheckName, checks.NewCountValuesCheck(), nil),
+		baseParsedRule(match, checks.AlertForIntervalCheckName, checks.NewAlertsForIntervalCheck(), nil),
+		baseParsedRule(match, checks.LabelShadowCheckName, checks.NewLabelShadowCheck(), nil),
+		baseParsedRule(match, checks.RecordingBoolCheckName, checks.NewRecordingBoolCheck(), nil),
+		baseParsedRule(match, checks.SubqueryCheckName, checks.NewSubqueryCheck(), nil),
+		baseParsedRule(match, checks.AlertsOrLabelsCheckName, checks.NewAlertsOrLabelsCheck(), nil),
+		baseParsedRule(match, checks.AggregateEmptyCheckName, checks.NewAggregateEmptyCheck(), nil),
+		baseParsedRule(match, checks.DoubleAggregateCheckName, checks.NewDoubleAggregateCheck(), nil),
+		baseParsedRule(match, checks.HistogramCheckName, checks.NewHistogramCheck(), nil),
+		baseParsedRule(match, checks.ComparisonLabelsCheckName, checks.NewComparisonLabelsCheck(), nil),
+		baseParsedRule(match, checks.LabelCollisionCheckName, checks.NewLabelCollisionCheck(), nil),
+		baseParsedRule(match, checks.SelfReferenceCheckName, checks.NewSelfReferenceCheck(), nil),
+		baseParsedRule(match, checks.JoinLabelCheckName, checks.NewJoinLabelCheck(), nil),
+		baseParsedRule(match, checks.CountConfusionCheckName, checks.NewCountConfusionCheck(), nil),
+		baseParsedRule(match, checks.GroupLabelsCheckName, checks.NewGroupLabelsCheck(), nil),
 		baseParsedRule(match, checks.RuleDependencyCheckName, checks.NewRuleDependencyCheck(), nil),
 	)
 
@@ -137,9 +167,16 @@ func baseRules(proms []*promapi.FailoverGroup, match []Match) (rules []parsedRul
 			baseParsedRule(match, checks.AlertsExternalLabelsCheckName, checks.NewAlertsExternalLabelsCheck(p), p.Tags()),
 			baseParsedRule(match, checks.CounterCheckName, checks.NewCounterCheck(p), p.Tags()),
 			baseParsedRule(match, checks.AlertsAbsentCheckName, checks.NewAlertsAbsentCheck(p), p.Tags()),
+			baseParsedRule(match, checks.CrossFileCollisionCheckName, checks.NewCrossFileCollisionCheck(p), p.Tags()),
 		)
 	}
 
+	// Without any Prometheus servers configured we assume that all rules
+	// are deployed to the same server.
+	if len(proms) == 0 {
+		rules = append(rules, baseParsedRule(match, checks.CrossFileCollisionCheckName, checks.NewCrossFileCollisionCheck(nil), nil))
+	}
+
 	return rules
 }
 
@@ -382,5 +419,106 @@ func parseRule(rule Rule, prometheusServers []*promapi.FailoverGroup, defaultSta
 		))
 	}
 
+	if rule.UnusedRecord != nil {
+		rules = append(rules, newParsedRule(
+			rule,
+			defaultStates,
+			checks.UnusedRecordCheckName,
+			checks.NewUnusedRecordCheck(rule.UnusedRecord.Comment, rule.UnusedRecord.getSeverity(checks.Information)),
+			nil,
+		))
+	}
+
+	if rule.ByVsWithout != nil {
+		rules = append(rules, newParsedRule(
+			rule,
+			defaultStates,
+			checks.ByVsWithoutCheckName,
+			checks.NewByVsWithoutCheck(rule.ByVsWithout.getMaxLabels(), rule.ByVsWithout.Comment, rule.ByVsWithout.getSeverity(checks.Information)),
+			nil,
+		))
+	}
+
+	if rule.RateSuffix != nil {
+		rules = append(rules, newParsedRule(
+			rule,
+			defaultStates,
+			checks.RateSuffixCheckName,
+			checks.NewRateSuffixCheck(rule.RateSuffix.Suffixes, rule.RateSuffix.Comment, rule.RateSuffix.getSeverity(checks.Warning)),
+			nil,
+		))
+	}
+
+	if rule.Scope != nil {
+		rules = append(rules, newParsedRule(
+			rule,
+			defaultStates,
+			checks.ScopeCheckName,
+			checks.NewScopeCheck(rule.Scope.Labels, rule.Scope.Comment, rule.Scope.getSeverity(checks.Warning)),
+			nil,
+		))
+	}
+
+	if rule.RangeInterval != nil {
+		interval, _ := parseDuration(rule.RangeInterval.Interval)
+		rules = append(rules, newParsedRule(
+			rule,
+			defaultStates,
+			checks.RangeIntervalCheckName,
+			checks.NewRangeIntervalCheck(interval, rule.RangeInterval.Comment, rule.RangeInterval.getSeverity(checks.Warning)),
+			nil,
+		))
+	}
+
+	if rule.GaugeOnly != nil {
+		rules = append(rules, newParsedRule(
+			rule,
+			defaultStates,
+			checks.GaugeOnlyCheckName,
+			checks.NewGaugeOnlyCheck(rule.GaugeOnly.Suffixes, rule.GaugeOnly.Comment, rule.GaugeOnly.getSeverity(checks.Warning)),
+			nil,
+		))
+	}
+
+	if rule.ForMissing != nil {
+		rules = append(rules, newParsedRule(
+			rule,
+			defaultStates,
+			checks.ForMissingCheckName,
+			checks.NewForMissingCheck(rule.ForMissing.Functions, rule.ForMissing.Comment, rule.ForMissing.getSeverity(checks.Information)),
+			nil,
+		))
+	}
+
+	if rule.RequiredAnnotations != nil {
+		rules = append(rules, newParsedRule(
+			rule,
+			defaultStates,
+			checks.RequiredAnnotationsCheckName,
+			checks.NewRequiredAnnotationsCheck(rule.RequiredAnnotations.Names, rule.RequiredAnnotations.Comment, rule.RequiredAnnotations.getSeverity(checks.Information)),
+			nil,
+		))
+	}
+
+	if rule.RecordingName != nil {
+		rules = append(rules, newParsedRule(
+			rule,
+			defaultStates,
+			checks.RecordingNameCheckName,
+			checks.NewRecordingNameCheck(rule.RecordingName.Parts, rule.RecordingName.Comment, rule.RecordingName.getSeverity(checks.Information)),
+			nil,
+		))
+	}
+
+	if rule.Anonymous != nil {
+		rules = append(rules, newParsedRule(
+			rule,
+			defaultStates,
+			checks.AnonymousCheckName,
+			checks.NewAnonymousCheck(rule.Anonymous.Comment, rule.Anonymous.getSeverity(checks.Warning)),
+			nil,
+		))
+	}
+
 	return rules
 }
@@ -0,0 +1,39 @@
+package config
+
+import (
+	"errors"
+
+	"github.com/cloudflare/pint/internal/checks"
+)
+
+type RangeIntervalSettings struct {
+	Interval string `hcl:"interval" json:"interval"`
+	Comment  string `hcl:"comment,optional" json:"comment,omitempty"`
+	Severity string `hcl:"severity,optional" json:"severity,omitempty"`
+}
+
+func (s RangeIntervalSettings) validate() error {
+	dur, err := parseDuration(s.Interval)
+	if err != nil {
+		return err
+	}
+	if dur == 0 {
+		return errors.New("range_interval interval value cannot be zero")
+	}
+
+	if s.Severity != "" {
+		if _, err := checks.ParseSeverity(s.Severity); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s RangeIntervalSettings) getSeverity(fallback checks.Severity) checks.Severity {
+	if s.Severity != "" {
+		sev, _ := checks.ParseSeverity(s.Severity)
+		return sev
+	}
+	return fallback
+}
@@ -0,0 +1,35 @@
+package config
+
+import (
+	"errors"
+
+	"github.com/cloudflare/pint/internal/checks"
+)
+
+type RateSuffixSettings struct {
+	Comment  string   `hcl:"comment,optional" json:"comment,omitempty"`
+	Severity string   `hcl:"severity,optional" json:"severity,omitempty"`
+	Suffixes []string `hcl:"suffixes,optional" json:"suffixes,omitempty"`
+}
+
+func (rs RateSuffixSettings) validate() error {
+	if rs.Severity != "" {
+		if _, err := checks.ParseSeverity(rs.Severity); err != nil {
+			return err
+		}
+	}
+	for _, suffix := range rs.Suffixes {
+		if suffix == "" {
+			return errors.New("suffixes cannot contain empty values")
+		}
+	}
+	return nil
+}
+
+func (rs RateSuffixSettings) getSeverity(fallback checks.Severity) checks.Severity {
+	if rs.Severity != "" {
+		sev, _ := checks.ParseSeverity(rs.Severity)
+		return sev
+	}
+	return fallback
+}
@@ -0,0 +1,33 @@
+package config
+
+import (
+	"errors"
+
+	"github.com/cloudflare/pint/internal/checks"
+)
+
+type RecordingNameSettings struct {
+	Comment  string `hcl:"comment,optional" json:"comment,omitempty"`
+	Severity string `hcl:"severity,optional" json:"severity,omitempty"`
+	Parts    int    `hcl:"parts,optional" json:"parts,omitempty"`
+}
+
+func (rs RecordingNameSettings) validate() error {
+	if rs.Severity != "" {
+		if _, err := checks.ParseSeverity(rs.Severity); err != nil {
+			return err
+		}
+	}
+	if rs.Parts < 0 || rs.Parts == 1 {
+		return errors.New("parts must be at least 2")
+	}
+	return nil
+}
+
+func (rs RecordingNameSettings) getSeverity(fallback checks.Severity) checks.Severity {
+	if rs.Severity != "" {
+		sev, _ := checks.ParseSeverity(rs.Severity)
+		return sev
+	}
+	return fallback
+}
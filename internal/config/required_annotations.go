@@ -0,0 +1,35 @@
+package config
+
+import (
+	"errors"
+
+	"github.com/cloudflare/pint/internal/checks"
+)
+
+type RequiredAnnotationsSettings struct {
+	Comment  string   `hcl:"comment,optional" json:"comment,omitempty"`
+	Severity string   `hcl:"severity,optional" json:"severity,omitempty"`
+	Names    []string `hcl:"names,optional" json:"names,omitempty"`
+}
+
+func (as RequiredAnnotationsSettings) validate() error {
+	if as.Severity != "" {
+		if _, err := checks.ParseSeverity(as.Severity); err != nil {
+			return err
+		}
+	}
+	for _, name := range as.Names {
+		if name == "" {
+			return errors.New("names cannot contain empty values")
+		}
+	}
+	return nil
+}
+
+func (as RequiredAnnotationsSettings) getSeverity(fallback checks.Severity) checks.Severity {
+	if as.Severity != "" {
+		sev, _ := checks.ParseSeverity(as.Severity)
+		return sev
+	}
+	return fallback
+}
@@ -6,29 +6,42 @@ import (
 	"regexp"
 	"time"
 
+	promParser "github.com/prometheus/prometheus/promql/parser"
+
 	"github.com/cloudflare/pint/internal/checks"
 	"github.com/cloudflare/pint/internal/comments"
 	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/parser/utils"
 )
 
 type Rule struct {
-	Match         []Match              `hcl:"match,block" json:"match,omitempty"`
-	Ignore        []Match              `hcl:"ignore,block" json:"ignore,omitempty"`
-	Enable        []string             `hcl:"enable,optional" json:"enable,omitempty"`
-	Disable       []string             `hcl:"disable,optional" json:"disable,omitempty"`
-	Aggregate     []AggregateSettings  `hcl:"aggregate,block" json:"aggregate,omitempty"`
-	Annotation    []AnnotationSettings `hcl:"annotation,block" json:"annotation,omitempty"`
-	Label         []AnnotationSettings `hcl:"label,block" json:"label,omitempty"`
-	Cost          *CostSettings        `hcl:"cost,block" json:"cost,omitempty"`
-	Alerts        *AlertsSettings      `hcl:"alerts,block" json:"alerts,omitempty"`
-	For           *ForSettings         `hcl:"for,block" json:"for,omitempty"`
-	KeepFiringFor *ForSettings         `hcl:"keep_firing_for,block" json:"keep_firing_for,omitempty"`
-	RangeQuery    *RangeQuerySettings  `hcl:"range_query,block" json:"range_query,omitempty"`
-	Report        *ReportSettings      `hcl:"report,block" json:"report,omitempty"`
-	Reject        []RejectSettings     `hcl:"reject,block" json:"reject,omitempty"`
-	RuleLink      []RuleLinkSettings   `hcl:"link,block" json:"link,omitempty"`
-	RuleName      []RuleNameSettings   `hcl:"name,block" json:"name,omitempty"`
-	Locked        bool                 `hcl:"locked,optional" json:"locked,omitempty"`
+	Match               []Match                      `hcl:"match,block" json:"match,omitempty"`
+	Ignore              []Match                      `hcl:"ignore,block" json:"ignore,omitempty"`
+	Enable              []string                     `hcl:"enable,optional" json:"enable,omitempty"`
+	Disable             []string                     `hcl:"disable,optional" json:"disable,omitempty"`
+	Aggregate           []AggregateSettings          `hcl:"aggregate,block" json:"aggregate,omitempty"`
+	Annotation          []AnnotationSettings         `hcl:"annotation,block" json:"annotation,omitempty"`
+	Label               []AnnotationSettings         `hcl:"label,block" json:"label,omitempty"`
+	Cost                *CostSettings                `hcl:"cost,block" json:"cost,omitempty"`
+	Alerts              *AlertsSettings              `hcl:"alerts,block" json:"alerts,omitempty"`
+	For                 *ForSettings                 `hcl:"for,block" json:"for,omitempty"`
+	KeepFiringFor       *ForSettings                 `hcl:"keep_firing_for,block" json:"keep_firing_for,omitempty"`
+	RangeQuery          *RangeQuerySettings          `hcl:"range_query,block" json:"range_query,omitempty"`
+	Report              *ReportSettings              `hcl:"report,block" json:"report,omitempty"`
+	Reject              []RejectSettings             `hcl:"reject,block" json:"reject,omitempty"`
+	RuleLink            []RuleLinkSettings           `hcl:"link,block" json:"link,omitempty"`
+	RuleName            []RuleNameSettings           `hcl:"name,block" json:"name,omitempty"`
+	UnusedRecord        *UnusedRecordSettings        `hcl:"unused_record,block" json:"unused_record,omitempty"`
+	ByVsWithout         *ByVsWithoutSettings         `hcl:"by_vs_without,block" json:"by_vs_without,omitempty"`
+	RateSuffix          *RateSuffixSettings          `hcl:"rate_suffix,block" json:"rate_suffix,omitempty"`
+	Scope               *ScopeSettings               `hcl:"scope,block" json:"scope,omitempty"`
+	RangeInterval       *RangeIntervalSettings       `hcl:"range_interval,block" json:"range_interval,omitempty"`
+	GaugeOnly           *GaugeOnlySettings           `hcl:"gauge_only,block" json:"gauge_only,omitempty"`
+	ForMissing          *ForMissingSettings          `hcl:"for_missing,block" json:"for_missing,omitempty"`
+	RequiredAnnotations *RequiredAnnotationsSettings `hcl:"required_annotations,block" json:"required_annotations,omitempty"`
+	RecordingName       *RecordingNameSettings       `hcl:"recording_name,block" json:"recording_name,omitempty"`
+	Anonymous           *AnonymousSettings           `hcl:"anonymous,block" json:"anonymous,omitempty"`
+	Locked              bool                         `hcl:"locked,optional" json:"locked,omitempty"`
 }
 
 func (rule Rule) validate() (err error) {
@@ -128,6 +141,66 @@ func (rule Rule) validate() (err error) {
 		}
 	}
 
+	if rule.UnusedRecord != nil {
+		if err = rule.UnusedRecord.validate(); err != nil {
+			return err
+		}
+	}
+
+	if rule.ByVsWithout != nil {
+		if err = rule.ByVsWithout.validate(); err != nil {
+			return err
+		}
+	}
+
+	if rule.RateSuffix != nil {
+		if err = rule.RateSuffix.validate(); err != nil {
+			return err
+		}
+	}
+
+	if rule.Scope != nil {
+		if err = rule.Scope.validate(); err != nil {
+			return err
+		}
+	}
+
+	if rule.RangeInterval != nil {
+		if err = rule.RangeInterval.validate(); err != nil {
+			return err
+		}
+	}
+
+	if rule.GaugeOnly != nil {
+		if err = rule.GaugeOnly.validate(); err != nil {
+			return err
+		}
+	}
+
+	if rule.ForMissing != nil {
+		if err = rule.ForMissing.validate(); err != nil {
+			return err
+		}
+	}
+
+	if rule.RequiredAnnotations != nil {
+		if err = rule.RequiredAnnotations.validate(); err != nil {
+			return err
+		}
+	}
+
+	if rule.RecordingName != nil {
+		if err = rule.RecordingName.validate(); err != nil {
+			return err
+		}
+	}
+
+	if rule.Anonymous != nil {
+		if err = rule.Anonymous.validate(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -139,9 +212,13 @@ func isDisabledForRule(rule parser.Rule, name string, check checks.RuleChecker,
 	for _, tag := range promTags {
 		matches = append(matches, fmt.Sprintf("%s(+%s)", name, tag))
 	}
+	var selectors []*promParser.VectorSelector
+	if expr := rule.Expr(); expr.Query != nil {
+		selectors = utils.CollectSelectors(expr.Query.Expr)
+	}
 	for _, disable := range comments.Only[comments.Disable](rule.Comments, comments.DisableType) {
 		for _, match := range matches {
-			if match == disable.Match {
+			if disable.Matches(match, selectors) {
 				slog.Debug(
 					"Check disabled by comment",
 					slog.String("check", check.String()),
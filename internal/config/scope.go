@@ -0,0 +1,35 @@
+package config
+
+import (
+	"errors"
+
+	"github.com/cloudflare/pint/internal/checks"
+)
+
+type ScopeSettings struct {
+	Comment  string   `hcl:"comment,optional" json:"comment,omitempty"`
+	Severity string   `hcl:"severity,optional" json:"severity,omitempty"`
+	Labels   []string `hcl:"labels,optional" json:"labels,omitempty"`
+}
+
+func (ss ScopeSettings) validate() error {
+	if ss.Severity != "" {
+		if _, err := checks.ParseSeverity(ss.Severity); err != nil {
+			return err
+		}
+	}
+	for _, name := range ss.Labels {
+		if name == "" {
+			return errors.New("labels cannot contain empty values")
+		}
+	}
+	return nil
+}
+
+func (ss ScopeSettings) getSeverity(fallback checks.Severity) checks.Severity {
+	if ss.Severity != "" {
+		sev, _ := checks.ParseSeverity(ss.Severity)
+		return sev
+	}
+	return fallback
+}
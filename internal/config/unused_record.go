@@ -0,0 +1,28 @@
+package config
+
+import (
+	"github.com/cloudflare/pint/internal/checks"
+)
+
+type UnusedRecordSettings struct {
+	Comment  string `hcl:"comment,optional" json:"comment,omitempty"`
+	Severity string `hcl:"severity,optional" json:"severity,omitempty"`
+}
+
+func (us UnusedRecordSettings) validate() error {
+	if us.Severity != "" {
+		if _, err := checks.ParseSeverity(us.Severity); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (us UnusedRecordSettings) getSeverity(fallback checks.Severity) checks.Severity {
+	if us.Severity != "" {
+		sev, _ := checks.ParseSeverity(us.Severity)
+		return sev
+	}
+	return fallback
+}
@@ -10,6 +10,8 @@ import (
 	"slices"
 	"time"
 
+	"github.com/prometheus/common/model"
+
 	"github.com/cloudflare/pint/internal/comments"
 	"github.com/cloudflare/pint/internal/parser"
 )
@@ -80,12 +82,83 @@ type Entry struct {
 	PathError      error
 	Path           Path
 	Owner          string
+	GroupName      string
+	GroupInterval  time.Duration // Evaluation interval of the rule group, zero if not set.
+	ContentHash    string        // Hash of the rule content, it doesn't change if only formatting or comments are modified.
 	ModifiedLines  []int
 	DisabledChecks []string
 	Rule           parser.Rule
 	State          ChangeType
 }
 
+// IsChanged returns true if this entry needs to be checked when only
+// changed rules are checked.
+// Removed rules are never checked and moved rules are always re-checked,
+// all other rules are only checked if any of their lines were modified.
+func (e Entry) IsChanged() bool {
+	// nolint: exhaustive
+	switch e.State {
+	case Removed:
+		return false
+	case Added, Modified, Moved:
+		return true
+	}
+	if e.PathError != nil {
+		return len(e.ModifiedLines) > 0
+	}
+	for _, line := range e.ModifiedLines {
+		if e.Rule.Lines.Contains(line) {
+			return true
+		}
+	}
+	return false
+}
+
+// DiffEntries compares rules from before and after a change and returns
+// entries with State set for each of them.
+// Rules are matched by content first and by name second, matched rules
+// are marked as Moved if they are now in a different file, Modified if their
+// content changed and Noop otherwise. Rules that are only present after
+// the change are marked as Added and rules only present before it as Removed.
+func DiffEntries(before, after []Entry) (entries []Entry) {
+	for _, me := range matchEntries(before, after) {
+		switch state := me.state(); state {
+		case Removed:
+			me.before.State = state
+			me.before.ModifiedLines = me.before.Rule.Lines.Expand()
+			entries = append(entries, me.before)
+		case Noop:
+			me.after.State = state
+			me.after.ModifiedLines = []int{}
+			entries = append(entries, me.after)
+		default:
+			me.after.State = state
+			me.after.ModifiedLines = me.after.Rule.Lines.Expand()
+			entries = append(entries, me.after)
+		}
+	}
+	return entries
+}
+
+// DiscoverStdin reads rules from given reader and returns entries for them
+// using name as the file path. It's meant for linting content that doesn't exist
+// on disk, like unsaved editor buffers, so all rules are marked as modified.
+func DiscoverStdin(r io.Reader, name string, isStrict bool, schema parser.Schema, names model.ValidationScheme) (entries []Entry, err error) {
+	p := parser.NewParser(isStrict, schema, names)
+	el, err := readRules(name, name, r, p, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid file syntax: %w", err)
+	}
+	for _, e := range el {
+		e.State = Modified
+		if len(e.ModifiedLines) == 0 {
+			e.ModifiedLines = e.Rule.Lines.Expand()
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
 func readRules(reportedPath, sourcePath string, r io.Reader, p parser.Parser, allowedOwners []*regexp.Regexp) (entries []Entry, err error) {
 	content, err := parser.ReadContent(r)
 	if err != nil {
@@ -100,6 +173,7 @@ func readRules(reportedPath, sourcePath string, r io.Reader, p parser.Parser, al
 	var badOwners []comments.Comment
 	var fileOwner string
 	var disabledChecks []string
+	var groupDisables []comments.GroupDisable
 	for _, comment := range content.FileComments {
 		// nolint:exhaustive
 		switch comment.Type {
@@ -115,6 +189,8 @@ func readRules(reportedPath, sourcePath string, r io.Reader, p parser.Parser, al
 			if !slices.Contains(disabledChecks, disable.Match) {
 				disabledChecks = append(disabledChecks, disable.Match)
 			}
+		case comments.GroupDisableType:
+			groupDisables = append(groupDisables, comment.Value.(comments.GroupDisable))
 		case comments.FileSnoozeType:
 			snooze := comment.Value.(comments.Snooze)
 			if !snooze.Until.After(time.Now()) {
@@ -159,7 +235,7 @@ func readRules(reportedPath, sourcePath string, r io.Reader, p parser.Parser, al
 		return entries, nil
 	}
 
-	rules, err := p.Parse(content.Body)
+	rules, groups, err := p.ParseGroups(content.Body)
 	if err != nil {
 		slog.Warn(
 			"Failed to parse file content",
@@ -180,6 +256,7 @@ func readRules(reportedPath, sourcePath string, r io.Reader, p parser.Parser, al
 	}
 
 	for _, rule := range rules {
+		group := ruleGroup(rule, groups)
 		ruleOwner := fileOwner
 		for _, owner := range comments.Only[comments.Owner](rule.Comments, comments.RuleOwnerType) {
 			ruleOwner = owner.Name
@@ -190,9 +267,12 @@ func readRules(reportedPath, sourcePath string, r io.Reader, p parser.Parser, al
 				SymlinkTarget: reportedPath,
 			},
 			Rule:           rule,
+			ContentHash:    rule.ContentHash(),
 			ModifiedLines:  rule.Lines.Expand(),
 			Owner:          ruleOwner,
-			DisabledChecks: disabledChecks,
+			GroupName:      group.Name,
+			GroupInterval:  group.Interval,
+			DisabledChecks: groupDisabledChecks(rule, groups, groupDisables, disabledChecks),
 		})
 	}
 
@@ -214,6 +294,35 @@ func readRules(reportedPath, sourcePath string, r io.Reader, p parser.Parser, al
 	return entries, nil
 }
 
+func ruleGroup(rule parser.Rule, groups []parser.Group) parser.Group {
+	for _, group := range groups {
+		if group.Contains(rule) {
+			return group
+		}
+	}
+	return parser.Group{}
+}
+
+func groupDisabledChecks(rule parser.Rule, groups []parser.Group, groupDisables []comments.GroupDisable, disabledChecks []string) []string {
+	if len(groupDisables) == 0 {
+		return disabledChecks
+	}
+	for _, group := range groups {
+		if !group.Contains(rule) {
+			continue
+		}
+		for _, gd := range groupDisables {
+			if gd.Group != group.Name {
+				continue
+			}
+			if !slices.Contains(disabledChecks, gd.Match) {
+				disabledChecks = append(slices.Clone(disabledChecks), gd.Match)
+			}
+		}
+	}
+	return disabledChecks
+}
+
 func isValidOwner(s string, valid []*regexp.Regexp) bool {
 	if len(valid) == 0 {
 		return true
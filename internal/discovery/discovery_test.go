@@ -6,12 +6,15 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"maps"
+	"slices"
 	"strings"
 	"testing"
 
 	"github.com/prometheus/common/model"
 	"github.com/stretchr/testify/require"
 
+	"github.com/cloudflare/pint/internal/comments"
 	"github.com/cloudflare/pint/internal/parser"
 )
 
@@ -165,12 +168,103 @@ groups:
 						Name:          "rules.yml",
 						SymlinkTarget: "rules.yml",
 					},
+					GroupName:      "foo",
 					ModifiedLines:  []int{7, 8},
 					Rule:           mustParse(6, "- record: foo\n  expr: bar\n"),
 					DisabledChecks: []string{"promql/series"},
 				},
 			},
 		},
+		{
+			title:        "group/disable comment",
+			reportedPath: "rules.yml",
+			sourcePath:   "rules.yml",
+			sourceFunc: func(_ *testing.T) io.Reader {
+				return bytes.NewBuffer([]byte(`
+# pint group/disable A promql/series
+
+groups:
+- name: A
+  rules:
+  - record: foo
+    expr: bar
+- name: B
+  rules:
+  - record: foo
+    expr: bar
+`))
+			},
+			isStrict: true,
+			entries: []Entry{
+				{
+					State: Unknown,
+					Path: Path{
+						Name:          "rules.yml",
+						SymlinkTarget: "rules.yml",
+					},
+					GroupName:      "A",
+					ModifiedLines:  []int{7, 8},
+					Rule:           mustParse(6, "- record: foo\n  expr: bar\n"),
+					DisabledChecks: []string{"promql/series"},
+				},
+				{
+					State: Unknown,
+					Path: Path{
+						Name:          "rules.yml",
+						SymlinkTarget: "rules.yml",
+					},
+					GroupName:     "B",
+					ModifiedLines: []int{11, 12},
+					Rule:          mustParse(10, "- record: foo\n  expr: bar\n"),
+				},
+			},
+		},
+		{
+			title:        "group/disable comment with file/disable",
+			reportedPath: "rules.yml",
+			sourcePath:   "rules.yml",
+			sourceFunc: func(_ *testing.T) io.Reader {
+				return bytes.NewBuffer([]byte(`
+# pint file/disable promql/rate
+# pint group/disable B promql/series
+
+groups:
+- name: A
+  rules:
+  - record: foo
+    expr: bar
+- name: B
+  rules:
+  - record: foo
+    expr: bar
+`))
+			},
+			isStrict: true,
+			entries: []Entry{
+				{
+					State: Unknown,
+					Path: Path{
+						Name:          "rules.yml",
+						SymlinkTarget: "rules.yml",
+					},
+					GroupName:      "A",
+					ModifiedLines:  []int{8, 9},
+					Rule:           mustParse(7, "- record: foo\n  expr: bar\n"),
+					DisabledChecks: []string{"promql/rate"},
+				},
+				{
+					State: Unknown,
+					Path: Path{
+						Name:          "rules.yml",
+						SymlinkTarget: "rules.yml",
+					},
+					GroupName:      "B",
+					ModifiedLines:  []int{12, 13},
+					Rule:           mustParse(11, "- record: foo\n  expr: bar\n"),
+					DisabledChecks: []string{"promql/rate", "promql/series"},
+				},
+			},
+		},
 		{
 			title:        "single expired snooze comment",
 			reportedPath: "rules.yml",
@@ -219,6 +313,7 @@ groups:
 						Name:          "rules.yml",
 						SymlinkTarget: "rules.yml",
 					},
+					GroupName:     "foo",
 					ModifiedLines: []int{7, 8},
 					Rule:          mustParse(6, "- record: foo\n  expr: bar\n"),
 				},
@@ -273,6 +368,7 @@ groups:
 						Name:          "rules.yml",
 						SymlinkTarget: "rules.yml",
 					},
+					GroupName:      "foo",
 					ModifiedLines:  []int{7, 8},
 					Rule:           mustParse(6, "- record: foo\n  expr: bar\n"),
 					DisabledChecks: []string{"promql/series"},
@@ -332,6 +428,95 @@ groups:
 				},
 			},
 		},
+		{
+			title:        "ignore/end without ignore/begin",
+			reportedPath: "rules.yml",
+			sourcePath:   "rules.yml",
+			sourceFunc: func(_ *testing.T) io.Reader {
+				return bytes.NewBuffer([]byte(`
+# pint ignore/end
+- record: foo
+  expr: bar
+`))
+			},
+			isStrict: false,
+			entries: []Entry{
+				{
+					State: Unknown,
+					Path: Path{
+						Name:          "rules.yml",
+						SymlinkTarget: "rules.yml",
+					},
+					ModifiedLines: []int{1, 2, 3, 4},
+					PathError: comments.CommentError{
+						Line: 2,
+						Err:  errors.New("ignore/end comment without a matching ignore/begin comment"),
+					},
+				},
+				{
+					State: Unknown,
+					Path: Path{
+						Name:          "rules.yml",
+						SymlinkTarget: "rules.yml",
+					},
+					ModifiedLines: []int{3, 4},
+					Rule:          mustParse(2, "- record: foo\n  expr: bar\n"),
+				},
+			},
+		},
+		{
+			title:        "multiple groups",
+			reportedPath: "rules.yml",
+			sourcePath:   "rules.yml",
+			sourceFunc: func(_ *testing.T) io.Reader {
+				return bytes.NewBuffer([]byte(`
+groups:
+- name: first
+  rules:
+  - record: foo
+    expr: bar
+  - record: bar
+    expr: foo
+- name: second
+  rules:
+  - record: foo
+    expr: bar
+`))
+			},
+			isStrict: true,
+			entries: []Entry{
+				{
+					State: Unknown,
+					Path: Path{
+						Name:          "rules.yml",
+						SymlinkTarget: "rules.yml",
+					},
+					GroupName:     "first",
+					ModifiedLines: []int{5, 6},
+					Rule:          mustParse(4, "- record: foo\n  expr: bar\n"),
+				},
+				{
+					State: Unknown,
+					Path: Path{
+						Name:          "rules.yml",
+						SymlinkTarget: "rules.yml",
+					},
+					GroupName:     "first",
+					ModifiedLines: []int{7, 8},
+					Rule:          mustParse(6, "- record: bar\n  expr: foo\n"),
+				},
+				{
+					State: Unknown,
+					Path: Path{
+						Name:          "rules.yml",
+						SymlinkTarget: "rules.yml",
+					},
+					GroupName:     "second",
+					ModifiedLines: []int{11, 12},
+					Rule:          mustParse(10, "- record: foo\n  expr: bar\n"),
+				},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -346,6 +531,11 @@ groups:
 				} else {
 					require.NoError(t, err)
 
+					for i := range tc.entries {
+						if tc.entries[i].PathError == nil {
+							tc.entries[i].ContentHash = tc.entries[i].Rule.ContentHash()
+						}
+					}
 					expected, err := json.MarshalIndent(tc.entries, "", "  ")
 					require.NoError(t, err, "json(expected)")
 					got, err := json.MarshalIndent(entries, "", "  ")
@@ -355,3 +545,181 @@ groups:
 			})
 	}
 }
+
+func TestDiscoverStdin(t *testing.T) {
+	content := []byte(`
+- record: foo
+  expr: sum(up)
+
+- alert: bar
+  expr: up == 0
+`)
+
+	entries, err := DiscoverStdin(bytes.NewReader(content), "buffer.yml", false, parser.PrometheusSchema, model.UTF8Validation)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	for _, e := range entries {
+		require.Equal(t, Modified, e.State)
+		require.Equal(t, Path{Name: "buffer.yml", SymlinkTarget: "buffer.yml"}, e.Path)
+		require.NoError(t, e.PathError)
+	}
+	require.Equal(t, "foo", entries[0].Rule.RecordingRule.Record.Value)
+	require.Equal(t, []int{2, 3}, entries[0].ModifiedLines)
+	require.Equal(t, "bar", entries[1].Rule.AlertingRule.Alert.Value)
+	require.Equal(t, []int{5, 6}, entries[1].ModifiedLines)
+}
+
+func TestDiscoverStdinError(t *testing.T) {
+	_, err := DiscoverStdin(failingReader{err: io.ErrClosedPipe}, "buffer.yml", false, parser.PrometheusSchema, model.UTF8Validation)
+	require.EqualError(t, err, "invalid file syntax: io: read/write on closed pipe")
+}
+
+func TestReadRulesContentHash(t *testing.T) {
+	read := func(content string) string {
+		p := parser.NewParser(false, parser.PrometheusSchema, model.UTF8Validation)
+		entries, err := readRules("rules.yml", "rules.yml", strings.NewReader(content), p, nil)
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		require.NotEmpty(t, entries[0].ContentHash)
+		return entries[0].ContentHash
+	}
+
+	orig := read("- alert: foo\n  expr: up == 0\n  annotations:\n    summary: foo\n    runbook: bar\n")
+	require.Equal(t, orig, read("# comment\n- alert: foo\n  expr: up==0\n  annotations:\n    runbook: bar\n    summary: foo\n"))
+	require.NotEqual(t, orig, read("- alert: foo\n  expr: up == 0\n  annotations:\n    summary: bar\n    runbook: foo\n"))
+	require.NotEqual(t, orig, read("- alert: foo\n  expr: up == 1\n  annotations:\n    summary: foo\n    runbook: bar\n"))
+}
+
+func TestEntryIsChanged(t *testing.T) {
+	type testCaseT struct {
+		description string
+		entry       Entry
+		changed     bool
+	}
+
+	rule := parser.Rule{Lines: parser.LineRange{First: 3, Last: 5}}
+
+	testCases := []testCaseT{
+		{
+			description: "unknown",
+			entry:       Entry{State: Unknown, Rule: rule},
+		},
+		{
+			description: "noop without modified lines",
+			entry:       Entry{State: Noop, Rule: rule},
+		},
+		{
+			description: "noop with modified lines outside of the rule",
+			entry:       Entry{State: Noop, Rule: rule, ModifiedLines: []int{1, 2, 6}},
+		},
+		{
+			description: "noop with modified lines inside the rule",
+			entry:       Entry{State: Noop, Rule: rule, ModifiedLines: []int{1, 5}},
+			changed:     true,
+		},
+		{
+			description: "unknown with modified lines inside the rule",
+			entry:       Entry{State: Unknown, Rule: rule, ModifiedLines: []int{3}},
+			changed:     true,
+		},
+		{
+			description: "added",
+			entry:       Entry{State: Added, Rule: rule, ModifiedLines: []int{3, 4, 5}},
+			changed:     true,
+		},
+		{
+			description: "modified",
+			entry:       Entry{State: Modified, Rule: rule, ModifiedLines: []int{4}},
+			changed:     true,
+		},
+		{
+			description: "moved",
+			entry:       Entry{State: Moved, Rule: rule},
+			changed:     true,
+		},
+		{
+			description: "removed",
+			entry:       Entry{State: Removed, Rule: rule, ModifiedLines: []int{3, 4, 5}},
+		},
+		{
+			description: "path error with modified lines",
+			entry:       Entry{State: Noop, PathError: errors.New("mock error"), ModifiedLines: []int{1}},
+			changed:     true,
+		},
+		{
+			description: "path error without modified lines",
+			entry:       Entry{State: Noop, PathError: errors.New("mock error")},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			require.Equal(t, tc.changed, tc.entry.IsChanged())
+		})
+	}
+}
+
+func TestDiffEntries(t *testing.T) {
+	type testCaseT struct {
+		description string
+		before      map[string]string
+		after       map[string]string
+		states      map[string]ChangeType
+	}
+
+	read := func(files map[string]string) (entries []Entry) {
+		p := parser.NewParser(false, parser.PrometheusSchema, model.UTF8Validation)
+		for _, path := range slices.Sorted(maps.Keys(files)) {
+			el, err := readRules(path, path, strings.NewReader(files[path]), p, nil)
+			require.NoError(t, err)
+			entries = append(entries, el...)
+		}
+		return entries
+	}
+
+	testCases := []testCaseT{
+		{
+			description: "unchanged rule",
+			before:      map[string]string{"a.yml": "- record: foo\n  expr: sum(up)\n"},
+			after:       map[string]string{"a.yml": "- record: foo\n  expr: sum(up)\n"},
+			states:      map[string]ChangeType{"a.yml:foo": Noop},
+		},
+		{
+			description: "rule moved between files",
+			before:      map[string]string{"a.yml": "- record: foo\n  expr: sum(up)\n- record: bar\n  expr: sum(down)\n"},
+			after: map[string]string{
+				"a.yml": "- record: bar\n  expr: sum(down)\n",
+				"b.yml": "- record: foo\n  expr: sum(up)\n",
+			},
+			states: map[string]ChangeType{"a.yml:bar": Noop, "b.yml:foo": Moved},
+		},
+		{
+			description: "rule modified in place",
+			before:      map[string]string{"a.yml": "- record: foo\n  expr: sum(up)\n"},
+			after:       map[string]string{"a.yml": "- record: foo\n  expr: sum(up) by (job)\n"},
+			states:      map[string]ChangeType{"a.yml:foo": Modified},
+		},
+		{
+			description: "rule added and removed",
+			before:      map[string]string{"a.yml": "- record: foo\n  expr: sum(up)\n"},
+			after:       map[string]string{"a.yml": "- record: bar\n  expr: sum(up) by (job)\n"},
+			states:      map[string]ChangeType{"a.yml:foo": Removed, "a.yml:bar": Added},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			states := map[string]ChangeType{}
+			for _, e := range DiffEntries(read(tc.before), read(tc.after)) {
+				states[e.Path.Name+":"+e.Rule.Name()] = e.State
+				if e.State == Noop {
+					require.Empty(t, e.ModifiedLines)
+				} else {
+					require.Equal(t, e.Rule.Lines.Expand(), e.ModifiedLines)
+				}
+			}
+			require.Equal(t, tc.states, states)
+		})
+	}
+}
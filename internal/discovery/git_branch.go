@@ -104,8 +104,8 @@ func (f GitBranchFinder) Find(allEntries []Entry) (entries []Entry, err error) {
 			slog.Any("modifiedLines", change.Body.ModifiedLines),
 		)
 		for _, me := range matchEntries(entriesBefore, entriesAfter) {
-			switch {
-			case !me.hasBefore && me.hasAfter:
+			switch me.state() {
+			case Added:
 				me.after.State = Added
 				me.after.ModifiedLines = commonLines(change.Body.ModifiedLines, me.after.ModifiedLines)
 				slog.Debug(
@@ -117,38 +117,48 @@ func (f GitBranchFinder) Find(allEntries []Entry) (entries []Entry, err error) {
 					slog.String("modifiedLines", output.FormatLineRangeString(me.after.ModifiedLines)),
 				)
 				entries = append(entries, me.after)
-			case me.hasBefore && me.hasAfter:
-				switch {
-				case me.isIdentical && !me.wasMoved:
-					me.after.State = Noop
-					me.after.ModifiedLines = []int{}
-					slog.Debug(
-						"Rule content was not modified on HEAD, identical rule present before",
-						slog.String("name", me.after.Rule.Name()),
-						slog.String("lines", me.after.Rule.Lines.String()),
-					)
-				case me.wasMoved:
-					me.after.State = Moved
-					me.after.ModifiedLines = git.CountLines(change.Body.After)
-					slog.Debug(
-						"Rule content was not modified on HEAD but the file was moved or renamed",
-						slog.String("name", me.after.Rule.Name()),
-						slog.String("lines", me.after.Rule.Lines.String()),
-					)
-				default:
-					me.after.State = Modified
-					me.after.ModifiedLines = commonLines(change.Body.ModifiedLines, me.after.ModifiedLines)
+			case Noop:
+				me.after.State = Noop
+				me.after.ModifiedLines = []int{}
+				slog.Debug(
+					"Rule content was not modified on HEAD, identical rule present before",
+					slog.String("name", me.after.Rule.Name()),
+					slog.String("lines", me.after.Rule.Lines.String()),
+				)
+				entries = append(entries, me.after)
+			case Moved:
+				me.after.State = Moved
+				me.after.ModifiedLines = git.CountLines(change.Body.After)
+				slog.Debug(
+					"Rule content was not modified on HEAD but the file was moved or renamed",
+					slog.String("name", me.after.Rule.Name()),
+					slog.String("lines", me.after.Rule.Lines.String()),
+				)
+				entries = append(entries, me.after)
+			case Modified:
+				me.after.State = Modified
+				me.after.ModifiedLines = commonLines(change.Body.ModifiedLines, me.after.ModifiedLines)
+				slog.Debug(
+					"Rule modified on HEAD branch",
+					slog.String("name", me.after.Rule.Name()),
+					slog.String("state", me.after.State.String()),
+					slog.String("path", me.after.Path.Name),
+					slog.String("ruleLines", me.after.Rule.Lines.String()),
+					slog.String("modifiedLines", output.FormatLineRangeString(me.after.ModifiedLines)),
+				)
+				entries = append(entries, me.after)
+			case Removed:
+				if len(failedEntries) > 0 {
 					slog.Debug(
-						"Rule modified on HEAD branch",
-						slog.String("name", me.after.Rule.Name()),
-						slog.String("state", me.after.State.String()),
-						slog.String("path", me.after.Path.Name),
-						slog.String("ruleLines", me.after.Rule.Lines.String()),
-						slog.String("modifiedLines", output.FormatLineRangeString(me.after.ModifiedLines)),
+						"Rule not present on HEAD branch but there are parse errors",
+						slog.String("name", me.before.Rule.Name()),
+						slog.String("state", me.before.State.String()),
+						slog.String("path", me.before.Path.Name),
+						slog.String("ruleLines", me.before.Rule.Lines.String()),
+						slog.String("modifiedLines", output.FormatLineRangeString(me.before.ModifiedLines)),
 					)
+					continue
 				}
-				entries = append(entries, me.after)
-			case me.hasBefore && !me.hasAfter && len(failedEntries) == 0:
 				me.before.State = Removed
 				ml := commonLines(change.Body.ModifiedLines, me.before.ModifiedLines)
 				if len(ml) > 0 {
@@ -163,15 +173,6 @@ func (f GitBranchFinder) Find(allEntries []Entry) (entries []Entry, err error) {
 					slog.String("modifiedLines", output.FormatLineRangeString(me.before.ModifiedLines)),
 				)
 				entries = append(entries, me.before)
-			case me.hasBefore && !me.hasAfter && len(failedEntries) > 0:
-				slog.Debug(
-					"Rule not present on HEAD branch but there are parse errors",
-					slog.String("name", me.before.Rule.Name()),
-					slog.String("state", me.before.State.String()),
-					slog.String("path", me.before.Path.Name),
-					slog.String("ruleLines", me.before.Rule.Lines.String()),
-					slog.String("modifiedLines", output.FormatLineRangeString(me.before.ModifiedLines)),
-				)
 			default:
 				slog.Warn(
 					"Unknown rule state",
@@ -268,6 +269,28 @@ type matchedEntry struct {
 	wasMoved    bool
 }
 
+// state returns the change type for this pair of entries.
+// Rules present on both sides are moved if they are in a different file now,
+// modified if their content changed and unchanged otherwise.
+func (m matchedEntry) state() ChangeType {
+	switch {
+	case !m.hasBefore && m.hasAfter:
+		return Added
+	case m.hasBefore && !m.hasAfter:
+		return Removed
+	case m.hasBefore && m.hasAfter:
+		switch {
+		case m.wasMoved:
+			return Moved
+		case m.isIdentical:
+			return Noop
+		default:
+			return Modified
+		}
+	}
+	return Unknown
+}
+
 func matchEntries(before, after []Entry) (ml []matchedEntry) {
 	for _, a := range after {
 		slog.Debug(
@@ -222,6 +222,7 @@ groups:
 						Name:          "rules.yml",
 						SymlinkTarget: "rules.yml",
 					},
+					GroupName:     "v2",
 					ModifiedLines: []int{},
 					Rule:          mustParse(4, "- record: up:count\n  expr: count(up == 1)\n"),
 				},
@@ -257,6 +258,7 @@ groups:
 						Name:          "rules.yml",
 						SymlinkTarget: "rules.yml",
 					},
+					GroupName:     "v2",
 					ModifiedLines: []int{6},
 					Rule:          mustParse(4, "- record: up:count\n  expr: count(up == 1)\n"),
 				},
@@ -472,6 +474,7 @@ groups:
 						Name:          "rules.yml",
 						SymlinkTarget: "rules.yml",
 					},
+					GroupName:     "v2",
 					ModifiedLines: []int{6},
 					Rule:          mustParse(4, "- record: up:count:1\n  expr: count(up == 1)\n"),
 				},
@@ -481,6 +484,7 @@ groups:
 						Name:          "rules.yml",
 						SymlinkTarget: "rules.yml",
 					},
+					GroupName:     "v2",
 					ModifiedLines: []int{7},
 					Rule:          mustParse(6, "- record: up:count:2a\n  expr: count(up)\n"),
 				},
@@ -490,6 +494,7 @@ groups:
 						Name:          "rules.yml",
 						SymlinkTarget: "rules.yml",
 					},
+					GroupName:     "v2",
 					ModifiedLines: []int{},
 					Rule:          mustParse(8, "- record: up:count:3\n  expr: count(up)\n"),
 				},
@@ -499,6 +504,7 @@ groups:
 						Name:          "rules.yml",
 						SymlinkTarget: "rules.yml",
 					},
+					GroupName:     "v2",
 					ModifiedLines: []int{11, 12},
 					Rule:          mustParse(10, "- record: up:count:4\n  expr: count(up)\n"),
 				},
@@ -508,6 +514,7 @@ groups:
 						Name:          "rules.yml",
 						SymlinkTarget: "rules.yml",
 					},
+					GroupName:     "v1",
 					ModifiedLines: []int{7},
 					Rule:          mustParse(6, "- record: up:count:2\n  expr: count(up)\n"),
 				},
@@ -722,6 +729,7 @@ groups:
 						Name:          "rules.yml",
 						SymlinkTarget: "rules.yml",
 					},
+					GroupName:     "v2",
 					ModifiedLines: nil,
 					Rule:          mustParse(4, "- record: up:count\n  expr: count(up)\n"),
 				},
@@ -731,6 +739,7 @@ groups:
 						Name:          "rules.yml",
 						SymlinkTarget: "rules.yml",
 					},
+					GroupName:     "v1",
 					ModifiedLines: []int{5, 6, 7},
 					Rule: parser.Rule{
 						Lines: parser.LineRange{First: 5, Last: 7},
@@ -1235,6 +1244,11 @@ groups:
 			} else {
 				require.NoError(t, err, "tc.finder.Find()")
 
+				for i := range tc.entries {
+					if tc.entries[i].PathError == nil {
+						tc.entries[i].ContentHash = tc.entries[i].Rule.ContentHash()
+					}
+				}
 				expected, err := json.MarshalIndent(tc.entries, "", "  ")
 				require.NoError(t, err, "json(expected)")
 				got, err := json.MarshalIndent(entries, "", "  ")
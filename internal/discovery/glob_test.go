@@ -347,6 +347,11 @@ func TestGlobPathFinder(t *testing.T) {
 			} else {
 				require.NoError(t, err)
 
+				for i := range tc.entries {
+					if tc.entries[i].PathError == nil {
+						tc.entries[i].ContentHash = tc.entries[i].Rule.ContentHash()
+					}
+				}
 				expected, err := json.MarshalIndent(tc.entries, "", "  ")
 				require.NoError(t, err, "json(expected)")
 				got, err := json.MarshalIndent(entries, "", "  ")
@@ -77,6 +77,9 @@ func addSymlinkedEntries(entries []Entry) ([]Entry, error) {
 					ModifiedLines:  entry.ModifiedLines,
 					Rule:           entry.Rule,
 					Owner:          entry.Owner,
+					GroupName:      entry.GroupName,
+					GroupInterval:  entry.GroupInterval,
+					ContentHash:    entry.ContentHash,
 					DisabledChecks: entry.DisabledChecks,
 				})
 			}
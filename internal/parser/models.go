@@ -5,7 +5,9 @@ import (
 	"slices"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/cespare/xxhash/v2"
 	"gopkg.in/yaml.v3"
 
 	"github.com/cloudflare/pint/internal/comments"
@@ -272,6 +274,37 @@ func (lr LineRange) Expand() []int {
 	return lines
 }
 
+// Overlaps returns true if both line ranges have at least one line in common.
+func (lr LineRange) Overlaps(other LineRange) bool {
+	return lr.First <= other.Last && other.First <= lr.Last
+}
+
+// Contains returns true if given line is part of this line range.
+func (lr LineRange) Contains(line int) bool {
+	return line >= lr.First && line <= lr.Last
+}
+
+// Union returns the smallest line range that includes both line ranges.
+// If ranges are disjoint then all lines between them are included too.
+func (lr LineRange) Union(other LineRange) LineRange {
+	return LineRange{
+		First: min(lr.First, other.First),
+		Last:  max(lr.Last, other.Last),
+	}
+}
+
+// Group describes a rule group, only set when parsing files in strict mode.
+type Group struct {
+	Name     string
+	Lines    LineRange
+	Interval time.Duration // Evaluation interval set on this group, zero if not set.
+}
+
+// Contains returns true if given rule is part of this group.
+func (g Group) Contains(rule Rule) bool {
+	return rule.Lines.First >= g.Lines.First && rule.Lines.Last <= g.Lines.Last
+}
+
 type Rule struct {
 	AlertingRule  *AlertingRule
 	RecordingRule *RecordingRule
@@ -306,6 +339,72 @@ func (r Rule) IsIdentical(b Rule) bool {
 	return slices.Equal(ac, bc)
 }
 
+// ContentHash returns a hash of the rule content that doesn't depend on
+// the order of keys, labels or annotations, formatting of the query or
+// comments that are not pint comments.
+func (r Rule) ContentHash() string {
+	h := xxhash.New()
+	write := func(section string, vals ...string) {
+		_, _ = h.WriteString(section)
+		_, _ = h.WriteString(":")
+		for _, v := range vals {
+			_, _ = h.WriteString(strconv.Quote(v))
+			_, _ = h.WriteString(",")
+		}
+		_, _ = h.WriteString("\n")
+	}
+	node := func(n *YamlNode) []string {
+		if n == nil {
+			return nil
+		}
+		return []string{n.Value}
+	}
+	items := func(m *YamlMap) []string {
+		if m == nil {
+			return nil
+		}
+		vals := make([]string, 0, len(m.Items))
+		for _, kv := range m.Items {
+			vals = append(vals, kv.Key.Value+"="+kv.Value.Value)
+		}
+		slices.Sort(vals)
+		return vals
+	}
+	expr := func(e PromQLExpr) string {
+		if e.SyntaxError != nil || e.Query == nil {
+			return strings.TrimSpace(e.Value.Value)
+		}
+		return e.Query.Expr.String()
+	}
+
+	write("type", string(r.Type()))
+	switch {
+	case r.AlertingRule != nil:
+		write("alert", r.AlertingRule.Alert.Value)
+		write("expr", expr(r.AlertingRule.Expr))
+		write("for", node(r.AlertingRule.For)...)
+		write("keep_firing_for", node(r.AlertingRule.KeepFiringFor)...)
+		write("labels", items(r.AlertingRule.Labels)...)
+		write("annotations", items(r.AlertingRule.Annotations)...)
+	case r.RecordingRule != nil:
+		write("record", r.RecordingRule.Record.Value)
+		write("expr", expr(r.RecordingRule.Expr))
+		write("labels", items(r.RecordingRule.Labels)...)
+	}
+	if r.Error.Err != nil {
+		write("error", r.Error.Err.Error())
+	}
+
+	cs := make([]string, 0, len(r.Comments))
+	for _, c := range r.Comments {
+		cs = append(cs, c.Value.String())
+	}
+	slices.Sort(cs)
+	write("comments", cs...)
+
+	return strconv.FormatUint(h.Sum64(), 16)
+}
+
 func (r Rule) IsSame(nr Rule) bool {
 	if (r.AlertingRule != nil) != (nr.AlertingRule != nil) {
 		return false
@@ -179,3 +179,252 @@ func TestRuleIsIdentical(t *testing.T) {
 		})
 	}
 }
+
+func TestRuleContentHash(t *testing.T) {
+	type testCaseT struct {
+		a     string
+		b     string
+		equal bool
+	}
+
+	testCases := []testCaseT{
+		{
+			a:     "- record: foo\n  expr: bob\n",
+			b:     "- record: foo\n  expr: bob\n",
+			equal: true,
+		},
+		{
+			a:     "- record: foo\n  expr: bob\n",
+			b:     "- record: bar\n  expr: bob\n",
+			equal: false,
+		},
+		{
+			a:     "- record: foo\n  expr: bob\n",
+			b:     "- expr: bob\n  record: foo\n",
+			equal: true,
+		},
+		{
+			a:     "- record: foo\n  expr: sum(bob) by(job)\n",
+			b:     "- record: foo\n  expr: |\n    sum(bob)\n      by (job)\n",
+			equal: true,
+		},
+		{
+			a:     "- record: foo\n  expr: sum(bob) by(job)\n",
+			b:     "- record: foo\n  expr: sum(bob) by(instance)\n",
+			equal: false,
+		},
+		{
+			a:     "- record: foo\n  expr: bob\n",
+			b:     "- alert: foo\n  expr: bob\n",
+			equal: false,
+		},
+		{
+			a:     "- alert: foo\n  expr: bob\n  annotations:\n    foo: bar\n    bar: foo\n",
+			b:     "- alert: foo\n  expr: bob\n  annotations:\n    bar: foo\n    foo: bar\n",
+			equal: true,
+		},
+		{
+			a:     "- alert: foo\n  expr: bob\n  annotations:\n    foo: bar\n    bar: foo\n",
+			b:     "- alert: foo\n  expr: bob\n  annotations:\n    foo: foo\n    bar: bar\n",
+			equal: false,
+		},
+		{
+			a:     "- alert: foo\n  expr: bob\n  labels:\n    foo: bar\n",
+			b:     "- alert: foo\n  expr: bob\n  annotations:\n    foo: bar\n",
+			equal: false,
+		},
+		{
+			a:     "- alert: foo\n  # some comment\n  expr: bob\n",
+			b:     "- alert: foo\n  expr: bob # another comment\n",
+			equal: true,
+		},
+		{
+			a:     "- alert: foo\n  # pint disable promql/series\n  expr: bob\n",
+			b:     "- alert: foo\n  expr: bob\n",
+			equal: false,
+		},
+		{
+			a:     "- alert: foo\n  expr: bob\n  for: 5m\n",
+			b:     "- alert: foo\n  expr: bob\n",
+			equal: false,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i+1), func(t *testing.T) {
+			a := newMustRule(tc.a)
+			b := newMustRule(tc.b)
+			require.NotEmpty(t, a.ContentHash())
+			require.Equal(t, tc.equal, a.ContentHash() == b.ContentHash())
+		})
+	}
+}
+
+func TestLineRangeOverlaps(t *testing.T) {
+	type testCaseT struct {
+		a        parser.LineRange
+		b        parser.LineRange
+		overlaps bool
+	}
+
+	testCases := []testCaseT{
+		{
+			a:        parser.LineRange{First: 1, Last: 1},
+			b:        parser.LineRange{First: 1, Last: 1},
+			overlaps: true,
+		},
+		{
+			a: parser.LineRange{First: 1, Last: 1},
+			b: parser.LineRange{First: 2, Last: 2},
+		},
+		{
+			a:        parser.LineRange{First: 3, Last: 5},
+			b:        parser.LineRange{First: 4, Last: 4},
+			overlaps: true,
+		},
+		{
+			a:        parser.LineRange{First: 4, Last: 4},
+			b:        parser.LineRange{First: 3, Last: 5},
+			overlaps: true,
+		},
+		{
+			a:        parser.LineRange{First: 3, Last: 5},
+			b:        parser.LineRange{First: 5, Last: 8},
+			overlaps: true,
+		},
+		{
+			a:        parser.LineRange{First: 5, Last: 8},
+			b:        parser.LineRange{First: 3, Last: 5},
+			overlaps: true,
+		},
+		{
+			a: parser.LineRange{First: 3, Last: 5},
+			b: parser.LineRange{First: 6, Last: 8},
+		},
+		{
+			a: parser.LineRange{First: 6, Last: 8},
+			b: parser.LineRange{First: 3, Last: 5},
+		},
+		{
+			a: parser.LineRange{First: 1, Last: 2},
+			b: parser.LineRange{First: 7, Last: 9},
+		},
+		{
+			a:        parser.LineRange{First: 1, Last: 9},
+			b:        parser.LineRange{First: 3, Last: 5},
+			overlaps: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.a.String()+"/"+tc.b.String(), func(t *testing.T) {
+			require.Equal(t, tc.overlaps, tc.a.Overlaps(tc.b))
+		})
+	}
+}
+
+func TestLineRangeContains(t *testing.T) {
+	type testCaseT struct {
+		lr       parser.LineRange
+		line     int
+		contains bool
+	}
+
+	testCases := []testCaseT{
+		{
+			lr:       parser.LineRange{First: 1, Last: 1},
+			line:     1,
+			contains: true,
+		},
+		{
+			lr:   parser.LineRange{First: 1, Last: 1},
+			line: 0,
+		},
+		{
+			lr:   parser.LineRange{First: 1, Last: 1},
+			line: 2,
+		},
+		{
+			lr:       parser.LineRange{First: 3, Last: 5},
+			line:     3,
+			contains: true,
+		},
+		{
+			lr:       parser.LineRange{First: 3, Last: 5},
+			line:     4,
+			contains: true,
+		},
+		{
+			lr:       parser.LineRange{First: 3, Last: 5},
+			line:     5,
+			contains: true,
+		},
+		{
+			lr:   parser.LineRange{First: 3, Last: 5},
+			line: 2,
+		},
+		{
+			lr:   parser.LineRange{First: 3, Last: 5},
+			line: 6,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.lr.String()+"/"+strconv.Itoa(tc.line), func(t *testing.T) {
+			require.Equal(t, tc.contains, tc.lr.Contains(tc.line))
+		})
+	}
+}
+
+func TestLineRangeUnion(t *testing.T) {
+	type testCaseT struct {
+		a     parser.LineRange
+		b     parser.LineRange
+		union parser.LineRange
+	}
+
+	testCases := []testCaseT{
+		{
+			a:     parser.LineRange{First: 1, Last: 1},
+			b:     parser.LineRange{First: 1, Last: 1},
+			union: parser.LineRange{First: 1, Last: 1},
+		},
+		{
+			a:     parser.LineRange{First: 1, Last: 1},
+			b:     parser.LineRange{First: 2, Last: 2},
+			union: parser.LineRange{First: 1, Last: 2},
+		},
+		{
+			a:     parser.LineRange{First: 3, Last: 5},
+			b:     parser.LineRange{First: 4, Last: 4},
+			union: parser.LineRange{First: 3, Last: 5},
+		},
+		{
+			a:     parser.LineRange{First: 3, Last: 5},
+			b:     parser.LineRange{First: 4, Last: 8},
+			union: parser.LineRange{First: 3, Last: 8},
+		},
+		{
+			a:     parser.LineRange{First: 3, Last: 5},
+			b:     parser.LineRange{First: 6, Last: 8},
+			union: parser.LineRange{First: 3, Last: 8},
+		},
+		{
+			a:     parser.LineRange{First: 6, Last: 8},
+			b:     parser.LineRange{First: 3, Last: 5},
+			union: parser.LineRange{First: 3, Last: 8},
+		},
+		{
+			a:     parser.LineRange{First: 1, Last: 2},
+			b:     parser.LineRange{First: 7, Last: 9},
+			union: parser.LineRange{First: 1, Last: 9},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.a.String()+"/"+tc.b.String(), func(t *testing.T) {
+			require.Equal(t, tc.union, tc.a.Union(tc.b))
+			require.Equal(t, tc.union, tc.b.Union(tc.a))
+		})
+	}
+}
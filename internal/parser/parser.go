@@ -49,8 +49,15 @@ type Parser struct {
 }
 
 func (p Parser) Parse(content []byte) (rules []Rule, err error) {
+	rules, _, err = p.ParseGroups(content)
+	return rules, err
+}
+
+// ParseGroups works like Parse but also returns all rule groups found in the content.
+// Groups are only returned when parsing in strict mode.
+func (p Parser) ParseGroups(content []byte) (rules []Rule, groups []Group, err error) {
 	if len(content) == 0 {
-		return nil, nil
+		return nil, nil, nil
 	}
 
 	defer func() {
@@ -68,15 +75,16 @@ func (p Parser) Parse(content []byte) (rules []Rule, err error) {
 			break
 		}
 		if decodeErr != nil {
-			return nil, tryDecodingYamlError(decodeErr)
+			return nil, nil, tryDecodingYamlError(decodeErr)
 		}
 		index++
 		if p.isStrict {
-			r, err := parseGroups(content, &doc, p.schema)
+			r, g, err := parseGroups(content, &doc, p.schema)
 			if err.Err != nil {
-				return rules, err
+				return rules, groups, err
 			}
 			rules = append(rules, r...)
+			groups = append(groups, g...)
 		} else {
 			rules = append(rules, parseNode(content, &doc, 0, p.schema)...)
 		}
@@ -93,7 +101,7 @@ To allow for multi-document YAML files set parser->relaxed option in pint config
 		}
 	}
 
-	return rules, err
+	return rules, groups, err
 }
 
 func parseNode(content []byte, node *yaml.Node, offset int, schema Schema) (rules []Rule) {
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/prometheus/common/model"
 	"github.com/stretchr/testify/require"
@@ -3093,3 +3094,62 @@ groups:
 		})
 	}
 }
+
+func TestParseGroups(t *testing.T) {
+	type testCaseT struct {
+		content []byte
+		groups  []parser.Group
+		strict  bool
+	}
+
+	testCases := []testCaseT{
+		{
+			content: []byte("- record: foo\n  expr: bar\n"),
+			strict:  false,
+		},
+		{
+			content: []byte(`
+groups:
+- name: foo
+  rules:
+  - record: foo
+    expr: bar
+`),
+			strict: false,
+		},
+		{
+			content: []byte(`
+groups:
+- name: foo
+  rules:
+  - record: foo
+    expr: bar
+  - record: bar
+    expr: foo
+- name: bar
+  interval: 1m
+  rules:
+  - alert: foo
+    expr: bar
+    labels:
+      foo: bar
+- name: empty
+`),
+			strict: true,
+			groups: []parser.Group{
+				{Name: "foo", Lines: parser.LineRange{First: 3, Last: 8}},
+				{Name: "bar", Lines: parser.LineRange{First: 9, Last: 15}, Interval: time.Minute},
+				{Name: "empty", Lines: parser.LineRange{First: 16, Last: 16}},
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i+1), func(t *testing.T) {
+			p := parser.NewParser(tc.strict, parser.PrometheusSchema, model.UTF8Validation)
+			_, groups, err := p.ParseGroups(tc.content)
+			require.NoError(t, err)
+			require.Equal(t, tc.groups, groups)
+		})
+	}
+}
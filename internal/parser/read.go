@@ -3,6 +3,7 @@ package parser
 import (
 	"bufio"
 	"errors"
+	"fmt"
 	"io"
 	"strings"
 
@@ -18,6 +19,7 @@ const (
 	skipEnd
 	skipCurrentLine
 	skipFile
+	skipBlock
 )
 
 func emptyLine(line string, comments []comments.Comment, stripComments bool) string {
@@ -59,10 +61,12 @@ func ReadContent(r io.Reader) (out Content, err error) {
 		found        bool
 		skip         skipMode
 
-		skipNext  bool
-		autoReset bool
-		skipAll   bool
-		inBegin   bool
+		skipNext    bool
+		autoReset   bool
+		skipAll     bool
+		inBegin     bool
+		beginLine   int // Line of the last ignore/begin comment.
+		ignoreUntil int // Last line ignored by ignore/block comment.
 	)
 
 	for {
@@ -93,13 +97,26 @@ func ReadContent(r io.Reader) (out Content, err error) {
 					found = true
 				case comments.IgnoreBeginType:
 					skip = skipBegin
+					if !inBegin {
+						beginLine = lineno
+					}
 					found = true
 				case comments.IgnoreEndType:
 					skip = skipEnd
 					found = true
+					if !inBegin {
+						out.FileComments = append(out.FileComments, unbalancedComment(
+							lineno,
+							"%s comment without a matching %s comment", comments.IgnoreEndComment, comments.IgnoreBeginComment,
+						))
+					}
 				case comments.IgnoreNextLineType:
 					skip = skipNextLine
 					found = true
+				case comments.IgnoreBlockType:
+					skip = skipBlock
+					ignoreUntil = lineno + comment.Value.(comments.IgnoreBlock).Lines
+					found = true
 				case comments.FileOwnerType:
 					out.FileComments = append(out.FileComments, comment)
 				case comments.RuleOwnerType:
@@ -114,6 +131,12 @@ func ReadContent(r io.Reader) (out Content, err error) {
 					// pass
 				case comments.RuleSetType:
 					// pass
+				case comments.RuleLinkType:
+					// pass
+				case comments.SeveritySetType:
+					// pass
+				case comments.GroupDisableType:
+					out.FileComments = append(out.FileComments, comment)
 				case comments.InvalidComment:
 					out.FileComments = append(out.FileComments, comment)
 				}
@@ -150,7 +173,11 @@ func ReadContent(r io.Reader) (out Content, err error) {
 					skipNext = false
 					autoReset = true
 					inBegin = false
+				case skipBlock:
+					out.Body = append(out.Body, []byte(line)...)
 				}
+			case lineno <= ignoreUntil:
+				out.Body = append(out.Body, []byte(emptyLine(line, lineComments, inBegin))...)
 			case skipNext:
 				out.Body = append(out.Body, []byte(emptyLine(line, lineComments, inBegin))...)
 				if autoReset {
@@ -170,5 +197,22 @@ func ReadContent(r io.Reader) (out Content, err error) {
 		return out, err
 	}
 
+	if inBegin && !out.Ignored {
+		out.FileComments = append(out.FileComments, unbalancedComment(
+			beginLine,
+			"%s comment without a matching %s comment, all lines after it will be ignored", comments.IgnoreBeginComment, comments.IgnoreEndComment,
+		))
+	}
+
 	return out, nil
 }
+
+func unbalancedComment(line int, format string, args ...any) comments.Comment {
+	return comments.Comment{
+		Type: comments.InvalidComment,
+		Value: comments.Invalid{Err: comments.CommentError{
+			Line: line,
+			Err:  fmt.Errorf(format, args...),
+		}},
+	}
+}
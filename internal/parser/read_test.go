@@ -2,6 +2,7 @@ package parser_test
 
 import (
 	"bytes"
+	"errors"
 	"strconv"
 	"strings"
 	"testing"
@@ -71,6 +72,18 @@ func TestReadContent(t *testing.T) {
 			input:  []byte("# pint ignore/next-line\nfoo\nbar\n"),
 			output: []byte("# pint ignore/next-line\n   \nbar\n"),
 		},
+		{
+			input:  []byte("# pint ignore/block 2\nfoo\nbar\nbaz\n"),
+			output: []byte("# pint ignore/block 2\n   \n   \nbaz\n"),
+		},
+		{
+			input:  []byte("foo\n# pint ignore/block 1\nbar # pint disable promql/series\nbaz\n"),
+			output: []byte("foo\n# pint ignore/block 1\n    # pint disable promql/series\nbaz\n"),
+		},
+		{
+			input:  []byte("# pint ignore/block 5\nfoo\nbar\n"),
+			output: []byte("# pint ignore/block 5\n   \n   \n"),
+		},
 		{
 			input:  []byte("# pint ignore/next-line  \nfoo\n"),
 			output: []byte("# pint ignore/next-line  \n   \n"),
@@ -94,18 +107,54 @@ func TestReadContent(t *testing.T) {
 		{
 			input:  []byte("# pint ignore/begin\nfoo\nbar\n"),
 			output: []byte("# pint ignore/begin\n   \n   \n"),
+			comments: []comments.Comment{
+				{
+					Type: comments.InvalidComment,
+					Value: comments.Invalid{Err: comments.CommentError{
+						Line: 1,
+						Err:  errors.New("ignore/begin comment without a matching ignore/end comment, all lines after it will be ignored"),
+					}},
+				},
+			},
 		},
 		{
 			input:  []byte("prefix # pint ignore/begin\nfoo\nbar\n"),
 			output: []byte("prefix # pint ignore/begin\n   \n   \n"),
+			comments: []comments.Comment{
+				{
+					Type: comments.InvalidComment,
+					Value: comments.Invalid{Err: comments.CommentError{
+						Line: 1,
+						Err:  errors.New("ignore/begin comment without a matching ignore/end comment, all lines after it will be ignored"),
+					}},
+				},
+			},
 		},
 		{
 			input:  []byte("# pint ignore/begin\nfoo\nbar\n# pint ignore/begin"),
 			output: []byte("# pint ignore/begin\n   \n   \n# pint ignore/begin"),
+			comments: []comments.Comment{
+				{
+					Type: comments.InvalidComment,
+					Value: comments.Invalid{Err: comments.CommentError{
+						Line: 1,
+						Err:  errors.New("ignore/begin comment without a matching ignore/end comment, all lines after it will be ignored"),
+					}},
+				},
+			},
 		},
 		{
 			input:  []byte("# pint ignore/begin\nfoo\nbar\n# pint ignore/begin\nfoo\n"),
 			output: []byte("# pint ignore/begin\n   \n   \n# pint ignore/begin\n   \n"),
+			comments: []comments.Comment{
+				{
+					Type: comments.InvalidComment,
+					Value: comments.Invalid{Err: comments.CommentError{
+						Line: 1,
+						Err:  errors.New("ignore/begin comment without a matching ignore/end comment, all lines after it will be ignored"),
+					}},
+				},
+			},
 		},
 		{
 			input:  []byte("# pint ignore/begin\nfoo\nbar\n# pint ignore/end\nfoo\n"),
@@ -114,6 +163,41 @@ func TestReadContent(t *testing.T) {
 		{
 			input:  []byte("# pint ignore/begin\nfoo # pint ignore/line\nbar\n# pint ignore/begin"),
 			output: []byte("# pint ignore/begin\n                      \n   \n# pint ignore/begin"),
+			comments: []comments.Comment{
+				{
+					Type: comments.InvalidComment,
+					Value: comments.Invalid{Err: comments.CommentError{
+						Line: 1,
+						Err:  errors.New("ignore/begin comment without a matching ignore/end comment, all lines after it will be ignored"),
+					}},
+				},
+			},
+		},
+		{
+			input:  []byte("foo\n# pint ignore/end\nbar\n"),
+			output: []byte("foo\n# pint ignore/end\nbar\n"),
+			comments: []comments.Comment{
+				{
+					Type: comments.InvalidComment,
+					Value: comments.Invalid{Err: comments.CommentError{
+						Line: 2,
+						Err:  errors.New("ignore/end comment without a matching ignore/begin comment"),
+					}},
+				},
+			},
+		},
+		{
+			input:  []byte("# pint ignore/begin\nfoo\n# pint ignore/end\n# pint ignore/end\nbar\n"),
+			output: []byte("# pint ignore/begin\n   \n# pint ignore/end\n# pint ignore/end\nbar\n"),
+			comments: []comments.Comment{
+				{
+					Type: comments.InvalidComment,
+					Value: comments.Invalid{Err: comments.CommentError{
+						Line: 4,
+						Err:  errors.New("ignore/end comment without a matching ignore/begin comment"),
+					}},
+				},
+			},
 		},
 		{
 			input:  []byte("line1\nline2 # pint ignore/line\n"),
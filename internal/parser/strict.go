@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/prometheus/common/model"
 	"gopkg.in/yaml.v3"
@@ -40,12 +41,12 @@ func describeTag(tag string) string {
 	}
 }
 
-func parseGroups(content []byte, doc *yaml.Node, schema Schema) (rules []Rule, err ParseError) {
+func parseGroups(content []byte, doc *yaml.Node, schema Schema) (rules []Rule, groups []Group, err ParseError) {
 	names := map[string]struct{}{}
 
 	for _, node := range unpackNodes(doc) {
 		if !isTag(node.ShortTag(), mapTag) {
-			return nil, ParseError{
+			return nil, nil, ParseError{
 				Line: node.Line,
 				Err:  fmt.Errorf("top level field must be a groups key, got %s", describeTag(node.ShortTag())),
 			}
@@ -53,45 +54,55 @@ func parseGroups(content []byte, doc *yaml.Node, schema Schema) (rules []Rule, e
 
 		for _, entry := range mappingNodes(node) {
 			if entry.key.ShortTag() != strTag {
-				return nil, ParseError{
+				return nil, nil, ParseError{
 					Line: entry.key.Line,
 					Err:  fmt.Errorf("groups key must be a %s, got a %s", describeTag(strTag), describeTag(entry.key.ShortTag())),
 				}
 			}
 			if entry.key.Value != "groups" {
-				return nil, ParseError{
+				return nil, nil, ParseError{
 					Line: entry.key.Line,
 					Err:  fmt.Errorf("unexpected key %s", entry.key.Value),
 				}
 			}
 			if !isTag(entry.val.ShortTag(), seqTag) {
-				return nil, ParseError{
+				return nil, nil, ParseError{
 					Line: entry.key.Line,
 					Err:  fmt.Errorf("groups value must be a %s, got %s", describeTag(seqTag), describeTag(entry.val.ShortTag())),
 				}
 			}
 			for _, group := range unpackNodes(entry.val) {
-				name, r, err := parseGroup(content, group, schema)
+				g, r, err := parseGroup(content, group, schema)
 				if err.Err != nil {
-					return rules, err
+					return rules, groups, err
 				}
-				if _, ok := names[name]; ok {
-					return nil, ParseError{
+				if _, ok := names[g.Name]; ok {
+					return nil, nil, ParseError{
 						Line: group.Line,
 						Err:  errors.New("duplicated group name"),
 					}
 				}
-				names[name] = struct{}{}
+				names[g.Name] = struct{}{}
 				rules = append(rules, r...)
+				g.Lines = groupLines(group, r)
+				groups = append(groups, g)
 			}
 		}
 	}
-	return rules, ParseError{}
+	return rules, groups, ParseError{}
 }
 
-func parseGroup(content []byte, group *yaml.Node, schema Schema) (name string, rules []Rule, err ParseError) {
+func groupLines(group *yaml.Node, rules []Rule) LineRange {
+	lr := rangeFromYamlMaps(mappingNodes(group))
+	for _, rule := range rules {
+		lr.Last = max(lr.Last, rule.Lines.Last)
+	}
+	return lr
+}
+
+func parseGroup(content []byte, group *yaml.Node, schema Schema) (g Group, rules []Rule, err ParseError) {
 	if !isTag(group.ShortTag(), mapTag) {
-		return "", nil, ParseError{
+		return Group{}, nil, ParseError{
 			Line: group.Line,
 			Err:  fmt.Errorf("group must be a %s, got %s", describeTag(mapTag), describeTag(group.ShortTag())),
 		}
@@ -103,41 +114,45 @@ func parseGroup(content []byte, group *yaml.Node, schema Schema) (name string, r
 		switch entry.key.Value {
 		case "name":
 			if entry.val.Kind != yaml.ScalarNode || entry.val.ShortTag() != strTag {
-				return "", nil, ParseError{
+				return Group{}, nil, ParseError{
 					Line: entry.key.Line,
 					Err:  fmt.Errorf("group name must be a %s, got %s", describeTag(strTag), describeTag(entry.val.ShortTag())),
 				}
 			}
 			if entry.val.Value == "" {
-				return "", nil, ParseError{
+				return Group{}, nil, ParseError{
 					Line: entry.key.Line,
 					Err:  errors.New("group name cannot be empty"),
 				}
 			}
-			name = entry.val.Value
+			g.Name = entry.val.Value
 		case "interval", "query_offset":
 			if entry.val.Kind != yaml.ScalarNode || entry.val.ShortTag() != strTag {
-				return "", nil, ParseError{
+				return Group{}, nil, ParseError{
 					Line: entry.key.Line,
 					Err:  fmt.Errorf("group %s must be a %s, got %s", entry.key.Value, describeTag(strTag), describeTag(entry.val.ShortTag())),
 				}
 			}
-			if _, err := model.ParseDuration(entry.val.Value); err != nil {
-				return "", nil, ParseError{
+			dur, err := model.ParseDuration(entry.val.Value)
+			if err != nil {
+				return Group{}, nil, ParseError{
 					Line: entry.key.Line,
 					Err:  fmt.Errorf("invalid %s value: %w", entry.key.Value, err),
 				}
 			}
+			if entry.key.Value == "interval" {
+				g.Interval = time.Duration(dur)
+			}
 		case "limit":
 			if entry.val.Kind != yaml.ScalarNode || entry.val.ShortTag() != intTag {
-				return "", nil, ParseError{
+				return Group{}, nil, ParseError{
 					Line: entry.key.Line,
 					Err:  fmt.Errorf("group limit must be a %s, got %s", describeTag(intTag), describeTag(entry.val.ShortTag())),
 				}
 			}
 		case "rules":
 			if !isTag(entry.val.ShortTag(), seqTag) {
-				return "", nil, ParseError{
+				return Group{}, nil, ParseError{
 					Line: entry.key.Line,
 					Err:  fmt.Errorf("rules must be a %s, got %s", describeTag(seqTag), describeTag(entry.val.ShortTag())),
 				}
@@ -145,19 +160,19 @@ func parseGroup(content []byte, group *yaml.Node, schema Schema) (name string, r
 			for _, rule := range unpackNodes(entry.val) {
 				r, err := parseRuleStrict(content, rule)
 				if err.Err != nil {
-					return "", nil, err
+					return Group{}, nil, err
 				}
 				rules = append(rules, r)
 			}
 		case "partial_response_strategy":
 			if schema != ThanosSchema {
-				return "", nil, ParseError{
+				return Group{}, nil, ParseError{
 					Line: entry.key.Line,
 					Err:  errors.New("partial_response_strategy is only valid when parser is configured to use the Thanos rule schema"),
 				}
 			}
 			if !isTag(entry.val.ShortTag(), strTag) {
-				return "", nil, ParseError{
+				return Group{}, nil, ParseError{
 					Line: entry.key.Line,
 					Err:  fmt.Errorf("partial_response_strategy must be a %s, got %s", describeTag(strTag), describeTag(entry.val.ShortTag())),
 				}
@@ -166,20 +181,20 @@ func parseGroup(content []byte, group *yaml.Node, schema Schema) (name string, r
 			case "warn":
 			case "abort":
 			default:
-				return "", nil, ParseError{
+				return Group{}, nil, ParseError{
 					Line: entry.key.Line,
 					Err:  fmt.Errorf("invalid partial_response_strategy value: %s", val),
 				}
 			}
 		default:
-			return "", nil, ParseError{
+			return Group{}, nil, ParseError{
 				Line: entry.key.Line,
 				Err:  fmt.Errorf("invalid group key %s", entry.key.Value),
 			}
 		}
 
 		if _, ok := setKeys[entry.key.Value]; ok {
-			return "", nil, ParseError{
+			return Group{}, nil, ParseError{
 				Line: entry.key.Line,
 				Err:  fmt.Errorf("duplicated key %s", entry.key.Value),
 			}
@@ -189,14 +204,14 @@ func parseGroup(content []byte, group *yaml.Node, schema Schema) (name string, r
 
 	if _, ok := setKeys["rules"]; ok {
 		if _, ok := setKeys["name"]; !ok {
-			return "", nil, ParseError{
+			return Group{}, nil, ParseError{
 				Line: group.Line,
 				Err:  errors.New("incomplete group definition, name is required and must be set"),
 			}
 		}
 	}
 
-	return name, rules, ParseError{}
+	return g, rules, ParseError{}
 }
 
 func parseRuleStrict(content []byte, rule *yaml.Node) (Rule, ParseError) {
@@ -0,0 +1,69 @@
+package utils
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	promParser "github.com/prometheus/prometheus/promql/parser"
+)
+
+type SourceCacheContextKey string
+
+const SourceCacheKey = SourceCacheContextKey("sourceCache")
+
+type sourceCacheKey struct {
+	node promParser.Node
+	expr string
+}
+
+// SourceCache stores LabelsSource() results, so multiple checks running
+// against the same rule don't need to walk the same query over and over.
+// Entries are keyed by the query string and the identity of the node,
+// so a cache should only live for a single lint run.
+func NewSourceCache() *SourceCache {
+	return &SourceCache{
+		entries: map[sourceCacheKey][]Source{},
+	}
+}
+
+type SourceCache struct {
+	entries map[sourceCacheKey][]Source
+	mu      sync.Mutex
+}
+
+// LabelsSource works like LabelsSource() but will return cached results
+// if given node was already processed.
+// Returned Source values share memory with the cache and must be treated as read-only.
+func (sc *SourceCache) LabelsSource(expr string, node promParser.Node) []Source {
+	// Expressions is a slice and so it cannot be used as a map key.
+	if _, ok := node.(promParser.Expressions); ok {
+		return LabelsSource(expr, node)
+	}
+
+	key := sourceCacheKey{expr: expr, node: node}
+
+	sc.mu.Lock()
+	src, ok := sc.entries[key]
+	sc.mu.Unlock()
+	if ok {
+		return slices.Clone(src)
+	}
+
+	src = LabelsSource(expr, node)
+
+	sc.mu.Lock()
+	sc.entries[key] = src
+	sc.mu.Unlock()
+
+	return slices.Clone(src)
+}
+
+// CachedLabelsSource will use SourceCache stored in the context if there is one,
+// otherwise it will call LabelsSource() directly.
+func CachedLabelsSource(ctx context.Context, expr string, node promParser.Node) []Source {
+	if sc, ok := ctx.Value(SourceCacheKey).(*SourceCache); ok {
+		return sc.LabelsSource(expr, node)
+	}
+	return LabelsSource(expr, node)
+}
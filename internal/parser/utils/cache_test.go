@@ -0,0 +1,106 @@
+package utils_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/parser/utils"
+
+	promParser "github.com/prometheus/prometheus/promql/parser"
+)
+
+func TestSourceCache(t *testing.T) {
+	expr := `sum(foo{job="bar"}) without(instance) / on(job) group_left(env) sum(bar) by(job, env)`
+	node, err := promParser.ParseExpr(expr)
+	require.NoError(t, err)
+
+	sc := utils.NewSourceCache()
+
+	first := sc.LabelsSource(expr, node)
+	require.Equal(t, utils.LabelsSource(expr, node), first)
+
+	second := sc.LabelsSource(expr, node)
+	require.Equal(t, first, second)
+
+	// Modifying returned slice must not modify cached entries.
+	second[0] = utils.Source{}
+	require.Equal(t, first, sc.LabelsSource(expr, node))
+
+	// Different node for the same query is a different cache entry.
+	lhs := node.(*promParser.BinaryExpr).LHS
+	require.Equal(t, utils.LabelsSource(expr, lhs), sc.LabelsSource(expr, lhs))
+	require.NotEqual(t, first, sc.LabelsSource(expr, lhs))
+
+	// Expressions cannot be used as a map key.
+	args := promParser.Expressions{lhs}
+	require.Equal(t, utils.LabelsSource(expr, args), sc.LabelsSource(expr, args))
+}
+
+func TestCachedLabelsSource(t *testing.T) {
+	expr := `sum(foo) by(job) > 5`
+	node, err := promParser.ParseExpr(expr)
+	require.NoError(t, err)
+
+	require.Equal(t, utils.LabelsSource(expr, node), utils.CachedLabelsSource(context.Background(), expr, node))
+
+	ctx := context.WithValue(context.Background(), utils.SourceCacheKey, utils.NewSourceCache())
+	require.Equal(t, utils.LabelsSource(expr, node), utils.CachedLabelsSource(ctx, expr, node))
+	require.Equal(t, utils.LabelsSource(expr, node), utils.CachedLabelsSource(ctx, expr, node))
+}
+
+func benchmarkRules(b *testing.B) []parser.Rule {
+	queries := []string{
+		`sum(rate(http_requests_total{job="api-%d"}[5m])) by(job, instance) / sum(rate(http_requests_total[5m])) by(job, instance) > 0.5`,
+		`count(up{job="node-%d"} == 0) without(instance) > 0 or vector(0)`,
+		`label_replace(max(foo{cluster="c%d"}) by(cluster), "dc", "$1", "cluster", "(.+)") * on(cluster) group_left(env) cluster_info`,
+		`absent(bar{job="bar-%d"}) or (avg_over_time(bar[10m]) < 1 and on(instance) up == 1)`,
+	}
+
+	var content strings.Builder
+	content.WriteString("groups:\n- name: bench\n  rules:\n")
+	for i := range 5000 {
+		fmt.Fprintf(&content, "  - record: rule:%d\n    expr: '%s'\n", i, fmt.Sprintf(queries[i%len(queries)], i))
+	}
+
+	p := parser.NewParser(true, parser.PrometheusSchema, model.UTF8Validation)
+	rules, err := p.Parse([]byte(content.String()))
+	require.NoError(b, err)
+	require.Len(b, rules, 5000)
+	return rules
+}
+
+// Simulate a number of checks calling LabelsSource() on each rule.
+const benchmarkChecks = 8
+
+func BenchmarkLabelsSource(b *testing.B) {
+	rules := benchmarkRules(b)
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		for _, rule := range rules {
+			expr := rule.Expr()
+			for range benchmarkChecks {
+				_ = utils.LabelsSource(expr.Value.Value, expr.Query.Expr)
+			}
+		}
+	}
+}
+
+func BenchmarkSourceCache(b *testing.B) {
+	rules := benchmarkRules(b)
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		sc := utils.NewSourceCache()
+		for _, rule := range rules {
+			expr := rule.Expr()
+			for range benchmarkChecks {
+				_ = sc.LabelsSource(expr.Value.Value, expr.Query.Expr)
+			}
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package utils
+
+import (
+	promParser "github.com/prometheus/prometheus/promql/parser"
+)
+
+// FindNode returns the first node, including given node itself, for which
+// match returns true, or nil if there's no such node.
+// Nodes are visited depth-first, in the order returned by promParser.Children().
+func FindNode(node promParser.Node, match func(promParser.Node) bool) promParser.Node {
+	if node == nil {
+		return nil
+	}
+
+	if match(node) {
+		return node
+	}
+
+	for _, child := range promParser.Children(node) {
+		if n := FindNode(child, match); n != nil {
+			return n
+		}
+	}
+
+	return nil
+}
+
+// FindAllNodes works like FindNode but returns all nodes for which match
+// returns true.
+func FindAllNodes(node promParser.Node, match func(promParser.Node) bool) (nodes []promParser.Node) {
+	if node == nil {
+		return nil
+	}
+
+	if match(node) {
+		nodes = append(nodes, node)
+	}
+
+	for _, child := range promParser.Children(node) {
+		nodes = append(nodes, FindAllNodes(child, match)...)
+	}
+
+	return nodes
+}
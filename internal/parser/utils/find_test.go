@@ -0,0 +1,97 @@
+package utils_test
+
+import (
+	"testing"
+
+	promParser "github.com/prometheus/prometheus/promql/parser"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudflare/pint/internal/parser/utils"
+)
+
+func isAggregation(node promParser.Node) bool {
+	_, ok := node.(*promParser.AggregateExpr)
+	return ok
+}
+
+func TestFindNode(t *testing.T) {
+	type testCaseT struct {
+		expr   string
+		output string
+	}
+
+	testCases := []testCaseT{
+		{
+			expr: "foo",
+		},
+		{
+			expr: "rate(foo[5m]) > 0",
+		},
+		{
+			expr:   "sum(foo) by(job)",
+			output: "sum by (job) (foo)",
+		},
+		{
+			expr:   "rate(foo[5m]) > 0 and on(job) count(bar) by(job) > sum(bar)",
+			output: "count by (job) (bar)",
+		},
+		{
+			expr:   "max(sum(foo) by(job))",
+			output: "max(sum by (job) (foo))",
+		},
+		{
+			expr:   "label_replace(max_over_time((sum(foo) by(job))[5m:]), \"a\", \"$1\", \"b\", \"(.+)\")",
+			output: "sum by (job) (foo)",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expr, func(t *testing.T) {
+			n, err := promParser.ParseExpr(tc.expr)
+			require.NoError(t, err)
+			node := utils.FindNode(n, isAggregation)
+			if tc.output == "" {
+				require.Nil(t, node)
+			} else {
+				require.NotNil(t, node)
+				require.Equal(t, tc.output, node.String())
+			}
+		})
+	}
+}
+
+func TestFindAllNodes(t *testing.T) {
+	type testCaseT struct {
+		expr   string
+		output []string
+	}
+
+	testCases := []testCaseT{
+		{
+			expr: "foo",
+		},
+		{
+			expr:   "sum(foo)",
+			output: []string{"sum(foo)"},
+		},
+		{
+			expr:   "max(sum(foo) by(job)) / count(bar)",
+			output: []string{"max(sum by (job) (foo))", "sum by (job) (foo)", "count(bar)"},
+		},
+		{
+			expr: "rate(foo[5m]) > 0 or vector(1)",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expr, func(t *testing.T) {
+			n, err := promParser.ParseExpr(tc.expr)
+			require.NoError(t, err)
+			var output []string
+			for _, node := range utils.FindAllNodes(n, isAggregation) {
+				output = append(output, node.String())
+			}
+			require.Equal(t, tc.output, output)
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package utils
+
+import (
+	"sync"
+)
+
+// FuncBehaviour describes how a PromQL function changes labels of the series it returns.
+type FuncBehaviour uint8
+
+const (
+	// FuncPreserveLabels is used for functions that return the same labels
+	// as the series passed to them, like abs() or rate().
+	FuncPreserveLabels FuncBehaviour = iota
+
+	// FuncFixedLabels is used for functions that return a vector with no labels,
+	// like vector().
+	FuncFixedLabels
+
+	// FuncScalar is used for functions that return a scalar value,
+	// like time().
+	FuncScalar
+)
+
+var (
+	registeredFunctions   = map[string]FuncBehaviour{}
+	registeredFunctionsMu sync.RWMutex
+)
+
+// RegisterFunction tells LabelsSource() how to handle a PromQL function
+// that pint doesn't know about.
+// This is only meant for code using this package directly, there's no config
+// option for it. The function must first be added to the PromQL parser
+// via promParser.Functions, otherwise queries using it will fail to parse
+// before LabelsSource() ever sees them.
+// Functions already supported by pint always use the built-in behaviour.
+func RegisterFunction(name string, behaviour FuncBehaviour) {
+	registeredFunctionsMu.Lock()
+	registeredFunctions[name] = behaviour
+	registeredFunctionsMu.Unlock()
+}
+
+// UnregisterFunction removes a function added with RegisterFunction().
+func UnregisterFunction(name string) {
+	registeredFunctionsMu.Lock()
+	delete(registeredFunctions, name)
+	registeredFunctionsMu.Unlock()
+}
+
+func registeredFunction(name string) (behaviour FuncBehaviour, ok bool) {
+	registeredFunctionsMu.RLock()
+	behaviour, ok = registeredFunctions[name]
+	registeredFunctionsMu.RUnlock()
+	return behaviour, ok
+}
@@ -0,0 +1,97 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudflare/pint/internal/parser/utils"
+
+	promParser "github.com/prometheus/prometheus/promql/parser"
+)
+
+func registerParserFunction(t *testing.T, name string, argTypes []promParser.ValueType, returnType promParser.ValueType) {
+	promParser.Functions[name] = &promParser.Function{
+		Name:       name,
+		ArgTypes:   argTypes,
+		ReturnType: returnType,
+	}
+	t.Cleanup(func() {
+		delete(promParser.Functions, name)
+	})
+}
+
+func TestRegisterFunction(t *testing.T) {
+	registerParserFunction(t, "my_func", []promParser.ValueType{promParser.ValueTypeVector}, promParser.ValueTypeVector)
+	registerParserFunction(t, "my_vector", []promParser.ValueType{}, promParser.ValueTypeVector)
+	registerParserFunction(t, "my_scalar", []promParser.ValueType{}, promParser.ValueTypeScalar)
+
+	parse := func(t *testing.T, expr string) []utils.Source {
+		node, err := promParser.ParseExpr(expr)
+		require.NoError(t, err)
+		return utils.LabelsSource(expr, node)
+	}
+
+	t.Run("unregistered", func(t *testing.T) {
+		src := parse(t, `my_func(sum(foo{job="bar"}) by(job))`)
+		require.Len(t, src, 1)
+		require.Equal(t, promParser.ValueTypeNone, src[0].Returns)
+		require.Nil(t, src[0].Call)
+	})
+
+	t.Run("preserve labels", func(t *testing.T) {
+		utils.RegisterFunction("my_func", utils.FuncPreserveLabels)
+		t.Cleanup(func() { utils.UnregisterFunction("my_func") })
+
+		src := parse(t, `my_func(sum(foo{job="bar"}) by(job))`)
+		require.Len(t, src, 1)
+		require.Equal(t, utils.FuncSource, src[0].Type)
+		require.Equal(t, "my_func", src[0].Operation)
+		require.Equal(t, promParser.ValueTypeVector, src[0].Returns)
+		require.NotNil(t, src[0].Call)
+		require.True(t, src[0].FixedLabels)
+		require.Equal(t, []string{"job"}, src[0].IncludedLabels)
+		require.Equal(t, []string{"job"}, src[0].GuaranteedLabels)
+		require.Len(t, src[0].Selectors, 1)
+		require.Equal(t, "foo", src[0].Selectors[0].Name)
+	})
+
+	t.Run("fixed labels", func(t *testing.T) {
+		utils.RegisterFunction("my_vector", utils.FuncFixedLabels)
+		t.Cleanup(func() { utils.UnregisterFunction("my_vector") })
+
+		src := parse(t, `my_vector()`)
+		require.Len(t, src, 1)
+		require.Equal(t, promParser.ValueTypeVector, src[0].Returns)
+		require.True(t, src[0].FixedLabels)
+		require.Empty(t, src[0].IncludedLabels)
+		require.Equal(t, []utils.ExcludedLabel{{
+			Reason:   "Calling `my_vector()` will return a vector value with no labels.",
+			Fragment: "my_vector()",
+		}}, src[0].ExcludeReasons(""))
+	})
+
+	t.Run("scalar", func(t *testing.T) {
+		utils.RegisterFunction("my_scalar", utils.FuncScalar)
+		t.Cleanup(func() { utils.UnregisterFunction("my_scalar") })
+
+		src := parse(t, `my_scalar()`)
+		require.Len(t, src, 1)
+		require.Equal(t, promParser.ValueTypeScalar, src[0].Returns)
+		require.True(t, src[0].FixedLabels)
+		require.Equal(t, []utils.ExcludedLabel{{
+			Reason:   "Calling `my_scalar()` will return a scalar value with no labels.",
+			Fragment: "my_scalar()",
+		}}, src[0].ExcludeReasons(""))
+	})
+
+	t.Run("built-in functions cannot be overridden", func(t *testing.T) {
+		utils.RegisterFunction("rate", utils.FuncScalar)
+		t.Cleanup(func() { utils.UnregisterFunction("rate") })
+
+		src := parse(t, `rate(foo[5m])`)
+		require.Len(t, src, 1)
+		require.Equal(t, promParser.ValueTypeVector, src[0].Returns)
+		require.False(t, src[0].FixedLabels)
+	})
+}
@@ -2,10 +2,14 @@ package utils
 
 import (
 	"fmt"
+	"maps"
 	"math"
 	"slices"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/cespare/xxhash/v2"
 	"github.com/prometheus/prometheus/model/labels"
 	promParser "github.com/prometheus/prometheus/promql/parser"
 	"github.com/prometheus/prometheus/promql/parser/posrange"
@@ -22,29 +26,348 @@ const (
 	AggregateSource
 )
 
+// CountComparison describes the result of comparing count() with a number.
+type CountComparison int
+
+const (
+	// CountNotCompared is used when count() isn't compared with a number.
+	CountNotCompared CountComparison = iota
+	// CountRequiresAbsence is used when the comparison would only match if count()
+	// returned zero, like `count(foo) == 0`. count() never returns zero so it will
+	// never match anything.
+	CountRequiresAbsence
+	// CountRequiresPresence is used when the comparison matches every value count()
+	// can return, like `count(foo) >= 1`, so it's true whenever any time series exists.
+	CountRequiresPresence
+)
+
 type ExcludedLabel struct {
 	Reason   string
 	Fragment string
+	Previous []ExcludedLabel // Earlier reasons for excluding the same label, in the order they were applied.
+}
+
+// Chain returns all reasons for excluding a label, in the order they were applied.
+func (e ExcludedLabel) Chain() []ExcludedLabel {
+	return append(slices.Clone(e.Previous), ExcludedLabel{Reason: e.Reason, Fragment: e.Fragment})
+}
+
+type DeadCode struct {
+	Reason   string
+	Fragment string
+}
+
+type DeprecatedFunction struct {
+	Name        string
+	Replacement string
+	Fragment    string
+}
+
+// DeprecatedFunctions maps names of PromQL functions that were renamed
+// to their new names.
+var DeprecatedFunctions = map[string]string{
+	"holt_winters": "double_exponential_smoothing",
 }
 
 type Source struct {
 	Selectors        []*promParser.VectorSelector
 	Call             *promParser.Call
 	ExcludeReason    map[string]ExcludedLabel // Reason why a label was excluded
+	DeadCode         *DeadCode                // Reason why this source is dead code, only set for some dead code.
+	Deprecated       *DeprecatedFunction      // Set if this source is using a deprecated function.
 	Operation        string
 	Returns          promParser.ValueType
-	ReturnedNumbers  []float64 // If AlwaysReturns=true this is the number that's returned
-	IncludedLabels   []string  // Labels that are included by filters, they will be present if exist on source series (by).
-	ExcludedLabels   []string  // Labels guaranteed to be excluded from the results (without).
-	GuaranteedLabels []string  // Labels guaranteed to be present on the results (matchers).
+	ComparisonOp     promParser.ItemType // Comparison operator applied to this source, as if this source was on the left hand side.
+	CountComparison  CountComparison     // Result of comparing count() with a number, only set for count() aggregations.
+	ReturnedNumbers  []float64           // If AlwaysReturns=true this is the number that's returned
+	MinValue         *float64            // Lowest value this source can return, if known, set by clamp() and clamp_min().
+	MaxValue         *float64            // Highest value this source can return, if known, set by clamp() and clamp_max().
+	Quantile         *float64            // Quantile passed to quantile() or quantile_over_time(), if it's a number.
+	Range            time.Duration       // Range of the matrix selector or subquery this source is reading from, if any.
+	Offset           time.Duration       // Offset modifier used by selectors, if any.
+	IncludedLabels   []string            // Labels that are included by filters, they will be present if exist on source series (by).
+	ExcludedLabels   []string            // Labels guaranteed to be excluded from the results (without).
+	GuaranteedLabels []string            // Labels guaranteed to be present on the results (matchers).
+	FilteredLabels   []string            // Labels only used in negative filters, they might not be present on the results (matchers).
+	Conflicts        []string            // Guaranteed labels overwritten by group_left(...) or group_right(...).
 	Type             SourceType
 	FixedLabels      bool // Labels are fixed and only allowed labels can be present.
 	IsDead           bool // True if this source cannot be reached and is dead code.
 	AlwaysReturns    bool // True if this source always returns results.
+	HasAtModifier    bool // True if selectors are using the @ modifier.
+
+	joinLabels        []string               // Labels added via group_left(...) or group_right(...).
+	selectorFragments []string               // Query fragments for each entry in Selectors.
+	metricNames       []string               // Metric names read by Selectors.
+	deadRange         posrange.PositionRange // Position of the query fragment that made this source dead code.
+}
+
+func (s *Source) markDead(pos posrange.PositionRange) {
+	if s.IsDead {
+		return
+	}
+	s.IsDead = true
+	s.deadRange = pos
+}
+
+// inheritLabels sets label details for functions that don't change labels
+// of the series they receive.
+// If the function argument is a single source then its label details are
+// copied, so labels kept by an aggregation inside the argument are preserved.
+// Otherwise guaranteed labels are calculated from selectors.
+func (s *Source) inheritLabels(args []Source) {
+	if len(args) != 1 {
+		s.GuaranteedLabels = appendToSlice(s.GuaranteedLabels, labelsFromSelectors(guaranteedLabelsMatches, s.Selectors...)...)
+		s.FilteredLabels = appendToSlice(s.FilteredLabels, filteredLabelsFromSelectors(s.Selectors...)...)
+		return
+	}
+	arg := args[0]
+	s.FixedLabels = arg.FixedLabels
+	s.IncludedLabels = appendToSlice(s.IncludedLabels, arg.IncludedLabels...)
+	s.ExcludedLabels = appendToSlice(s.ExcludedLabels, arg.ExcludedLabels...)
+	s.GuaranteedLabels = appendToSlice(s.GuaranteedLabels, arg.GuaranteedLabels...)
+	s.FilteredLabels = appendToSlice(s.FilteredLabels, arg.FilteredLabels...)
+	s.joinLabels = appendToSlice(s.joinLabels, arg.joinLabels...)
+	for name, reason := range arg.ExcludeReason {
+		s.ExcludeReason = setInMap(s.ExcludeReason, name, reason)
+	}
+}
+
+// ExcludeReasons returns all reasons why given label was removed from the results.
+// Reasons specific to this label are returned first, followed by reasons
+// for removing all labels that are not explicitly included.
+func (s Source) ExcludeReasons(name string) (reasons []ExcludedLabel) {
+	if reason, ok := s.ExcludeReason[name]; ok && name != "" {
+		reasons = append(reasons, reason.Chain()...)
+	}
+	if reason, ok := s.ExcludeReason[""]; ok && (name == "" || (s.FixedLabels && !slices.Contains(s.IncludedLabels, name))) {
+		reasons = append(reasons, reason.Chain()...)
+	}
+	return reasons
+}
+
+// Describe returns a human readable summary of the results returned by this source:
+// value type, labels that can or will be present and reasons why any labels were removed.
+func (s Source) Describe() string {
+	var b strings.Builder
+
+	b.WriteString("Returns: ")
+	b.WriteString(string(s.Returns))
+	b.WriteRune('\n')
+
+	b.WriteString("Labels: ")
+	switch {
+	case s.FixedLabels && len(s.IncludedLabels) == 0:
+		b.WriteString("none")
+	case s.FixedLabels:
+		b.WriteString("only ")
+		b.WriteString(describeLabels(s.IncludedLabels))
+	case len(s.ExcludedLabels) > 0:
+		b.WriteString("all labels from selected time series except ")
+		b.WriteString(describeLabels(s.ExcludedLabels))
+	default:
+		b.WriteString("all labels from selected time series")
+	}
+	b.WriteRune('\n')
+
+	b.WriteString("Guaranteed labels: ")
+	if len(s.GuaranteedLabels) == 0 {
+		b.WriteString("none")
+	} else {
+		b.WriteString(describeLabels(s.GuaranteedLabels))
+	}
+	b.WriteRune('\n')
+
+	for _, name := range slices.Sorted(maps.Keys(s.ExcludeReason)) {
+		for _, reason := range s.ExcludeReason[name].Chain() {
+			if name == "" {
+				b.WriteString("Removed labels: ")
+			} else {
+				b.WriteString("Removed `")
+				b.WriteString(name)
+				b.WriteString("` label: ")
+			}
+			b.WriteString(reason.Reason)
+			if reason.Fragment != "" {
+				b.WriteString("\nQuery fragment: `")
+				b.WriteString(reason.Fragment)
+				b.WriteString("`.")
+			}
+			b.WriteRune('\n')
+		}
+	}
+
+	return b.String()
+}
+
+func describeLabels(names []string) string {
+	quoted := make([]string, 0, len(names))
+	for _, name := range names {
+		quoted = append(quoted, "`"+name+"`")
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// MetricNames returns names of all metrics this source is reading, taken
+// from `__name__` equality matchers on Selectors.
+// Selectors that only match metric names using a regexp are not included.
+func (s Source) MetricNames() []string {
+	return s.metricNames
+}
+
+// SelectorFragments returns the query fragment for each entry in Selectors,
+// in the same order, as it was written in the original query.
+func (s Source) SelectorFragments() []string {
+	return s.selectorFragments
+}
+
+// IncludedByJoin returns labels that were added to the results via group_left(...)
+// or group_right(...) and are still present after any aggregation applied to this source.
+// Labels used for vector matching with on(...) are not included.
+func (s Source) IncludedByJoin() []string {
+	return slices.Clone(s.joinLabels)
+}
+
+// HighChurnLabels returns labels from given list that are known to be present
+// on the results of this source, either because they are explicitly kept
+// by aggregations or vector matching, or because they are guaranteed by selectors.
+func (s Source) HighChurnLabels(names []string) (found []string) {
+	for _, name := range names {
+		if slices.Contains(found, name) {
+			continue
+		}
+		if (s.FixedLabels && slices.Contains(s.IncludedLabels, name)) || slices.Contains(s.GuaranteedLabels, name) {
+			found = append(found, name)
+		}
+	}
+	return found
+}
+
+// DeadSources returns position ranges of all query fragments that make
+// some part of the query dead code, so they can never produce any results.
+func DeadSources(expr string, node promParser.Node) (ranges []posrange.PositionRange) {
+	for _, s := range LabelsSource(expr, node) {
+		if !s.IsDead || slices.Contains(ranges, s.deadRange) {
+			continue
+		}
+		ranges = append(ranges, s.deadRange)
+	}
+	return ranges
+}
+
+// CompatibleLabels returns labels from consumerRefs that won't ever be present
+// on the results of given source. It's meant to verify that labels used by
+// alerts or other rules are not removed by a recording rule they depend on.
+func CompatibleLabels(record Source, consumerRefs []string) (missing []string) {
+	if record.IsDead {
+		return nil
+	}
+	for _, name := range consumerRefs {
+		if slices.Contains(missing, name) || slices.Contains(record.GuaranteedLabels, name) {
+			continue
+		}
+		if record.FixedLabels && !slices.Contains(record.IncludedLabels, name) {
+			missing = append(missing, name)
+			continue
+		}
+		if slices.Contains(record.ExcludedLabels, name) {
+			missing = append(missing, name)
+		}
+	}
+	return missing
+}
+
+// Fingerprint returns a hash of everything that describes time series returned by this source:
+// the returned value type, labels that are included, excluded or guaranteed to be present,
+// and matchers used by all selectors.
+// Two sources with identical fingerprints will return the same set of labels.
+func (s Source) Fingerprint() uint64 {
+	h := xxhash.New()
+	write := func(section string, vals ...string) {
+		_, _ = h.WriteString(section)
+		_, _ = h.WriteString(":")
+		for _, v := range vals {
+			_, _ = h.WriteString(v)
+			_, _ = h.WriteString(",")
+		}
+		_, _ = h.WriteString("\n")
+	}
+
+	write("returns", string(s.Returns))
+	write("fixed", strconv.FormatBool(s.FixedLabels))
+	write("included", sortedClone(s.IncludedLabels)...)
+	write("excluded", sortedClone(s.ExcludedLabels)...)
+	write("guaranteed", sortedClone(s.GuaranteedLabels)...)
+
+	sets := make([]string, 0, len(s.Selectors))
+	for _, ms := range s.MatcherSets() {
+		vals := make([]string, 0, len(ms))
+		for _, m := range ms {
+			vals = append(vals, m.String())
+		}
+		slices.Sort(vals)
+		sets = append(sets, strings.Join(vals, ","))
+	}
+	write("selectors", sortedClone(sets)...)
+
+	return h.Sum64()
+}
+
+func sortedClone(s []string) []string {
+	s = slices.Clone(s)
+	slices.Sort(s)
+	return s
+}
+
+// MatcherSets returns the full set of label matchers for each selector used by this source.
+// If a selector has a metric name but no __name__ matcher then one is added.
+// Selectors with identical sets of matchers are only returned once.
+func (s Source) MatcherSets() (sets [][]*labels.Matcher) {
+	seen := map[string]struct{}{}
+	for _, vs := range s.Selectors {
+		matchers := make([]*labels.Matcher, 0, len(vs.LabelMatchers)+1)
+		keys := make([]string, 0, len(vs.LabelMatchers)+1)
+		var hasName bool
+		for _, lm := range vs.LabelMatchers {
+			if lm.Name == labels.MetricName {
+				hasName = true
+			}
+			matchers = append(matchers, lm)
+			keys = append(keys, lm.String())
+		}
+		if !hasName && vs.Name != "" {
+			lm := labels.MustNewMatcher(labels.MatchEqual, labels.MetricName, vs.Name)
+			matchers = append([]*labels.Matcher{lm}, matchers...)
+			keys = append(keys, lm.String())
+		}
+		slices.Sort(keys)
+		key := strings.Join(keys, ",")
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		sets = append(sets, matchers)
+	}
+	return sets
 }
 
 func LabelsSource(expr string, node promParser.Node) (src []Source) {
-	return walkNode(expr, node)
+	WalkSources(expr, node, func(s Source) bool {
+		src = append(src, s)
+		return true
+	})
+	return src
+}
+
+// WalkSources calls visit for each Source of given query, in the same order
+// as they would be returned by LabelsSource.
+// Walking stops as soon as visit returns false.
+func WalkSources(expr string, node promParser.Node, visit func(Source) bool) {
+	for _, s := range walkNode(expr, node) {
+		if !visit(s) {
+			return
+		}
+	}
 }
 
 func walkNode(expr string, node promParser.Node) (src []Source) {
@@ -61,10 +384,16 @@ func walkNode(expr string, node promParser.Node) (src []Source) {
 		src = append(src, s)
 
 	case *promParser.MatrixSelector:
-		src = append(src, walkNode(expr, n.VectorSelector)...)
+		for _, s = range walkNode(expr, n.VectorSelector) {
+			s.Range = n.Range
+			src = append(src, s)
+		}
 
 	case *promParser.SubqueryExpr:
-		src = append(src, walkNode(expr, n.Expr)...)
+		for _, s = range walkNode(expr, n.Expr) {
+			s.Range = n.Range
+			src = append(src, s)
+		}
 
 	case *promParser.NumberLiteral:
 		s.Type = NumberSource
@@ -105,7 +434,12 @@ func walkNode(expr string, node promParser.Node) (src []Source) {
 		src = append(src, s)
 
 	case *promParser.UnaryExpr:
-		src = append(src, walkNode(expr, n.Expr)...)
+		for _, s = range walkNode(expr, n.Expr) {
+			if n.Op == promParser.SUB {
+				s.MinValue, s.MaxValue = negateBound(s.MaxValue), negateBound(s.MinValue)
+			}
+			src = append(src, s)
+		}
 
 	case *promParser.StepInvariantExpr:
 		// Not possible to get this from the parser.
@@ -114,7 +448,12 @@ func walkNode(expr string, node promParser.Node) (src []Source) {
 		s.Type = SelectorSource
 		s.Returns = promParser.ValueTypeVector
 		s.Selectors = append(s.Selectors, n)
+		s.selectorFragments = append(s.selectorFragments, getQueryFragment(expr, n.PosRange))
+		s.metricNames = appendToSlice(s.metricNames, metricNamesFromSelector(n)...)
 		s.GuaranteedLabels = appendToSlice(s.GuaranteedLabels, labelsFromSelectors(guaranteedLabelsMatches, n)...)
+		s.FilteredLabels = appendToSlice(s.FilteredLabels, filteredLabelsFromSelectors(n)...)
+		s.HasAtModifier = n.Timestamp != nil || n.StartOrEnd != 0
+		s.Offset = n.OriginalOffset
 		src = append(src, s)
 
 	default:
@@ -123,6 +462,18 @@ func walkNode(expr string, node promParser.Node) (src []Source) {
 	return src
 }
 
+// shadowedLabels returns labels that are guaranteed to be present on the
+// results but are also copied from the other side of the query with
+// group_left(...) or group_right(...), which will overwrite their values.
+func shadowedLabels(guaranteed, include []string) (names []string) {
+	for _, name := range include {
+		if slices.Contains(guaranteed, name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
 func removeFromSlice(sl []string, s ...string) []string {
 	for _, v := range s {
 		idx := slices.Index(sl, v)
@@ -149,12 +500,24 @@ func setInMap(dst map[string]ExcludedLabel, key string, val ExcludedLabel) map[s
 	if dst == nil {
 		dst = map[string]ExcludedLabel{}
 	}
+	if old, ok := dst[key]; ok {
+		if old.Reason == val.Reason && old.Fragment == val.Fragment {
+			return dst
+		}
+		val.Previous = slices.Concat(old.Chain(), val.Previous)
+	}
 	dst[key] = val
 	return dst
 }
 
 var guaranteedLabelsMatches = []labels.MatchType{labels.MatchEqual, labels.MatchRegexp}
 
+// GuaranteedLabels returns names of all labels that are guaranteed to be
+// present on every time series returned by given selectors.
+func GuaranteedLabels(selectors ...*promParser.VectorSelector) []string {
+	return labelsFromSelectors(guaranteedLabelsMatches, selectors...)
+}
+
 func labelsFromSelectors(matches []labels.MatchType, selectors ...*promParser.VectorSelector) (names []string) {
 	nameCount := map[string]int{}
 	var ok bool
@@ -169,6 +532,12 @@ func labelsFromSelectors(matches []labels.MatchType, selectors ...*promParser.Ve
 				continue
 			}
 
+			// Regexp matchers that accept empty strings, like job=~".*", will also
+			// match series without that label, so it's not guaranteed to be present.
+			if lm.Type == labels.MatchRegexp && lm.Matches("") {
+				continue
+			}
+
 			names = appendToSlice(names, lm.Name)
 
 			if _, ok = nameCount[lm.Name]; !ok {
@@ -185,6 +554,37 @@ func labelsFromSelectors(matches []labels.MatchType, selectors ...*promParser.Ve
 	return names
 }
 
+// metricNamesFromSelector returns metric names required by `__name__` equality matchers.
+func metricNamesFromSelector(selector *promParser.VectorSelector) (names []string) {
+	for _, lm := range selector.LabelMatchers {
+		if lm.Name == labels.MetricName && lm.Type == labels.MatchEqual {
+			names = appendToSlice(names, lm.Value)
+		}
+	}
+	return names
+}
+
+// filteredLabelsFromSelectors returns labels that are only used in negative
+// filters, like foo{job!="bar"}, so they are not guaranteed to be present.
+func filteredLabelsFromSelectors(selectors ...*promParser.VectorSelector) (names []string) {
+	guaranteed := labelsFromSelectors(guaranteedLabelsMatches, selectors...)
+	for _, selector := range selectors {
+		for _, lm := range selector.LabelMatchers {
+			if lm.Name == labels.MetricName {
+				continue
+			}
+			if lm.Type != labels.MatchNotEqual && lm.Type != labels.MatchNotRegexp {
+				continue
+			}
+			if slices.Contains(guaranteed, lm.Name) {
+				continue
+			}
+			names = appendToSlice(names, lm.Name)
+		}
+	}
+	return names
+}
+
 func getQueryFragment(expr string, pos posrange.PositionRange) string {
 	return expr[pos.Start:pos.End]
 }
@@ -245,6 +645,7 @@ func walkAggregation(expr string, n *promParser.AggregateExpr) (src []Source) {
 	case promParser.QUANTILE:
 		for _, s = range parseAggregation(expr, n) {
 			s.Operation = "quantile"
+			s.Quantile = numberLiteralValue(n.Param)
 			src = append(src, s)
 		}
 	case promParser.TOPK:
@@ -281,6 +682,8 @@ func parseAggregation(expr string, n *promParser.AggregateExpr) (src []Source) {
 			s.ExcludedLabels = appendToSlice(s.ExcludedLabels, n.Grouping...)
 			s.IncludedLabels = removeFromSlice(s.IncludedLabels, n.Grouping...)
 			s.GuaranteedLabels = removeFromSlice(s.GuaranteedLabels, n.Grouping...)
+			s.FilteredLabels = removeFromSlice(s.FilteredLabels, n.Grouping...)
+			s.joinLabels = removeFromSlice(s.joinLabels, n.Grouping...)
 			for _, name := range n.Grouping {
 				s.ExcludeReason = setInMap(
 					s.ExcludeReason,
@@ -296,6 +699,8 @@ func parseAggregation(expr string, n *promParser.AggregateExpr) (src []Source) {
 			if len(n.Grouping) == 0 {
 				s.IncludedLabels = nil
 				s.GuaranteedLabels = nil
+				s.FilteredLabels = nil
+				s.joinLabels = nil
 				s.ExcludeReason = setInMap(
 					s.ExcludeReason,
 					"",
@@ -320,15 +725,54 @@ func parseAggregation(expr string, n *promParser.AggregateExpr) (src []Source) {
 							Fragment: getQueryFragment(expr, n.PosRange),
 						},
 					)
+				} else if before := len(s.IncludedLabels); before > 0 {
+					// Only labels kept by both aggregations will be present.
+					s.IncludedLabels = slices.DeleteFunc(s.IncludedLabels, func(name string) bool {
+						return !slices.Contains(n.Grouping, name)
+					})
+					if len(s.IncludedLabels) == 0 {
+						s.IncludedLabels = nil
+					}
+					if len(s.IncludedLabels) != before {
+						s.ExcludeReason = setInMap(
+							s.ExcludeReason,
+							"",
+							ExcludedLabel{
+								Reason: fmt.Sprintf("Query is using aggregation with `by(%s)`, only labels included inside `by(...)` will be present on the results.",
+									strings.Join(n.Grouping, ", ")),
+								Fragment: getQueryFragment(expr, n.PosRange),
+							},
+						)
+					}
 				}
 				for _, name := range s.GuaranteedLabels {
 					if !slices.Contains(n.Grouping, name) {
 						s.GuaranteedLabels = removeFromSlice(s.GuaranteedLabels, name)
 					}
 				}
+				s.FilteredLabels = slices.DeleteFunc(s.FilteredLabels, func(name string) bool {
+					return !slices.Contains(n.Grouping, name)
+				})
+				if len(s.FilteredLabels) == 0 {
+					s.FilteredLabels = nil
+				}
+				s.joinLabels = slices.DeleteFunc(s.joinLabels, func(name string) bool {
+					return !slices.Contains(n.Grouping, name)
+				})
+				if len(s.joinLabels) == 0 {
+					s.joinLabels = nil
+				}
 			}
 			s.FixedLabels = true
 		}
+		// Only aggregations returning one of the values, or a value between them,
+		// will keep the results within the same bounds.
+		// nolint: exhaustive
+		switch n.Op {
+		case promParser.MIN, promParser.MAX, promParser.AVG, promParser.QUANTILE:
+		default:
+			s.MinValue, s.MaxValue = nil, nil
+		}
 		s.Type = AggregateSource
 		s.Returns = promParser.ValueTypeVector
 		s.Call = nil
@@ -343,6 +787,7 @@ func parseCall(expr string, n *promParser.Call) (s Source) {
 	s.Call = n
 
 	var vt promParser.ValueType
+	var argSources []Source
 	for i, e := range n.Args {
 		if i >= len(n.Func.ArgTypes) {
 			vt = n.Func.ArgTypes[len(n.Func.ArgTypes)-1]
@@ -354,33 +799,67 @@ func parseCall(expr string, n *promParser.Call) (s Source) {
 		switch vt {
 		case promParser.ValueTypeVector, promParser.ValueTypeMatrix:
 			for _, es := range walkNode(expr, e) {
+				argSources = append(argSources, es)
 				s.Selectors = append(s.Selectors, es.Selectors...)
+				s.selectorFragments = append(s.selectorFragments, es.selectorFragments...)
+				s.metricNames = appendToSlice(s.metricNames, es.metricNames...)
+				s.HasAtModifier = s.HasAtModifier || es.HasAtModifier
+				if s.Offset == 0 {
+					s.Offset = es.Offset
+				}
+				if s.Range == 0 {
+					s.Range = es.Range
+				}
+				if s.Deprecated == nil {
+					s.Deprecated = es.Deprecated
+				}
 			}
 		}
 	}
 
+	if replacement, ok := DeprecatedFunctions[n.Func.Name]; ok {
+		s.Deprecated = &DeprecatedFunction{
+			Name:        n.Func.Name,
+			Replacement: replacement,
+			Fragment:    expr[n.PosRange.Start:n.PosRange.End],
+		}
+	}
+
 	switch n.Func.Name {
 	case "abs", "sgn", "acos", "acosh", "asin", "asinh", "atan", "atanh", "cos", "cosh", "sin", "sinh", "tan", "tanh":
 		// No change to labels.
 		s.Returns = promParser.ValueTypeVector
 		s.GuaranteedLabels = appendToSlice(s.GuaranteedLabels, labelsFromSelectors(guaranteedLabelsMatches, s.Selectors...)...)
+		s.FilteredLabels = appendToSlice(s.FilteredLabels, filteredLabelsFromSelectors(s.Selectors...)...)
 
 	case "ceil", "floor", "round":
 		// No change to labels.
 		s.Returns = promParser.ValueTypeVector
 		s.GuaranteedLabels = appendToSlice(s.GuaranteedLabels, labelsFromSelectors(guaranteedLabelsMatches, s.Selectors...)...)
+		s.FilteredLabels = appendToSlice(s.FilteredLabels, filteredLabelsFromSelectors(s.Selectors...)...)
 
 	case "changes", "resets":
 		// No change to labels.
 		s.Returns = promParser.ValueTypeVector
 		s.GuaranteedLabels = appendToSlice(s.GuaranteedLabels, labelsFromSelectors(guaranteedLabelsMatches, s.Selectors...)...)
+		s.FilteredLabels = appendToSlice(s.FilteredLabels, filteredLabelsFromSelectors(s.Selectors...)...)
 
 	case "clamp", "clamp_max", "clamp_min":
 		// No change to labels.
 		s.Returns = promParser.ValueTypeVector
 		s.GuaranteedLabels = appendToSlice(s.GuaranteedLabels, labelsFromSelectors(guaranteedLabelsMatches, s.Selectors...)...)
+		s.FilteredLabels = appendToSlice(s.FilteredLabels, filteredLabelsFromSelectors(s.Selectors...)...)
+		switch {
+		case n.Func.Name == "clamp" && len(n.Args) == 3:
+			s.MinValue = numberLiteralValue(n.Args[1])
+			s.MaxValue = numberLiteralValue(n.Args[2])
+		case n.Func.Name == "clamp_min" && len(n.Args) == 2:
+			s.MinValue = numberLiteralValue(n.Args[1])
+		case n.Func.Name == "clamp_max" && len(n.Args) == 2:
+			s.MaxValue = numberLiteralValue(n.Args[1])
+		}
 
-	case "absent", "absent_over_time":
+	case "absent":
 		s.Returns = promParser.ValueTypeVector
 		s.FixedLabels = true
 		for _, name := range labelsFromSelectors([]labels.MatchType{labels.MatchEqual}, s.Selectors...) {
@@ -391,20 +870,43 @@ func parseCall(expr string, n *promParser.Call) (s Source) {
 			s.ExcludeReason,
 			"",
 			ExcludedLabel{
-				Reason: fmt.Sprintf(`The [%s()](https://prometheus.io/docs/prometheus/latest/querying/functions/#%s) function is used to check if provided query doesn't match any time series.
+				Reason: `The [absent()](https://prometheus.io/docs/prometheus/latest/querying/functions/#absent) function is used to check if provided query doesn't match any time series.
 You will only get any results back if the metric selector you pass doesn't match anything.
 Since there are no matching time series there are also no labels. If some time series is missing you cannot read its labels.
 This means that the only labels you can get back from absent call are the ones you pass to it.
-If you're hoping to get instance specific labels this way and alert when some target is down then that won't work, use the `+"`up`"+` metric instead.`,
-					n.Func.Name, n.Func.Name),
+If you're hoping to get instance specific labels this way and alert when some target is down then that won't work, use the ` + "`up`" + ` metric instead.`,
+				Fragment: getQueryFragment(expr, n.PosRange),
+			},
+		)
+
+	case "absent_over_time":
+		s.Returns = promParser.ValueTypeVector
+		s.FixedLabels = true
+		for _, name := range labelsFromSelectors([]labels.MatchType{labels.MatchEqual}, s.Selectors...) {
+			s.IncludedLabels = appendToSlice(s.IncludedLabels, name)
+			s.GuaranteedLabels = appendToSlice(s.GuaranteedLabels, name)
+		}
+		s.ExcludeReason = setInMap(
+			s.ExcludeReason,
+			"",
+			ExcludedLabel{
+				Reason: `The [absent_over_time()](https://prometheus.io/docs/prometheus/latest/querying/functions/#absent_over_time) function is used to check if provided range selector doesn't match any samples over the whole time range.
+You will only get any results back if there were no matching samples at any point inside that time range.
+Since there are no matching samples there are also no labels. Time series that were present only for a part of the time range will not be reported and you cannot read labels of the ones that are missing.
+This means that the only labels you can get back from absent_over_time call are the ones you pass to the selector using equality matchers.
+If you're hoping to get instance specific labels this way and alert when some target is down then that won't work, use the ` + "`up`" + ` metric instead.`,
 				Fragment: getQueryFragment(expr, n.PosRange),
 			},
 		)
 
 	case "avg_over_time", "count_over_time", "last_over_time", "max_over_time", "min_over_time", "present_over_time", "quantile_over_time", "stddev_over_time", "stdvar_over_time", "sum_over_time":
 		// No change to labels.
+		// The argument might be a subquery, so keep labels of its source.
 		s.Returns = promParser.ValueTypeVector
-		s.GuaranteedLabels = appendToSlice(s.GuaranteedLabels, labelsFromSelectors(guaranteedLabelsMatches, s.Selectors...)...)
+		s.inheritLabels(argSources)
+		if n.Func.Name == "quantile_over_time" && len(n.Args) > 0 {
+			s.Quantile = numberLiteralValue(n.Args[0])
+		}
 
 	case "days_in_month", "day_of_month", "day_of_week", "day_of_year", "hour", "minute", "month", "year":
 		s.Returns = promParser.ValueTypeVector
@@ -426,34 +928,79 @@ If you're hoping to get instance specific labels this way and alert when some ta
 			)
 		} else {
 			s.GuaranteedLabels = appendToSlice(s.GuaranteedLabels, labelsFromSelectors(guaranteedLabelsMatches, s.Selectors...)...)
+			s.FilteredLabels = appendToSlice(s.FilteredLabels, filteredLabelsFromSelectors(s.Selectors...)...)
 		}
 
 	case "deg", "rad", "ln", "log10", "log2", "sqrt", "exp":
 		// No change to labels.
 		s.Returns = promParser.ValueTypeVector
 		s.GuaranteedLabels = appendToSlice(s.GuaranteedLabels, labelsFromSelectors(guaranteedLabelsMatches, s.Selectors...)...)
+		s.FilteredLabels = appendToSlice(s.FilteredLabels, filteredLabelsFromSelectors(s.Selectors...)...)
 
 	case "delta", "idelta", "increase", "deriv", "irate", "rate":
 		// No change to labels.
 		s.Returns = promParser.ValueTypeVector
 		s.GuaranteedLabels = appendToSlice(s.GuaranteedLabels, labelsFromSelectors(guaranteedLabelsMatches, s.Selectors...)...)
+		s.FilteredLabels = appendToSlice(s.FilteredLabels, filteredLabelsFromSelectors(s.Selectors...)...)
 
 	case "histogram_avg", "histogram_count", "histogram_sum", "histogram_stddev", "histogram_stdvar", "histogram_fraction", "histogram_quantile":
 		// No change to labels.
 		s.Returns = promParser.ValueTypeVector
 		s.GuaranteedLabels = appendToSlice(s.GuaranteedLabels, labelsFromSelectors(guaranteedLabelsMatches, s.Selectors...)...)
+		s.FilteredLabels = appendToSlice(s.FilteredLabels, filteredLabelsFromSelectors(s.Selectors...)...)
 
-	case "holt_winters", "predict_linear":
+	case "double_exponential_smoothing", "holt_winters", "predict_linear":
 		// No change to labels.
 		s.Returns = promParser.ValueTypeVector
 		s.GuaranteedLabels = appendToSlice(s.GuaranteedLabels, labelsFromSelectors(guaranteedLabelsMatches, s.Selectors...)...)
+		s.FilteredLabels = appendToSlice(s.FilteredLabels, filteredLabelsFromSelectors(s.Selectors...)...)
 
-	case "label_replace", "label_join":
+	case "label_replace":
 		// One label added to the results.
 		s.Returns = promParser.ValueTypeVector
 		s.GuaranteedLabels = appendToSlice(s.GuaranteedLabels, labelsFromSelectors(guaranteedLabelsMatches, s.Selectors...)...)
+		s.FilteredLabels = appendToSlice(s.FilteredLabels, filteredLabelsFromSelectors(s.Selectors...)...)
 		s.GuaranteedLabels = appendToSlice(s.GuaranteedLabels, s.Call.Args[1].(*promParser.StringLiteral).Val)
 
+	case "label_join":
+		// One label added to the results, but only if any of the source labels is present.
+		s.Returns = promParser.ValueTypeVector
+		s.GuaranteedLabels = appendToSlice(s.GuaranteedLabels, labelsFromSelectors(guaranteedLabelsMatches, s.Selectors...)...)
+		s.FilteredLabels = appendToSlice(s.FilteredLabels, filteredLabelsFromSelectors(s.Selectors...)...)
+		dst := s.Call.Args[1].(*promParser.StringLiteral).Val
+		var srcLabels []string
+		var hasGuaranteed bool
+		for _, arg := range s.Call.Args[3:] {
+			name := arg.(*promParser.StringLiteral).Val
+			srcLabels = append(srcLabels, name)
+			if slices.Contains(s.GuaranteedLabels, name) {
+				hasGuaranteed = true
+			}
+		}
+		if hasGuaranteed {
+			s.GuaranteedLabels = appendToSlice(s.GuaranteedLabels, dst)
+			break
+		}
+		// If all source labels are missing then the destination label value is empty,
+		// which means that it will be removed from the results.
+		s.GuaranteedLabels = removeFromSlice(s.GuaranteedLabels, dst)
+		s.IncludedLabels = appendToSlice(s.IncludedLabels, dst)
+		var reason string
+		if len(srcLabels) == 0 {
+			reason = fmt.Sprintf("Calling `label_join()` with no source labels will set `%s` label to an empty value, which removes it.", dst)
+		} else {
+			reason = fmt.Sprintf("`%s` label will only be set by `label_join()` if any of the source labels (%s) is present, but none of them is guaranteed to be present.",
+				dst, describeLabels(srcLabels))
+		}
+		s.ExcludeReason = setInMap(
+			s.ExcludeReason,
+			dst,
+			ExcludedLabel{
+				Reason:   reason,
+				Fragment: getQueryFragment(expr, n.PosRange),
+			},
+		)
+
 	case "pi":
 		s.Returns = promParser.ValueTypeScalar
 		s.IncludedLabels = nil
@@ -488,6 +1035,14 @@ If you're hoping to get instance specific labels this way and alert when some ta
 		// No change to labels.
 		s.Returns = promParser.ValueTypeVector
 
+	case "sort_by_label", "sort_by_label_desc":
+		// No change to labels.
+		// These are experimental and require promParser.EnableExperimentalFunctions to be parsed.
+		// Label names passed as string arguments are not selectors and are ignored.
+		s.Returns = promParser.ValueTypeVector
+		s.GuaranteedLabels = appendToSlice(s.GuaranteedLabels, labelsFromSelectors(guaranteedLabelsMatches, s.Selectors...)...)
+		s.FilteredLabels = appendToSlice(s.FilteredLabels, filteredLabelsFromSelectors(s.Selectors...)...)
+
 	case "time":
 		s.Returns = promParser.ValueTypeScalar
 		s.IncludedLabels = nil
@@ -506,15 +1061,18 @@ If you're hoping to get instance specific labels this way and alert when some ta
 	case "timestamp":
 		// No change to labels.
 		s.Returns = promParser.ValueTypeVector
-		s.GuaranteedLabels = appendToSlice(s.GuaranteedLabels, labelsFromSelectors(guaranteedLabelsMatches, s.Selectors...)...)
+		s.inheritLabels(argSources)
 
 	case "vector":
 		s.Returns = promParser.ValueTypeVector
 		s.IncludedLabels = nil
 		s.GuaranteedLabels = nil
 		s.FixedLabels = true
+		// vector() always returns a single series, but we only know its value
+		// if it's a number literal, vector(time()) or vector(scalar(foo))
+		// will return something we can't calculate.
 		s.AlwaysReturns = true
-		if v, ok := n.Args[0].(*promParser.NumberLiteral); ok {
+		if v, ok := unwrapParens(n.Args[0]).(*promParser.NumberLiteral); ok {
 			s.ReturnedNumbers = append(s.ReturnedNumbers, v.Val)
 		}
 		s.ExcludeReason = setInMap(
@@ -527,13 +1085,101 @@ If you're hoping to get instance specific labels this way and alert when some ta
 		)
 
 	default:
-		// Unsupported function
-		s.Returns = promParser.ValueTypeNone
-		s.Call = nil
+		behaviour, ok := registeredFunction(n.Func.Name)
+		if !ok {
+			// Unsupported function
+			s.Returns = promParser.ValueTypeNone
+			s.Call = nil
+			break
+		}
+		switch behaviour {
+		case FuncPreserveLabels:
+			// No change to labels.
+			s.Returns = promParser.ValueTypeVector
+			s.inheritLabels(argSources)
+		case FuncFixedLabels:
+			s.Returns = promParser.ValueTypeVector
+			s.IncludedLabels = nil
+			s.GuaranteedLabels = nil
+			s.FixedLabels = true
+			s.ExcludeReason = setInMap(
+				s.ExcludeReason,
+				"",
+				ExcludedLabel{
+					Reason:   fmt.Sprintf("Calling `%s()` will return a vector value with no labels.", n.Func.Name),
+					Fragment: getQueryFragment(expr, n.PosRange),
+				},
+			)
+		case FuncScalar:
+			s.Returns = promParser.ValueTypeScalar
+			s.IncludedLabels = nil
+			s.GuaranteedLabels = nil
+			s.FixedLabels = true
+			s.ExcludeReason = setInMap(
+				s.ExcludeReason,
+				"",
+				ExcludedLabel{
+					Reason:   fmt.Sprintf("Calling `%s()` will return a scalar value with no labels.", n.Func.Name),
+					Fragment: getQueryFragment(expr, n.PosRange),
+				},
+			)
+		}
+	}
+	return s
+}
+
+// isInvalidStringOp returns true if given arithmetic operation has a string
+// on one side and a scalar or vector on the other side.
+func isInvalidStringOp(op promParser.ItemType, ls, rs Source) bool {
+	if op.IsComparisonOperator() || op.IsSetOperator() {
+		return false
+	}
+	isValue := func(s Source) bool {
+		return s.Returns == promParser.ValueTypeScalar || s.Returns == promParser.ValueTypeVector
 	}
+	return (ls.Returns == promParser.ValueTypeString && isValue(rs)) ||
+		(rs.Returns == promParser.ValueTypeString && isValue(ls))
+}
+
+func invalidStringOpSource(expr string, n *promParser.BinaryExpr, ls, rs Source) (s Source) {
+	s.Type = UnknownSource
+	s.Operation = n.Op.String()
+	s.Returns = promParser.ValueTypeNone
+	s.FixedLabels = true
+	s.ExcludeReason = setInMap(
+		s.ExcludeReason,
+		"",
+		ExcludedLabel{
+			Reason: fmt.Sprintf("This query is using `%s` operator between %s and %s, binary operations on strings are not allowed in PromQL.",
+				n.Op, describeValueType(ls.Returns), describeValueType(rs.Returns)),
+			Fragment: getQueryFragment(expr, n.PositionRange()),
+		},
+	)
 	return s
 }
 
+func unwrapParens(node promParser.Expr) promParser.Expr {
+	for {
+		p, ok := node.(*promParser.ParenExpr)
+		if !ok {
+			return node
+		}
+		node = p.Expr
+	}
+}
+
+func describeValueType(vt promParser.ValueType) string {
+	// nolint:exhaustive
+	switch vt {
+	case promParser.ValueTypeString:
+		return "a string"
+	case promParser.ValueTypeScalar:
+		return "a scalar"
+	default:
+		return "an instant vector"
+	}
+}
+
 func parseBinOps(expr string, n *promParser.BinaryExpr) (src []Source) {
 	var s Source
 	switch {
@@ -548,19 +1194,50 @@ func parseBinOps(expr string, n *promParser.BinaryExpr) (src []Source) {
 		for _, ls := range lhs {
 			for _, rs := range rhs {
 				switch {
+				case isInvalidStringOp(n.Op, ls, rs):
+					// "foo" + 1
+					// Prometheus will refuse to parse it, but we might get such AST from other sources.
+					src = append(src, invalidStringOpSource(expr, n, ls, rs))
 				case ls.AlwaysReturns && rs.AlwaysReturns:
 					// Both sides always return something
+					isDead := ls.IsDead
 					for i, lv := range ls.ReturnedNumbers {
 						for _, rv := range rs.ReturnedNumbers {
-							ls.ReturnedNumbers[i], ls.IsDead = calculateStaticReturn(lv, rv, n.Op, ls.IsDead)
+							ls.ReturnedNumbers[i], isDead = calculateStaticReturn(lv, rv, n.Op, isDead)
 						}
 					}
+					if isDead {
+						ls.markDead(n.PositionRange())
+					}
+					setComparisonOp(&ls, n, false)
 					src = append(src, ls)
 				case ls.Returns == promParser.ValueTypeVector, ls.Returns == promParser.ValueTypeMatrix:
 					// Use labels from LHS
+					if !n.ReturnBool && isCountAggregation(n.LHS) {
+						ls.CountComparison = countComparison(n.Op, rs, false)
+						if ls.CountComparison == CountRequiresAbsence {
+							ls.markDead(n.PositionRange())
+						}
+					}
+					if !n.ReturnBool && !ls.IsDead && isBoundComparisonDead(n.Op, ls, rs, false) {
+						ls.markDead(n.PositionRange())
+						ls.DeadCode = boundComparisonDeadCode(expr, n, n.LHS, ls)
+					}
+					setComparisonOp(&ls, n, false)
 					src = append(src, ls)
 				case rs.Returns == promParser.ValueTypeVector, rs.Returns == promParser.ValueTypeMatrix:
 					// Use labels from RHS
+					if !n.ReturnBool && isCountAggregation(n.RHS) {
+						rs.CountComparison = countComparison(n.Op, ls, true)
+						if rs.CountComparison == CountRequiresAbsence {
+							rs.markDead(n.PositionRange())
+						}
+					}
+					if !n.ReturnBool && !rs.IsDead && isBoundComparisonDead(n.Op, rs, ls, true) {
+						rs.markDead(n.PositionRange())
+						rs.DeadCode = boundComparisonDeadCode(expr, n, n.RHS, rs)
+					}
+					setComparisonOp(&rs, n, true)
 					src = append(src, rs)
 				}
 			}
@@ -598,6 +1275,7 @@ func parseBinOps(expr string, n *promParser.BinaryExpr) (src []Source) {
 			} else {
 				s.IncludedLabels = removeFromSlice(s.IncludedLabels, n.VectorMatching.MatchingLabels...)
 				s.GuaranteedLabels = removeFromSlice(s.GuaranteedLabels, n.VectorMatching.MatchingLabels...)
+				s.FilteredLabels = removeFromSlice(s.FilteredLabels, n.VectorMatching.MatchingLabels...)
 				s.ExcludedLabels = appendToSlice(s.ExcludedLabels, n.VectorMatching.MatchingLabels...)
 				for _, name := range n.VectorMatching.MatchingLabels {
 					s.ExcludeReason = setInMap(
@@ -622,6 +1300,7 @@ func parseBinOps(expr string, n *promParser.BinaryExpr) (src []Source) {
 			if s.Operation == "" {
 				s.Operation = n.VectorMatching.Card.String()
 			}
+			setComparisonOp(&s, n, false)
 			src = append(src, s)
 		}
 
@@ -629,7 +1308,9 @@ func parseBinOps(expr string, n *promParser.BinaryExpr) (src []Source) {
 		// foo{} + ignoring(...) group_left(...) bar{}
 	case n.VectorMatching.Card == promParser.CardOneToMany:
 		for _, s = range walkNode(expr, n.RHS) {
+			s.Conflicts = appendToSlice(s.Conflicts, shadowedLabels(s.GuaranteedLabels, n.VectorMatching.Include)...)
 			s.IncludedLabels = appendToSlice(s.IncludedLabels, n.VectorMatching.Include...)
+			s.joinLabels = appendToSlice(s.joinLabels, n.VectorMatching.Include...)
 			if n.VectorMatching.On {
 				s.IncludedLabels = appendToSlice(s.IncludedLabels, n.VectorMatching.MatchingLabels...)
 				for _, name := range n.VectorMatching.MatchingLabels {
@@ -643,6 +1324,7 @@ func parseBinOps(expr string, n *promParser.BinaryExpr) (src []Source) {
 			if s.Operation == "" {
 				s.Operation = n.VectorMatching.Card.String()
 			}
+			setComparisonOp(&s, n, true)
 			src = append(src, s)
 		}
 
@@ -650,7 +1332,9 @@ func parseBinOps(expr string, n *promParser.BinaryExpr) (src []Source) {
 		// foo{} + ignoring(...) group_right(...) bar{}
 	case n.VectorMatching.Card == promParser.CardManyToOne:
 		for _, s = range walkNode(expr, n.LHS) {
+			s.Conflicts = appendToSlice(s.Conflicts, shadowedLabels(s.GuaranteedLabels, n.VectorMatching.Include)...)
 			s.IncludedLabels = appendToSlice(s.IncludedLabels, n.VectorMatching.Include...)
+			s.joinLabels = appendToSlice(s.joinLabels, n.VectorMatching.Include...)
 			if n.VectorMatching.On {
 				s.IncludedLabels = appendToSlice(s.IncludedLabels, n.VectorMatching.MatchingLabels...)
 				for _, name := range n.VectorMatching.MatchingLabels {
@@ -664,6 +1348,7 @@ func parseBinOps(expr string, n *promParser.BinaryExpr) (src []Source) {
 			if s.Operation == "" {
 				s.Operation = n.VectorMatching.Card.String()
 			}
+			setComparisonOp(&s, n, false)
 			src = append(src, s)
 		}
 
@@ -671,6 +1356,7 @@ func parseBinOps(expr string, n *promParser.BinaryExpr) (src []Source) {
 		// foo{} and ignoring(...) bar{}
 	case n.VectorMatching.Card == promParser.CardManyToMany:
 		var lhsCanBeEmpty bool // true if any of the LHS query can produce empty results.
+		lhsHasNoLabels := true // true if all LHS results are guaranteed to have no labels.
 		for _, s = range walkNode(expr, n.LHS) {
 			if n.VectorMatching.On {
 				s.IncludedLabels = appendToSlice(s.IncludedLabels, n.VectorMatching.MatchingLabels...)
@@ -681,11 +1367,26 @@ func parseBinOps(expr string, n *promParser.BinaryExpr) (src []Source) {
 			if s.Operation == "" {
 				s.Operation = n.VectorMatching.Card.String()
 			}
-			if !s.AlwaysReturns {
+			if s.IsDead || !alwaysMatches(s) {
 				lhsCanBeEmpty = true
 			}
+			if !s.FixedLabels || len(s.IncludedLabels) > 0 {
+				lhsHasNoLabels = false
+			}
 			src = append(src, s)
 		}
+		if n.Op == promParser.LAND || n.Op == promParser.LUNLESS {
+			rhs := walkNode(expr, n.RHS)
+			for i := range src {
+				if reason := setOperationDeadReason(n, src[i], rhs); reason != "" {
+					src[i].markDead(n.PositionRange())
+					src[i].DeadCode = &DeadCode{
+						Reason:   reason,
+						Fragment: getQueryFragment(expr, n.LHS.PositionRange()),
+					}
+				}
+			}
+		}
 		if n.Op == promParser.LOR {
 			for _, s = range walkNode(expr, n.RHS) {
 				if s.Operation == "" {
@@ -693,15 +1394,230 @@ func parseBinOps(expr string, n *promParser.BinaryExpr) (src []Source) {
 				}
 				// If LHS can NOT be empty then RHS is dead code.
 				if !lhsCanBeEmpty {
-					s.IsDead = true
+					s.markDead(n.RHS.PositionRange())
+				}
+				// RHS results are only guaranteed to be dropped if they would
+				// always match labels of LHS results.
+				if !lhsCanBeEmpty && ((n.VectorMatching.On && len(n.VectorMatching.MatchingLabels) == 0) || (lhsHasNoLabels && s.FixedLabels && len(s.IncludedLabels) == 0)) {
+					s.DeadCode = &DeadCode{
+						Reason:   "The left hand side of `or` always returns results, so the right hand side will never be used.",
+						Fragment: getQueryFragment(expr, n.RHS.PositionRange()),
+					}
 				}
 				src = append(src, s)
 			}
 		}
 	}
+
+	// Comparisons (without bool) and set operators only filter results,
+	// any other operation will change the values.
+	if (!n.Op.IsComparisonOperator() && !n.Op.IsSetOperator()) || n.ReturnBool {
+		for i := range src {
+			src[i].MinValue, src[i].MaxValue = nil, nil
+		}
+	}
 	return src
 }
 
+// setOperationDeadReason returns the reason why results of the left hand side
+// of `and` or `unless` will always be removed, or an empty string if they might
+// be returned.
+// foo and vector(1) will never return anything because vector(1) has no labels
+// and it won't match any series of foo, while foo unless on() vector(1) will
+// always match and remove all series of foo.
+func setOperationDeadReason(n *promParser.BinaryExpr, ls Source, rhs []Source) string {
+	if ls.IsDead || len(rhs) == 0 {
+		return ""
+	}
+
+	matchesAll := n.VectorMatching.On && len(n.VectorMatching.MatchingLabels) == 0
+	matchesOnlyEmpty := !n.VectorMatching.On && len(n.VectorMatching.MatchingLabels) == 0
+	lhsHasNoLabels := ls.FixedLabels && len(ls.IncludedLabels) == 0
+
+	switch n.Op {
+	case promParser.LAND:
+		for _, rs := range rhs {
+			if rs.IsDead {
+				continue
+			}
+			if matchesOnlyEmpty && !lhsHasNoLabels && rs.FixedLabels && len(rs.IncludedLabels) == 0 {
+				continue
+			}
+			return ""
+		}
+		return "The right hand side of `and` will never match any results from the left hand side, so this query will never return anything."
+	case promParser.LUNLESS:
+		for _, rs := range rhs {
+			if rs.IsDead || !alwaysMatches(rs) {
+				continue
+			}
+			if matchesAll || (matchesOnlyEmpty && lhsHasNoLabels && rs.FixedLabels && len(rs.IncludedLabels) == 0) {
+				return "The right hand side of `unless` will always match all results from the left hand side, so this query will never return anything."
+			}
+		}
+	}
+	return ""
+}
+
+// alwaysMatches returns true if given source always returns results.
+// If results are filtered by a comparison then the value must be known,
+// otherwise we can't tell if the comparison will pass.
+func alwaysMatches(s Source) bool {
+	if !s.AlwaysReturns {
+		return false
+	}
+	if s.ComparisonOp != 0 && len(s.ReturnedNumbers) == 0 {
+		return false
+	}
+	return true
+}
+
+func setComparisonOp(s *Source, n *promParser.BinaryExpr, isSwapped bool) {
+	if !n.Op.IsComparisonOperator() || s.ComparisonOp != 0 {
+		return
+	}
+	s.ComparisonOp = n.Op
+	if isSwapped {
+		s.ComparisonOp = swapComparisonOp(n.Op)
+	}
+}
+
+// swapComparisonOp returns the comparison operator that needs to be used
+// when both sides of the comparison are swapped, so `5 < foo` becomes `foo > 5`.
+func swapComparisonOp(op promParser.ItemType) promParser.ItemType {
+	// nolint: exhaustive
+	switch op {
+	case promParser.LSS:
+		return promParser.GTR
+	case promParser.LTE:
+		return promParser.GTE
+	case promParser.GTR:
+		return promParser.LSS
+	case promParser.GTE:
+		return promParser.LTE
+	}
+	return op
+}
+
+func isCountAggregation(node promParser.Node) bool {
+	switch n := node.(type) {
+	case *promParser.ParenExpr:
+		return isCountAggregation(n.Expr)
+	case *promParser.AggregateExpr:
+		return n.Op == promParser.COUNT
+	}
+	return false
+}
+
+// count() only returns results when there are matching time series, so every
+// value it returns is >= 1. Comparing it with a number that requires a lower
+// value will never match anything, which is what happens when someone tries to
+// detect missing time series with `count(...) == 0`.
+// Comparing it with a number that every value >= 1 passes, like `count(...) >= 1`,
+// will match whenever there are any matching time series.
+func countComparison(op promParser.ItemType, number Source, isSwapped bool) CountComparison {
+	if !number.AlwaysReturns || len(number.ReturnedNumbers) != 1 {
+		return CountNotCompared
+	}
+	v := number.ReturnedNumbers[0]
+	if isSwapped {
+		op = swapComparisonOp(op)
+	}
+	// nolint: exhaustive
+	switch op {
+	case promParser.EQLC:
+		if v < 1 {
+			return CountRequiresAbsence
+		}
+	case promParser.LSS:
+		if v <= 1 {
+			return CountRequiresAbsence
+		}
+	case promParser.LTE:
+		if v < 1 {
+			return CountRequiresAbsence
+		}
+	case promParser.NEQ:
+		if v < 1 {
+			return CountRequiresPresence
+		}
+	case promParser.GTR:
+		if v < 1 {
+			return CountRequiresPresence
+		}
+	case promParser.GTE:
+		if v <= 1 {
+			return CountRequiresPresence
+		}
+	}
+	return CountNotCompared
+}
+
+// isBoundComparisonDead returns true if values returned by given source are
+// limited by clamp() and comparing them with a number will never match
+// anything, like in `clamp_max(foo, 5) > 10`.
+func isBoundComparisonDead(op promParser.ItemType, s, number Source, isSwapped bool) bool {
+	if !number.AlwaysReturns || len(number.ReturnedNumbers) != 1 {
+		return false
+	}
+	v := number.ReturnedNumbers[0]
+	if isSwapped {
+		op = swapComparisonOp(op)
+	}
+	// nolint: exhaustive
+	switch op {
+	case promParser.EQLC:
+		return (s.MinValue != nil && v < *s.MinValue) || (s.MaxValue != nil && v > *s.MaxValue)
+	case promParser.GTR:
+		return s.MaxValue != nil && v >= *s.MaxValue
+	case promParser.GTE:
+		return s.MaxValue != nil && v > *s.MaxValue
+	case promParser.LSS:
+		return s.MinValue != nil && v <= *s.MinValue
+	case promParser.LTE:
+		return s.MinValue != nil && v < *s.MinValue
+	}
+	return false
+}
+
+func boundComparisonDeadCode(expr string, n *promParser.BinaryExpr, side promParser.Expr, s Source) *DeadCode {
+	var reason string
+	switch {
+	case s.MinValue != nil && s.MaxValue != nil:
+		reason = fmt.Sprintf("`%s` will only return values between %s and %s, so this comparison will never match anything.",
+			getQueryFragment(expr, side.PositionRange()), formatNumber(*s.MinValue), formatNumber(*s.MaxValue))
+	case s.MaxValue != nil:
+		reason = fmt.Sprintf("`%s` will never return values higher than %s, so this comparison will never match anything.",
+			getQueryFragment(expr, side.PositionRange()), formatNumber(*s.MaxValue))
+	default:
+		reason = fmt.Sprintf("`%s` will never return values lower than %s, so this comparison will never match anything.",
+			getQueryFragment(expr, side.PositionRange()), formatNumber(*s.MinValue))
+	}
+	return &DeadCode{
+		Reason:   reason,
+		Fragment: getQueryFragment(expr, n.PositionRange()),
+	}
+}
+
+func numberLiteralValue(node promParser.Expr) *float64 {
+	if v, ok := unwrapParens(node).(*promParser.NumberLiteral); ok {
+		return &v.Val
+	}
+	return nil
+}
+
+func negateBound(v *float64) *float64 {
+	if v == nil {
+		return nil
+	}
+	neg := -*v
+	return &neg
+}
+
+func formatNumber(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
 func calculateStaticReturn(lv, rv float64, op promParser.ItemType, isDead bool) (float64, bool) {
 	switch op {
 	case promParser.EQLC:
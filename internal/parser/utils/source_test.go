@@ -1,6 +1,8 @@
 package utils_test
 
 import (
+	"maps"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -10,6 +12,7 @@ import (
 	"github.com/cloudflare/pint/internal/parser"
 	"github.com/cloudflare/pint/internal/parser/utils"
 
+	"github.com/prometheus/prometheus/model/labels"
 	promParser "github.com/prometheus/prometheus/promql/parser"
 	"github.com/prometheus/prometheus/promql/parser/posrange"
 )
@@ -81,6 +84,7 @@ func TestLabelsSource(t *testing.T) {
 				{
 					Type:            utils.NumberSource,
 					Returns:         promParser.ValueTypeScalar,
+					ComparisonOp:    promParser.EQLC,
 					FixedLabels:     true,
 					AlwaysReturns:   true,
 					IsDead:          true,
@@ -100,6 +104,7 @@ func TestLabelsSource(t *testing.T) {
 				{
 					Type:            utils.NumberSource,
 					Returns:         promParser.ValueTypeScalar,
+					ComparisonOp:    promParser.LTE,
 					FixedLabels:     true,
 					AlwaysReturns:   true,
 					IsDead:          true,
@@ -119,6 +124,7 @@ func TestLabelsSource(t *testing.T) {
 				{
 					Type:            utils.NumberSource,
 					Returns:         promParser.ValueTypeScalar,
+					ComparisonOp:    promParser.GTE,
 					FixedLabels:     true,
 					AlwaysReturns:   true,
 					IsDead:          true,
@@ -138,6 +144,7 @@ func TestLabelsSource(t *testing.T) {
 				{
 					Type:            utils.NumberSource,
 					Returns:         promParser.ValueTypeScalar,
+					ComparisonOp:    promParser.LTE,
 					FixedLabels:     true,
 					AlwaysReturns:   true,
 					ReturnedNumbers: []float64{3},
@@ -156,6 +163,7 @@ func TestLabelsSource(t *testing.T) {
 				{
 					Type:            utils.NumberSource,
 					Returns:         promParser.ValueTypeScalar,
+					ComparisonOp:    promParser.LSS,
 					FixedLabels:     true,
 					AlwaysReturns:   true,
 					IsDead:          true,
@@ -175,6 +183,7 @@ func TestLabelsSource(t *testing.T) {
 				{
 					Type:            utils.NumberSource,
 					Returns:         promParser.ValueTypeScalar,
+					ComparisonOp:    promParser.LSS,
 					FixedLabels:     true,
 					AlwaysReturns:   true,
 					IsDead:          true,
@@ -333,6 +342,7 @@ func TestLabelsSource(t *testing.T) {
 				{
 					Type:            utils.NumberSource,
 					Returns:         promParser.ValueTypeScalar,
+					ComparisonOp:    promParser.GTR,
 					FixedLabels:     true,
 					AlwaysReturns:   true,
 					ReturnedNumbers: []float64{1},
@@ -351,6 +361,7 @@ func TestLabelsSource(t *testing.T) {
 				{
 					Type:            utils.NumberSource,
 					Returns:         promParser.ValueTypeScalar,
+					ComparisonOp:    promParser.GTR,
 					FixedLabels:     true,
 					AlwaysReturns:   true,
 					ReturnedNumbers: []float64{20},
@@ -392,6 +403,72 @@ func TestLabelsSource(t *testing.T) {
 				},
 			},
 		},
+		{
+			expr: "foo @ 1700000000",
+			output: []utils.Source{
+				{
+					Type:    utils.SelectorSource,
+					Returns: promParser.ValueTypeVector,
+					Selectors: []*promParser.VectorSelector{
+						mustParseVector("foo @ 1700000000", 0),
+					},
+					HasAtModifier: true,
+				},
+			},
+		},
+		{
+			expr: "foo offset 5m",
+			output: []utils.Source{
+				{
+					Type:    utils.SelectorSource,
+					Returns: promParser.ValueTypeVector,
+					Selectors: []*promParser.VectorSelector{
+						mustParseVector("foo offset 5m", 0),
+					},
+					Offset: time.Minute * 5,
+				},
+			},
+		},
+		{
+			expr: "foo > 5",
+			output: []utils.Source{
+				{
+					Type:         utils.SelectorSource,
+					Returns:      promParser.ValueTypeVector,
+					ComparisonOp: promParser.GTR,
+					Selectors: []*promParser.VectorSelector{
+						mustParseVector("foo", 0),
+					},
+				},
+			},
+		},
+		{
+			expr: "5 < foo",
+			output: []utils.Source{
+				{
+					Type:         utils.SelectorSource,
+					Returns:      promParser.ValueTypeVector,
+					ComparisonOp: promParser.GTR,
+					Selectors: []*promParser.VectorSelector{
+						mustParseVector("foo", 4),
+					},
+				},
+			},
+		},
+		{
+			expr: "foo > bar",
+			output: []utils.Source{
+				{
+					Type:         utils.SelectorSource,
+					Returns:      promParser.ValueTypeVector,
+					ComparisonOp: promParser.GTR,
+					Operation:    promParser.CardOneToOne.String(),
+					Selectors: []*promParser.VectorSelector{
+						mustParseVector("foo", 0),
+					},
+				},
+			},
+		},
 		{
 			expr: `foo{job="bar"}`,
 			output: []utils.Source{
@@ -456,6 +533,7 @@ func TestLabelsSource(t *testing.T) {
 			output: []utils.Source{
 				{
 					Type:    utils.SelectorSource,
+					Range:   time.Minute * 5,
 					Returns: promParser.ValueTypeVector,
 					Selectors: []*promParser.VectorSelector{
 						mustParseVector("foo", 0),
@@ -468,6 +546,7 @@ func TestLabelsSource(t *testing.T) {
 			output: []utils.Source{
 				{
 					Type:    utils.SelectorSource,
+					Range:   time.Minute * 2,
 					Returns: promParser.ValueTypeVector,
 					Selectors: []*promParser.VectorSelector{
 						mustParseVector("prometheus_build_info", 0),
@@ -480,6 +559,7 @@ func TestLabelsSource(t *testing.T) {
 			output: []utils.Source{
 				{
 					Type:      utils.FuncSource,
+					Range:     time.Minute * 5,
 					Returns:   promParser.ValueTypeVector,
 					Operation: "deriv",
 					Selectors: []*promParser.VectorSelector{
@@ -829,6 +909,7 @@ func TestLabelsSource(t *testing.T) {
 			output: []utils.Source{
 				{
 					Type:      utils.AggregateSource,
+					Range:     time.Minute * 5,
 					Returns:   promParser.ValueTypeVector,
 					Operation: "stddev",
 					Selectors: []*promParser.VectorSelector{
@@ -849,6 +930,7 @@ func TestLabelsSource(t *testing.T) {
 			output: []utils.Source{
 				{
 					Type:      utils.AggregateSource,
+					Range:     time.Minute * 5,
 					Returns:   promParser.ValueTypeVector,
 					Operation: "stdvar",
 					Selectors: []*promParser.VectorSelector{
@@ -869,6 +951,7 @@ func TestLabelsSource(t *testing.T) {
 			output: []utils.Source{
 				{
 					Type:      utils.FuncSource,
+					Range:     time.Minute * 5,
 					Returns:   promParser.ValueTypeVector,
 					Operation: "stddev_over_time",
 					Selectors: []*promParser.VectorSelector{
@@ -899,6 +982,7 @@ func TestLabelsSource(t *testing.T) {
 			output: []utils.Source{
 				{
 					Type:      utils.FuncSource,
+					Range:     time.Minute * 5,
 					Returns:   promParser.ValueTypeVector,
 					Operation: "stdvar_over_time",
 					Selectors: []*promParser.VectorSelector{
@@ -929,11 +1013,13 @@ func TestLabelsSource(t *testing.T) {
 			output: []utils.Source{
 				{
 					Type:      utils.AggregateSource,
+					Range:     time.Minute * 5,
 					Returns:   promParser.ValueTypeVector,
 					Operation: "quantile",
 					Selectors: []*promParser.VectorSelector{
 						mustParseVector(`foo`, 19),
 					},
+					Quantile:    func() *float64 { v := 0.9; return &v }(),
 					FixedLabels: true,
 					ExcludeReason: map[string]utils.ExcludedLabel{
 						"": {
@@ -1036,9 +1122,10 @@ func TestLabelsSource(t *testing.T) {
 			expr: `topk(10, foo{job="myjob"}) > 10`,
 			output: []utils.Source{
 				{
-					Type:      utils.AggregateSource,
-					Returns:   promParser.ValueTypeVector,
-					Operation: "topk",
+					Type:         utils.AggregateSource,
+					Returns:      promParser.ValueTypeVector,
+					ComparisonOp: promParser.GTR,
+					Operation:    "topk",
 					Selectors: []*promParser.VectorSelector{
 						mustParseVector(`foo{job="myjob"}`, 9),
 					},
@@ -1072,6 +1159,7 @@ func TestLabelsSource(t *testing.T) {
 			output: []utils.Source{
 				{
 					Type:      utils.FuncSource,
+					Range:     time.Minute * 10,
 					Returns:   promParser.ValueTypeVector,
 					Operation: "rate",
 					Selectors: []*promParser.VectorSelector{
@@ -1102,6 +1190,7 @@ func TestLabelsSource(t *testing.T) {
 			output: []utils.Source{
 				{
 					Type:      utils.AggregateSource,
+					Range:     time.Minute * 10,
 					Returns:   promParser.ValueTypeVector,
 					Operation: "sum",
 					Selectors: []*promParser.VectorSelector{
@@ -1218,6 +1307,207 @@ func TestLabelsSource(t *testing.T) {
 				},
 			},
 		},
+		{
+			expr: `count(foo) == 0`,
+			output: []utils.Source{
+				{
+					Type:            utils.AggregateSource,
+					Returns:         promParser.ValueTypeVector,
+					ComparisonOp:    promParser.EQLC,
+					CountComparison: utils.CountRequiresAbsence,
+					Operation:       "count",
+					Selectors: []*promParser.VectorSelector{
+						mustParseVector(`foo`, 6),
+					},
+					FixedLabels: true,
+					IsDead:      true,
+					ExcludeReason: map[string]utils.ExcludedLabel{
+						"": {
+							Reason:   "Query is using aggregation that removes all labels.",
+							Fragment: `count(foo)`,
+						},
+					},
+				},
+			},
+		},
+		{
+			expr: `count(foo) < 1`,
+			output: []utils.Source{
+				{
+					Type:            utils.AggregateSource,
+					Returns:         promParser.ValueTypeVector,
+					ComparisonOp:    promParser.LSS,
+					CountComparison: utils.CountRequiresAbsence,
+					Operation:       "count",
+					Selectors: []*promParser.VectorSelector{
+						mustParseVector(`foo`, 6),
+					},
+					FixedLabels: true,
+					IsDead:      true,
+					ExcludeReason: map[string]utils.ExcludedLabel{
+						"": {
+							Reason:   "Query is using aggregation that removes all labels.",
+							Fragment: `count(foo)`,
+						},
+					},
+				},
+			},
+		},
+		{
+			expr: `count(foo) <= 0.5`,
+			output: []utils.Source{
+				{
+					Type:            utils.AggregateSource,
+					Returns:         promParser.ValueTypeVector,
+					ComparisonOp:    promParser.LTE,
+					CountComparison: utils.CountRequiresAbsence,
+					Operation:       "count",
+					Selectors: []*promParser.VectorSelector{
+						mustParseVector(`foo`, 6),
+					},
+					FixedLabels: true,
+					IsDead:      true,
+					ExcludeReason: map[string]utils.ExcludedLabel{
+						"": {
+							Reason:   "Query is using aggregation that removes all labels.",
+							Fragment: `count(foo)`,
+						},
+					},
+				},
+			},
+		},
+		{
+			expr: `0 == count(foo)`,
+			output: []utils.Source{
+				{
+					Type:            utils.AggregateSource,
+					Returns:         promParser.ValueTypeVector,
+					ComparisonOp:    promParser.EQLC,
+					CountComparison: utils.CountRequiresAbsence,
+					Operation:       "count",
+					Selectors: []*promParser.VectorSelector{
+						mustParseVector(`foo`, 11),
+					},
+					FixedLabels: true,
+					IsDead:      true,
+					ExcludeReason: map[string]utils.ExcludedLabel{
+						"": {
+							Reason:   "Query is using aggregation that removes all labels.",
+							Fragment: `count(foo)`,
+						},
+					},
+				},
+			},
+		},
+		{
+			expr: `count(foo) >= 1`,
+			output: []utils.Source{
+				{
+					Type:            utils.AggregateSource,
+					Returns:         promParser.ValueTypeVector,
+					ComparisonOp:    promParser.GTE,
+					CountComparison: utils.CountRequiresPresence,
+					Operation:       "count",
+					Selectors: []*promParser.VectorSelector{
+						mustParseVector(`foo`, 6),
+					},
+					FixedLabels: true,
+					ExcludeReason: map[string]utils.ExcludedLabel{
+						"": {
+							Reason:   "Query is using aggregation that removes all labels.",
+							Fragment: `count(foo)`,
+						},
+					},
+				},
+			},
+		},
+		{
+			expr: `count(foo) > 0`,
+			output: []utils.Source{
+				{
+					Type:            utils.AggregateSource,
+					Returns:         promParser.ValueTypeVector,
+					ComparisonOp:    promParser.GTR,
+					CountComparison: utils.CountRequiresPresence,
+					Operation:       "count",
+					Selectors: []*promParser.VectorSelector{
+						mustParseVector(`foo`, 6),
+					},
+					FixedLabels: true,
+					ExcludeReason: map[string]utils.ExcludedLabel{
+						"": {
+							Reason:   "Query is using aggregation that removes all labels.",
+							Fragment: `count(foo)`,
+						},
+					},
+				},
+			},
+		},
+		{
+			expr: `count(foo) == bool 0`,
+			output: []utils.Source{
+				{
+					Type:         utils.AggregateSource,
+					Returns:      promParser.ValueTypeVector,
+					ComparisonOp: promParser.EQLC,
+					Operation:    "count",
+					Selectors: []*promParser.VectorSelector{
+						mustParseVector(`foo`, 6),
+					},
+					FixedLabels: true,
+					ExcludeReason: map[string]utils.ExcludedLabel{
+						"": {
+							Reason:   "Query is using aggregation that removes all labels.",
+							Fragment: `count(foo)`,
+						},
+					},
+				},
+			},
+		},
+		{
+			expr: `1 > count(foo)`,
+			output: []utils.Source{
+				{
+					Type:            utils.AggregateSource,
+					Returns:         promParser.ValueTypeVector,
+					ComparisonOp:    promParser.LSS,
+					CountComparison: utils.CountRequiresAbsence,
+					Operation:       "count",
+					Selectors: []*promParser.VectorSelector{
+						mustParseVector(`foo`, 10),
+					},
+					FixedLabels: true,
+					IsDead:      true,
+					ExcludeReason: map[string]utils.ExcludedLabel{
+						"": {
+							Reason:   "Query is using aggregation that removes all labels.",
+							Fragment: `count(foo)`,
+						},
+					},
+				},
+			},
+		},
+		{
+			expr: `count(foo) - 1 == 0`,
+			output: []utils.Source{
+				{
+					Type:         utils.AggregateSource,
+					Returns:      promParser.ValueTypeVector,
+					ComparisonOp: promParser.EQLC,
+					Operation:    "count",
+					Selectors: []*promParser.VectorSelector{
+						mustParseVector(`foo`, 6),
+					},
+					FixedLabels: true,
+					ExcludeReason: map[string]utils.ExcludedLabel{
+						"": {
+							Reason:   "Query is using aggregation that removes all labels.",
+							Fragment: `count(foo)`,
+						},
+					},
+				},
+			},
+		},
 		{
 			expr: `count(up{job="a"} / on () up{job="b"})`,
 			output: []utils.Source{
@@ -1233,6 +1523,12 @@ func TestLabelsSource(t *testing.T) {
 						"": {
 							Reason:   "Query is using aggregation that removes all labels.",
 							Fragment: `count(up{job="a"} / on () up{job="b"})`,
+							Previous: []utils.ExcludedLabel{
+								{
+									Reason:   "Query is using one-to-one vector matching with `on()`, only labels included inside `on(...)` will be present on the results.",
+									Fragment: `up{job="a"} / on () up{job="b"}`,
+								},
+							},
 						},
 					},
 				},
@@ -1253,6 +1549,12 @@ func TestLabelsSource(t *testing.T) {
 						"": {
 							Reason:   "Query is using aggregation that removes all labels.",
 							Fragment: `count(up{job="a"} / on (env) up{job="b"})`,
+							Previous: []utils.ExcludedLabel{
+								{
+									Reason:   "Query is using one-to-one vector matching with `on(env)`, only labels included inside `on(...)` will be present on the results.",
+									Fragment: `up{job="a"} / on (env) up{job="b"}`,
+								},
+							},
 						},
 					},
 				},
@@ -1331,6 +1633,7 @@ func TestLabelsSource(t *testing.T) {
 			output: []utils.Source{
 				{
 					Type:      utils.AggregateSource,
+					Range:     time.Minute * 5,
 					Returns:   promParser.ValueTypeVector,
 					Operation: "bottomk",
 					Selectors: []*promParser.VectorSelector{
@@ -1442,9 +1745,10 @@ func TestLabelsSource(t *testing.T) {
 			expr: `count(sum(up{job="foo", cluster="dev"}) by(job, cluster) == 0) without(job, cluster)`,
 			output: []utils.Source{
 				{
-					Type:      utils.AggregateSource,
-					Returns:   promParser.ValueTypeVector,
-					Operation: "count",
+					Type:         utils.AggregateSource,
+					Returns:      promParser.ValueTypeVector,
+					ComparisonOp: promParser.EQLC,
+					Operation:    "count",
 					Selectors: []*promParser.VectorSelector{
 						mustParseVector(`up{job="foo", cluster="dev"}`, 10),
 					},
@@ -1585,36 +1889,142 @@ func TestLabelsSource(t *testing.T) {
 			},
 		},
 		{
-			expr: `
-(
-	sum(foo:sum > 0) without(notify)
-	* on(job) group_left(notify)
-	job:notify
-)
-and on(job)
-sum(foo:count) by(job) > 20`,
+			expr: `label_join(foo, "dst", "-", "a", "b")`,
 			output: []utils.Source{
 				{
-					Type:      utils.AggregateSource,
+					Type:      utils.FuncSource,
 					Returns:   promParser.ValueTypeVector,
-					Operation: "sum",
+					Operation: "label_join",
 					Selectors: []*promParser.VectorSelector{
-						mustParseVector(`foo:sum`, 8),
+						mustParseVector(`foo`, 11),
+					},
+
+					IncludedLabels: []string{"dst"},
+					ExcludeReason: map[string]utils.ExcludedLabel{
+						"dst": {
+							Reason:   "`dst` label will only be set by `label_join()` if any of the source labels (`a`, `b`) is present, but none of them is guaranteed to be present.",
+							Fragment: `label_join(foo, "dst", "-", "a", "b")`,
+						},
+					},
+					Call: &promParser.Call{
+						Func: &promParser.Function{
+							Name: "label_join",
+							ArgTypes: []promParser.ValueType{
+								promParser.ValueTypeVector,
+								promParser.ValueTypeString,
+								promParser.ValueTypeString,
+								promParser.ValueTypeString,
+							},
+							Variadic:   -1,
+							ReturnType: promParser.ValueTypeVector,
+						},
+						Args: promParser.Expressions{
+							mustParseVector(`foo`, 11),
+							&promParser.StringLiteral{
+								Val:      "dst",
+								PosRange: posrange.PositionRange{Start: 16, End: 21},
+							},
+							&promParser.StringLiteral{
+								Val:      "-",
+								PosRange: posrange.PositionRange{Start: 23, End: 26},
+							},
+							&promParser.StringLiteral{
+								Val:      "a",
+								PosRange: posrange.PositionRange{Start: 28, End: 31},
+							},
+							&promParser.StringLiteral{
+								Val:      "b",
+								PosRange: posrange.PositionRange{Start: 33, End: 36},
+							},
+						},
+						PosRange: posrange.PositionRange{
+							Start: 0,
+							End:   37,
+						},
 					},
-					IncludedLabels: []string{"notify", "job"},
-					ExcludeReason:  map[string]utils.ExcludedLabel{},
 				},
 			},
 		},
 		{
-			expr: `container_file_descriptors / on (instance, app_name) container_ulimits_soft{ulimit="max_open_files"}`,
+			expr: `label_join(foo{dst="bar"}, "dst", "-")`,
 			output: []utils.Source{
 				{
-					Type:      utils.SelectorSource,
+					Type:      utils.FuncSource,
 					Returns:   promParser.ValueTypeVector,
-					Operation: promParser.CardOneToOne.String(),
+					Operation: "label_join",
 					Selectors: []*promParser.VectorSelector{
-						mustParseVector(`container_file_descriptors`, 0),
+						mustParseVector(`foo{dst="bar"}`, 11),
+					},
+					IncludedLabels: []string{"dst"},
+					ExcludeReason: map[string]utils.ExcludedLabel{
+						"dst": {
+							Reason:   "Calling `label_join()` with no source labels will set `dst` label to an empty value, which removes it.",
+							Fragment: `label_join(foo{dst="bar"}, "dst", "-")`,
+						},
+					},
+					Call: &promParser.Call{
+						Func: &promParser.Function{
+							Name: "label_join",
+							ArgTypes: []promParser.ValueType{
+								promParser.ValueTypeVector,
+								promParser.ValueTypeString,
+								promParser.ValueTypeString,
+								promParser.ValueTypeString,
+							},
+							Variadic:   -1,
+							ReturnType: promParser.ValueTypeVector,
+						},
+						Args: promParser.Expressions{
+							mustParseVector(`foo{dst="bar"}`, 11),
+							&promParser.StringLiteral{
+								Val:      "dst",
+								PosRange: posrange.PositionRange{Start: 27, End: 32},
+							},
+							&promParser.StringLiteral{
+								Val:      "-",
+								PosRange: posrange.PositionRange{Start: 34, End: 37},
+							},
+						},
+						PosRange: posrange.PositionRange{
+							Start: 0,
+							End:   38,
+						},
+					},
+				},
+			},
+		},
+		{
+			expr: `
+(
+	sum(foo:sum > 0) without(notify)
+	* on(job) group_left(notify)
+	job:notify
+)
+and on(job)
+sum(foo:count) by(job) > 20`,
+			output: []utils.Source{
+				{
+					Type:         utils.AggregateSource,
+					Returns:      promParser.ValueTypeVector,
+					ComparisonOp: promParser.GTR,
+					Operation:    "sum",
+					Selectors: []*promParser.VectorSelector{
+						mustParseVector(`foo:sum`, 8),
+					},
+					IncludedLabels: []string{"notify", "job"},
+					ExcludeReason:  map[string]utils.ExcludedLabel{},
+				},
+			},
+		},
+		{
+			expr: `container_file_descriptors / on (instance, app_name) container_ulimits_soft{ulimit="max_open_files"}`,
+			output: []utils.Source{
+				{
+					Type:      utils.SelectorSource,
+					Returns:   promParser.ValueTypeVector,
+					Operation: promParser.CardOneToOne.String(),
+					Selectors: []*promParser.VectorSelector{
+						mustParseVector(`container_file_descriptors`, 0),
 					},
 					IncludedLabels: []string{"instance", "app_name"},
 					FixedLabels:    true,
@@ -1828,9 +2238,10 @@ sum(foo:count) by(job) > 20`,
 			expr: `count(node_exporter_build_info) by (instance, version) != ignoring(package,version) group_left(foo) count(deb_package_version) by (instance, version, package)`,
 			output: []utils.Source{
 				{
-					Type:      utils.AggregateSource,
-					Returns:   promParser.ValueTypeVector,
-					Operation: "count",
+					Type:         utils.AggregateSource,
+					Returns:      promParser.ValueTypeVector,
+					ComparisonOp: promParser.NEQ,
+					Operation:    "count",
 					Selectors: []*promParser.VectorSelector{
 						mustParseVector(`node_exporter_build_info`, 6),
 					},
@@ -1919,6 +2330,7 @@ sum(foo:count) by(job) > 20`,
 			output: []utils.Source{
 				{
 					Type:      utils.FuncSource,
+					Range:     time.Minute * 5,
 					Returns:   promParser.ValueTypeVector,
 					Operation: "absent_over_time",
 					Selectors: []*promParser.VectorSelector{
@@ -1927,7 +2339,7 @@ sum(foo:count) by(job) > 20`,
 					FixedLabels: true,
 					ExcludeReason: map[string]utils.ExcludedLabel{
 						"": {
-							Reason:   "The [absent_over_time()](https://prometheus.io/docs/prometheus/latest/querying/functions/#absent_over_time) function is used to check if provided query doesn't match any time series.\nYou will only get any results back if the metric selector you pass doesn't match anything.\nSince there are no matching time series there are also no labels. If some time series is missing you cannot read its labels.\nThis means that the only labels you can get back from absent call are the ones you pass to it.\nIf you're hoping to get instance specific labels this way and alert when some target is down then that won't work, use the `up` metric instead.",
+							Reason:   "The [absent_over_time()](https://prometheus.io/docs/prometheus/latest/querying/functions/#absent_over_time) function is used to check if provided range selector doesn't match any samples over the whole time range.\nYou will only get any results back if there were no matching samples at any point inside that time range.\nSince there are no matching samples there are also no labels. Time series that were present only for a part of the time range will not be reported and you cannot read labels of the ones that are missing.\nThis means that the only labels you can get back from absent_over_time call are the ones you pass to the selector using equality matchers.\nIf you're hoping to get instance specific labels this way and alert when some target is down then that won't work, use the `up` metric instead.",
 							Fragment: `absent_over_time(foo[5m])`,
 						},
 					},
@@ -2160,6 +2572,7 @@ sum(foo:count) by(job) > 20`,
 				{
 					Type:          utils.FuncSource,
 					Returns:       promParser.ValueTypeVector,
+					ComparisonOp:  promParser.EQLC,
 					Operation:     "vector",
 					FixedLabels:   true,
 					AlwaysReturns: true,
@@ -2211,6 +2624,7 @@ sum(foo:count) by(job) > 20`,
 			output: []utils.Source{
 				{
 					Type:      utils.FuncSource,
+					Range:     time.Minute * 5,
 					Returns:   promParser.ValueTypeVector,
 					Operation: "sum_over_time",
 					Selectors: []*promParser.VectorSelector{
@@ -2368,8 +2782,9 @@ sum(foo:count) by(job) > 20`,
 			expr: `(time() - my_metric) > 5*3600`,
 			output: []utils.Source{
 				{
-					Type:    utils.SelectorSource,
-					Returns: promParser.ValueTypeVector,
+					Type:         utils.SelectorSource,
+					Returns:      promParser.ValueTypeVector,
+					ComparisonOp: promParser.GTR,
 					Selectors: []*promParser.VectorSelector{
 						mustParseVector("my_metric", 10),
 					},
@@ -2408,12 +2823,14 @@ or avg without(router, colo_id, instance) (router_anycast_prefix_enabled{cidr_us
 `,
 			output: []utils.Source{
 				{
-					Type:      utils.AggregateSource,
-					Returns:   promParser.ValueTypeVector,
-					Operation: "avg",
+					Type:         utils.AggregateSource,
+					Returns:      promParser.ValueTypeVector,
+					ComparisonOp: promParser.LSS,
+					Operation:    "avg",
 					Selectors: []*promParser.VectorSelector{
 						mustParseVector(`router_anycast_prefix_enabled{cidr_use_case!~".*offpeak.*"}`, 41),
 					},
+					FilteredLabels: []string{"cidr_use_case"},
 					ExcludedLabels: []string{"router", "colo_id", "instance"},
 					ExcludeReason: map[string]utils.ExcludedLabel{
 						"router": {
@@ -2431,9 +2848,10 @@ or avg without(router, colo_id, instance) (router_anycast_prefix_enabled{cidr_us
 					},
 				},
 				{
-					Type:      utils.AggregateSource,
-					Returns:   promParser.ValueTypeVector,
-					Operation: "sum",
+					Type:         utils.AggregateSource,
+					Returns:      promParser.ValueTypeVector,
+					ComparisonOp: promParser.LSS,
+					Operation:    "sum",
 					Selectors: []*promParser.VectorSelector{
 						mustParseVector(`router_anycast_prefix_enabled{cidr_use_case=~".*tier1.*"}`, 155),
 					},
@@ -2460,9 +2878,10 @@ or avg without(router, colo_id, instance) (router_anycast_prefix_enabled{cidr_us
 					},
 				},
 				{
-					Type:      utils.AggregateSource,
-					Returns:   promParser.ValueTypeVector,
-					Operation: "avg",
+					Type:         utils.AggregateSource,
+					Returns:      promParser.ValueTypeVector,
+					ComparisonOp: promParser.LSS,
+					Operation:    "avg",
 					Selectors: []*promParser.VectorSelector{
 						mustParseVector(`router_anycast_prefix_enabled{cidr_use_case=~".*regional.*"}`, 343),
 					},
@@ -2566,9 +2985,11 @@ sum by (region, target, colo_name) (
 ) == 0`,
 			output: []utils.Source{
 				{
-					Type:      utils.AggregateSource,
-					Returns:   promParser.ValueTypeVector,
-					Operation: "sum",
+					Type:         utils.AggregateSource,
+					Returns:      promParser.ValueTypeVector,
+					Range:        time.Minute * 5,
+					ComparisonOp: promParser.EQLC,
+					Operation:    "sum",
 					Selectors: []*promParser.VectorSelector{
 						mustParseVector(`probe_success{job="abc"}`, 56),
 					},
@@ -2584,6 +3005,7 @@ sum by (region, target, colo_name) (
 				{
 					Type:            utils.AggregateSource,
 					Returns:         promParser.ValueTypeVector,
+					ComparisonOp:    promParser.EQLC,
 					Operation:       "sum",
 					FixedLabels:     true,
 					AlwaysReturns:   true,
@@ -2649,12 +3071,130 @@ sum by (region, target, colo_name) (
 				},
 			},
 		},
+		{
+			expr: `vector(1) or sum(foo)`,
+			output: []utils.Source{
+				{
+					Type:            utils.FuncSource,
+					Returns:         promParser.ValueTypeVector,
+					Operation:       "vector",
+					FixedLabels:     true,
+					AlwaysReturns:   true,
+					ReturnedNumbers: []float64{1},
+					ExcludeReason: map[string]utils.ExcludedLabel{
+						"": {
+							Reason:   "Calling `vector()` will return a vector value with no labels.",
+							Fragment: "vector(1)",
+						},
+					},
+					Call: &promParser.Call{
+						Func: &promParser.Function{
+							Name: "vector",
+							ArgTypes: []promParser.ValueType{
+								promParser.ValueTypeScalar,
+							},
+							Variadic:   0,
+							ReturnType: promParser.ValueTypeVector,
+						},
+						Args: promParser.Expressions{
+							&promParser.NumberLiteral{
+								Val: 1,
+								PosRange: posrange.PositionRange{
+									Start: 7,
+									End:   8,
+								},
+							},
+						},
+						PosRange: posrange.PositionRange{
+							Start: 0,
+							End:   9,
+						},
+					},
+				},
+				{
+					Type:      utils.AggregateSource,
+					Operation: "sum",
+					Returns:   promParser.ValueTypeVector,
+					Selectors: []*promParser.VectorSelector{
+						mustParseVector("foo", 17),
+					},
+					FixedLabels: true,
+					ExcludeReason: map[string]utils.ExcludedLabel{
+						"": {
+							Reason:   "Query is using aggregation that removes all labels.",
+							Fragment: "sum(foo)",
+						},
+					},
+					IsDead: true,
+					DeadCode: &utils.DeadCode{
+						Reason:   "The left hand side of `or` always returns results, so the right hand side will never be used.",
+						Fragment: "sum(foo)",
+					},
+				},
+			},
+		},
+		{
+			expr: `vector(1) or on() foo`,
+			output: []utils.Source{
+				{
+					Type:            utils.FuncSource,
+					Returns:         promParser.ValueTypeVector,
+					Operation:       "vector",
+					FixedLabels:     true,
+					AlwaysReturns:   true,
+					ReturnedNumbers: []float64{1},
+					ExcludeReason: map[string]utils.ExcludedLabel{
+						"": {
+							Reason:   "Calling `vector()` will return a vector value with no labels.",
+							Fragment: "vector(1)",
+						},
+					},
+					Call: &promParser.Call{
+						Func: &promParser.Function{
+							Name: "vector",
+							ArgTypes: []promParser.ValueType{
+								promParser.ValueTypeScalar,
+							},
+							Variadic:   0,
+							ReturnType: promParser.ValueTypeVector,
+						},
+						Args: promParser.Expressions{
+							&promParser.NumberLiteral{
+								Val: 1,
+								PosRange: posrange.PositionRange{
+									Start: 7,
+									End:   8,
+								},
+							},
+						},
+						PosRange: posrange.PositionRange{
+							Start: 0,
+							End:   9,
+						},
+					},
+				},
+				{
+					Type:      utils.SelectorSource,
+					Operation: promParser.CardManyToMany.String(),
+					Returns:   promParser.ValueTypeVector,
+					Selectors: []*promParser.VectorSelector{
+						mustParseVector("foo", 18),
+					},
+					IsDead: true,
+					DeadCode: &utils.DeadCode{
+						Reason:   "The left hand side of `or` always returns results, so the right hand side will never be used.",
+						Fragment: "foo",
+					},
+				},
+			},
+		},
 		{
 			expr: `vector(0) > 0`,
 			output: []utils.Source{
 				{
 					Type:            utils.FuncSource,
 					Returns:         promParser.ValueTypeVector,
+					ComparisonOp:    promParser.GTR,
 					Operation:       "vector",
 					FixedLabels:     true,
 					AlwaysReturns:   true,
@@ -2692,13 +3232,58 @@ sum by (region, target, colo_name) (
 				},
 			},
 		},
+		{
+			expr: `topk(5, vector(1) > 2)`,
+			output: []utils.Source{
+				{
+					Type:            utils.AggregateSource,
+					Returns:         promParser.ValueTypeVector,
+					ComparisonOp:    promParser.GTR,
+					Operation:       "topk",
+					FixedLabels:     true,
+					AlwaysReturns:   true,
+					ReturnedNumbers: []float64{1},
+					IsDead:          true,
+					ExcludeReason: map[string]utils.ExcludedLabel{
+						"": {
+							Reason:   "Calling `vector()` will return a vector value with no labels.",
+							Fragment: "vector(1)",
+						},
+					},
+					Call: &promParser.Call{
+						Func: &promParser.Function{
+							Name: "vector",
+							ArgTypes: []promParser.ValueType{
+								promParser.ValueTypeScalar,
+							},
+							Variadic:   0,
+							ReturnType: promParser.ValueTypeVector,
+						},
+						Args: promParser.Expressions{
+							&promParser.NumberLiteral{
+								Val: 1,
+								PosRange: posrange.PositionRange{
+									Start: 15,
+									End:   16,
+								},
+							},
+						},
+						PosRange: posrange.PositionRange{
+							Start: 8,
+							End:   17,
+						},
+					},
+				},
+			},
+		},
 		{
 			expr: `sum(foo or vector(0)) > 0`,
 			output: []utils.Source{
 				{
-					Type:      utils.AggregateSource,
-					Returns:   promParser.ValueTypeVector,
-					Operation: "sum",
+					Type:         utils.AggregateSource,
+					Returns:      promParser.ValueTypeVector,
+					ComparisonOp: promParser.GTR,
+					Operation:    "sum",
 					Selectors: []*promParser.VectorSelector{
 						mustParseVector(`foo`, 4),
 					},
@@ -2713,6 +3298,7 @@ sum by (region, target, colo_name) (
 				{
 					Type:            utils.AggregateSource,
 					Returns:         promParser.ValueTypeVector,
+					ComparisonOp:    promParser.GTR,
 					Operation:       "sum",
 					FixedLabels:     true,
 					AlwaysReturns:   true,
@@ -2722,6 +3308,12 @@ sum by (region, target, colo_name) (
 						"": {
 							Reason:   "Query is using aggregation that removes all labels.",
 							Fragment: "sum(foo or vector(0))",
+							Previous: []utils.ExcludedLabel{
+								{
+									Reason:   "Calling `vector()` will return a vector value with no labels.",
+									Fragment: "vector(0)",
+								},
+							},
 						},
 					},
 				},
@@ -2731,9 +3323,10 @@ sum by (region, target, colo_name) (
 			expr: `(sum(foo or vector(1)) > 0) == 2`,
 			output: []utils.Source{
 				{
-					Type:      utils.AggregateSource,
-					Returns:   promParser.ValueTypeVector,
-					Operation: "sum",
+					Type:         utils.AggregateSource,
+					Returns:      promParser.ValueTypeVector,
+					ComparisonOp: promParser.GTR,
+					Operation:    "sum",
 					Selectors: []*promParser.VectorSelector{
 						mustParseVector(`foo`, 5),
 					},
@@ -2748,6 +3341,7 @@ sum by (region, target, colo_name) (
 				{
 					Type:            utils.AggregateSource,
 					Returns:         promParser.ValueTypeVector,
+					ComparisonOp:    promParser.GTR,
 					Operation:       "sum",
 					FixedLabels:     true,
 					AlwaysReturns:   true,
@@ -2757,6 +3351,12 @@ sum by (region, target, colo_name) (
 						"": {
 							Reason:   "Query is using aggregation that removes all labels.",
 							Fragment: "sum(foo or vector(1))",
+							Previous: []utils.ExcludedLabel{
+								{
+									Reason:   "Calling `vector()` will return a vector value with no labels.",
+									Fragment: "vector(1)",
+								},
+							},
 						},
 					},
 				},
@@ -2766,9 +3366,10 @@ sum by (region, target, colo_name) (
 			expr: `(sum(foo or vector(1)) > 0) != 2`,
 			output: []utils.Source{
 				{
-					Type:      utils.AggregateSource,
-					Returns:   promParser.ValueTypeVector,
-					Operation: "sum",
+					Type:         utils.AggregateSource,
+					Returns:      promParser.ValueTypeVector,
+					ComparisonOp: promParser.GTR,
+					Operation:    "sum",
 					Selectors: []*promParser.VectorSelector{
 						mustParseVector(`foo`, 5),
 					},
@@ -2783,6 +3384,7 @@ sum by (region, target, colo_name) (
 				{
 					Type:            utils.AggregateSource,
 					Returns:         promParser.ValueTypeVector,
+					ComparisonOp:    promParser.GTR,
 					Operation:       "sum",
 					FixedLabels:     true,
 					AlwaysReturns:   true,
@@ -2791,6 +3393,12 @@ sum by (region, target, colo_name) (
 						"": {
 							Reason:   "Query is using aggregation that removes all labels.",
 							Fragment: "sum(foo or vector(1))",
+							Previous: []utils.ExcludedLabel{
+								{
+									Reason:   "Calling `vector()` will return a vector value with no labels.",
+									Fragment: "vector(1)",
+								},
+							},
 						},
 					},
 				},
@@ -2800,9 +3408,10 @@ sum by (region, target, colo_name) (
 			expr: `(sum(foo or vector(2)) > 0) != 2`,
 			output: []utils.Source{
 				{
-					Type:      utils.AggregateSource,
-					Returns:   promParser.ValueTypeVector,
-					Operation: "sum",
+					Type:         utils.AggregateSource,
+					Returns:      promParser.ValueTypeVector,
+					ComparisonOp: promParser.GTR,
+					Operation:    "sum",
 					Selectors: []*promParser.VectorSelector{
 						mustParseVector(`foo`, 5),
 					},
@@ -2817,6 +3426,7 @@ sum by (region, target, colo_name) (
 				{
 					Type:            utils.AggregateSource,
 					Returns:         promParser.ValueTypeVector,
+					ComparisonOp:    promParser.GTR,
 					Operation:       "sum",
 					FixedLabels:     true,
 					AlwaysReturns:   true,
@@ -2826,6 +3436,12 @@ sum by (region, target, colo_name) (
 						"": {
 							Reason:   "Query is using aggregation that removes all labels.",
 							Fragment: "sum(foo or vector(2))",
+							Previous: []utils.ExcludedLabel{
+								{
+									Reason:   "Calling `vector()` will return a vector value with no labels.",
+									Fragment: "vector(2)",
+								},
+							},
 						},
 					},
 				},
@@ -2903,3 +3519,1568 @@ func TestLabelsSourceCallCoverageFail(t *testing.T) {
 	require.Len(t, output, 1)
 	require.Nil(t, output[0].Call, "no call should have been detected in fake function")
 }
+
+func TestSourceModifiers(t *testing.T) {
+	type testCaseT struct {
+		expr          string
+		offset        time.Duration
+		hasAtModifier bool
+	}
+
+	testCases := []testCaseT{
+		{
+			expr: "foo",
+		},
+		{
+			expr:          "foo @ 1700000000",
+			hasAtModifier: true,
+		},
+		{
+			expr:          "foo @ end()",
+			hasAtModifier: true,
+		},
+		{
+			expr:   "foo offset 5m",
+			offset: time.Minute * 5,
+		},
+		{
+			expr:   "foo offset -5m",
+			offset: time.Minute * -5,
+		},
+		{
+			expr:          "rate(foo[5m] @ 1700000000 offset 1h)",
+			offset:        time.Hour,
+			hasAtModifier: true,
+		},
+		{
+			expr:   `sum(foo{job="bar"} offset 10m) by(job) > 0`,
+			offset: time.Minute * 10,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expr, func(t *testing.T) {
+			n, err := promParser.ParseExpr(tc.expr)
+			require.NoError(t, err)
+			output := utils.LabelsSource(tc.expr, n)
+			require.Len(t, output, 1)
+			require.Equal(t, tc.offset, output[0].Offset)
+			require.Equal(t, tc.hasAtModifier, output[0].HasAtModifier)
+		})
+	}
+}
+
+func TestSourceRange(t *testing.T) {
+	type testCaseT struct {
+		expr     string
+		rng      time.Duration
+		included []string
+	}
+
+	testCases := []testCaseT{
+		{
+			expr: "absent(foo)",
+		},
+		{
+			expr: "foo",
+		},
+		{
+			expr: "foo[5m]",
+			rng:  time.Minute * 5,
+		},
+		{
+			expr: "rate(foo[5m])",
+			rng:  time.Minute * 5,
+		},
+		{
+			expr:     "sum(rate(foo[2m])) by(job) > 0",
+			rng:      time.Minute * 2,
+			included: []string{"job"},
+		},
+		{
+			expr: "max_over_time(foo[1h:1m])",
+			rng:  time.Hour,
+		},
+		{
+			expr: "max_over_time(rate(foo[5m])[1h:1m])",
+			rng:  time.Hour,
+		},
+		{
+			expr: "min_over_time(max_over_time(rate(foo[5m])[30m:1m])[2h:5m])",
+			rng:  time.Hour * 2,
+		},
+		{
+			expr: "label_replace(increase(foo[15m]), \"a\", \"$1\", \"b\", \"(.*)\")",
+			rng:  time.Minute * 15,
+		},
+		{
+			expr: "absent_over_time(foo[1h])",
+			rng:  time.Hour,
+		},
+		{
+			expr:     `absent_over_time(foo{job="bar", instance=~".+"}[15m])`,
+			rng:      time.Minute * 15,
+			included: []string{"job"},
+		},
+		{
+			expr:     `absent_over_time(foo{job="bar"}[2h:1m])`,
+			rng:      time.Hour * 2,
+			included: []string{"job"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expr, func(t *testing.T) {
+			n, err := promParser.ParseExpr(tc.expr)
+			require.NoError(t, err)
+			output := utils.LabelsSource(tc.expr, n)
+			require.Len(t, output, 1)
+			require.Equal(t, tc.rng, output[0].Range)
+			require.Equal(t, tc.included, output[0].IncludedLabels)
+		})
+	}
+}
+
+func TestSourceExcludeReasons(t *testing.T) {
+	type testCaseT struct {
+		expr    string
+		label   string
+		reasons []string
+	}
+
+	testCases := []testCaseT{
+		{
+			expr:  "foo",
+			label: "job",
+		},
+		{
+			expr:  "sum(foo) without(job)",
+			label: "job",
+			reasons: []string{
+				"Query is using aggregation with `without(job)`, all labels included inside `without(...)` will be removed from the results.",
+			},
+		},
+		{
+			expr:  "sum(foo) without(job) + on(instance) bar",
+			label: "job",
+			reasons: []string{
+				"Query is using aggregation with `without(job)`, all labels included inside `without(...)` will be removed from the results.",
+				"Query is using one-to-one vector matching with `on(instance)`, only labels included inside `on(...)` will be present on the results.",
+			},
+		},
+		{
+			expr:  "sum(foo) without(job) + on(instance) bar",
+			label: "instance",
+		},
+		{
+			expr:  "sum(foo) by(job) + on(instance) bar",
+			label: "env",
+			reasons: []string{
+				"Query is using aggregation with `by(job)`, only labels included inside `by(...)` will be present on the results.",
+				"Query is using one-to-one vector matching with `on(instance)`, only labels included inside `on(...)` will be present on the results.",
+			},
+		},
+		{
+			expr:  "sum(sum(foo) by(job)) by(job)",
+			label: "instance",
+			reasons: []string{
+				"Query is using aggregation with `by(job)`, only labels included inside `by(...)` will be present on the results.",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expr, func(t *testing.T) {
+			n, err := promParser.ParseExpr(tc.expr)
+			require.NoError(t, err)
+			output := utils.LabelsSource(tc.expr, n)
+			require.Len(t, output, 1)
+			var reasons []string
+			for _, reason := range output[0].ExcludeReasons(tc.label) {
+				reasons = append(reasons, reason.Reason)
+			}
+			require.Equal(t, tc.reasons, reasons)
+		})
+	}
+}
+
+func TestSourceNestedBy(t *testing.T) {
+	type testCaseT struct {
+		expr     string
+		label    string
+		included []string
+		reasons  []string
+	}
+
+	testCases := []testCaseT{
+		{
+			expr:     "sum(sum(foo) by(pod, job)) by(job)",
+			label:    "pod",
+			included: []string{"job"},
+			reasons: []string{
+				"Query is using aggregation with `by(pod, job)`, only labels included inside `by(...)` will be present on the results.",
+				"Query is using aggregation with `by(job)`, only labels included inside `by(...)` will be present on the results.",
+			},
+		},
+		{
+			expr:     "sum(sum(foo) by(job)) by(job, pod)",
+			label:    "pod",
+			included: []string{"job"},
+			reasons: []string{
+				"Query is using aggregation with `by(job)`, only labels included inside `by(...)` will be present on the results.",
+			},
+		},
+		{
+			expr:  "sum(sum(foo) by(pod, job)) by(env)",
+			label: "job",
+			reasons: []string{
+				"Query is using aggregation with `by(pod, job)`, only labels included inside `by(...)` will be present on the results.",
+				"Query is using aggregation with `by(env)`, only labels included inside `by(...)` will be present on the results.",
+			},
+		},
+		{
+			expr:     "max(sum(rate(foo[5m])) by(pod, job, instance)) by(job, instance)",
+			label:    "pod",
+			included: []string{"job", "instance"},
+			reasons: []string{
+				"Query is using aggregation with `by(pod, job, instance)`, only labels included inside `by(...)` will be present on the results.",
+				"Query is using aggregation with `by(job, instance)`, only labels included inside `by(...)` will be present on the results.",
+			},
+		},
+		{
+			expr:     "sum(sum(foo) by(job)) by(job)",
+			label:    "job",
+			included: []string{"job"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expr, func(t *testing.T) {
+			n, err := promParser.ParseExpr(tc.expr)
+			require.NoError(t, err)
+			output := utils.LabelsSource(tc.expr, n)
+			require.Len(t, output, 1)
+			require.True(t, output[0].FixedLabels)
+			require.Equal(t, tc.included, output[0].IncludedLabels)
+			var reasons []string
+			for _, reason := range output[0].ExcludeReasons(tc.label) {
+				reasons = append(reasons, reason.Reason)
+			}
+			require.Equal(t, tc.reasons, reasons)
+		})
+	}
+}
+
+func TestSourceDescribe(t *testing.T) {
+	type testCaseT struct {
+		expr   string
+		output string
+	}
+
+	testCases := []testCaseT{
+		{
+			expr: `foo{job="a"}`,
+			output: `Returns: vector
+Labels: all labels from selected time series
+Guaranteed labels: ` + "`job`" + `
+`,
+		},
+		{
+			expr: `sum without(instance) (foo)`,
+			output: `Returns: vector
+Labels: all labels from selected time series except ` + "`instance`" + `
+Guaranteed labels: none
+Removed ` + "`instance`" + ` label: Query is using aggregation with ` + "`without(instance)`" + `, all labels included inside ` + "`without(...)`" + ` will be removed from the results.
+Query fragment: ` + "`sum without(instance) (foo)`" + `.
+`,
+		},
+		{
+			expr: `sum(foo{job="a"}) by(job, instance)`,
+			output: `Returns: vector
+Labels: only ` + "`job`, `instance`" + `
+Guaranteed labels: ` + "`job`" + `
+Removed labels: Query is using aggregation with ` + "`by(job, instance)`" + `, only labels included inside ` + "`by(...)`" + ` will be present on the results.
+Query fragment: ` + "`sum(foo{job=\"a\"}) by(job, instance)`" + `.
+`,
+		},
+		{
+			expr: `absent(foo{job="a"})`,
+			output: `Returns: vector
+Labels: only ` + "`job`" + `
+Guaranteed labels: ` + "`job`" + `
+Removed labels: The [absent()](https://prometheus.io/docs/prometheus/latest/querying/functions/#absent) function is used to check if provided query doesn't match any time series.
+You will only get any results back if the metric selector you pass doesn't match anything.
+Since there are no matching time series there are also no labels. If some time series is missing you cannot read its labels.
+This means that the only labels you can get back from absent call are the ones you pass to it.
+If you're hoping to get instance specific labels this way and alert when some target is down then that won't work, use the ` + "`up`" + ` metric instead.
+Query fragment: ` + "`absent(foo{job=\"a\"})`" + `.
+`,
+		},
+		{
+			expr: `vector(1)`,
+			output: `Returns: vector
+Labels: none
+Guaranteed labels: none
+Removed labels: Calling ` + "`vector()`" + ` will return a vector value with no labels.
+Query fragment: ` + "`vector(1)`" + `.
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expr, func(t *testing.T) {
+			n, err := promParser.ParseExpr(tc.expr)
+			require.NoError(t, err)
+			output := utils.LabelsSource(tc.expr, n)
+			require.Len(t, output, 1)
+			require.Equal(t, tc.output, output[0].Describe())
+		})
+	}
+}
+
+func TestSourceSelectorFragments(t *testing.T) {
+	type testCaseT struct {
+		expr   string
+		output [][]string
+	}
+
+	testCases := []testCaseT{
+		{
+			expr:   "vector(1)",
+			output: [][]string{nil},
+		},
+		{
+			expr:   `foo{job="x"}`,
+			output: [][]string{{`foo{job="x"}`}},
+		},
+		{
+			expr:   `rate(foo{job="x"}[5m])`,
+			output: [][]string{{`foo{job="x"}`}},
+		},
+		{
+			expr:   `sum(rate(foo{ job = "x" } [5m] offset 1m)) by(job)`,
+			output: [][]string{{`foo{ job = "x" }`}},
+		},
+		{
+			expr:   `label_replace(absent(foo{job="x"}), "a", "$1", "job", "(.*)")`,
+			output: [][]string{{`foo{job="x"}`}},
+		},
+		{
+			expr:   `foo{job="x"} / on(job) bar{job="y"}`,
+			output: [][]string{{`foo{job="x"}`}},
+		},
+		{
+			expr:   `foo{job="x"} or bar{job="y"}`,
+			output: [][]string{{`foo{job="x"}`}, {`bar{job="y"}`}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expr, func(t *testing.T) {
+			n, err := promParser.ParseExpr(tc.expr)
+			require.NoError(t, err)
+			var output [][]string
+			for _, s := range utils.LabelsSource(tc.expr, n) {
+				require.Len(t, s.SelectorFragments(), len(s.Selectors))
+				output = append(output, s.SelectorFragments())
+			}
+			require.Equal(t, tc.output, output)
+		})
+	}
+}
+
+func TestSourceMetricNames(t *testing.T) {
+	type testCaseT struct {
+		expr   string
+		output [][]string
+	}
+
+	testCases := []testCaseT{
+		{
+			expr:   "vector(1)",
+			output: [][]string{nil},
+		},
+		{
+			expr:   `foo{job="x"}`,
+			output: [][]string{{"foo"}},
+		},
+		{
+			expr:   `{__name__="foo", job="x"}`,
+			output: [][]string{{"foo"}},
+		},
+		{
+			expr:   `{__name__=~"foo|bar", job="x"}`,
+			output: [][]string{nil},
+		},
+		{
+			expr:   `{__name__!="foo", job="x"}`,
+			output: [][]string{nil},
+		},
+		{
+			expr:   `sum(rate(foo{job="x"}[5m])) by(job)`,
+			output: [][]string{{"foo"}},
+		},
+		{
+			expr:   `label_replace(absent(foo{job="x"}), "a", "$1", "job", "(.*)")`,
+			output: [][]string{{"foo"}},
+		},
+		{
+			expr:   `histogram_quantile(0.9, sum(rate(foo_bucket[5m])) by(le)) / sum(rate(foo_count[5m]))`,
+			output: [][]string{{"foo_bucket"}},
+		},
+		{
+			expr:   `foo{job="x"} or bar{job="y"} or {__name__=~"b.+"}`,
+			output: [][]string{{"foo"}, {"bar"}, nil},
+		},
+		{
+			expr:   `sum(foo or bar)`,
+			output: [][]string{{"foo"}, {"bar"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expr, func(t *testing.T) {
+			n, err := promParser.ParseExpr(tc.expr)
+			require.NoError(t, err)
+			var output [][]string
+			for _, s := range utils.LabelsSource(tc.expr, n) {
+				output = append(output, s.MetricNames())
+			}
+			require.Equal(t, tc.output, output)
+		})
+	}
+}
+
+func TestSourceIncludedByJoin(t *testing.T) {
+	type testCaseT struct {
+		expr   string
+		output [][]string
+	}
+
+	testCases := []testCaseT{
+		{
+			expr:   "foo",
+			output: [][]string{nil},
+		},
+		{
+			expr:   "foo * on(x) bar",
+			output: [][]string{nil},
+		},
+		{
+			expr:   "foo * on(x) group_left(a,b) bar",
+			output: [][]string{{"a", "b"}},
+		},
+		{
+			expr:   "foo * ignoring(x) group_right(c) bar",
+			output: [][]string{{"c"}},
+		},
+		{
+			expr:   "sum(foo * on(x) group_left(a,b) bar) by(a, x)",
+			output: [][]string{{"a"}},
+		},
+		{
+			expr:   "sum(foo * on(x) group_left(a,b) bar) by(x)",
+			output: [][]string{nil},
+		},
+		{
+			expr:   "sum(foo * on(x) group_left(a,b) bar)",
+			output: [][]string{nil},
+		},
+		{
+			expr:   "sum(foo * on(x) group_left(a,b) bar) without(a)",
+			output: [][]string{{"b"}},
+		},
+		{
+			expr:   "foo * on(x) group_left(a) bar or baz",
+			output: [][]string{{"a"}, nil},
+		},
+		{
+			expr:   "(foo * on(x) group_left(a) bar) * on(y) group_left(b) baz",
+			output: [][]string{{"a", "b"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expr, func(t *testing.T) {
+			n, err := promParser.ParseExpr(tc.expr)
+			require.NoError(t, err)
+			var output [][]string
+			for _, s := range utils.LabelsSource(tc.expr, n) {
+				output = append(output, s.IncludedByJoin())
+			}
+			require.Equal(t, tc.output, output)
+		})
+	}
+}
+
+func TestSourceMatcherSets(t *testing.T) {
+	type testCaseT struct {
+		expr   string
+		output [][]string
+	}
+
+	testCases := []testCaseT{
+		{
+			expr:   "1",
+			output: nil,
+		},
+		{
+			expr:   "foo",
+			output: [][]string{{`__name__="foo"`}},
+		},
+		{
+			expr:   `foo{job="bar", instance!~"a|b"}`,
+			output: [][]string{{`job="bar"`, `instance!~"a|b"`, `__name__="foo"`}},
+		},
+		{
+			expr:   `{__name__=~"foo|bar"}`,
+			output: [][]string{{`__name__=~"foo|bar"`}},
+		},
+		{
+			expr:   `sum(foo{job="a"} or bar) by(job)`,
+			output: [][]string{{`job="a"`, `__name__="foo"`}, {`__name__="bar"`}},
+		},
+		{
+			expr:   `foo{job="a"} / foo{job="a"}`,
+			output: [][]string{{`job="a"`, `__name__="foo"`}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expr, func(t *testing.T) {
+			n, err := promParser.ParseExpr(tc.expr)
+			require.NoError(t, err)
+			var output [][]string
+			for _, s := range utils.LabelsSource(tc.expr, n) {
+				for _, ms := range s.MatcherSets() {
+					var out []string
+					for _, m := range ms {
+						out = append(out, m.String())
+					}
+					output = append(output, out)
+				}
+			}
+			require.Equal(t, tc.output, output)
+		})
+	}
+}
+
+func TestSourceMatcherSetsImpliedName(t *testing.T) {
+	s := utils.Source{
+		Selectors: []*promParser.VectorSelector{
+			{
+				Name: "foo",
+				LabelMatchers: []*labels.Matcher{
+					labels.MustNewMatcher(labels.MatchEqual, "job", "bar"),
+				},
+			},
+		},
+	}
+	require.Equal(t, [][]*labels.Matcher{
+		{
+			labels.MustNewMatcher(labels.MatchEqual, labels.MetricName, "foo"),
+			labels.MustNewMatcher(labels.MatchEqual, "job", "bar"),
+		},
+	}, s.MatcherSets())
+}
+
+func TestSourceFingerprint(t *testing.T) {
+	type testCaseT struct {
+		lhs     string
+		rhs     string
+		isEqual bool
+	}
+
+	testCases := []testCaseT{
+		{lhs: "foo", rhs: "foo", isEqual: true},
+		{lhs: `foo{job="bar", instance="a"}`, rhs: `foo{instance="a", job="bar"}`, isEqual: true},
+		{lhs: `sum(foo) without(a, b)`, rhs: `sum(foo) without(b, a)`, isEqual: true},
+		{lhs: `sum(foo) by(job)`, rhs: `max(foo) by(job)`, isEqual: true},
+		{lhs: `foo{job="bar"}`, rhs: `foo{job="foo"}`, isEqual: false},
+		{lhs: `foo{job="bar"}`, rhs: `foo{job=~"bar"}`, isEqual: false},
+		{lhs: "foo", rhs: "bar", isEqual: false},
+		{lhs: `sum(foo) by(job)`, rhs: `sum(foo) by(instance)`, isEqual: false},
+		{lhs: `sum(foo) by(job)`, rhs: `sum(foo) without(job)`, isEqual: false},
+	}
+
+	fingerprint := func(t *testing.T, expr string) uint64 {
+		n, err := promParser.ParseExpr(expr)
+		require.NoError(t, err)
+		src := utils.LabelsSource(expr, n)
+		require.Len(t, src, 1)
+		return src[0].Fingerprint()
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.lhs+" vs "+tc.rhs, func(t *testing.T) {
+			lhs := fingerprint(t, tc.lhs)
+			require.Equal(t, lhs, fingerprint(t, tc.lhs), "fingerprint is not stable")
+			if tc.isEqual {
+				require.Equal(t, lhs, fingerprint(t, tc.rhs))
+			} else {
+				require.NotEqual(t, lhs, fingerprint(t, tc.rhs))
+			}
+		})
+	}
+}
+
+func TestSourceDeprecated(t *testing.T) {
+	// holt_winters was removed from the Prometheus parser, register it again
+	// with the same definition as double_exponential_smoothing.
+	def := *promParser.Functions["double_exponential_smoothing"]
+	def.Name = "holt_winters"
+	def.Experimental = false
+	promParser.Functions["holt_winters"] = &def
+	t.Cleanup(func() { delete(promParser.Functions, "holt_winters") })
+
+	type testCaseT struct {
+		expr   string
+		output []*utils.DeprecatedFunction
+	}
+
+	testCases := []testCaseT{
+		{
+			expr:   "rate(foo[5m])",
+			output: []*utils.DeprecatedFunction{nil},
+		},
+		{
+			expr: "holt_winters(foo[5m], 0.5, 0.5)",
+			output: []*utils.DeprecatedFunction{
+				{Name: "holt_winters", Replacement: "double_exponential_smoothing", Fragment: "holt_winters(foo[5m], 0.5, 0.5)"},
+			},
+		},
+		{
+			expr: "sum(abs(holt_winters(foo[5m], 0.5, 0.5))) by(job)",
+			output: []*utils.DeprecatedFunction{
+				{Name: "holt_winters", Replacement: "double_exponential_smoothing", Fragment: "holt_winters(foo[5m], 0.5, 0.5)"},
+			},
+		},
+		{
+			expr: "holt_winters(foo[5m], 0.5, 0.5) > bar",
+			output: []*utils.DeprecatedFunction{
+				{Name: "holt_winters", Replacement: "double_exponential_smoothing", Fragment: "holt_winters(foo[5m], 0.5, 0.5)"},
+			},
+		},
+		{
+			expr: "foo or holt_winters(bar[5m], 0.5, 0.5)",
+			output: []*utils.DeprecatedFunction{
+				nil,
+				{Name: "holt_winters", Replacement: "double_exponential_smoothing", Fragment: "holt_winters(bar[5m], 0.5, 0.5)"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expr, func(t *testing.T) {
+			n, err := promParser.ParseExpr(tc.expr)
+			require.NoError(t, err)
+			var output []*utils.DeprecatedFunction
+			for _, s := range utils.LabelsSource(tc.expr, n) {
+				output = append(output, s.Deprecated)
+			}
+			require.Equal(t, tc.output, output)
+		})
+	}
+
+	t.Run("labels", func(t *testing.T) {
+		promParser.EnableExperimentalFunctions = true
+		t.Cleanup(func() { promParser.EnableExperimentalFunctions = false })
+
+		for _, q := range []string{
+			`holt_winters(foo{job="bar"}[5m], 0.5, 0.5)`,
+			`sum(holt_winters(foo{job="bar"}[5m], 0.5, 0.5)) by(job)`,
+			`sum without(instance) (holt_winters(foo{job="bar", env!="dev"}[5m] offset 5m, 0.5, 0.5)) > 0`,
+		} {
+			dq := strings.ReplaceAll(q, "holt_winters", "double_exponential_smoothing")
+			n, err := promParser.ParseExpr(q)
+			require.NoError(t, err)
+			dn, err := promParser.ParseExpr(dq)
+			require.NoError(t, err)
+			src := utils.LabelsSource(q, n)
+			dsrc := utils.LabelsSource(dq, dn)
+			require.Len(t, src, 1)
+			require.Len(t, dsrc, 1)
+			require.Equal(t, dsrc[0].Type, src[0].Type)
+			require.Equal(t, dsrc[0].Returns, src[0].Returns)
+			require.Equal(t, dsrc[0].ComparisonOp, src[0].ComparisonOp)
+			require.Equal(t, dsrc[0].GuaranteedLabels, src[0].GuaranteedLabels)
+			require.Equal(t, dsrc[0].IncludedLabels, src[0].IncludedLabels)
+			require.Equal(t, dsrc[0].ExcludedLabels, src[0].ExcludedLabels)
+			require.Equal(t, dsrc[0].FilteredLabels, src[0].FilteredLabels)
+			require.Equal(t, dsrc[0].FixedLabels, src[0].FixedLabels)
+			require.Equal(t, dsrc[0].Range, src[0].Range)
+			require.Equal(t, dsrc[0].Offset, src[0].Offset)
+			require.Equal(t, slices.Sorted(maps.Keys(dsrc[0].ExcludeReason)), slices.Sorted(maps.Keys(src[0].ExcludeReason)))
+			require.Nil(t, dsrc[0].Deprecated)
+		}
+	})
+}
+
+func TestCompatibleLabels(t *testing.T) {
+	type testCaseT struct {
+		expr   string
+		refs   []string
+		output [][]string
+	}
+
+	testCases := []testCaseT{
+		{
+			expr:   "foo",
+			refs:   []string{"job", "instance"},
+			output: [][]string{nil},
+		},
+		{
+			expr:   "sum(foo) by(job)",
+			refs:   []string{"job", "instance", "cluster", "instance"},
+			output: [][]string{{"instance", "cluster"}},
+		},
+		{
+			expr:   "sum(foo) without(instance)",
+			refs:   []string{"job", "instance"},
+			output: [][]string{{"instance"}},
+		},
+		{
+			expr:   "count(foo)",
+			refs:   []string{"job"},
+			output: [][]string{{"job"}},
+		},
+		{
+			expr:   "sum(foo) without(job) * on(instance) group_left(job) bar",
+			refs:   []string{"job", "instance"},
+			output: [][]string{nil},
+		},
+		{
+			expr:   "sum(foo) by(job) or sum(bar) by(instance)",
+			refs:   []string{"job", "instance"},
+			output: [][]string{{"instance"}, {"job"}},
+		},
+		{
+			expr:   "vector(1)",
+			refs:   []string{"job"},
+			output: [][]string{{"job"}},
+		},
+		{
+			expr:   "sum(foo) by(job)",
+			refs:   nil,
+			output: [][]string{nil},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expr, func(t *testing.T) {
+			n, err := promParser.ParseExpr(tc.expr)
+			require.NoError(t, err)
+			var output [][]string
+			for _, s := range utils.LabelsSource(tc.expr, n) {
+				output = append(output, utils.CompatibleLabels(s, tc.refs))
+			}
+			require.Equal(t, tc.output, output)
+		})
+	}
+}
+
+func TestLabelsSourceInvalidStringOp(t *testing.T) {
+	// Prometheus parser refuses to parse these queries, so build the AST directly.
+	str := &promParser.StringLiteral{Val: "foo", PosRange: posrange.PositionRange{Start: 0, End: 5}}
+
+	type testCaseT struct {
+		expr   string
+		node   promParser.Expr
+		reason string
+	}
+
+	testCases := []testCaseT{
+		{
+			expr: `"foo" + 1`,
+			node: &promParser.BinaryExpr{
+				Op:  promParser.ADD,
+				LHS: str,
+				RHS: &promParser.NumberLiteral{Val: 1, PosRange: posrange.PositionRange{Start: 8, End: 9}},
+			},
+			reason: "This query is using `+` operator between a string and a scalar, binary operations on strings are not allowed in PromQL.",
+		},
+		{
+			expr: `"foo" * bar`,
+			node: &promParser.BinaryExpr{
+				Op:  promParser.MUL,
+				LHS: str,
+				RHS: &promParser.VectorSelector{Name: "bar", PosRange: posrange.PositionRange{Start: 8, End: 11}},
+			},
+			reason: "This query is using `*` operator between a string and an instant vector, binary operations on strings are not allowed in PromQL.",
+		},
+		{
+			expr: `1 - "foo"`,
+			node: &promParser.BinaryExpr{
+				Op:  promParser.SUB,
+				LHS: &promParser.NumberLiteral{Val: 1, PosRange: posrange.PositionRange{Start: 0, End: 1}},
+				RHS: &promParser.StringLiteral{Val: "foo", PosRange: posrange.PositionRange{Start: 4, End: 9}},
+			},
+			reason: "This query is using `-` operator between a scalar and a string, binary operations on strings are not allowed in PromQL.",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expr, func(t *testing.T) {
+			output := utils.LabelsSource(tc.expr, tc.node)
+			require.Len(t, output, 1)
+			require.Equal(t, promParser.ValueTypeNone, output[0].Returns)
+			require.Equal(t, utils.UnknownSource, output[0].Type)
+			require.Equal(t, utils.ExcludedLabel{Reason: tc.reason, Fragment: tc.expr}, output[0].ExcludeReason[""])
+		})
+	}
+}
+
+func TestSourceHighChurnLabels(t *testing.T) {
+	type testCaseT struct {
+		expr   string
+		output [][]string
+	}
+
+	names := []string{"pod", "container_id", "replica"}
+	testCases := []testCaseT{
+		{
+			expr:   "foo",
+			output: [][]string{nil},
+		},
+		{
+			expr:   "sum(foo) by(job)",
+			output: [][]string{nil},
+		},
+		{
+			expr:   "sum(foo) by(job, pod)",
+			output: [][]string{{"pod"}},
+		},
+		{
+			expr:   "sum(foo) without(instance)",
+			output: [][]string{nil},
+		},
+		{
+			expr:   "sum(sum(foo) by(pod, replica)) by(pod)",
+			output: [][]string{{"pod"}},
+		},
+		{
+			expr:   `foo{pod="abc"}`,
+			output: [][]string{{"pod"}},
+		},
+		{
+			expr:   "max(foo) by(job) or count(bar) by(pod, container_id)",
+			output: [][]string{nil, {"pod", "container_id"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expr, func(t *testing.T) {
+			n, err := promParser.ParseExpr(tc.expr)
+			require.NoError(t, err)
+			var output [][]string
+			for _, s := range utils.LabelsSource(tc.expr, n) {
+				output = append(output, s.HighChurnLabels(names))
+			}
+			require.Equal(t, tc.output, output)
+		})
+	}
+}
+
+func TestSourceVectorAlwaysReturns(t *testing.T) {
+	type testCaseT struct {
+		expr            string
+		alwaysReturns   bool
+		returnedNumbers []float64
+		isDead          bool
+	}
+
+	testCases := []testCaseT{
+		{
+			expr:            "vector(1)",
+			alwaysReturns:   true,
+			returnedNumbers: []float64{1},
+		},
+		{
+			expr:            "vector((2))",
+			alwaysReturns:   true,
+			returnedNumbers: []float64{2},
+		},
+		{
+			expr:          "vector(time())",
+			alwaysReturns: true,
+		},
+		{
+			expr:          "vector(scalar(up))",
+			alwaysReturns: true,
+		},
+		{
+			expr:          "vector(scalar(up)) > 10",
+			alwaysReturns: true,
+		},
+		{
+			expr:            "vector(1) > 10",
+			alwaysReturns:   true,
+			returnedNumbers: []float64{1},
+			isDead:          true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expr, func(t *testing.T) {
+			n, err := promParser.ParseExpr(tc.expr)
+			require.NoError(t, err)
+			src := utils.LabelsSource(tc.expr, n)
+			require.Len(t, src, 1)
+			require.Equal(t, tc.alwaysReturns, src[0].AlwaysReturns)
+			require.Equal(t, tc.returnedNumbers, src[0].ReturnedNumbers)
+			require.Equal(t, tc.isDead, src[0].IsDead)
+		})
+	}
+}
+
+func TestSourceClampBounds(t *testing.T) {
+	type testCaseT struct {
+		minValue *float64
+		maxValue *float64
+		expr     string
+		isDead   bool
+	}
+
+	value := func(v float64) *float64 { return &v }
+
+	testCases := []testCaseT{
+		{
+			expr:     "clamp_max(foo, 5)",
+			maxValue: value(5),
+		},
+		{
+			expr:     "clamp_min(foo, (1))",
+			minValue: value(1),
+		},
+		{
+			expr:     "clamp(foo, 1, 5)",
+			minValue: value(1),
+			maxValue: value(5),
+		},
+		{
+			expr: "clamp_max(foo, scalar(bar))",
+		},
+		{
+			expr:     "clamp_max(foo, 5) > 10",
+			maxValue: value(5),
+			isDead:   true,
+		},
+		{
+			expr:     "clamp_max(foo, 5) > 3",
+			maxValue: value(5),
+		},
+		{
+			expr:     "clamp_max(foo, 5) >= 5",
+			maxValue: value(5),
+		},
+		{
+			expr:     "10 < clamp_max(foo, 5)",
+			maxValue: value(5),
+			isDead:   true,
+		},
+		{
+			expr:     "clamp_min(foo, 1) < 0",
+			minValue: value(1),
+			isDead:   true,
+		},
+		{
+			expr:     "clamp(foo, 1, 5) == 7",
+			minValue: value(1),
+			maxValue: value(5),
+			isDead:   true,
+		},
+		{
+			expr:     "max(clamp_max(foo, 5)) by(job) > 10",
+			maxValue: value(5),
+			isDead:   true,
+		},
+		{
+			expr: "sum(clamp_max(foo, 5)) > 10",
+		},
+		{
+			expr: "clamp_max(foo, 5) * 3 > 10",
+		},
+		{
+			expr: "clamp_max(foo, 5) > bool 10",
+		},
+		{
+			expr:     "-clamp_max(foo, 5) < -10",
+			minValue: value(-5),
+			isDead:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expr, func(t *testing.T) {
+			n, err := promParser.ParseExpr(tc.expr)
+			require.NoError(t, err)
+			src := utils.LabelsSource(tc.expr, n)
+			require.Len(t, src, 1)
+			require.Equal(t, tc.minValue, src[0].MinValue, "MinValue")
+			require.Equal(t, tc.maxValue, src[0].MaxValue, "MaxValue")
+			require.Equal(t, tc.isDead, src[0].IsDead, "IsDead")
+		})
+	}
+}
+
+func TestSourceSortByLabel(t *testing.T) {
+	promParser.EnableExperimentalFunctions = true
+	t.Cleanup(func() { promParser.EnableExperimentalFunctions = false })
+
+	type testCaseT struct {
+		expr       string
+		operation  string
+		guaranteed []string
+		included   []string
+		selectors  int
+		fixed      bool
+	}
+
+	testCases := []testCaseT{
+		{
+			expr:       `sort_by_label(foo{job="bar"}, "job")`,
+			operation:  "sort_by_label",
+			guaranteed: []string{"job"},
+			selectors:  1,
+		},
+		{
+			expr:       `sort_by_label_desc(foo{job="bar", instance="a"}, "instance", "job")`,
+			operation:  "sort_by_label_desc",
+			guaranteed: []string{"job", "instance"},
+			selectors:  1,
+		},
+		{
+			expr:      `sum(sort_by_label(foo, "job")) by(job)`,
+			operation: "sum",
+			included:  []string{"job"},
+			selectors: 1,
+			fixed:     true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expr, func(t *testing.T) {
+			n, err := promParser.ParseExpr(tc.expr)
+			require.NoError(t, err)
+			src := utils.LabelsSource(tc.expr, n)
+			require.Len(t, src, 1)
+			require.Equal(t, promParser.ValueTypeVector, src[0].Returns)
+			require.Equal(t, tc.operation, src[0].Operation)
+			require.Equal(t, tc.guaranteed, src[0].GuaranteedLabels)
+			require.Equal(t, tc.included, src[0].IncludedLabels)
+			require.Equal(t, tc.fixed, src[0].FixedLabels)
+			require.Len(t, src[0].Selectors, tc.selectors)
+		})
+	}
+}
+
+func TestSourceTimestamp(t *testing.T) {
+	type testCaseT struct {
+		expr       string
+		guaranteed []string
+		included   []string
+		excluded   []string
+		selectors  int
+		fixed      bool
+	}
+
+	testCases := []testCaseT{
+		{
+			expr:       `timestamp(foo{job="bar"})`,
+			guaranteed: []string{"job"},
+			selectors:  1,
+		},
+		{
+			expr:      `timestamp(sum(foo) by(job))`,
+			included:  []string{"job"},
+			selectors: 1,
+			fixed:     true,
+		},
+		{
+			expr:       `timestamp(sum(foo{job="bar"}) without(instance))`,
+			excluded:   []string{"instance"},
+			guaranteed: []string{"job"},
+			selectors:  1,
+		},
+		{
+			expr:       `timestamp(foo{job="bar"} or bar{job="foo"})`,
+			guaranteed: []string{"job"},
+			selectors:  2,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expr, func(t *testing.T) {
+			n, err := promParser.ParseExpr(tc.expr)
+			require.NoError(t, err)
+			src := utils.LabelsSource(tc.expr, n)
+			require.Len(t, src, 1)
+			require.Equal(t, promParser.ValueTypeVector, src[0].Returns)
+			require.Equal(t, "timestamp", src[0].Operation)
+			require.Equal(t, tc.guaranteed, src[0].GuaranteedLabels)
+			require.Equal(t, tc.included, src[0].IncludedLabels)
+			require.Equal(t, tc.excluded, src[0].ExcludedLabels)
+			require.Equal(t, tc.fixed, src[0].FixedLabels)
+			require.Len(t, src[0].Selectors, tc.selectors)
+			if tc.fixed {
+				require.Contains(t, src[0].ExcludeReason, "")
+			}
+		})
+	}
+}
+
+func TestSourceOverTime(t *testing.T) {
+	type testCaseT struct {
+		expr       string
+		operation  string
+		guaranteed []string
+		included   []string
+		excluded   []string
+		selectors  int
+		fixed      bool
+	}
+
+	testCases := []testCaseT{
+		{
+			expr:       `avg_over_time(foo{job="bar"}[5m])`,
+			operation:  "avg_over_time",
+			guaranteed: []string{"job"},
+			selectors:  1,
+		},
+		{
+			expr:      `avg_over_time((sum(foo) by(job))[5m:1m])`,
+			operation: "avg_over_time",
+			included:  []string{"job"},
+			selectors: 1,
+			fixed:     true,
+		},
+		{
+			expr:      `quantile_over_time(0.9, (max(foo) by(job, instance))[5m:1m])`,
+			operation: "quantile_over_time",
+			included:  []string{"job", "instance"},
+			selectors: 1,
+			fixed:     true,
+		},
+		{
+			expr:       `max_over_time((sum(foo{job="bar"}) without(instance))[5m:1m])`,
+			operation:  "max_over_time",
+			excluded:   []string{"instance"},
+			guaranteed: []string{"job"},
+			selectors:  1,
+		},
+		{
+			expr:      `sum_over_time((sum(foo) by(job) or sum(bar) by(job))[5m:1m])`,
+			operation: "sum_over_time",
+			selectors: 2,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expr, func(t *testing.T) {
+			n, err := promParser.ParseExpr(tc.expr)
+			require.NoError(t, err)
+			src := utils.LabelsSource(tc.expr, n)
+			require.Len(t, src, 1)
+			require.Equal(t, promParser.ValueTypeVector, src[0].Returns)
+			require.Equal(t, tc.operation, src[0].Operation)
+			require.Equal(t, tc.guaranteed, src[0].GuaranteedLabels)
+			require.Equal(t, tc.included, src[0].IncludedLabels)
+			require.Equal(t, tc.excluded, src[0].ExcludedLabels)
+			require.Equal(t, tc.fixed, src[0].FixedLabels)
+			require.Len(t, src[0].Selectors, tc.selectors)
+		})
+	}
+}
+
+func TestWalkSources(t *testing.T) {
+	type testCaseT struct {
+		expr  string
+		limit int
+		names []string
+	}
+
+	testCases := []testCaseT{
+		{
+			expr:  "foo",
+			limit: 10,
+			names: []string{"foo"},
+		},
+		{
+			expr:  "foo or bar or baz",
+			limit: 10,
+			names: []string{"foo", "bar", "baz"},
+		},
+		{
+			expr:  "foo or bar or baz",
+			limit: 1,
+			names: []string{"foo"},
+		},
+		{
+			expr:  "foo or bar or baz",
+			limit: 2,
+			names: []string{"foo", "bar"},
+		},
+		{
+			expr:  "sum(foo) or max(bar)",
+			limit: 10,
+			names: []string{"foo", "bar"},
+		},
+		{
+			expr:  "1",
+			limit: 10,
+			names: []string{""},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expr, func(t *testing.T) {
+			n, err := promParser.ParseExpr(tc.expr)
+			require.NoError(t, err)
+
+			var names []string
+			utils.WalkSources(tc.expr, n, func(s utils.Source) bool {
+				var name string
+				if len(s.Selectors) > 0 {
+					name = s.Selectors[0].Name
+				}
+				names = append(names, name)
+				return len(names) < tc.limit
+			})
+			require.Equal(t, tc.names, names)
+
+			src := utils.LabelsSource(tc.expr, n)
+			require.GreaterOrEqual(t, len(src), len(names))
+			for i, name := range names {
+				var expected string
+				if len(src[i].Selectors) > 0 {
+					expected = src[i].Selectors[0].Name
+				}
+				require.Equal(t, expected, name)
+			}
+		})
+	}
+}
+
+func TestSourceConflicts(t *testing.T) {
+	type testCaseT struct {
+		expr      string
+		conflicts []string
+	}
+
+	testCases := []testCaseT{
+		{
+			expr: `foo * on(x) group_left(job) bar`,
+		},
+		{
+			expr:      `foo{job="a"} * on(x) group_left(job) bar{job="b"}`,
+			conflicts: []string{"job"},
+		},
+		{
+			expr:      `foo{job="a", env="prod"} * on(x) group_left(env, job, team) bar`,
+			conflicts: []string{"env", "job"},
+		},
+		{
+			expr:      `foo{job="a"} * on(x) group_right(job) bar{job="b"}`,
+			conflicts: []string{"job"},
+		},
+		{
+			expr: `foo{job="a"} * on(x) group_right(job) bar`,
+		},
+		{
+			expr: `sum(foo{job="a"}) by(x) * on(x) group_left(job) bar{job="b"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expr, func(t *testing.T) {
+			n, err := promParser.ParseExpr(tc.expr)
+			require.NoError(t, err)
+			src := utils.LabelsSource(tc.expr, n)
+			require.Len(t, src, 1)
+			require.Equal(t, tc.conflicts, src[0].Conflicts)
+		})
+	}
+}
+
+func TestSourceFilteredLabels(t *testing.T) {
+	type testCaseT struct {
+		expr       string
+		guaranteed []string
+		filtered   []string
+	}
+
+	testCases := []testCaseT{
+		{
+			expr:       `foo{job!="x", env="prod"}`,
+			guaranteed: []string{"env"},
+			filtered:   []string{"job"},
+		},
+		{
+			expr:     `foo{job!~"x|y", env!=""}`,
+			filtered: []string{"job", "env"},
+		},
+		{
+			expr:       `foo{job!="x", job=~"a.+"}`,
+			guaranteed: []string{"job"},
+		},
+		{
+			expr:     `rate(foo{job!="x"}[5m])`,
+			filtered: []string{"job"},
+		},
+		{
+			expr:     `sum(foo{job!="x", env!="dev"}) by(job)`,
+			filtered: []string{"job"},
+		},
+		{
+			expr:     `sum(foo{job!="x", env!="dev"}) without(job)`,
+			filtered: []string{"env"},
+		},
+		{
+			expr: `sum(foo{job!="x"})`,
+		},
+		{
+			expr:       `foo{job=~"api"}`,
+			guaranteed: []string{"job"},
+		},
+		{
+			expr: `foo{job=~".*"}`,
+		},
+		{
+			expr:       `foo{job=~".+"}`,
+			guaranteed: []string{"job"},
+		},
+		{
+			expr: `foo{job=~"api|"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expr, func(t *testing.T) {
+			n, err := promParser.ParseExpr(tc.expr)
+			require.NoError(t, err)
+			src := utils.LabelsSource(tc.expr, n)
+			require.Len(t, src, 1)
+			require.Equal(t, tc.guaranteed, src[0].GuaranteedLabels)
+			require.Equal(t, tc.filtered, src[0].FilteredLabels)
+		})
+	}
+}
+
+func TestDeadSources(t *testing.T) {
+	type testCaseT struct {
+		expr   string
+		output []posrange.PositionRange
+	}
+
+	testCases := []testCaseT{
+		{
+			expr: "foo",
+		},
+		{
+			expr: "foo or vector(0) == 1",
+			output: []posrange.PositionRange{
+				{Start: 7, End: 21},
+			},
+		},
+		{
+			expr: "vector(1) or foo",
+			output: []posrange.PositionRange{
+				{Start: 13, End: 16},
+			},
+		},
+		{
+			expr: "vector(1) or on() foo",
+			output: []posrange.PositionRange{
+				{Start: 18, End: 21},
+			},
+		},
+		{
+			expr: "vector(1) or on() (foo or bar)",
+			output: []posrange.PositionRange{
+				{Start: 18, End: 30},
+			},
+		},
+		{
+			expr: "count(foo) == 0 or bar",
+			output: []posrange.PositionRange{
+				{Start: 0, End: 15},
+			},
+		},
+		{
+			expr: "(clamp_max(foo, 5) > 10) > 20",
+			output: []posrange.PositionRange{
+				{Start: 1, End: 23},
+			},
+		},
+		{
+			expr: "foo and vector(1)",
+			output: []posrange.PositionRange{
+				{Start: 0, End: 17},
+			},
+		},
+		{
+			expr: "foo and on() vector(1)",
+		},
+		{
+			expr: "sum(foo) and vector(1)",
+		},
+		{
+			expr: "foo and (vector(1) or vector(2))",
+			output: []posrange.PositionRange{
+				{Start: 0, End: 32},
+			},
+		},
+		{
+			expr: "foo and (bar or vector(1))",
+		},
+		{
+			expr: "foo unless vector(1)",
+		},
+		{
+			expr: "foo unless on() vector(1)",
+			output: []posrange.PositionRange{
+				{Start: 0, End: 25},
+			},
+		},
+		{
+			expr: "sum(foo) unless vector(1)",
+			output: []posrange.PositionRange{
+				{Start: 0, End: 25},
+			},
+		},
+		{
+			expr: "foo unless on() bar",
+		},
+		{
+			expr: "up == 0 unless on() (hour() > 22)",
+		},
+		{
+			expr: "up == 0 unless on() (day_of_week() == 1)",
+		},
+		{
+			expr: "(hour() > 22) or on() vector(0)",
+		},
+		{
+			expr: "(vector(0) > 1) or on() vector(1)",
+			output: []posrange.PositionRange{
+				{Start: 1, End: 14},
+			},
+		},
+		{
+			expr: "foo unless on() (vector(1) > 0)",
+			output: []posrange.PositionRange{
+				{Start: 0, End: 31},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expr, func(t *testing.T) {
+			n, err := promParser.ParseExpr(tc.expr)
+			require.NoError(t, err)
+			require.Equal(t, tc.output, utils.DeadSources(tc.expr, n))
+		})
+	}
+}
+
+func TestSourceCountComparison(t *testing.T) {
+	type testCaseT struct {
+		expr       string
+		comparison utils.CountComparison
+	}
+
+	testCases := []testCaseT{
+		{
+			expr: "count(foo)",
+		},
+		{
+			expr: "sum(foo) == 0",
+		},
+		{
+			expr:       "count(foo) == 0",
+			comparison: utils.CountRequiresAbsence,
+		},
+		{
+			expr:       "1 > count(foo)",
+			comparison: utils.CountRequiresAbsence,
+		},
+		{
+			expr: "count(foo) == bool 0",
+		},
+		{
+			expr:       "count(foo) >= 1",
+			comparison: utils.CountRequiresPresence,
+		},
+		{
+			expr:       "count(foo) > 0",
+			comparison: utils.CountRequiresPresence,
+		},
+		{
+			expr:       "count(foo) != 0",
+			comparison: utils.CountRequiresPresence,
+		},
+		{
+			expr:       "0 < count(foo)",
+			comparison: utils.CountRequiresPresence,
+		},
+		{
+			expr: "count(foo) >= 2",
+		},
+		{
+			expr: "count(foo) == 1",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expr, func(t *testing.T) {
+			n, err := promParser.ParseExpr(tc.expr)
+			require.NoError(t, err)
+			output := utils.LabelsSource(tc.expr, n)
+			require.Len(t, output, 1)
+			require.Equal(t, tc.comparison, output[0].CountComparison)
+		})
+	}
+}
+
+func TestSourceQuantile(t *testing.T) {
+	type testCaseT struct {
+		quantile *float64
+		expr     string
+	}
+
+	value := func(v float64) *float64 { return &v }
+
+	testCases := []testCaseT{
+		{
+			expr: "foo",
+		},
+		{
+			expr:     "quantile(1.5, foo)",
+			quantile: value(1.5),
+		},
+		{
+			expr:     "quantile by(job) ((0.9), foo)",
+			quantile: value(0.9),
+		},
+		{
+			expr:     "quantile_over_time(0.99, foo[5m])",
+			quantile: value(0.99),
+		},
+		{
+			expr: "quantile(scalar(bar), quantile_over_time(0.99, foo[5m]))",
+		},
+		{
+			expr: "max_over_time(foo[5m])",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expr, func(t *testing.T) {
+			n, err := promParser.ParseExpr(tc.expr)
+			require.NoError(t, err)
+			src := utils.LabelsSource(tc.expr, n)
+			require.Len(t, src, 1)
+			require.Equal(t, tc.quantile, src[0].Quantile)
+		})
+	}
+}
@@ -1,9 +1,12 @@
 package utils
 
 import (
-	"github.com/cloudflare/pint/internal/parser"
+	"slices"
 
 	promParser "github.com/prometheus/prometheus/promql/parser"
+	"github.com/prometheus/prometheus/promql/parser/posrange"
+
+	"github.com/cloudflare/pint/internal/parser"
 )
 
 func HasVectorSelector(node *parser.PromQLNode) (vs []promParser.VectorSelector) {
@@ -17,3 +20,24 @@ func HasVectorSelector(node *parser.PromQLNode) (vs []promParser.VectorSelector)
 
 	return vs
 }
+
+// CollectSelectors returns all vector selectors used in given query,
+// in the order in which they appear in it.
+// Every selector is only returned once even if the same node is reachable
+// more than once.
+func CollectSelectors(node promParser.Node) (vs []*promParser.VectorSelector) {
+	if node == nil {
+		return nil
+	}
+
+	var seen []posrange.PositionRange
+	promParser.Inspect(node, func(n promParser.Node, _ []promParser.Node) error {
+		if s, ok := n.(*promParser.VectorSelector); ok && !slices.Contains(seen, s.PosRange) {
+			seen = append(seen, s.PosRange)
+			vs = append(vs, s)
+		}
+		return nil
+	})
+
+	return vs
+}
@@ -3,6 +3,7 @@ package utils_test
 import (
 	"testing"
 
+	promParser "github.com/prometheus/prometheus/promql/parser"
 	"github.com/stretchr/testify/require"
 
 	"github.com/cloudflare/pint/internal/parser"
@@ -64,3 +65,56 @@ func TestHasVectorSelector(t *testing.T) {
 		})
 	}
 }
+
+func TestCollectSelectors(t *testing.T) {
+	type testCaseT struct {
+		expr   string
+		output []string
+	}
+
+	testCases := []testCaseT{
+		{
+			expr: "1",
+		},
+		{
+			expr:   "foo",
+			output: []string{"foo"},
+		},
+		{
+			expr:   `sum(rate(foo{job="bar"}[5m])) by(job)`,
+			output: []string{`foo{job="bar"}`},
+		},
+		{
+			expr:   `foo / on(job) group_left(instance) (bar or baz)`,
+			output: []string{"foo", "bar", "baz"},
+		},
+		{
+			expr:   `sum(foo) by(job) > 0 and count(bar) by(job) unless absent(baz{job="a"})`,
+			output: []string{"foo", "bar", `baz{job="a"}`},
+		},
+		{
+			expr:   `label_replace(max_over_time((foo - bar)[5m:1m]), "a", "$1", "b", "(.+)")`,
+			output: []string{"foo", "bar"},
+		},
+		{
+			expr:   `foo or foo`,
+			output: []string{"foo", "foo"},
+		},
+		{
+			expr:   `topk(5, histogram_quantile(0.9, sum(rate(foo_bucket[5m])) by(le)) * on() group_left() vector(1))`,
+			output: []string{"foo_bucket"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expr, func(t *testing.T) {
+			n, err := promParser.ParseExpr(tc.expr)
+			require.NoError(t, err)
+			var output []string
+			for _, vs := range utils.CollectSelectors(n) {
+				output = append(output, vs.String())
+			}
+			require.Equal(t, tc.output, output)
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package reporter
+
+import (
+	"encoding/json"
+	"io"
+)
+
+type flusher interface {
+	Flush() error
+}
+
+func NewJSONLinesReporter(output io.Writer) JSONLinesReporter {
+	return JSONLinesReporter{output: output, enc: json.NewEncoder(output)}
+}
+
+// JSONLinesReporter writes each report as a single line JSON object.
+type JSONLinesReporter struct {
+	output io.Writer
+	enc    *json.Encoder
+}
+
+type JSONLinesReport struct {
+	Path          string `json:"path"`
+	SymlinkTarget string `json:"symlinkTarget"`
+	Reporter      string `json:"reporter"`
+	Severity      string `json:"severity"`
+	Problem       string `json:"problem"`
+	Lines         []int  `json:"lines"`
+}
+
+func (jr JSONLinesReporter) Push(report Report) error {
+	err := jr.enc.Encode(JSONLinesReport{
+		Path:          report.Path.Name,
+		SymlinkTarget: report.Path.SymlinkTarget,
+		Reporter:      report.Problem.Reporter,
+		Severity:      report.Problem.Severity.String(),
+		Problem:       report.Problem.Text,
+		Lines:         report.Problem.Lines.Expand(),
+	})
+	if err != nil {
+		return err
+	}
+	if f, ok := jr.output.(flusher); ok {
+		return f.Flush()
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package reporter_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/discovery"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/reporter"
+)
+
+type flushBuffer struct {
+	bytes.Buffer
+	flushes int
+}
+
+func (fb *flushBuffer) Flush() error {
+	fb.flushes++
+	return nil
+}
+
+type failingWriter struct{}
+
+func (fw failingWriter) Write(_ []byte) (int, error) {
+	return 0, errors.New("write error")
+}
+
+func TestJSONLinesReporter(t *testing.T) {
+	out := &flushBuffer{}
+	var stream reporter.StreamReporter = reporter.NewJSONLinesReporter(out)
+
+	severities := []checks.Severity{checks.Information, checks.Warning, checks.Bug, checks.Fatal}
+	for i := range 100 {
+		err := stream.Push(reporter.Report{
+			Path: discovery.Path{
+				Name:          fmt.Sprintf("rules/%d.yml", i),
+				SymlinkTarget: fmt.Sprintf("rules/target-%d.yml", i),
+			},
+			Problem: checks.Problem{
+				Lines:    parser.LineRange{First: i + 1, Last: i + 2},
+				Reporter: "mock",
+				Text:     fmt.Sprintf("problem \"%d\"\nsecond line", i),
+				Details:  "details are not included",
+				Severity: severities[i%len(severities)],
+			},
+		})
+		require.NoError(t, err)
+		// Every report must be written as soon as it's pushed.
+		require.Equal(t, i+1, out.flushes)
+		require.Equal(t, i+1, bytes.Count(out.Bytes(), []byte("\n")))
+	}
+
+	var lines int
+	scanner := bufio.NewScanner(bytes.NewReader(out.Bytes()))
+	for scanner.Scan() {
+		var r reporter.JSONLinesReport
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &r), "line %d is not valid JSON: %s", lines, scanner.Text())
+		require.Equal(t, reporter.JSONLinesReport{
+			Path:          fmt.Sprintf("rules/%d.yml", lines),
+			SymlinkTarget: fmt.Sprintf("rules/target-%d.yml", lines),
+			Reporter:      "mock",
+			Severity:      severities[lines%len(severities)].String(),
+			Problem:       fmt.Sprintf("problem \"%d\"\nsecond line", lines),
+			Lines:         []int{lines + 1, lines + 2},
+		}, r)
+		lines++
+	}
+	require.NoError(t, scanner.Err())
+	require.Equal(t, 100, lines)
+
+	require.Equal(t, `{"path":"rules/0.yml","symlinkTarget":"rules/target-0.yml","reporter":"mock","severity":"Information","problem":"problem \"0\"\nsecond line","lines":[1,2]}`,
+		string(bytes.SplitN(out.Bytes(), []byte("\n"), 2)[0]))
+}
+
+func TestJSONLinesReporterError(t *testing.T) {
+	stream := reporter.NewJSONLinesReporter(failingWriter{})
+	err := stream.Push(reporter.Report{})
+	require.EqualError(t, err, "write error")
+}
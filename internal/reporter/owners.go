@@ -0,0 +1,91 @@
+package reporter
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/cloudflare/pint/internal/checks"
+)
+
+const noOwner = "(no owner)"
+
+func NewOwnerSummaryReporter(output io.Writer) OwnerSummaryReporter {
+	return OwnerSummaryReporter{
+		output: output,
+	}
+}
+
+// OwnerSummaryReporter prints the number of problems for each rule owner,
+// split by severity.
+type OwnerSummaryReporter struct {
+	output io.Writer
+}
+
+type ownerSummary struct {
+	counts map[checks.Severity]int
+	owner  string
+	total  int
+}
+
+func (or OwnerSummaryReporter) Submit(summary Summary) error {
+	owners := map[string]*ownerSummary{}
+	for _, report := range summary.reports {
+		o, ok := owners[report.Owner]
+		if !ok {
+			o = &ownerSummary{owner: report.Owner, counts: map[checks.Severity]int{}}
+			owners[report.Owner] = o
+		}
+		o.counts[report.Problem.Severity]++
+		o.total++
+	}
+	if len(owners) == 0 {
+		return nil
+	}
+
+	sorted := make([]*ownerSummary, 0, len(owners))
+	for _, o := range owners {
+		sorted = append(sorted, o)
+	}
+	// Rules without an owner are always listed last.
+	slices.SortFunc(sorted, func(a, b *ownerSummary) int {
+		switch {
+		case a.owner == "" && b.owner != "":
+			return 1
+		case a.owner != "" && b.owner == "":
+			return -1
+		default:
+			return cmp.Compare(a.owner, b.owner)
+		}
+	})
+
+	var buf strings.Builder
+	buf.WriteString("Problems by owner:\n")
+	for _, o := range sorted {
+		owner := o.owner
+		if owner == "" {
+			owner = noOwner
+		}
+		buf.WriteString(owner)
+		buf.WriteString(": ")
+		buf.WriteString(strconv.Itoa(o.total))
+		buf.WriteString(" problem(s)")
+		for _, s := range []checks.Severity{checks.Fatal, checks.Bug, checks.Warning, checks.Information} {
+			if c, ok := o.counts[s]; ok {
+				buf.WriteRune(' ')
+				buf.WriteString(s.String())
+				buf.WriteRune('=')
+				buf.WriteString(strconv.Itoa(c))
+			}
+		}
+		buf.WriteRune('\n')
+	}
+
+	if _, err := fmt.Fprint(or.output, buf.String()); err != nil {
+		return fmt.Errorf("failed to write owner summary: %w", err)
+	}
+	return nil
+}
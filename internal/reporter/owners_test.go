@@ -0,0 +1,108 @@
+package reporter_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/discovery"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/reporter"
+)
+
+func TestOwnerSummaryReporter(t *testing.T) {
+	type testCaseT struct {
+		description string
+		output      string
+		err         string
+		summary     reporter.Summary
+	}
+
+	p := parser.NewParser(false, parser.PrometheusSchema, model.UTF8Validation)
+	mockRules, _ := p.Parse([]byte(`
+- record: target is down
+  expr: up == 0
+`))
+
+	report := func(owner string, line int, severity checks.Severity) reporter.Report {
+		return reporter.Report{
+			Path: discovery.Path{
+				SymlinkTarget: "foo.txt",
+				Name:          "foo.txt",
+			},
+			Owner:         owner,
+			ModifiedLines: []int{2, 3},
+			Rule:          mockRules[0],
+			Problem: checks.Problem{
+				Lines: parser.LineRange{
+					First: line,
+					Last:  line,
+				},
+				Reporter: "mock",
+				Text:     "mock text",
+				Severity: severity,
+			},
+		}
+	}
+
+	testCases := []testCaseT{
+		{
+			description: "no reports",
+			summary:     reporter.Summary{},
+			output:      "",
+		},
+		{
+			description: "two teams with mixed severities",
+			summary: reporter.NewSummary([]reporter.Report{
+				report("team-b", 1, checks.Warning),
+				report("team-a", 2, checks.Bug),
+				report("team-b", 3, checks.Bug),
+				report("team-a", 4, checks.Information),
+				report("team-b", 5, checks.Warning),
+				report("team-a", 6, checks.Bug),
+				report("team-b", 7, checks.Fatal),
+			}),
+			output: `Problems by owner:
+team-a: 3 problem(s) Bug=2 Information=1
+team-b: 4 problem(s) Fatal=1 Bug=1 Warning=2
+`,
+		},
+		{
+			description: "rules without owner are listed last",
+			summary: reporter.NewSummary([]reporter.Report{
+				report("", 1, checks.Bug),
+				report("team-b", 2, checks.Warning),
+				report("", 3, checks.Warning),
+				report("team-a", 4, checks.Information),
+			}),
+			output: `Problems by owner:
+team-a: 1 problem(s) Information=1
+team-b: 1 problem(s) Warning=1
+(no owner): 2 problem(s) Bug=1 Warning=1
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			out := bytes.NewBuffer(nil)
+			r := reporter.NewOwnerSummaryReporter(out)
+			err := r.Submit(tc.summary)
+			if tc.err != "" {
+				require.EqualError(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.output, out.String())
+			}
+		})
+	}
+
+	t.Run("write error", func(t *testing.T) {
+		r := reporter.NewOwnerSummaryReporter(failingWriter{})
+		err := r.Submit(reporter.NewSummary([]reporter.Report{report("team-a", 1, checks.Bug)}))
+		require.EqualError(t, err, "failed to write owner summary: write error")
+	})
+}
@@ -173,3 +173,9 @@ func (s Summary) CountBySeverity() map[checks.Severity]int {
 type Reporter interface {
 	Submit(Summary) error
 }
+
+// StreamReporter receives reports one at a time, as soon as each problem
+// is found, instead of waiting for a Summary with all reports.
+type StreamReporter interface {
+	Push(Report) error
+}
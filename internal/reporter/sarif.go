@@ -0,0 +1,166 @@
+package reporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/cloudflare/pint/internal/checks"
+)
+
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+)
+
+func NewSARIFReporter(output io.Writer, version string) SARIFReporter {
+	return SARIFReporter{output: output, version: version}
+}
+
+// SARIFReporter writes all problems as a SARIF 2.1.0 log, which can be uploaded
+// to code scanning tools like GitHub Advanced Security.
+type SARIFReporter struct {
+	output  io.Writer
+	version string
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+	HelpURI          string       `json:"helpUri"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+	RuleIndex int             `json:"ruleIndex"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+	EndLine   int `json:"endLine"`
+}
+
+func sarifLevel(s checks.Severity) string {
+	switch s {
+	case checks.Information:
+		return "note"
+	case checks.Warning:
+		return "warning"
+	case checks.Bug, checks.Fatal:
+		return "error"
+	}
+	return "none"
+}
+
+func (sr SARIFReporter) Submit(summary Summary) error {
+	reports := summary.Reports()
+
+	var ruleIDs []string
+	results := make([]sarifResult, 0, len(reports))
+	for _, report := range reports {
+		idx := slices.Index(ruleIDs, report.Problem.Reporter)
+		if idx < 0 {
+			ruleIDs = append(ruleIDs, report.Problem.Reporter)
+			idx = len(ruleIDs) - 1
+		}
+
+		msg := report.Problem.Text
+		if report.Problem.Details != "" {
+			msg += "\n" + report.Problem.Details
+		}
+
+		results = append(results, sarifResult{
+			RuleID:    report.Problem.Reporter,
+			RuleIndex: idx,
+			Level:     sarifLevel(report.Problem.Severity),
+			Message:   sarifMessage{Text: msg},
+			Locations: []sarifLocation{
+				{
+					PhysicalLocation: sarifPhysicalLocation{
+						ArtifactLocation: sarifArtifactLocation{URI: report.Path.Name},
+						// SARIF line numbers start at 1, but some problems, like file level
+						// errors, don't point to any specific line.
+						Region: sarifRegion{
+							StartLine: max(report.Problem.Lines.First, 1),
+							EndLine:   max(report.Problem.Lines.Last, 1),
+						},
+					},
+				},
+			},
+		})
+	}
+
+	rules := make([]sarifRule, 0, len(ruleIDs))
+	for _, id := range ruleIDs {
+		rules = append(rules, sarifRule{
+			ID:               id,
+			ShortDescription: sarifMessage{Text: id},
+			HelpURI:          fmt.Sprintf("https://cloudflare.github.io/pint/checks/%s.html", id),
+		})
+	}
+
+	out := sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs: []sarifRun{
+			{
+				Tool: sarifTool{
+					Driver: sarifDriver{
+						Name:           "pint",
+						Version:        sr.version,
+						InformationURI: "https://cloudflare.github.io/pint/",
+						Rules:          rules,
+					},
+				},
+				Results: results,
+			},
+		},
+	}
+
+	enc := json.NewEncoder(sr.output)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
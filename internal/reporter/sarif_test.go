@@ -0,0 +1,315 @@
+package reporter_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/neilotoole/slogt"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/discovery"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/reporter"
+)
+
+func TestSARIFReporter(t *testing.T) {
+	type testCaseT struct {
+		description string
+		output      string
+		summary     reporter.Summary
+	}
+
+	p := parser.NewParser(false, parser.PrometheusSchema, model.UTF8Validation)
+	mockRules, _ := p.Parse([]byte(`
+- record: target is down
+  expr: up == 0
+`))
+
+	testCases := []testCaseT{
+		{
+			description: "no reports",
+			summary:     reporter.Summary{},
+			output: `{
+  "$schema": "https://json.schemastore.org/sarif-2.1.0.json",
+  "version": "2.1.0",
+  "runs": [
+    {
+      "tool": {
+        "driver": {
+          "name": "pint",
+          "version": "v1.0.0",
+          "informationUri": "https://cloudflare.github.io/pint/",
+          "rules": []
+        }
+      },
+      "results": []
+    }
+  ]
+}
+`,
+		},
+		{
+			description: "multiple reports",
+			summary: reporter.NewSummary([]reporter.Report{
+				{
+					Path: discovery.Path{
+						SymlinkTarget: "foo.yml",
+						Name:          "foo.yml",
+					},
+					ModifiedLines: []int{2, 4, 5},
+					Rule:          mockRules[0],
+					Problem: checks.Problem{
+						Lines: parser.LineRange{
+							First: 5,
+							Last:  6,
+						},
+						Reporter: "mock/bug",
+						Text:     "mock text",
+						Details:  "mock details",
+						Severity: checks.Bug,
+					},
+				},
+				{
+					Path: discovery.Path{
+						SymlinkTarget: "bar.yml",
+						Name:          "bar.yml",
+					},
+					ModifiedLines: []int{1},
+					Rule:          mockRules[0],
+					Problem: checks.Problem{
+						Lines: parser.LineRange{
+							First: 1,
+							Last:  1,
+						},
+						Reporter: "mock/info",
+						Text:     "info text",
+						Severity: checks.Information,
+					},
+				},
+				{
+					Path: discovery.Path{
+						SymlinkTarget: "foo.yml",
+						Name:          "foo.yml",
+					},
+					ModifiedLines: []int{2, 4, 5},
+					Rule:          mockRules[0],
+					Problem: checks.Problem{
+						Lines: parser.LineRange{
+							First: 2,
+							Last:  2,
+						},
+						Reporter: "mock/bug",
+						Text:     "warning text",
+						Severity: checks.Warning,
+					},
+				},
+			}),
+			output: `{
+  "$schema": "https://json.schemastore.org/sarif-2.1.0.json",
+  "version": "2.1.0",
+  "runs": [
+    {
+      "tool": {
+        "driver": {
+          "name": "pint",
+          "version": "v1.0.0",
+          "informationUri": "https://cloudflare.github.io/pint/",
+          "rules": [
+            {
+              "id": "mock/bug",
+              "shortDescription": {
+                "text": "mock/bug"
+              },
+              "helpUri": "https://cloudflare.github.io/pint/checks/mock/bug.html"
+            },
+            {
+              "id": "mock/info",
+              "shortDescription": {
+                "text": "mock/info"
+              },
+              "helpUri": "https://cloudflare.github.io/pint/checks/mock/info.html"
+            }
+          ]
+        }
+      },
+      "results": [
+        {
+          "ruleId": "mock/bug",
+          "level": "error",
+          "message": {
+            "text": "mock text\nmock details"
+          },
+          "locations": [
+            {
+              "physicalLocation": {
+                "artifactLocation": {
+                  "uri": "foo.yml"
+                },
+                "region": {
+                  "startLine": 5,
+                  "endLine": 6
+                }
+              }
+            }
+          ],
+          "ruleIndex": 0
+        },
+        {
+          "ruleId": "mock/info",
+          "level": "note",
+          "message": {
+            "text": "info text"
+          },
+          "locations": [
+            {
+              "physicalLocation": {
+                "artifactLocation": {
+                  "uri": "bar.yml"
+                },
+                "region": {
+                  "startLine": 1,
+                  "endLine": 1
+                }
+              }
+            }
+          ],
+          "ruleIndex": 1
+        },
+        {
+          "ruleId": "mock/bug",
+          "level": "warning",
+          "message": {
+            "text": "warning text"
+          },
+          "locations": [
+            {
+              "physicalLocation": {
+                "artifactLocation": {
+                  "uri": "foo.yml"
+                },
+                "region": {
+                  "startLine": 2,
+                  "endLine": 2
+                }
+              }
+            }
+          ],
+          "ruleIndex": 0
+        }
+      ]
+    }
+  ]
+}
+`,
+		},
+		{
+			description: "problem without lines",
+			summary: reporter.NewSummary([]reporter.Report{
+				{
+					Path: discovery.Path{
+						SymlinkTarget: "foo.yml",
+						Name:          "foo.yml",
+					},
+					ModifiedLines: []int{1},
+					Rule:          mockRules[0],
+					Problem: checks.Problem{
+						Lines: parser.LineRange{
+							First: 0,
+							Last:  0,
+						},
+						Reporter: "mock/fatal",
+						Text:     "fatal text",
+						Severity: checks.Fatal,
+					},
+				},
+			}),
+			output: `{
+  "$schema": "https://json.schemastore.org/sarif-2.1.0.json",
+  "version": "2.1.0",
+  "runs": [
+    {
+      "tool": {
+        "driver": {
+          "name": "pint",
+          "version": "v1.0.0",
+          "informationUri": "https://cloudflare.github.io/pint/",
+          "rules": [
+            {
+              "id": "mock/fatal",
+              "shortDescription": {
+                "text": "mock/fatal"
+              },
+              "helpUri": "https://cloudflare.github.io/pint/checks/mock/fatal.html"
+            }
+          ]
+        }
+      },
+      "results": [
+        {
+          "ruleId": "mock/fatal",
+          "level": "error",
+          "message": {
+            "text": "fatal text"
+          },
+          "locations": [
+            {
+              "physicalLocation": {
+                "artifactLocation": {
+                  "uri": "foo.yml"
+                },
+                "region": {
+                  "startLine": 1,
+                  "endLine": 1
+                }
+              }
+            }
+          ],
+          "ruleIndex": 0
+        }
+      ]
+    }
+  ]
+}
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			slog.SetDefault(slogt.New(t))
+
+			out := bytes.NewBuffer(nil)
+
+			reporter := reporter.NewSARIFReporter(out, "v1.0.0")
+			err := reporter.Submit(tc.summary)
+			require.NoError(t, err)
+			require.Equal(t, tc.output, out.String())
+
+			// Every result must point to a rule with the same id.
+			var log struct {
+				Runs []struct {
+					Tool struct {
+						Driver struct {
+							Rules []struct {
+								ID string `json:"id"`
+							} `json:"rules"`
+						} `json:"driver"`
+					} `json:"tool"`
+					Results []struct {
+						RuleID    string `json:"ruleId"`
+						RuleIndex int    `json:"ruleIndex"`
+					} `json:"results"`
+				} `json:"runs"`
+			}
+			require.NoError(t, json.Unmarshal(out.Bytes(), &log))
+			require.Len(t, log.Runs, 1)
+			for _, result := range log.Runs[0].Results {
+				require.Equal(t, result.RuleID, log.Runs[0].Tool.Driver.Rules[result.RuleIndex].ID)
+			}
+		})
+	}
+}